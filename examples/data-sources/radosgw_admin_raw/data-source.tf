@@ -0,0 +1,12 @@
+# Read the rate limits of a user, which the provider does not model yet
+data "radosgw_admin_raw" "ratelimit" {
+  path = "/ratelimit"
+  query = {
+    ratelimit-scope = "user"
+    uid             = "example-user"
+  }
+}
+
+output "user_ratelimit" {
+  value = jsondecode(data.radosgw_admin_raw.ratelimit.response)
+}
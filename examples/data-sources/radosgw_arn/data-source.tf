@@ -0,0 +1,35 @@
+data "radosgw_arn" "app_user" {
+  type = "user"
+  name = "acme$app"
+}
+
+data "radosgw_arn" "data_bucket" {
+  type   = "bucket"
+  tenant = "acme"
+  name   = "data"
+}
+
+data "radosgw_arn" "data_objects" {
+  type   = "object"
+  tenant = "acme"
+  name   = "data"
+  key    = "*"
+}
+
+# Grant a tenanted user read access to a tenanted bucket
+data "radosgw_iam_policy_document" "read_data" {
+  statement {
+    effect  = "Allow"
+    actions = ["s3:GetObject", "s3:ListBucket"]
+
+    principals {
+      type        = "AWS"
+      identifiers = [data.radosgw_arn.app_user.arn]
+    }
+
+    resources = [
+      data.radosgw_arn.data_bucket.arn,
+      data.radosgw_arn.data_objects.arn,
+    ]
+  }
+}
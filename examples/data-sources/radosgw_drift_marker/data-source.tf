@@ -0,0 +1,20 @@
+variable "last_apply" {
+  description = "Timestamp of the last successful apply"
+  type        = string
+  default     = "2024-01-01T00:00:00Z"
+}
+
+# Check whether managed users or buckets changed since the last apply
+data "radosgw_drift_marker" "example" {
+  since    = var.last_apply
+  user_ids = ["app-user", "backup-user"]
+  buckets  = ["app-data", "backup-data"]
+}
+
+output "drift_likely" {
+  value = data.radosgw_drift_marker.example.drift_likely
+}
+
+output "changed_buckets" {
+  value = data.radosgw_drift_marker.example.changed_buckets
+}
@@ -0,0 +1,19 @@
+data "radosgw_health" "gateway" {
+  check_swift = true
+}
+
+# Stop before making changes when the gateway is degraded
+resource "radosgw_s3_bucket" "example" {
+  bucket = "example-bucket"
+
+  lifecycle {
+    precondition {
+      condition     = data.radosgw_health.gateway.healthy
+      error_message = "RadosGW is degraded: ${jsonencode(data.radosgw_health.gateway.errors)}"
+    }
+  }
+}
+
+output "s3_latency_ms" {
+  value = data.radosgw_health.gateway.s3_latency_ms
+}
@@ -18,7 +18,7 @@ data "radosgw_iam_openid_connect_provider" "google" {
   url = "accounts.google.com"
 }
 
-data "radosgw_policy_document" "assume_role_with_oidc" {
+data "radosgw_iam_policy_document" "assume_role_with_oidc" {
   statement {
     effect  = "Allow"
     actions = ["sts:AssumeRoleWithWebIdentity"]
@@ -36,9 +36,9 @@ data "radosgw_policy_document" "assume_role_with_oidc" {
   }
 }
 
-resource "radosgw_role" "oidc_role" {
+resource "radosgw_iam_role" "oidc_role" {
   name               = "OIDCFederatedRole"
-  assume_role_policy = data.radosgw_policy_document.assume_role_with_oidc.json
+  assume_role_policy = data.radosgw_iam_policy_document.assume_role_with_oidc.json
 }
 
 # Output OIDC provider details
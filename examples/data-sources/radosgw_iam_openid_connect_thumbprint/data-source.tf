@@ -0,0 +1,10 @@
+# Compute the thumbprint of an identity provider instead of running openssl
+data "radosgw_iam_openid_connect_thumbprint" "keycloak" {
+  url = "https://keycloak.example.com/realms/myrealm"
+}
+
+resource "radosgw_iam_openid_connect_provider" "keycloak" {
+  url             = data.radosgw_iam_openid_connect_thumbprint.keycloak.url
+  client_id_list  = ["my-app"]
+  thumbprint_list = [data.radosgw_iam_openid_connect_thumbprint.keycloak.thumbprint]
+}
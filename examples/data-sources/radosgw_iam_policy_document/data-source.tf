@@ -73,13 +73,13 @@ data "radosgw_iam_policy_document" "multi_statement" {
 }
 
 # Use the policy documents with roles
-resource "radosgw_role" "example" {
+resource "radosgw_iam_role" "example" {
   name               = "ExampleRole"
   assume_role_policy = data.radosgw_iam_policy_document.trust_policy.json
 }
 
-resource "radosgw_role_policy" "example" {
-  role   = radosgw_role.example.name
+resource "radosgw_iam_role_policy" "example" {
+  role   = radosgw_iam_role.example.name
   name   = "S3AccessPolicy"
   policy = data.radosgw_iam_policy_document.s3_access.json
 }
@@ -100,6 +100,36 @@ data "radosgw_iam_policy_document" "public_bucket" {
   }
 }
 
+# Trust policy that allows passing session tags when assuming the role
+data "radosgw_iam_policy_document" "tagged_trust_policy" {
+  statement {
+    effect = "Allow"
+
+    principals {
+      type        = "AWS"
+      identifiers = ["arn:aws:iam:::user/app-user"]
+    }
+
+    actions = ["sts:AssumeRole", "sts:TagSession"]
+
+    condition {
+      test     = "ForAllValues:StringEquals"
+      variable = "aws:TagKeys"
+      values   = ["team"]
+    }
+  }
+}
+
+# ABAC permissions based on session tags.
+# Use &{...} for policy variables, it is rendered as ${...}.
+data "radosgw_iam_policy_document" "team_access" {
+  statement {
+    effect    = "Allow"
+    actions   = ["s3:GetObject", "s3:PutObject"]
+    resources = ["arn:aws:s3:::&{aws:PrincipalTag/team}/*"]
+  }
+}
+
 # Use with bucket_policy resource
 resource "radosgw_s3_bucket" "public" {
   bucket = "my-public-bucket"
@@ -122,3 +152,24 @@ output "trust_policy_json" {
 output "bucket_policy_json" {
   value = data.radosgw_iam_policy_document.public_bucket.json
 }
+
+# Extend a shared document and override one of its statements by Sid
+data "radosgw_iam_policy_document" "extended" {
+  source_documents = [data.radosgw_iam_policy_document.s3_access.json]
+
+  statement {
+    sid       = "DenyDeleteBucket"
+    effect    = "Deny"
+    actions   = ["s3:DeleteBucket"]
+    resources = ["arn:aws:s3:::my-bucket"]
+  }
+
+  override_statements = [
+    jsonencode({
+      Sid      = "AllowS3Access"
+      Effect   = "Allow"
+      Action   = ["s3:GetObject", "s3:ListBucket"]
+      Resource = ["arn:aws:s3:::my-bucket", "arn:aws:s3:::my-bucket/*"]
+    }),
+  ]
+}
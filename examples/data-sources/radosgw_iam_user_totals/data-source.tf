@@ -0,0 +1,22 @@
+# Statistics of a single user
+data "radosgw_iam_user_totals" "app" {
+  user_id = "app-user"
+}
+
+# Statistics of all users of a tenant, recalculated before reading
+data "radosgw_iam_user_totals" "tenant" {
+  tenant     = "customer-a"
+  sync_stats = true
+}
+
+output "app_usage_gib" {
+  value = data.radosgw_iam_user_totals.app.total_bytes / pow(1024, 3)
+}
+
+# Users storing more than 100 GiB, as candidates for a quota
+output "large_users" {
+  value = [
+    for id, user in data.radosgw_iam_user_totals.tenant.users : id
+    if user.total_bytes > 100 * pow(1024, 3)
+  ]
+}
@@ -22,3 +22,30 @@ output "test_user_ids" {
   description = "User IDs matching test-* pattern"
   value       = data.radosgw_iam_users.test_users.user_ids
 }
+
+# Fetch the details of every matching user. The users are read concurrently,
+# so this stays fast even with hundreds of users.
+data "radosgw_iam_users" "test_users_detail" {
+  name_regex      = "^test-.*"
+  names_only      = false
+  max_concurrency = 20
+}
+
+# Output the display name of each user
+output "test_user_display_names" {
+  description = "Display names of users matching test-* pattern"
+  value       = { for id, user in data.radosgw_iam_users.test_users_detail.users : id => user.display_name }
+}
+
+# Audit the users of a tenant
+data "radosgw_iam_users" "acme" {
+  tenant          = "acme"
+  name_prefix     = "svc-"
+  include_details = true
+}
+
+# Output the suspended users of the tenant
+output "acme_suspended_users" {
+  description = "Suspended service users of the acme tenant"
+  value       = [for user in data.radosgw_iam_users.acme.details : user.user_id if user.suspended]
+}
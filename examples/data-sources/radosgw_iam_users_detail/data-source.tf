@@ -0,0 +1,25 @@
+# Fetch the details of every user matching a pattern in one read
+data "radosgw_iam_users" "team" {
+  name_regex = "^team-.*"
+}
+
+data "radosgw_iam_users_detail" "team" {
+  user_ids        = data.radosgw_iam_users.team.user_ids
+  max_concurrency = 20
+}
+
+# Skip users that were removed since the list was built
+data "radosgw_iam_users_detail" "known" {
+  user_ids       = ["alice", "bob", "tenant1$carol"]
+  ignore_missing = true
+}
+
+# Use the map directly in for_each
+output "team_emails" {
+  description = "Email address of each team user"
+  value       = { for id, user in data.radosgw_iam_users_detail.team.users : id => user.email }
+}
+
+output "missing_users" {
+  value = data.radosgw_iam_users_detail.known.missing_user_ids
+}
@@ -0,0 +1,26 @@
+# Audit every user with full access to user management
+data "radosgw_iam_users_with_caps" "user_admins" {
+  cap_type = "users"
+  perm     = "*"
+}
+
+# Users able to write metadata, including those with full access
+data "radosgw_iam_users_with_caps" "metadata_writers" {
+  cap_type        = "metadata"
+  perm            = "write"
+  max_concurrency = 20
+}
+
+# Any permission on the usage API
+data "radosgw_iam_users_with_caps" "usage" {
+  cap_type = "usage"
+}
+
+output "user_admins" {
+  description = "Users holding users=*"
+  value       = data.radosgw_iam_users_with_caps.user_admins.user_ids
+}
+
+output "usage_permissions" {
+  value = data.radosgw_iam_users_with_caps.usage.users
+}
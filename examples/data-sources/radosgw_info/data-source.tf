@@ -0,0 +1,17 @@
+data "radosgw_info" "cluster" {}
+
+# Only manage buckets from the master zone of the realm
+resource "radosgw_s3_bucket" "example" {
+  bucket = "example-bucket"
+
+  lifecycle {
+    precondition {
+      condition     = data.radosgw_info.cluster.zone_is_master
+      error_message = "Zone ${data.radosgw_info.cluster.zone} is not the master zone."
+    }
+  }
+}
+
+output "ceph_release" {
+  value = data.radosgw_info.cluster.ceph_release
+}
@@ -0,0 +1,15 @@
+data "radosgw_placement_targets" "current" {}
+
+resource "radosgw_iam_user" "example" {
+  user_id           = "example-user"
+  display_name      = "Example User"
+  default_placement = data.radosgw_placement_targets.current.default_placement
+}
+
+# Storage classes available in the default placement target
+output "storage_classes" {
+  value = one([
+    for target in data.radosgw_placement_targets.current.placement_targets : target.storage_classes
+    if target.name == data.radosgw_placement_targets.current.default_placement
+  ])
+}
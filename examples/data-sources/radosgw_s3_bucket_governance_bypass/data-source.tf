@@ -0,0 +1,20 @@
+resource "radosgw_s3_bucket" "locked" {
+  bucket              = "locked-bucket"
+  object_lock_enabled = true
+  force_destroy       = true
+}
+
+# Check whether the provider credentials can remove locked objects
+data "radosgw_s3_bucket_governance_bypass" "locked" {
+  bucket = radosgw_s3_bucket.locked.bucket
+}
+
+# Fail during plan instead of at destroy time
+resource "terraform_data" "destroy_guard" {
+  lifecycle {
+    precondition {
+      condition     = data.radosgw_s3_bucket_governance_bypass.locked.admin_purge_allowed || data.radosgw_s3_bucket_governance_bypass.locked.can_bypass_governance
+      error_message = "The provider credentials cannot remove objects under governance retention from ${radosgw_s3_bucket.locked.bucket}."
+    }
+  }
+}
@@ -0,0 +1,27 @@
+# List the uploads a lifecycle rule aborting incomplete uploads after 7 days
+# should have removed
+data "radosgw_s3_bucket_multipart_uploads" "stale" {
+  bucket          = "uploads"
+  older_than_days = 8
+}
+
+check "stale_multipart_uploads" {
+  assert {
+    condition     = length(data.radosgw_s3_bucket_multipart_uploads.stale.uploads) == 0
+    error_message = "Bucket uploads holds ${length(data.radosgw_s3_bucket_multipart_uploads.stale.uploads)} multipart uploads the lifecycle rule did not abort."
+  }
+}
+
+# Audit the in-progress uploads under a prefix of a tenant's bucket
+data "radosgw_s3_bucket_multipart_uploads" "backups" {
+  bucket = "backups"
+  tenant = "tenant1"
+  prefix = "daily/"
+}
+
+output "backup_uploads" {
+  value = {
+    for upload in data.radosgw_s3_bucket_multipart_uploads.backups.uploads :
+    upload.upload_id => "${upload.key} (${upload.age_days} days)"
+  }
+}
@@ -0,0 +1,9 @@
+# Look up the notifications configured on a bucket
+data "radosgw_s3_bucket_notification" "example" {
+  bucket = "my-bucket"
+}
+
+output "notification_topics" {
+  description = "ARNs of the topics the bucket publishes to"
+  value       = data.radosgw_s3_bucket_notification.example.topic[*].topic_arn
+}
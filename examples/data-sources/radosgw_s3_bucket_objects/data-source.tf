@@ -0,0 +1,32 @@
+# List the objects of a small configuration bucket
+data "radosgw_s3_bucket_objects" "configs" {
+  bucket = "app-configs"
+  prefix = "production/"
+}
+
+output "config_keys" {
+  value = data.radosgw_s3_bucket_objects.configs.keys
+}
+
+# List the top-level "directories" of a bucket
+data "radosgw_s3_bucket_objects" "top_level" {
+  bucket    = "app-configs"
+  delimiter = "/"
+}
+
+output "environments" {
+  value = data.radosgw_s3_bucket_objects.top_level.common_prefixes
+}
+
+# Fail the read rather than silently returning a partial listing
+data "radosgw_s3_bucket_objects" "certificates" {
+  bucket              = "certificates"
+  tenant              = "tenant1"
+  max_keys            = 100
+  error_on_truncation = true
+}
+
+# Check that an object exists
+output "has_ca_bundle" {
+  value = contains(data.radosgw_s3_bucket_objects.certificates.keys, "ca-bundle.pem")
+}
@@ -36,3 +36,14 @@ output "bucket_policy" {
   description = "The bucket policy document"
   value       = data.radosgw_s3_bucket_policy.managed.policy
 }
+
+# Warn when a statement allows anonymous access
+check "no_anonymous_access" {
+  assert {
+    condition = alltrue([
+      for statement in data.radosgw_s3_bucket_policy.managed.statements :
+      statement.effect != "Allow" || !anytrue([for principal in statement.principals : principal.type == "*"])
+    ])
+    error_message = "The bucket policy allows anonymous access."
+  }
+}
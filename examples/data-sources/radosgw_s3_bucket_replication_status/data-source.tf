@@ -0,0 +1,17 @@
+# Point the provider at the endpoint of the zone taking over the bucket
+data "radosgw_s3_bucket_replication_status" "assets" {
+  bucket = "assets"
+}
+
+# Only switch the application to the new zone once every change of the
+# bucket has been replicated to it
+resource "terraform_data" "cutover" {
+  input = "https://rgw-eu-west-2.example.com"
+
+  lifecycle {
+    precondition {
+      condition     = data.radosgw_s3_bucket_replication_status.assets.caught_up
+      error_message = "Bucket assets is still replicating: ${jsonencode(data.radosgw_s3_bucket_replication_status.assets.source_zones)}"
+    }
+  }
+}
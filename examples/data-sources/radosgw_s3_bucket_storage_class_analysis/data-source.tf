@@ -0,0 +1,19 @@
+# Analyze the storage classes of a bucket, scanning at most 50000 objects
+data "radosgw_s3_bucket_storage_class_analysis" "logs" {
+  bucket      = "my-log-bucket"
+  prefix      = "app/"
+  max_objects = 50000
+}
+
+# Share of the bytes still in the default storage class
+output "standard_ratio" {
+  value = (
+    data.radosgw_s3_bucket_storage_class_analysis.logs.size == 0 ? 0 :
+    try(data.radosgw_s3_bucket_storage_class_analysis.logs.storage_classes["STANDARD"].size, 0) /
+    data.radosgw_s3_bucket_storage_class_analysis.logs.size
+  )
+}
+
+output "analysis_is_sampled" {
+  value = data.radosgw_s3_bucket_storage_class_analysis.logs.is_sampled
+}
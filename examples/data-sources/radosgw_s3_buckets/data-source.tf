@@ -0,0 +1,19 @@
+# List every bucket of the cluster
+data "radosgw_s3_buckets" "all" {}
+
+# List the buckets of a single user
+data "radosgw_s3_buckets" "user" {
+  owner = "myuser"
+}
+
+# List the temporary buckets of a tenant
+data "radosgw_s3_buckets" "tenant_tmp" {
+  tenant     = "mytenant"
+  name_regex = "^tmp-.*"
+}
+
+# Report the size of each bucket without a tenant
+output "bucket_sizes" {
+  description = "Size in bytes of each bucket"
+  value       = { for b in data.radosgw_s3_buckets.all.buckets : b.bucket => b.size if b.tenant == "" }
+}
@@ -0,0 +1,12 @@
+# List all topics
+data "radosgw_sns_topics" "all" {}
+
+# List the topics of the orders application
+data "radosgw_sns_topics" "orders" {
+  name_regex = "^orders-"
+}
+
+output "order_topic_endpoints" {
+  description = "Push endpoint of each orders topic"
+  value       = { for topic in data.radosgw_sns_topics.orders.topics : topic.name => topic.push_endpoint }
+}
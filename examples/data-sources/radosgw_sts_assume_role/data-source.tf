@@ -0,0 +1,21 @@
+# Validate during plan that the provider credentials can assume the role
+data "radosgw_sts_assume_role" "check" {
+  role_arn          = radosgw_iam_role.example.arn
+  role_session_name = "terraform-trust-check"
+  duration_seconds  = 900
+}
+
+# Pass session tags, usable in policies as aws:PrincipalTag/team
+data "radosgw_sts_assume_role" "tagged" {
+  role_arn          = radosgw_iam_role.example.arn
+  role_session_name = "terraform-tagged"
+
+  tags = {
+    team = "storage"
+  }
+  transitive_tag_keys = ["team"]
+}
+
+output "assumed_role_arn" {
+  value = data.radosgw_sts_assume_role.check.assumed_role_arn
+}
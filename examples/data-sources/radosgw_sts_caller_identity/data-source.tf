@@ -0,0 +1,21 @@
+data "radosgw_sts_caller_identity" "current" {}
+
+# Trust the provider credentials in a role
+resource "radosgw_iam_role" "example" {
+  name = "example-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = { AWS = [data.radosgw_sts_caller_identity.current.arn] }
+        Action    = ["sts:AssumeRole"]
+      }
+    ]
+  })
+}
+
+output "caller_arn" {
+  value = data.radosgw_sts_caller_identity.current.arn
+}
@@ -0,0 +1,23 @@
+# Usage of a tenant user during May 2024
+data "radosgw_usage" "tenant_user" {
+  user_id    = "mytenant$myuser"
+  start_time = "2024-05-01T00:00:00Z"
+  end_time   = "2024-06-01T00:00:00Z"
+}
+
+# Object transfer of a single bucket
+data "radosgw_usage" "bucket" {
+  bucket     = "my-bucket"
+  categories = ["put_obj", "get_obj"]
+}
+
+# Export the per-user totals to a monitoring configuration
+output "usage_per_user" {
+  value = {
+    for user in data.radosgw_usage.tenant_user.summary : user.user => {
+      ops            = user.ops
+      bytes_sent     = user.bytes_sent
+      bytes_received = user.bytes_received
+    }
+  }
+}
@@ -0,0 +1,23 @@
+# Let a new instance fetch its configuration object without S3 credentials
+ephemeral "radosgw_s3_presigned_url" "config" {
+  bucket     = "bootstrap"
+  key        = "config/app.json"
+  expires_in = 900
+}
+
+resource "terraform_data" "bootstrap" {
+  provisioner "local-exec" {
+    command = "curl -fsS -o app.json \"$CONFIG_URL\""
+    environment = {
+      CONFIG_URL = ephemeral.radosgw_s3_presigned_url.config.url
+    }
+  }
+}
+
+# Let a CI job upload a build artifact into a tenant's bucket
+ephemeral "radosgw_s3_presigned_url" "artifact" {
+  bucket = "artifacts"
+  tenant = "ci"
+  key    = "builds/latest.tar.gz"
+  method = "PUT"
+}
@@ -0,0 +1,37 @@
+# Assume a role with the provider credentials and configure a second provider
+# with the temporary credentials, without storing them in the state
+ephemeral "radosgw_sts_session" "deployer" {
+  role_arn          = radosgw_iam_role.deployer.arn
+  role_session_name = "terraform-deployer"
+  duration_seconds  = 900
+}
+
+provider "aws" {
+  alias      = "deployer"
+  region     = "default"
+  access_key = ephemeral.radosgw_sts_session.deployer.access_key_id
+  secret_key = ephemeral.radosgw_sts_session.deployer.secret_access_key
+  token      = ephemeral.radosgw_sts_session.deployer.session_token
+
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_region_validation      = true
+  s3_use_path_style           = true
+
+  endpoints {
+    s3 = "http://radosgw.example.com:8080"
+  }
+}
+
+# Exchange an OpenID Connect token for temporary credentials
+variable "oidc_token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+ephemeral "radosgw_sts_session" "ci" {
+  role_arn           = radosgw_iam_role.ci.arn
+  role_session_name  = "ci-pipeline"
+  web_identity_token = var.oidc_token
+}
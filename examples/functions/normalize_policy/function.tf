@@ -0,0 +1,24 @@
+# Detect whether the policy stored on a bucket differs from the desired one
+data "radosgw_s3_bucket_policy" "data" {
+  bucket = "data"
+}
+
+data "radosgw_iam_policy_document" "desired" {
+  statement {
+    effect    = "Allow"
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::data/*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["arn:aws:iam:::user/reader"]
+    }
+  }
+}
+
+output "policy_in_sync" {
+  value = (
+    provider::radosgw::normalize_policy(data.radosgw_s3_bucket_policy.data.policy) ==
+    provider::radosgw::normalize_policy(data.radosgw_iam_policy_document.desired.json)
+  )
+}
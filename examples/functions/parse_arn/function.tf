@@ -0,0 +1,8 @@
+# The tenant of the principal of a bucket policy statement
+locals {
+  principal_tenant = provider::radosgw::parse_arn("arn:aws:iam::acme:user/alice").tenant
+}
+
+output "principal_tenant" {
+  value = local.principal_tenant # "acme"
+}
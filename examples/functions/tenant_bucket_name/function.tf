@@ -0,0 +1,9 @@
+variable "tenant" {
+  type    = string
+  default = "acme"
+}
+
+# The name other tenants use to address the bucket through S3
+output "shared_bucket" {
+  value = provider::radosgw::tenant_bucket_name(var.tenant, "shared") # "acme:shared"
+}
@@ -49,3 +49,32 @@ provider "radosgw" {
 #   secret_key                 = "admin-secret-key"
 #   tls_insecure_skip_verify   = true
 # }
+
+# Example assuming a role for S3 and IAM calls
+# The access key is only used to assume the role and for Admin API calls
+# provider "radosgw" {
+#   endpoint   = "https://rgw.example.com:7480"
+#   access_key = "deploy-access-key"
+#   secret_key = "deploy-secret-key"
+#
+#   assume_role {
+#     role_arn     = "arn:aws:iam:::role/terraform"
+#     session_name = "terraform"
+#     duration     = "1h"
+#   }
+# }
+
+# Example tagging every object managed by radosgw_s3_object_tagging
+# Tags of a resource take precedence over default tags with the same key
+# provider "radosgw" {
+#   endpoint   = "https://rgw.example.com:7480"
+#   access_key = "admin-access-key"
+#   secret_key = "admin-secret-key"
+#
+#   default_tags {
+#     tags = {
+#       managed-by = "terraform"
+#       team       = "storage"
+#     }
+#   }
+# }
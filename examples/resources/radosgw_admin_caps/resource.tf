@@ -0,0 +1,14 @@
+# Grant all capabilities required by the provider to the provider user.
+# The user must already hold users=* to grant capabilities.
+resource "radosgw_admin_caps" "provider" {}
+
+# Resources that need the capabilities depend on the bootstrap
+resource "radosgw_s3_bucket" "example" {
+  bucket = "example-bucket"
+
+  depends_on = [radosgw_admin_caps.provider]
+}
+
+output "provider_user" {
+  value = radosgw_admin_caps.provider.user_id
+}
@@ -0,0 +1,40 @@
+# Rate limit a user through the Admin Ops API, and remove the limit on destroy
+resource "radosgw_admin_raw" "user_ratelimit" {
+  method = "POST"
+  path   = "/ratelimit"
+  query = {
+    ratelimit-scope = "user"
+    uid             = "example-user"
+    max-read-ops    = "1024"
+    max-write-ops   = "256"
+    enabled         = "true"
+  }
+
+  destroy_method = "POST"
+  destroy_path   = "/ratelimit"
+  destroy_query = {
+    ratelimit-scope = "user"
+    uid             = "example-user"
+    enabled         = "false"
+  }
+}
+
+output "response" {
+  value = radosgw_admin_raw.user_ratelimit.response
+}
+
+# Endpoints such as /metadata read a JSON document from the request body
+resource "radosgw_admin_raw" "user_metadata" {
+  method = "PUT"
+  path   = "/metadata/user"
+  query = {
+    key = "example-user"
+  }
+  body = jsonencode({
+    key = "user:example-user"
+    data = {
+      user_id      = "example-user"
+      display_name = "Example User"
+    }
+  })
+}
@@ -11,6 +11,12 @@ resource "radosgw_iam_access_key" "custom" {
   secret_key = "MyCustomSecretKey123456789012345678901234"
 }
 
+# Deactivate an old key during a rotation before deleting it (Ceph Squid+)
+resource "radosgw_iam_access_key" "retired" {
+  user_id = radosgw_iam_user.example.user_id
+  active  = false
+}
+
 # Create a Swift access key for a subuser
 resource "radosgw_iam_access_key" "swift" {
   user_id    = radosgw_iam_user.example.user_id
@@ -19,6 +25,12 @@ resource "radosgw_iam_access_key" "swift" {
   secret_key = "swift_secret_password"
 }
 
+# Create an S3 access key bound to a subuser
+resource "radosgw_iam_access_key" "subuser_s3" {
+  user_id = radosgw_iam_user.example.user_id
+  subuser = radosgw_iam_subuser.swift.subuser
+}
+
 # Reference resources
 resource "radosgw_iam_user" "example" {
   user_id      = "key-example-user"
@@ -0,0 +1,2 @@
+# Import a RadosGW account by account ID
+terraform import radosgw_iam_account.example RGW00000000000000001
@@ -0,0 +1,24 @@
+# Create an account with a generated account ID
+resource "radosgw_iam_account" "example" {
+  name  = "example-account"
+  email = "account@example.com"
+}
+
+# Create an account with a fixed ID and custom limits
+resource "radosgw_iam_account" "limited" {
+  account_id      = "RGW00000000000000001"
+  name            = "limited-account"
+  max_users       = 10
+  max_roles       = 10
+  max_groups      = 10
+  max_access_keys = 2
+  max_buckets     = 50
+}
+
+# Create the account root user
+resource "radosgw_iam_user" "root" {
+  user_id      = "example-account-root"
+  display_name = "Example Account Root"
+  account_id   = radosgw_iam_account.example.id
+  account_root = true
+}
@@ -0,0 +1,6 @@
+# Import an account quota (total quota across all account's buckets)
+# Format: account_id:type (type is "account" or "bucket")
+terraform import radosgw_iam_account_quota.account_quota RGW00000000000000001:account
+
+# Import a bucket quota (per-bucket quota for all account's buckets)
+terraform import radosgw_iam_account_quota.bucket_quota RGW00000000000000001:bucket
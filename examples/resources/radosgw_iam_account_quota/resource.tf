@@ -0,0 +1,22 @@
+# Account quota - limits total storage across ALL buckets owned by the account
+resource "radosgw_iam_account_quota" "account_quota" {
+  account_id  = radosgw_iam_account.example.id
+  type        = "account"
+  enabled     = true
+  max_size    = 107374182400 # 100 GB in bytes
+  max_objects = 1000000
+}
+
+# Bucket quota - per-bucket limit applied to EACH bucket the account owns
+resource "radosgw_iam_account_quota" "bucket_quota" {
+  account_id  = radosgw_iam_account.example.id
+  type        = "bucket"
+  enabled     = true
+  max_size    = 10737418240 # 10 GB in bytes
+  max_objects = 100000
+}
+
+# Reference account resource
+resource "radosgw_iam_account" "example" {
+  name = "quota-example-account"
+}
@@ -0,0 +1,3 @@
+# Import a group
+# Format: group_name
+terraform import radosgw_iam_group.developers "developers"
@@ -0,0 +1,5 @@
+# Create a group in the account of the provider credentials
+resource "radosgw_iam_group" "developers" {
+  name = "developers"
+  path = "/engineering/"
+}
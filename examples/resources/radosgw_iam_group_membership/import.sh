@@ -0,0 +1,3 @@
+# Import the members of a group
+# Format: group_name
+terraform import radosgw_iam_group_membership.developers "developers"
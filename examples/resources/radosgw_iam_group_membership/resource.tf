@@ -0,0 +1,9 @@
+resource "radosgw_iam_group" "developers" {
+  name = "developers"
+}
+
+# The IAM user name of an account user is its display name
+resource "radosgw_iam_group_membership" "developers" {
+  group = radosgw_iam_group.developers.name
+  users = ["alice", "bob"]
+}
@@ -0,0 +1,3 @@
+# Import a group policy
+# Format: group_name:policy_name
+terraform import radosgw_iam_group_policy.shared_bucket "developers:SharedBucketAccess"
@@ -0,0 +1,27 @@
+resource "radosgw_iam_group" "developers" {
+  name = "developers"
+}
+
+# Grant every member of the group access to the shared bucket
+resource "radosgw_iam_group_policy" "shared_bucket" {
+  group = radosgw_iam_group.developers.name
+  name  = "SharedBucketAccess"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject",
+          "s3:PutObject",
+          "s3:ListBucket"
+        ]
+        Resource = [
+          "arn:aws:s3:::shared-bucket",
+          "arn:aws:s3:::shared-bucket/*"
+        ]
+      }
+    ]
+  })
+}
@@ -0,0 +1,3 @@
+# Import an MFA device
+# Format: user_id:serial
+terraform import radosgw_iam_mfa_device.compliance "compliance-admin:compliance-totp"
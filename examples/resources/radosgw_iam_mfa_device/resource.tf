@@ -0,0 +1,17 @@
+resource "radosgw_iam_user" "compliance" {
+  user_id      = "compliance-admin"
+  display_name = "Compliance Administrator"
+}
+
+# TOTP device with a base32 seed, as used by authenticator apps
+resource "radosgw_iam_mfa_device" "compliance" {
+  user_id   = radosgw_iam_user.compliance.user_id
+  serial    = "compliance-totp"
+  seed      = var.totp_seed
+  seed_type = "base32"
+}
+
+variable "totp_seed" {
+  type      = string
+  sensitive = true
+}
@@ -43,3 +43,31 @@ resource "radosgw_iam_openid_connect_provider" "cognito" {
     "9e99a48a9960b14926bb7f3b02e22da2b0ab7280"
   ]
 }
+
+# Map federated claims to a role in its trust policy. The issuer prefixes the
+# claim condition keys, and session tags are taken from the
+# "https://aws.amazon.com/tags" claim of the token.
+resource "radosgw_iam_role" "keycloak_developers" {
+  name = "keycloak-developers"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Federated = [radosgw_iam_openid_connect_provider.keycloak.arn]
+        }
+        Action = ["sts:AssumeRoleWithWebIdentity", "sts:TagSession"]
+        Condition = {
+          StringEquals = {
+            "${radosgw_iam_openid_connect_provider.keycloak.issuer}:aud" = "my-app-client"
+          }
+          StringLike = {
+            "${radosgw_iam_openid_connect_provider.keycloak.issuer}:sub" = "developer-*"
+          }
+        }
+      }
+    ]
+  })
+}
@@ -0,0 +1,3 @@
+# Import a managed policy
+# Format: policy_arn
+terraform import radosgw_iam_policy.s3_readonly "arn:aws:iam::RGW11111111111111111:policy/S3ReadOnly"
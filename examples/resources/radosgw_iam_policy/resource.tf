@@ -0,0 +1,23 @@
+# Create a managed policy in the account of the provider credentials
+resource "radosgw_iam_policy" "s3_readonly" {
+  name        = "S3ReadOnly"
+  path        = "/"
+  description = "Read-only access to the shared bucket"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject",
+          "s3:ListBucket"
+        ]
+        Resource = [
+          "arn:aws:s3:::shared-bucket",
+          "arn:aws:s3:::shared-bucket/*"
+        ]
+      }
+    ]
+  })
+}
@@ -3,7 +3,7 @@ resource "radosgw_iam_quota" "user_quota" {
   user_id     = radosgw_iam_user.example.user_id
   type        = "user"
   enabled     = true
-  max_size    = 10737418240 # 10 GB in bytes
+  max_size    = "10GiB"
   max_objects = 10000
 }
 
@@ -12,7 +12,7 @@ resource "radosgw_iam_quota" "bucket_quota" {
   user_id     = radosgw_iam_user.example.user_id
   type        = "bucket"
   enabled     = true
-  max_size    = 5368709120 # 5 GB in bytes
+  max_size    = "5GiB"
   max_objects = 5000
 }
 
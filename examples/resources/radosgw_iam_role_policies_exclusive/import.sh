@@ -0,0 +1,3 @@
+# Import exclusive inline policy management for a role
+# Format: role_name
+terraform import radosgw_iam_role_policies_exclusive.example "example-role"
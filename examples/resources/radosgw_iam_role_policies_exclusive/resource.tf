@@ -0,0 +1,9 @@
+# Only the listed inline policies may exist on the role.
+# Any other inline policy is removed on apply.
+resource "radosgw_iam_role_policies_exclusive" "example" {
+  role_name = radosgw_iam_role.example.name
+  policy_names = [
+    radosgw_iam_role_policy.s3_access.name,
+    radosgw_iam_role_policy.s3_readonly.name,
+  ]
+}
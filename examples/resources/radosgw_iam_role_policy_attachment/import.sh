@@ -0,0 +1,3 @@
+# Import a role policy attachment
+# Format: role_name:policy_arn
+terraform import radosgw_iam_role_policy_attachment.s3_readonly "example-role:arn:aws:iam::RGW11111111111111111:policy/S3ReadOnly"
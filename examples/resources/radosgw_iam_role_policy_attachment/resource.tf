@@ -0,0 +1,11 @@
+# Attach a managed policy to a role
+resource "radosgw_iam_role_policy_attachment" "s3_readonly" {
+  role       = radosgw_iam_role.example.name
+  policy_arn = radosgw_iam_policy.s3_readonly.arn
+}
+
+# Attach an AWS managed policy to a role
+resource "radosgw_iam_role_policy_attachment" "s3_full" {
+  role       = radosgw_iam_role.example.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonS3FullAccess"
+}
@@ -19,6 +19,14 @@ resource "radosgw_iam_subuser" "readonly" {
   access  = "read"
 }
 
+# Create a subuser without storing the auto-generated Swift key in state
+resource "radosgw_iam_subuser" "no_secret" {
+  user_id      = radosgw_iam_user.example.user_id
+  subuser      = "app"
+  access       = "read-write"
+  store_secret = false
+}
+
 # Reference user resource
 resource "radosgw_iam_user" "example" {
   user_id      = "subuser-example"
@@ -0,0 +1,3 @@
+# Import all subusers of a user
+# Format: user_id
+terraform import radosgw_iam_subusers.example "example-user"
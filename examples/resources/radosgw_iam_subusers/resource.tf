@@ -0,0 +1,23 @@
+# Only the listed subusers may exist on the user.
+# Any other subuser is removed on apply, together with its keys.
+resource "radosgw_iam_subusers" "example" {
+  user_id = radosgw_iam_user.example.user_id
+
+  subusers = {
+    swift    = "full-control"
+    readonly = "read"
+  }
+}
+
+# Manage the Swift key of a subuser explicitly
+resource "radosgw_iam_access_key" "swift" {
+  user_id  = radosgw_iam_subusers.example.user_id
+  subuser  = "swift"
+  key_type = "swift"
+}
+
+# Reference resources
+resource "radosgw_iam_user" "example" {
+  user_id      = "subusers-example-user"
+  display_name = "Subusers Example User"
+}
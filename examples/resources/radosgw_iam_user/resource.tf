@@ -18,9 +18,53 @@ resource "radosgw_iam_user" "custom" {
   default_placement = "default-placement"
 }
 
+# Create a user with a generated S3 key pair
+resource "radosgw_iam_user" "with_key" {
+  user_id      = "app-user"
+  display_name = "Application User"
+  generate_key = true
+}
+
+output "app_user_access_key" {
+  value     = radosgw_iam_user.with_key.access_key
+  sensitive = true
+}
+
+# Create a system user for multisite sync (requires system user credentials)
+resource "radosgw_iam_user" "sync" {
+  user_id      = "sync-user"
+  display_name = "Multisite Sync User"
+  system       = true
+  generate_key = true
+}
+
+# Create a service user that cannot be destroyed until deletion_protection
+# is set to false
+resource "radosgw_iam_user" "protected" {
+  user_id             = "billing-service"
+  display_name        = "Billing Service"
+  deletion_protection = true
+}
+
 # Create a suspended user
 resource "radosgw_iam_user" "suspended" {
   user_id      = "suspended-user"
   display_name = "Suspended User"
   suspended    = true
 }
+
+# Suspend a compromised user and delete its keys, so that they stay unusable
+# once the user is reinstated
+resource "radosgw_iam_user" "compromised" {
+  user_id               = "compromised-user"
+  display_name          = "Compromised User"
+  suspended             = true
+  purge_keys_on_suspend = true
+}
+
+# Create a user inside an account (Ceph Squid or later)
+resource "radosgw_iam_user" "account_member" {
+  user_id      = "account-member"
+  display_name = "Account Member"
+  account_id   = "RGW00000000000000001"
+}
@@ -0,0 +1,3 @@
+# Import the attributes of a user
+# Format: user_id
+terraform import radosgw_iam_user_attributes.analytics "analytics"
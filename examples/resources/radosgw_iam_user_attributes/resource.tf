@@ -0,0 +1,15 @@
+resource "radosgw_iam_user" "analytics" {
+  user_id      = "analytics"
+  display_name = "Analytics pipeline"
+}
+
+# Operator metadata stored on the user
+resource "radosgw_iam_user_attributes" "analytics" {
+  user_id = radosgw_iam_user.analytics.user_id
+
+  attributes = {
+    team        = "data-platform"
+    cost-center = "cc-4711"
+    contact     = "data-platform@example.com"
+  }
+}
@@ -0,0 +1,4 @@
+# Import a user policy
+# Format: user_id:policy_name
+terraform import radosgw_iam_user_policy.s3_access "policy-example-user:S3AccessPolicy"
+terraform import radosgw_iam_user_policy.s3_readonly "policy-example-user:S3ReadOnlyPolicy"
@@ -0,0 +1,51 @@
+# Create an inline policy for a user using jsonencode
+resource "radosgw_iam_user_policy" "s3_access" {
+  user = radosgw_iam_user.example.user_id
+  name = "S3AccessPolicy"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AllowS3Access"
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject",
+          "s3:PutObject",
+          "s3:DeleteObject",
+          "s3:ListBucket"
+        ]
+        Resource = [
+          "arn:aws:s3:::my-bucket",
+          "arn:aws:s3:::my-bucket/*"
+        ]
+      }
+    ]
+  })
+}
+
+# Create a policy using the policy document data source
+resource "radosgw_iam_user_policy" "s3_readonly" {
+  user   = radosgw_iam_user.example.user_id
+  name   = "S3ReadOnlyPolicy"
+  policy = data.radosgw_iam_policy_document.s3_readonly.json
+}
+
+# Policy document data source
+data "radosgw_iam_policy_document" "s3_readonly" {
+  statement {
+    sid     = "AllowS3ReadOnly"
+    effect  = "Allow"
+    actions = ["s3:GetObject", "s3:ListBucket"]
+    resources = [
+      "arn:aws:s3:::readonly-bucket",
+      "arn:aws:s3:::readonly-bucket/*"
+    ]
+  }
+}
+
+# Reference user resource
+resource "radosgw_iam_user" "example" {
+  user_id      = "policy-example-user"
+  display_name = "Policy Example User"
+}
@@ -0,0 +1,3 @@
+# Import a user policy attachment
+# Format: user_name:policy_arn
+terraform import radosgw_iam_user_policy_attachment.s3_readonly "example-user:arn:aws:iam::RGW11111111111111111:policy/S3ReadOnly"
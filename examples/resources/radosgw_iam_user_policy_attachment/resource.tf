@@ -0,0 +1,6 @@
+# Attach a managed policy to an account user.
+# The IAM user name of an account user is its display name.
+resource "radosgw_iam_user_policy_attachment" "s3_readonly" {
+  user       = radosgw_iam_user.example.display_name
+  policy_arn = radosgw_iam_policy.s3_readonly.arn
+}
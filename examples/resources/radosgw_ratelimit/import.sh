@@ -0,0 +1,6 @@
+# Import a user rate limit
+# Format: scope:target_id (scope is "user" or "bucket")
+terraform import radosgw_ratelimit.user user:example-user
+
+# Import a bucket rate limit (use tenant/bucket for buckets in a tenant)
+terraform import radosgw_ratelimit.bucket bucket:example-bucket
@@ -0,0 +1,25 @@
+# User rate limit - applies to all requests made by the user
+resource "radosgw_ratelimit" "user" {
+  scope           = "user"
+  target_id       = radosgw_iam_user.example.user_id
+  max_read_ops    = 1000
+  max_write_ops   = 100
+  max_read_bytes  = 104857600 # 100 MB per minute
+  max_write_bytes = 10485760  # 10 MB per minute
+}
+
+# Bucket rate limit - applies to all requests made to the bucket
+resource "radosgw_ratelimit" "bucket" {
+  scope         = "bucket"
+  target_id     = radosgw_s3_bucket.example.bucket
+  max_write_ops = 50
+}
+
+resource "radosgw_iam_user" "example" {
+  user_id      = "ratelimit-example-user"
+  display_name = "Rate Limit Example User"
+}
+
+resource "radosgw_s3_bucket" "example" {
+  bucket = "ratelimit-example-bucket"
+}
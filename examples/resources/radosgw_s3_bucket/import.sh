@@ -3,3 +3,6 @@ terraform import radosgw_s3_bucket.example "my-bucket-name"
 
 # Import a bucket with special characters in the name
 terraform import radosgw_s3_bucket.logs "my-app-logs-2024"
+
+# Import a bucket of a tenant
+terraform import radosgw_s3_bucket.tenant "my-tenant:my-bucket-name"
@@ -3,11 +3,43 @@ resource "radosgw_s3_bucket" "example" {
   bucket = "my-example-bucket"
 }
 
+# Create a bucket with a generated unique name, e.g. "ci-run-20260101120000a1b2c3d4"
+resource "radosgw_s3_bucket" "generated" {
+  bucket_prefix = "ci-run-"
+  force_destroy = true
+
+  # Refuse to destroy the bucket if it has been filled with real data
+  max_objects_on_destroy = 1000
+}
+
+# Manage a bucket that was created outside of Terraform, instead of failing
+# because it already exists
+resource "radosgw_s3_bucket" "adopted" {
+  bucket         = "legacy-app-bucket"
+  adopt_existing = true
+  versioning     = "enabled"
+}
+
+# Create a bucket that cannot be destroyed or replaced until
+# deletion_protection is set to false
+resource "radosgw_s3_bucket" "protected" {
+  bucket              = "my-critical-bucket"
+  deletion_protection = true
+}
+
 # Create a bucket with force_destroy enabled
 # This allows the bucket to be deleted even if it contains objects
 resource "radosgw_s3_bucket" "with_force_destroy" {
   bucket        = "my-temporary-bucket"
   force_destroy = true
+
+  # Delete the objects in batches through the S3 API, logging the progress
+  force_destroy_mode = "s3"
+
+  # Purging millions of objects can take longer than the default of 1h
+  timeouts {
+    delete = "6h"
+  }
 }
 
 # Create a bucket with object lock enabled
@@ -29,7 +61,7 @@ resource "radosgw_s3_bucket" "with_quota" {
 
   bucket_quota = {
     enabled     = true
-    max_size    = 10737418240 # 10 GB in bytes
+    max_size    = "10GiB"
     max_objects = 10000
   }
 }
@@ -41,6 +73,14 @@ resource "radosgw_s3_bucket" "with_tenant" {
   tenant = "mytenant"
 }
 
+# Create a bucket on a specific placement target, e.g. backed by SSD pools
+# Note: the placement target must exist in the zonegroup and
+# placement_rule cannot be changed after creation
+resource "radosgw_s3_bucket" "with_placement" {
+  bucket         = "my-fast-bucket"
+  placement_rule = "ssd-placement"
+}
+
 # Create a fully configured bucket
 resource "radosgw_s3_bucket" "full_example" {
   bucket        = "my-full-bucket"
@@ -49,7 +89,7 @@ resource "radosgw_s3_bucket" "full_example" {
 
   bucket_quota = {
     enabled     = true
-    max_size    = 53687091200 # 50 GB
+    max_size    = "50GiB"
     max_objects = 100000
   }
 }
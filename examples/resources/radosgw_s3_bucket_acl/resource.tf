@@ -37,3 +37,15 @@ resource "radosgw_s3_bucket_acl" "auth_read" {
   bucket = radosgw_s3_bucket.auth_read.bucket
   acl    = "authenticated-read"
 }
+
+# Manage the ACL of a bucket owned by another user, with the keys of the owner
+resource "radosgw_iam_access_key" "owner" {
+  user_id = "bucket-owner"
+}
+
+resource "radosgw_s3_bucket_acl" "owned" {
+  bucket        = "owner-bucket"
+  acl           = "public-read"
+  s3_access_key = radosgw_iam_access_key.owner.access_key
+  s3_secret_key = radosgw_iam_access_key.owner.secret_key
+}
@@ -0,0 +1,14 @@
+# Move every bucket of an offboarded tenant to an archive user
+data "radosgw_s3_buckets" "offboarded" {
+  tenant = "offboarded"
+}
+
+resource "radosgw_iam_user" "archive" {
+  user_id      = "offboarded$archive"
+  display_name = "Archive"
+}
+
+resource "radosgw_s3_bucket_bulk_link" "archive" {
+  links           = { for name in data.radosgw_s3_buckets.offboarded.names : name => radosgw_iam_user.archive.user_id }
+  max_concurrency = 20
+}
@@ -1,2 +1,5 @@
 # Import a bucket lifecycle configuration by bucket name
 terraform import radosgw_s3_bucket_lifecycle_configuration.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_lifecycle_configuration.tenant "my-tenant:my-bucket-name"
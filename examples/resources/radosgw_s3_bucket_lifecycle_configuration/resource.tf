@@ -170,6 +170,41 @@ resource "radosgw_s3_bucket_lifecycle_configuration" "complex_filter" {
   }
 }
 
+# Expire objects on a fixed date
+resource "radosgw_s3_bucket_lifecycle_configuration" "expire_on_date" {
+  bucket = radosgw_s3_bucket.example.bucket
+
+  rule {
+    id     = "expire-on-date"
+    status = "Enabled"
+
+    expiration {
+      date = "2030-01-01T00:00:00Z"
+    }
+  }
+}
+
+# Filter by object size (requires Ceph Squid or later)
+resource "radosgw_s3_bucket_lifecycle_configuration" "large_objects" {
+  bucket = radosgw_s3_bucket.example.bucket
+
+  rule {
+    id     = "expire-large-uploads"
+    status = "Enabled"
+
+    filter {
+      and {
+        prefix                   = "uploads/"
+        object_size_greater_than = 104857600
+      }
+    }
+
+    expiration {
+      days = 30
+    }
+  }
+}
+
 # Disabled rule (for temporary suspension)
 resource "radosgw_s3_bucket_lifecycle_configuration" "disabled_rule" {
   bucket = radosgw_s3_bucket.example.bucket
@@ -183,3 +218,23 @@ resource "radosgw_s3_bucket_lifecycle_configuration" "disabled_rule" {
     }
   }
 }
+
+# Rules written with the legacy rule-level prefix, for old clients that parse
+# the lifecycle configuration themselves
+resource "radosgw_s3_bucket_lifecycle_configuration" "legacy" {
+  bucket               = radosgw_s3_bucket.example.bucket
+  legacy_prefix_compat = true
+
+  rule {
+    id     = "expire-tmp"
+    status = "Enabled"
+
+    filter {
+      prefix = "tmp/"
+    }
+
+    expiration {
+      days = 1
+    }
+  }
+}
@@ -4,5 +4,5 @@ terraform import radosgw_s3_bucket_link.example "my-bucket"
 # Import with explicit owner (bucket:uid format)
 terraform import radosgw_s3_bucket_link.example "my-bucket:bucket-owner"
 
-# Import a bucket in a tenant
-terraform import radosgw_s3_bucket_link.tenant "my-bucket:tenant1\$user1"
+# Import a bucket in a tenant (tenant/bucket:uid format)
+terraform import radosgw_s3_bucket_link.tenant "tenant1/my-bucket:tenant1\$user1"
@@ -6,50 +6,59 @@ resource "radosgw_s3_bucket" "managed" {
 
 resource "radosgw_s3_bucket_link" "managed" {
   bucket = radosgw_s3_bucket.managed.bucket
-  uid    = radosgw_user.new_owner.user_id
+  uid    = radosgw_iam_user.new_owner.user_id
 
   # On destroy, transfer back to original owner
-  unlink_to_uid = radosgw_user.original_owner.user_id
+  unlink_to_uid = radosgw_iam_user.original_owner.user_id
 }
 
 # Transfer ownership of an existing bucket
 resource "radosgw_s3_bucket_link" "transfer" {
   bucket = "existing-bucket"
-  uid    = radosgw_user.new_owner.user_id
+  uid    = radosgw_iam_user.new_owner.user_id
 }
 
 # Transfer bucket with automatic reversion on destroy
 resource "radosgw_s3_bucket_link" "temporary" {
   bucket        = "shared-bucket"
-  uid           = radosgw_user.temporary_user.user_id
-  unlink_to_uid = radosgw_user.original_owner.user_id
+  uid           = radosgw_iam_user.temporary_user.user_id
+  unlink_to_uid = radosgw_iam_user.original_owner.user_id
 }
 
-# Rename a bucket while transferring ownership
+# Rename a bucket while transferring ownership. Changing new_bucket_name
+# later renames the bucket in place.
 resource "radosgw_s3_bucket_link" "rename" {
   bucket          = "old-bucket-name"
-  uid             = radosgw_user.new_owner.user_id
+  uid             = radosgw_iam_user.new_owner.user_id
   new_bucket_name = "new-bucket-name"
 }
 
 # Move bucket between tenants
 resource "radosgw_s3_bucket_link" "tenant_move" {
   bucket = "bucket-to-move"
-  uid    = "tenant1$user1" # tenant$user format
+  tenant = "tenant1"       # tenant of the bucket before the link
+  uid    = "tenant2$user2" # tenant$user format, the bucket moves to tenant2
+}
+
+# Link a specific bucket instance when stale instances exist
+resource "radosgw_s3_bucket_link" "pinned" {
+  bucket    = "resharded-bucket"
+  bucket_id = "f3b4c5d6-1234-5678-9abc-def012345678.4567.1"
+  uid       = radosgw_iam_user.new_owner.user_id
 }
 
 # Reference user resources
-resource "radosgw_user" "new_owner" {
+resource "radosgw_iam_user" "new_owner" {
   user_id      = "new-owner"
   display_name = "New Bucket Owner"
 }
 
-resource "radosgw_user" "original_owner" {
+resource "radosgw_iam_user" "original_owner" {
   user_id      = "original-owner"
   display_name = "Original Bucket Owner"
 }
 
-resource "radosgw_user" "temporary_user" {
+resource "radosgw_iam_user" "temporary_user" {
   user_id      = "temporary-user"
   display_name = "Temporary User"
 }
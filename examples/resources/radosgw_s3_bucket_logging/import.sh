@@ -0,0 +1,5 @@
+# Import bucket logging by bucket name
+terraform import radosgw_s3_bucket_logging.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_logging.tenant "my-tenant:my-bucket-name"
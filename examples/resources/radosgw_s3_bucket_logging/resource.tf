@@ -0,0 +1,14 @@
+resource "radosgw_s3_bucket" "app" {
+  bucket = "app-data"
+}
+
+resource "radosgw_s3_bucket" "logs" {
+  bucket = "app-access-logs"
+}
+
+# Write access logs of app-data into app-access-logs
+resource "radosgw_s3_bucket_logging" "app" {
+  bucket        = radosgw_s3_bucket.app.bucket
+  target_bucket = radosgw_s3_bucket.logs.bucket
+  target_prefix = "app-data/"
+}
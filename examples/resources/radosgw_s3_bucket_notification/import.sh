@@ -1,2 +1,5 @@
 # Import a bucket notification using the bucket name
 terraform import radosgw_s3_bucket_notification.example "my-data-bucket"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_notification.tenant "my-tenant:my-bucket-name"
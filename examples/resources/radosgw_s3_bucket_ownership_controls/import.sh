@@ -0,0 +1,5 @@
+# Import bucket ownership controls by bucket name
+terraform import radosgw_s3_bucket_ownership_controls.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_ownership_controls.tenant "my-tenant:my-bucket-name"
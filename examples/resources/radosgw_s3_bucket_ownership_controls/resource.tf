@@ -0,0 +1,10 @@
+resource "radosgw_s3_bucket" "shared" {
+  bucket = "shared-uploads"
+}
+
+# Make the bucket owner own every object, including objects uploaded by
+# other users through the bucket policy
+resource "radosgw_s3_bucket_ownership_controls" "shared" {
+  bucket           = radosgw_s3_bucket.shared.bucket
+  object_ownership = "BucketOwnerEnforced"
+}
@@ -1,2 +1,5 @@
 # Import a bucket policy by bucket name
 terraform import radosgw_s3_bucket_policy.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_policy.tenant "my-tenant:my-bucket-name"
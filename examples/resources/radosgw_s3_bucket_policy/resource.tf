@@ -94,9 +94,12 @@ data "radosgw_iam_policy_document" "restricted" {
   }
 }
 
+# Fail the refresh instead of silently restoring the policy when
+# someone changes it outside of Terraform
 resource "radosgw_s3_bucket_policy" "restricted" {
-  bucket = radosgw_s3_bucket.restricted.bucket
-  policy = data.radosgw_iam_policy_document.restricted.json
+  bucket   = radosgw_s3_bucket.restricted.bucket
+  policy   = data.radosgw_iam_policy_document.restricted.json
+  on_drift = "error"
 }
 
 # Policy with conditions
@@ -134,3 +137,26 @@ resource "radosgw_s3_bucket_policy" "conditional" {
   bucket = radosgw_s3_bucket.conditional.bucket
   policy = data.radosgw_iam_policy_document.conditional.json
 }
+
+# Bucket policy on a bucket that belongs to a tenant
+resource "radosgw_s3_bucket" "tenant_bucket" {
+  bucket = "my-tenant-bucket"
+  tenant = "mytenant"
+}
+
+resource "radosgw_s3_bucket_policy" "tenant_bucket" {
+  bucket = radosgw_s3_bucket.tenant_bucket.bucket
+  tenant = radosgw_s3_bucket.tenant_bucket.tenant
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3::mytenant:my-tenant-bucket/*"
+      }
+    ]
+  })
+}
@@ -0,0 +1,5 @@
+# Import a bucket quota by bucket name
+terraform import radosgw_s3_bucket_quota.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_quota.tenant "my-tenant:my-bucket-name"
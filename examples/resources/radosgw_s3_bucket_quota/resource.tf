@@ -0,0 +1,13 @@
+# Limit a bucket created outside Terraform
+resource "radosgw_s3_bucket_quota" "example" {
+  bucket      = "existing-bucket"
+  max_size    = "10GiB"
+  max_objects = 100000
+}
+
+# Limit a bucket of a tenant
+resource "radosgw_s3_bucket_quota" "tenant" {
+  bucket   = "shared-data"
+  tenant   = "acme"
+  max_size = "500M"
+}
@@ -0,0 +1,5 @@
+# Import the default encryption of a bucket by bucket name
+terraform import radosgw_s3_bucket_server_side_encryption_configuration.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_server_side_encryption_configuration.tenant "my-tenant:my-bucket-name"
@@ -0,0 +1,17 @@
+resource "radosgw_s3_bucket" "example" {
+  bucket = "encrypted-bucket"
+}
+
+# Encrypt new objects with SSE-S3
+resource "radosgw_s3_bucket_server_side_encryption_configuration" "sse_s3" {
+  bucket        = radosgw_s3_bucket.example.bucket
+  sse_algorithm = "AES256"
+}
+
+# Encrypt new objects with a key stored in Vault
+resource "radosgw_s3_bucket_server_side_encryption_configuration" "sse_kms" {
+  bucket            = "tenant-data"
+  tenant            = "acme"
+  sse_algorithm     = "aws:kms"
+  kms_master_key_id = "acme-data-key"
+}
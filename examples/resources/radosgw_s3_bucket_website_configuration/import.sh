@@ -1,2 +1,5 @@
 # Import a bucket website configuration by bucket name
 terraform import radosgw_s3_bucket_website_configuration.example "my-bucket-name"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_bucket_website_configuration.tenant "my-tenant:my-bucket-name"
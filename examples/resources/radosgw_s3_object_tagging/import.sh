@@ -0,0 +1,5 @@
+# Import object tagging by bucket name and object key
+terraform import radosgw_s3_object_tagging.example "my-bucket-name/path/to/object.csv"
+
+# Buckets of a tenant are given as tenant:bucket
+terraform import radosgw_s3_object_tagging.tenant "my-tenant:my-bucket-name/path/to/object.csv"
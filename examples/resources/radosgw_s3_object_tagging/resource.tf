@@ -0,0 +1,31 @@
+resource "radosgw_s3_bucket" "reports" {
+  bucket = "reports"
+}
+
+# Tag an object uploaded by an application, so that lifecycle rules
+# filtering on the tag apply to it
+resource "radosgw_s3_object_tagging" "quarterly" {
+  bucket = radosgw_s3_bucket.reports.bucket
+  key    = "2024/q4.csv"
+
+  tags = {
+    classification = "internal"
+    retention      = "long-term"
+  }
+}
+
+# Also fix the metadata of the object, which copies it onto itself
+resource "radosgw_s3_object_tagging" "index" {
+  bucket = radosgw_s3_bucket.reports.bucket
+  key    = "index.html"
+
+  tags = {
+    classification = "public"
+  }
+
+  content_type  = "text/html"
+  cache_control = "max-age=300"
+  metadata = {
+    owner = "web"
+  }
+}
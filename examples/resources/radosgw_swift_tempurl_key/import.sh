@@ -0,0 +1,3 @@
+# Import the temp URL keys of a Swift account
+# Format: user_id:subuser_name
+terraform import radosgw_swift_tempurl_key.example "tempurl-example:swift"
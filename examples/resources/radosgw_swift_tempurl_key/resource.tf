@@ -0,0 +1,23 @@
+resource "radosgw_iam_user" "example" {
+  user_id      = "tempurl-example"
+  display_name = "Temp URL Example User"
+}
+
+# The subuser authenticates against the Swift API to set the account metadata
+resource "radosgw_iam_subuser" "swift" {
+  user_id = radosgw_iam_user.example.user_id
+  subuser = "swift"
+  access  = "full-control"
+}
+
+variable "tempurl_key" {
+  type      = string
+  sensitive = true
+}
+
+# Set the temp URL key used to sign temporary URLs
+resource "radosgw_swift_tempurl_key" "example" {
+  user_id = radosgw_iam_subuser.swift.user_id
+  subuser = radosgw_iam_subuser.swift.subuser
+  key     = var.tempurl_key
+}
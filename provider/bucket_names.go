@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Length limits of bucket names. RadosGW accepts up to 255 characters when
+// rgw_relaxed_s3_bucket_names is enabled, and DNS compliant names otherwise.
+const (
+	minBucketNameLength        = 3
+	maxBucketNameLength        = 63
+	maxRelaxedBucketNameLength = 255
+)
+
+// validateBucketName checks a bucket name against the naming rules of
+// RadosGW. Strict rules are the DNS compliant names RadosGW enforces by
+// default; relaxed rules are those of rgw_relaxed_s3_bucket_names = true.
+func validateBucketName(name string, strict bool) error {
+	maxLength := maxRelaxedBucketNameLength
+	if strict {
+		maxLength = maxBucketNameLength
+	}
+	if len(name) < minBucketNameLength || len(name) > maxLength {
+		return fmt.Errorf("must be between %d and %d characters long", minBucketNameLength, maxLength)
+	}
+
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '.':
+		case c >= 'A' && c <= 'Z' && !strict:
+		case c == '_' && !strict:
+		default:
+			if strict {
+				return fmt.Errorf("must only contain lowercase letters, numbers, hyphens and periods, got %q", c)
+			}
+			return fmt.Errorf("must only contain letters, numbers, hyphens, underscores and periods, got %q", c)
+		}
+	}
+
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("must not be formatted as an IP address")
+	}
+
+	if strict {
+		if !isLowerAlphanumeric(name[0]) || !isLowerAlphanumeric(name[len(name)-1]) {
+			return fmt.Errorf("must start and end with a lowercase letter or number")
+		}
+		if strings.Contains(name, "..") {
+			return fmt.Errorf("must not contain two adjacent periods")
+		}
+	}
+
+	return nil
+}
+
+func isLowerAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+// validateStrictBucketName reports a bucket name that breaks the strict
+// naming rules, unless the provider is configured with
+// strict_bucket_names = false. The relaxed rules are checked by
+// bucketNameValidator, which does not know the provider configuration.
+func (c *RadosgwClient) validateStrictBucketName(diags *diag.Diagnostics, attributePath path.Path, name string) {
+	if !c.StrictBucketNames || name == "" {
+		return
+	}
+
+	if err := validateBucketName(name, true); err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Bucket Name",
+			fmt.Sprintf("Bucket name %q %s. If the cluster runs with rgw_relaxed_s3_bucket_names enabled, "+
+				"set the strict_bucket_names provider attribute to false.", name, err.Error()),
+		)
+	}
+}
+
+// bucketNameValidator validates a bucket name against the relaxed naming
+// rules, which every RadosGW cluster enforces. Set tenantQualified for
+// attributes that also accept the "tenant/bucket" and "tenant:bucket" forms.
+type bucketNameValidator struct {
+	tenantQualified bool
+}
+
+func (v bucketNameValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a bucket name of %d to %d letters, numbers, hyphens, underscores and periods",
+		minBucketNameLength, maxRelaxedBucketNameLength)
+}
+
+func (v bucketNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v bucketNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+	if v.tenantQualified {
+		_, name = splitBucketID(name)
+	}
+
+	if err := validateBucketName(name, false); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Bucket Name",
+			fmt.Sprintf("Bucket name %q %s.", name, err.Error()),
+		)
+	}
+}
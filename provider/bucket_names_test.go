@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateBucketName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name         string
+		strictError  bool
+		relaxedError bool
+	}{
+		"simple":           {name: "my-bucket"},
+		"periods":          {name: "logs.example.com"},
+		"digits":           {name: "123"},
+		"too short":        {name: "ab", strictError: true, relaxedError: true},
+		"63 characters":    {name: strings.Repeat("a", 63)},
+		"64 characters":    {name: strings.Repeat("a", 64), strictError: true},
+		"255 characters":   {name: strings.Repeat("a", 255), strictError: true},
+		"256 characters":   {name: strings.Repeat("a", 256), strictError: true, relaxedError: true},
+		"uppercase":        {name: "MyBucket", strictError: true},
+		"underscore":       {name: "my_bucket", strictError: true},
+		"leading hyphen":   {name: "-bucket", strictError: true},
+		"trailing period":  {name: "bucket.", strictError: true},
+		"adjacent periods": {name: "my..bucket", strictError: true},
+		"ip address":       {name: "192.168.1.1", strictError: true, relaxedError: true},
+		"slash":            {name: "tenant/bucket", strictError: true, relaxedError: true},
+		"colon":            {name: "tenant:bucket", strictError: true, relaxedError: true},
+		"space":            {name: "my bucket", strictError: true, relaxedError: true},
+		"non-ascii":        {name: "bücket", strictError: true, relaxedError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := validateBucketName(testCase.name, true); (err != nil) != testCase.strictError {
+				t.Errorf("strict: expected error %t, got %v", testCase.strictError, err)
+			}
+			if err := validateBucketName(testCase.name, false); (err != nil) != testCase.relaxedError {
+				t.Errorf("relaxed: expected error %t, got %v", testCase.relaxedError, err)
+			}
+		})
+	}
+}
+
+func TestBucketNameValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		validator   bucketNameValidator
+		value       types.String
+		expectError bool
+	}{
+		"null":                  {value: types.StringNull()},
+		"unknown":               {value: types.StringUnknown()},
+		"relaxed name":          {value: types.StringValue("My_Bucket")},
+		"invalid name":          {value: types.StringValue("my bucket"), expectError: true},
+		"tenant not allowed":    {value: types.StringValue("tenant/bucket"), expectError: true},
+		"admin form":            {validator: bucketNameValidator{tenantQualified: true}, value: types.StringValue("tenant/bucket")},
+		"s3 form":               {validator: bucketNameValidator{tenantQualified: true}, value: types.StringValue("tenant:bucket")},
+		"invalid tenant bucket": {validator: bucketNameValidator{tenantQualified: true}, value: types.StringValue("tenant:b"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("bucket"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+			testCase.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestValidateStrictBucketName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		strict      bool
+		name        string
+		expectError bool
+	}{
+		"strict valid":   {strict: true, name: "my-bucket"},
+		"strict invalid": {strict: true, name: "My_Bucket", expectError: true},
+		"strict empty":   {strict: true, name: ""},
+		"relaxed":        {strict: false, name: "My_Bucket"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &RadosgwClient{StrictBucketNames: testCase.strict}
+			var diags diag.Diagnostics
+			client.validateStrictBucketName(&diags, path.Root("bucket"), testCase.name)
+
+			if diags.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AdminRawDataSource{}
+
+func NewAdminRawDataSource() datasource.DataSource {
+	return &AdminRawDataSource{}
+}
+
+// AdminRawDataSource reads arbitrary endpoints of the Admin Ops API.
+type AdminRawDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// AdminRawDataSourceModel describes the data source data model.
+type AdminRawDataSourceModel struct {
+	Path     types.String `tfsdk:"path"`
+	Query    types.Map    `tfsdk:"query"`
+	Response types.String `tfsdk:"response"`
+	ID       types.String `tfsdk:"id"`
+}
+
+func (d *AdminRawDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_raw"
+}
+
+func (d *AdminRawDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a raw `GET` request to the RadosGW [Admin Ops API](https://docs.ceph.com/en/latest/radosgw/adminops/) " +
+			"and returns the response. Use it to read information the provider does not model yet. The request is signed " +
+			"with the provider credentials, so the user needs the capabilities of the called endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the request relative to `/admin`, e.g. `/user` or `/bucket`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"query": schema.MapAttribute{
+				MarkdownDescription: "The query parameters of the request. The `format` parameter is always set to `json`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The response body. JSON responses are normalized; use `jsondecode()` to read them.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The path of the request.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AdminRawDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *AdminRawDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config AdminRawDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := adminRawQuery(ctx, config.Query)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestPath := adminRawPath(config.Path.ValueString())
+
+	tflog.Debug(ctx, "Reading raw Admin API data source", map[string]any{
+		"path": requestPath,
+	})
+
+	body, err := d.adminClient.DoRequest(ctx, http.MethodGet, requestPath, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Sending Admin API Request",
+			fmt.Sprintf("Could not send GET %s: %s", requestPath, err.Error()),
+		)
+		return
+	}
+
+	config.Response = types.StringValue(adminRawResponse(body))
+	config.ID = types.StringValue(requestPath)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ARNDataSource{}
+
+func NewARNDataSource() datasource.DataSource {
+	return &ARNDataSource{}
+}
+
+// ARNDataSource builds the ARN of a RadosGW user, role, OIDC provider, bucket
+// or object.
+type ARNDataSource struct{}
+
+// ARNDataSourceModel describes the data source data model.
+type ARNDataSourceModel struct {
+	Type   types.String `tfsdk:"type"`
+	Name   types.String `tfsdk:"name"`
+	Tenant types.String `tfsdk:"tenant"`
+	Path   types.String `tfsdk:"path"`
+	Key    types.String `tfsdk:"key"`
+	ARN    types.String `tfsdk:"arn"`
+}
+
+// ARN types supported by the data source.
+const (
+	arnTypeUser         = "user"
+	arnTypeRole         = "role"
+	arnTypeOIDCProvider = "oidc-provider"
+	arnTypeBucket       = "bucket"
+	arnTypeObject       = "object"
+)
+
+func (d *ARNDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_arn"
+}
+
+func (d *ARNDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds the ARN RadosGW uses for a user, role, OIDC provider, bucket or object, for use in " +
+			"`radosgw_iam_policy_document` principals and resources. RadosGW places the tenant (or the account ID of " +
+			"account resources) in the account field of IAM ARNs and, unlike AWS, of S3 ARNs too, so ARNs assembled by " +
+			"hand as `arn:aws:s3:::bucket` silently stop matching tenanted buckets.\n\n" +
+			"The ARN is computed locally without calling RadosGW.",
+
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the ARN. One of `user`, `role`, `oidc-provider`, `bucket` and `object`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(arnTypeUser, arnTypeRole, arnTypeOIDCProvider, arnTypeBucket, arnTypeObject),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The user ID (`uid:subuser` for a subuser), role name, OIDC provider URL or bucket " +
+					"name. User IDs of the form `tenant$uid` and bucket names of the form `tenant:bucket` or " +
+					"`tenant/bucket` also set the tenant.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant, or the account ID for resources of an account. Leave unset for " +
+					"resources without a tenant.",
+				Optional: true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of a role, e.g. `/application/`. Only valid for `role`. Default is `/`.",
+				Optional:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the object, which may contain `*` wildcards, e.g. `logs/*`. Required for " +
+					"`object` and only valid for it.",
+				Optional: true,
+			},
+			"arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ARNDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// This data source doesn't need any provider configuration
+}
+
+func (d *ARNDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ARNDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	arnType := data.Type.ValueString()
+	if !data.Path.IsNull() && arnType != arnTypeRole {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid Attribute Combination",
+			fmt.Sprintf("path is only valid for role ARNs, not %s ARNs.", arnType))
+	}
+	if !data.Key.IsNull() && arnType != arnTypeObject {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Invalid Attribute Combination",
+			fmt.Sprintf("key is only valid for object ARNs, not %s ARNs.", arnType))
+	}
+	if data.Key.IsNull() && arnType == arnTypeObject {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Missing Required Attribute",
+			"key is required for object ARNs.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	arn, err := buildARN(arnType, data.Tenant.ValueString(), data.Name.ValueString(), data.Path.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ARN Component", err.Error())
+		return
+	}
+
+	data.ARN = types.StringValue(arn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// buildARN returns the RadosGW ARN of type arnType. A tenant qualifying name
+// must match tenant when both are set.
+func buildARN(arnType, tenant, name, rolePath, key string) (string, error) {
+	var nameTenant string
+	switch arnType {
+	case arnTypeUser:
+		nameTenant, name = splitUserID(name)
+	case arnTypeBucket, arnTypeObject:
+		nameTenant, name = splitBucketID(name)
+		if err := validateBucketName(name, false); err != nil {
+			return "", fmt.Errorf("bucket name %q %s", name, err.Error())
+		}
+	}
+	if nameTenant != "" {
+		if tenant != "" && tenant != nameTenant {
+			return "", fmt.Errorf("name %q belongs to tenant %q, but tenant is %q", name, nameTenant, tenant)
+		}
+		tenant = nameTenant
+	}
+	if name == "" {
+		return "", fmt.Errorf("name must not be empty")
+	}
+
+	switch arnType {
+	case arnTypeUser:
+		return fmt.Sprintf("arn:aws:iam::%s:user/%s", tenant, name), nil
+	case arnTypeRole:
+		if rolePath == "" {
+			rolePath = "/"
+		}
+		if !strings.HasPrefix(rolePath, "/") || !strings.HasSuffix(rolePath, "/") {
+			return "", fmt.Errorf("role path %q must start and end with /", rolePath)
+		}
+		return fmt.Sprintf("arn:aws:iam::%s:role%s%s", tenant, rolePath, name), nil
+	case arnTypeOIDCProvider:
+		return fmt.Sprintf("arn:aws:iam::%s:oidc-provider/%s", tenant, oidcIssuer(name)), nil
+	case arnTypeBucket:
+		return fmt.Sprintf("arn:aws:s3::%s:%s", tenant, name), nil
+	case arnTypeObject:
+		return fmt.Sprintf("arn:aws:s3::%s:%s/%s", tenant, name, key), nil
+	}
+	return "", fmt.Errorf("unsupported ARN type %q", arnType)
+}
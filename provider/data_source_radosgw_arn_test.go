@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBuildARN(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		arnType     string
+		tenant      string
+		name        string
+		path        string
+		key         string
+		expected    string
+		expectError string
+	}{
+		"user":                 {arnType: "user", name: "alice", expected: "arn:aws:iam:::user/alice"},
+		"tenant user":          {arnType: "user", tenant: "acme", name: "alice", expected: "arn:aws:iam::acme:user/alice"},
+		"qualified user":       {arnType: "user", name: "acme$alice", expected: "arn:aws:iam::acme:user/alice"},
+		"same tenant":          {arnType: "user", tenant: "acme", name: "acme$alice", expected: "arn:aws:iam::acme:user/alice"},
+		"conflicting tenant":   {arnType: "user", tenant: "other", name: "acme$alice", expectError: "belongs to tenant"},
+		"subuser":              {arnType: "user", tenant: "acme", name: "alice:swift", expected: "arn:aws:iam::acme:user/alice:swift"},
+		"role":                 {arnType: "role", tenant: "RGW11111111111111111", name: "app", expected: "arn:aws:iam::RGW11111111111111111:role/app"},
+		"role path":            {arnType: "role", name: "app", path: "/team/", expected: "arn:aws:iam:::role/team/app"},
+		"invalid role path":    {arnType: "role", name: "app", path: "team", expectError: "must start and end with /"},
+		"oidc provider":        {arnType: "oidc-provider", name: "https://idp.example.com/realms/ceph", expected: "arn:aws:iam:::oidc-provider/idp.example.com/realms/ceph"},
+		"bucket":               {arnType: "bucket", name: "data", expected: "arn:aws:s3:::data"},
+		"tenant bucket":        {arnType: "bucket", tenant: "acme", name: "data", expected: "arn:aws:s3::acme:data"},
+		"qualified bucket":     {arnType: "bucket", name: "acme/data", expected: "arn:aws:s3::acme:data"},
+		"invalid bucket":       {arnType: "bucket", name: "Data!", expectError: "bucket name"},
+		"object":               {arnType: "object", tenant: "acme", name: "data", key: "logs/*", expected: "arn:aws:s3::acme:data/logs/*"},
+		"qualified object":     {arnType: "object", name: "acme:data", key: "*", expected: "arn:aws:s3::acme:data/*"},
+		"empty qualified user": {arnType: "user", name: "acme$", expectError: "must not be empty"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			arn, err := buildARN(testCase.arnType, testCase.tenant, testCase.name, testCase.path, testCase.key)
+			if testCase.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+					t.Fatalf("expected error containing %q, got %v", testCase.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if arn != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, arn)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwARNDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwARNDataSourceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_arn.user", "arn", "arn:aws:iam::acme:user/alice"),
+					resource.TestCheckResourceAttr("data.radosgw_arn.objects", "arn", "arn:aws:s3::acme:data/logs/*"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwARNDataSourceConfig_basic() string {
+	return providerConfig() + `
+data "radosgw_arn" "user" {
+  type = "user"
+  name = "acme$alice"
+}
+
+data "radosgw_arn" "objects" {
+  type   = "object"
+  tenant = "acme"
+  name   = "data"
+  key    = "logs/*"
+}
+`
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DriftMarkerDataSource{}
+
+func NewDriftMarkerDataSource() datasource.DataSource {
+	return &DriftMarkerDataSource{}
+}
+
+// DriftMarkerDataSource reports whether users or buckets were modified
+// after a given point in time, so pipelines can refresh only the affected
+// resources instead of the whole state.
+type DriftMarkerDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// DriftMarkerDataSourceModel describes the data source data model.
+type DriftMarkerDataSourceModel struct {
+	Since          types.String `tfsdk:"since"`
+	UserIDs        types.Set    `tfsdk:"user_ids"`
+	Buckets        types.Set    `tfsdk:"buckets"`
+	ChangedUserIDs types.Set    `tfsdk:"changed_user_ids"`
+	ChangedBuckets types.Set    `tfsdk:"changed_buckets"`
+	DriftLikely    types.Bool   `tfsdk:"drift_likely"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (d *DriftMarkerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_drift_marker"
+}
+
+func (d *DriftMarkerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Reports which of the given users and buckets were modified in RadosGW after a point in time. ` +
+			`Pipelines can use the result as a "drift likely" marker and run targeted refreshes ` +
+			`(e.g. ` + "`terraform plan -refresh-only -target=...`" + `) instead of refreshing thousands of resources.
+
+A user or bucket is reported as changed when its metadata modification time is later than ` + "`since`" + `, ` +
+			`or when it no longer exists. Bucket metadata changes include policy, ACL, lifecycle, notification and ownership updates; ` +
+			`object writes are not tracked.
+
+~> **Note:** This data source is experimental. RadosGW delivers bucket notifications only by pushing them to an ` +
+			`external endpoint, so the provider cannot subscribe to a notification topic. It inspects metadata modification ` +
+			`times through the Admin API instead, which requires the ` + "`metadata=read`" + ` and ` + "`buckets=read`" + ` capabilities.`,
+
+		Attributes: map[string]schema.Attribute{
+			"since": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp (e.g. `2024-01-01T00:00:00Z`). Changes made after this time are reported. " +
+					"Typically the time of the last successful apply.",
+				Required: true,
+			},
+			"user_ids": schema.SetAttribute{
+				MarkdownDescription: "User IDs to check. Tenant users are given as `tenant$user_id`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"buckets": schema.SetAttribute{
+				MarkdownDescription: "Bucket names to check.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"changed_user_ids": schema.SetAttribute{
+				MarkdownDescription: "User IDs that were modified or removed after `since`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"changed_buckets": schema.SetAttribute{
+				MarkdownDescription: "Bucket names that were modified or removed after `since`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"drift_likely": schema.BoolAttribute{
+				MarkdownDescription: "Whether any of the given users or buckets changed after `since`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DriftMarkerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *DriftMarkerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DriftMarkerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since, err := time.Parse(time.RFC3339, config.Since.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Timestamp",
+			fmt.Sprintf("Could not parse since %q as an RFC 3339 timestamp: %s", config.Since.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var userIDs, buckets []string
+	if !config.UserIDs.IsNull() {
+		resp.Diagnostics.Append(config.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+	}
+	if !config.Buckets.IsNull() {
+		resp.Diagnostics.Append(config.Buckets.ElementsAs(ctx, &buckets, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW drift marker", map[string]any{
+		"since":   since.Format(time.RFC3339),
+		"users":   len(userIDs),
+		"buckets": len(buckets),
+	})
+
+	changedUsers := []string{}
+	for _, userID := range userIDs {
+		mtime, err := d.getUserMtime(ctx, userID)
+		if err != nil {
+			if isAdminNotFoundError(err) {
+				changedUsers = append(changedUsers, userID)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading User Metadata",
+				fmt.Sprintf("Could not read metadata for user %s: %s", userID, err.Error()),
+			)
+			return
+		}
+		if mtime.After(since) {
+			changedUsers = append(changedUsers, userID)
+		}
+	}
+
+	changedBuckets := []string{}
+	for _, bucket := range buckets {
+		info, err := d.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucket})
+		if err != nil {
+			if errors.Is(err, admin.ErrNoSuchBucket) {
+				changedBuckets = append(changedBuckets, bucket)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Bucket Info",
+				fmt.Sprintf("Could not read info for bucket %s: %s", bucket, err.Error()),
+			)
+			return
+		}
+		mtime, err := parseRGWTimestamp(info.Mtime)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Bucket Info",
+				fmt.Sprintf("Could not parse modification time of bucket %s: %s", bucket, err.Error()),
+			)
+			return
+		}
+		if mtime.After(since) {
+			changedBuckets = append(changedBuckets, bucket)
+		}
+	}
+
+	sort.Strings(changedUsers)
+	sort.Strings(changedBuckets)
+
+	tflog.Debug(ctx, "Computed RadosGW drift marker", map[string]any{
+		"changed_users":   changedUsers,
+		"changed_buckets": changedBuckets,
+	})
+
+	changedUserSet, diags := types.SetValueFrom(ctx, types.StringType, changedUsers)
+	resp.Diagnostics.Append(diags...)
+	changedBucketSet, diags := types.SetValueFrom(ctx, types.StringType, changedBuckets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ChangedUserIDs = changedUserSet
+	config.ChangedBuckets = changedBucketSet
+	config.DriftLikely = types.BoolValue(len(changedUsers) > 0 || len(changedBuckets) > 0)
+	config.ID = types.StringValue(since.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// getUserMtime returns the last modification time of the user's metadata.
+// The user info returned by go-ceph does not include it, so the metadata
+// endpoint is queried directly.
+func (d *DriftMarkerDataSource) getUserMtime(ctx context.Context, userID string) (time.Time, error) {
+	body, err := d.adminClient.DoRequest(ctx, http.MethodGet, "/metadata/user", url.Values{"key": {userID}})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var entry struct {
+		Mtime string `json:"mtime"`
+	}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse user metadata: %w", err)
+	}
+
+	return parseRGWTimestamp(entry.Mtime)
+}
+
+// parseRGWTimestamp parses the timestamps returned by the Admin API. Depending
+// on the Ceph release they are formatted either as RFC 3339 or with a space
+// separating date and time (e.g. "2024-01-01 12:00:00.000000Z").
+func parseRGWTimestamp(value string) (time.Time, error) {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999Z",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format: %q", value)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwDriftMarkerDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckRadosgwS3BucketDestroy,
+			testAccCheckRadosgwIAMUserDestroy,
+		),
+		Steps: []resource.TestStep{
+			// Everything was modified after a timestamp in the past
+			{
+				Config: testAccRadosgwDriftMarkerDataSourceConfig_basic(userID, bucketName, "2000-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_drift_marker.test", "drift_likely", "true"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_drift_marker.test", "changed_user_ids.*", userID),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_drift_marker.test", "changed_buckets.*", bucketName),
+				),
+			},
+			// Nothing was modified after a timestamp in the future
+			{
+				Config: testAccRadosgwDriftMarkerDataSourceConfig_basic(userID, bucketName, "2999-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_drift_marker.test", "drift_likely", "false"),
+					resource.TestCheckResourceAttr("data.radosgw_drift_marker.test", "changed_user_ids.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_drift_marker.test", "changed_buckets.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwDriftMarkerDataSource_missing(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-missing-user")
+	bucketName := randomName("tf-acc-missing-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwDriftMarkerDataSourceConfig_missing(userID, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_drift_marker.test", "drift_likely", "true"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_drift_marker.test", "changed_user_ids.*", userID),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_drift_marker.test", "changed_buckets.*", bucketName),
+				),
+			},
+		},
+	})
+}
+
+func testAccRadosgwDriftMarkerDataSourceConfig_basic(userID, bucketName, since string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Drift Marker User"
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_drift_marker" "test" {
+  since    = %q
+  user_ids = [radosgw_iam_user.test.user_id]
+  buckets  = [radosgw_s3_bucket.test.bucket]
+}
+`, userID, bucketName, since)
+}
+
+func testAccRadosgwDriftMarkerDataSourceConfig_missing(userID, bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+data "radosgw_drift_marker" "test" {
+  since    = "2999-01-01T00:00:00Z"
+  user_ids = [%q]
+  buckets  = [%q]
+}
+`, userID, bucketName)
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HealthDataSource{}
+
+func NewHealthDataSource() datasource.DataSource {
+	return &HealthDataSource{}
+}
+
+// HealthDataSource defines the data source implementation.
+type HealthDataSource struct {
+	client *RadosgwClient
+}
+
+// HealthDataSourceModel describes the data source data model.
+type HealthDataSourceModel struct {
+	CheckSwift       types.Bool   `tfsdk:"check_swift"`
+	SwiftHealthcheck types.String `tfsdk:"swift_healthcheck_path"`
+	AdminReachable   types.Bool   `tfsdk:"admin_reachable"`
+	AdminLatencyMs   types.Int64  `tfsdk:"admin_latency_ms"`
+	S3Reachable      types.Bool   `tfsdk:"s3_reachable"`
+	S3LatencyMs      types.Int64  `tfsdk:"s3_latency_ms"`
+	SwiftReachable   types.Bool   `tfsdk:"swift_reachable"`
+	SwiftLatencyMs   types.Int64  `tfsdk:"swift_latency_ms"`
+	Healthy          types.Bool   `tfsdk:"healthy"`
+	Errors           types.Map    `tfsdk:"errors"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// defaultSwiftHealthcheckPath is the path of the RadosGW Swift healthcheck
+// endpoint when rgw_swift_url_prefix is left at its default.
+const defaultSwiftHealthcheckPath = "/swift/healthcheck"
+
+func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Probes the RadosGW endpoint configured in the provider and reports whether the Admin, S3 " +
+			"and (optionally) Swift APIs respond, along with their latency. Use it in `precondition` blocks to fail fast " +
+			"when the gateway is degraded.\n\n" +
+			"The Admin API is probed with an authenticated `GET /admin/info` and the S3 API with an authenticated " +
+			"`ListBuckets` call limited to one bucket. An `AccessDenied` answer still counts as reachable, since the " +
+			"gateway accepted the credentials. The Swift API is probed through its unauthenticated healthcheck endpoint.\n\n" +
+			"~> **Note:** Failed probes do not fail the read; check `healthy` or the individual `*_reachable` attributes instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"check_swift": schema.BoolAttribute{
+				MarkdownDescription: "Whether to probe the Swift API. Default is false.",
+				Optional:            true,
+			},
+			"swift_healthcheck_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the Swift healthcheck endpoint, relative to the provider endpoint. " +
+					"Default is `" + defaultSwiftHealthcheckPath + "`. Change it when `rgw_swift_url_prefix` is customized.",
+				Optional: true,
+			},
+			"admin_reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Admin API responded.",
+				Computed:            true,
+			},
+			"admin_latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Round trip time of the Admin API probe in milliseconds.",
+				Computed:            true,
+			},
+			"s3_reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the S3 API responded.",
+				Computed:            true,
+			},
+			"s3_latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Round trip time of the S3 API probe in milliseconds.",
+				Computed:            true,
+			},
+			"swift_reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Swift healthcheck succeeded. Null when `check_swift` is not enabled.",
+				Computed:            true,
+			},
+			"swift_latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Round trip time of the Swift healthcheck in milliseconds. Null when `check_swift` is not enabled.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether every probed API is reachable.",
+				Computed:            true,
+			},
+			"errors": schema.MapAttribute{
+				MarkdownDescription: "Error messages of the failed probes, keyed by API (`admin`, `s3`, `swift`).",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The provider endpoint.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config HealthDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Probing RadosGW endpoint", map[string]any{
+		"endpoint": d.client.Admin.Endpoint,
+	})
+
+	probeErrors := map[string]string{}
+
+	// Admin API: an AccessDenied answer means the gateway authenticated the
+	// request but the credentials lack the info=read capability
+	adminLatency, err := measureProbe(func() error {
+		_, err := d.client.Admin.GetInfo(ctx)
+		return err
+	})
+	adminReachable := err == nil || errors.Is(err, admin.ErrAccessDenied)
+	if !adminReachable {
+		probeErrors["admin"] = err.Error()
+	}
+
+	// S3 API
+	s3Latency, err := measureProbe(func() error {
+		_, err := d.client.S3.ListBuckets(ctx, &s3.ListBucketsInput{
+			MaxBuckets: aws.Int32(1),
+		})
+		return err
+	})
+	s3Reachable := err == nil || isS3AccessDenied(err)
+	if !s3Reachable {
+		probeErrors["s3"] = err.Error()
+	}
+
+	healthy := adminReachable && s3Reachable
+
+	config.SwiftReachable = types.BoolNull()
+	config.SwiftLatencyMs = types.Int64Null()
+	if config.CheckSwift.ValueBool() {
+		healthcheckPath := defaultSwiftHealthcheckPath
+		if !config.SwiftHealthcheck.IsNull() && config.SwiftHealthcheck.ValueString() != "" {
+			healthcheckPath = "/" + strings.TrimPrefix(config.SwiftHealthcheck.ValueString(), "/")
+		}
+
+		swiftLatency, err := measureProbe(func() error {
+			return d.probeSwift(ctx, d.client.Admin.Endpoint+healthcheckPath)
+		})
+		if err != nil {
+			probeErrors["swift"] = err.Error()
+		}
+		config.SwiftReachable = types.BoolValue(err == nil)
+		config.SwiftLatencyMs = types.Int64Value(swiftLatency)
+		healthy = healthy && err == nil
+	}
+
+	errorsValue, diags := types.MapValueFrom(ctx, types.StringType, probeErrors)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.AdminReachable = types.BoolValue(adminReachable)
+	config.AdminLatencyMs = types.Int64Value(adminLatency)
+	config.S3Reachable = types.BoolValue(s3Reachable)
+	config.S3LatencyMs = types.Int64Value(s3Latency)
+	config.Healthy = types.BoolValue(healthy)
+	config.Errors = errorsValue
+	config.ID = types.StringValue(d.client.Admin.Endpoint)
+
+	if !healthy {
+		tflog.Warn(ctx, "RadosGW endpoint is degraded", map[string]any{
+			"endpoint": d.client.Admin.Endpoint,
+			"errors":   probeErrors,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// probeSwift calls the Swift healthcheck endpoint, which answers 200 without
+// authentication while the gateway is serving requests.
+func (d *HealthDataSource) probeSwift(ctx context.Context, healthcheckURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthcheckURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := d.client.Admin.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("healthcheck returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// measureProbe runs a probe and returns its duration in milliseconds.
+func measureProbe(probe func() error) (int64, error) {
+	start := time.Now()
+	err := probe()
+	return time.Since(start).Milliseconds(), err
+}
+
+// isS3AccessDenied reports whether an S3 error is an AccessDenied answer.
+func isS3AccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDenied"
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwHealthDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwHealthDataSourceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_health.test", "admin_reachable", "true"),
+					resource.TestCheckResourceAttrSet("data.radosgw_health.test", "admin_latency_ms"),
+					resource.TestCheckResourceAttr("data.radosgw_health.test", "s3_reachable", "true"),
+					resource.TestCheckResourceAttrSet("data.radosgw_health.test", "s3_latency_ms"),
+					resource.TestCheckNoResourceAttr("data.radosgw_health.test", "swift_reachable"),
+					resource.TestCheckResourceAttr("data.radosgw_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.radosgw_health.test", "errors.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwHealthDataSourceConfig_basic() string {
+	return providerConfig() + `
+data "radosgw_health" "test" {}
+`
+}
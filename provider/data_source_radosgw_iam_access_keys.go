@@ -77,7 +77,7 @@ func (d *AccessKeysDataSource) Schema(ctx context.Context, req datasource.Schema
 							Computed:            true,
 						},
 						"user": schema.StringAttribute{
-							MarkdownDescription: "The user or subuser ID associated with this key. For S3 keys this is the user ID. For Swift keys this is `user_id:subuser`.",
+							MarkdownDescription: "The user or subuser ID associated with this key. For S3 keys this is the user ID, or `user_id:subuser` for S3 keys bound to a subuser. For Swift keys this is `user_id:subuser`.",
 							Computed:            true,
 						},
 						"key_type": schema.StringAttribute{
@@ -36,6 +36,7 @@ type OIDCProviderDataSourceModel struct {
 	URL            types.String `tfsdk:"url"`
 	ClientIDList   types.Set    `tfsdk:"client_id_list"`
 	ThumbprintList types.List   `tfsdk:"thumbprint_list"`
+	Issuer         types.String `tfsdk:"issuer"`
 }
 
 // XML response structures for ListOpenIDConnectProviders
@@ -98,6 +99,11 @@ func (d *OIDCProviderDataSource) Schema(ctx context.Context, req datasource.Sche
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "The provider URL without the protocol, which prefixes the claim condition keys " +
+					"(such as `<issuer>:sub` and `<issuer>:aud`) of role trust policies.",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -117,12 +123,7 @@ func (d *OIDCProviderDataSource) Configure(ctx context.Context, req datasource.C
 	}
 
 	d.client = client
-	d.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	d.iamClient = client.IAM
 }
 
 func (d *OIDCProviderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -143,8 +144,7 @@ func (d *OIDCProviderDataSource) Read(ctx context.Context, req datasource.ReadRe
 		providerURL := config.URL.ValueString()
 
 		// Normalize URL - remove https:// prefix if present
-		providerURL = strings.TrimPrefix(providerURL, "https://")
-		providerURL = strings.TrimPrefix(providerURL, "http://")
+		providerURL = oidcIssuer(providerURL)
 
 		foundArn, err := d.findOIDCProviderByURL(ctx, providerURL)
 		if err != nil {
@@ -196,6 +196,7 @@ func (d *OIDCProviderDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Populate the model
 	config.ARN = types.StringValue(arn)
 	config.URL = types.StringValue(response.Result.URL)
+	config.Issuer = types.StringValue(oidcIssuer(response.Result.URL))
 
 	clientIDSet, diags := types.SetValueFrom(ctx, types.StringType, response.Result.ClientIDList.Members)
 	resp.Diagnostics.Append(diags...)
@@ -221,35 +222,49 @@ func (d *OIDCProviderDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 // findOIDCProviderByURL lists all OIDC providers and finds one matching the given URL.
 func (d *OIDCProviderDataSource) findOIDCProviderByURL(ctx context.Context, targetURL string) (string, error) {
-	params := url.Values{}
-	params.Set("Action", "ListOpenIDConnectProviders")
-
-	body, err := d.iamClient.DoRequest(ctx, params, "iam")
+	arns, err := listOIDCProviderARNs(ctx, d.iamClient)
 	if err != nil {
-		return "", fmt.Errorf("failed to list OIDC providers: %w", err)
-	}
-
-	var response listOIDCProvidersResponseXML
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return "", fmt.Errorf("failed to parse ListOpenIDConnectProviders response: %w", err)
+		return "", err
 	}
 
 	// Normalize target URL for comparison
 	normalizedTarget := strings.ToLower(strings.TrimSuffix(targetURL, "/"))
 
-	for _, provider := range response.Result.OpenIDConnectProviderList.Members {
+	for _, arn := range sortedKeys(arns) {
 		// Extract URL from ARN: arn:aws:iam:::oidc-provider/<url>
-		providerURL := urlFromOIDCProviderARN(provider.Arn)
+		providerURL := urlFromOIDCProviderARN(arn)
 		normalizedProvider := strings.ToLower(strings.TrimSuffix(providerURL, "/"))
 
 		if normalizedProvider == normalizedTarget {
-			return provider.Arn, nil
+			return arn, nil
 		}
 	}
 
 	return "", fmt.Errorf("no OIDC provider found with URL: %s", targetURL)
 }
 
+// listOIDCProviderARNs returns the set of ARNs of all OIDC providers.
+func listOIDCProviderARNs(ctx context.Context, iamClient *IAMClient) (map[string]bool, error) {
+	params := url.Values{}
+	params.Set("Action", "ListOpenIDConnectProviders")
+
+	body, err := iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OIDC providers: %w", err)
+	}
+
+	var response listOIDCProvidersResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse ListOpenIDConnectProviders response: %w", err)
+	}
+
+	arns := make(map[string]bool, len(response.Result.OpenIDConnectProviderList.Members))
+	for _, provider := range response.Result.OpenIDConnectProviderList.Members {
+		arns[provider.Arn] = true
+	}
+	return arns, nil
+}
+
 // urlFromOIDCProviderARN extracts the URL portion from an OIDC provider ARN.
 // ARN format: arn:aws:iam:::oidc-provider/<url>
 func urlFromOIDCProviderARN(arn string) string {
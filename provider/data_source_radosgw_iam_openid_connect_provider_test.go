@@ -23,6 +23,7 @@ func TestAccRadosgwIAMOIDCProviderDataSource_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrPair("data.radosgw_iam_openid_connect_provider.test", "url", "radosgw_iam_openid_connect_provider.test", "url"),
 					resource.TestCheckResourceAttrPair("data.radosgw_iam_openid_connect_provider.test", "arn", "radosgw_iam_openid_connect_provider.test", "arn"),
+					resource.TestCheckResourceAttrPair("data.radosgw_iam_openid_connect_provider.test", "issuer", "radosgw_iam_openid_connect_provider.test", "issuer"),
 					resource.TestCheckResourceAttrSet("data.radosgw_iam_openid_connect_provider.test", "client_id_list.#"),
 					resource.TestCheckResourceAttrSet("data.radosgw_iam_openid_connect_provider.test", "thumbprint_list.#"),
 				),
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // OIDC provider thumbprints are SHA-1 by definition
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OIDCThumbprintDataSource{}
+
+func NewIAMOIDCThumbprintDataSource() datasource.DataSource {
+	return &OIDCThumbprintDataSource{}
+}
+
+// oidcDiscoveryTimeout bounds each request to the identity provider.
+const oidcDiscoveryTimeout = 30 * time.Second
+
+// OIDCThumbprintDataSource defines the data source implementation.
+type OIDCThumbprintDataSource struct {
+	httpClient *http.Client
+}
+
+// OIDCThumbprintDataSourceModel describes the data source data model.
+type OIDCThumbprintDataSourceModel struct {
+	URL                    types.String `tfsdk:"url"`
+	JWKSURI                types.String `tfsdk:"jwks_uri"`
+	Thumbprint             types.String `tfsdk:"thumbprint"`
+	CertificateThumbprints types.List   `tfsdk:"certificate_thumbprints"`
+	ID                     types.String `tfsdk:"id"`
+}
+
+func (d *OIDCThumbprintDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_openid_connect_thumbprint"
+}
+
+func (d *OIDCThumbprintDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Computes the certificate thumbprint of an OpenID Connect identity provider for the " +
+			"`thumbprint_list` of `radosgw_iam_openid_connect_provider`, so that it does not have to be computed " +
+			"with `openssl` by hand.\n\n" +
+			"The data source reads the discovery document at `<url>/.well-known/openid-configuration`, connects to " +
+			"the host of its `jwks_uri`, and returns the hex-encoded SHA-1 hash of the last certificate of the chain " +
+			"the host presents, which is the top intermediate or root CA.\n\n" +
+			"~> **Note:** The identity provider is contacted from the machine running Terraform, not from RadosGW. " +
+			"The thumbprint changes when the identity provider rotates its CA, so a refresh can plan an update of " +
+			"the OIDC provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the identity provider, the `iss` claim of its tokens. Must use `https://`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://[^/]`), "must be an https:// URL"),
+				},
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "The URL of the JSON Web Key Set of the identity provider, from its discovery document.",
+				Computed:            true,
+			},
+			"thumbprint": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-1 thumbprint of the last certificate presented by the `jwks_uri` host.",
+				Computed:            true,
+			},
+			"certificate_thumbprints": schema.ListAttribute{
+				MarkdownDescription: "The hex-encoded SHA-1 thumbprints of all certificates presented by the `jwks_uri` host, " +
+					"starting with the server certificate.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the identity provider.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *OIDCThumbprintDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.httpClient = &http.Client{Timeout: oidcDiscoveryTimeout}
+}
+
+func (d *OIDCThumbprintDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config OIDCThumbprintDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuerURL := config.URL.ValueString()
+	jwksURI, thumbprints, err := discoverOIDCThumbprints(ctx, d.httpClient, issuerURL)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Discovering OIDC Provider Thumbprint",
+			fmt.Sprintf("Could not compute the thumbprint of %s: %s", issuerURL, err.Error()),
+		)
+		return
+	}
+
+	thumbprintList, diags := types.ListValueFrom(ctx, types.StringType, thumbprints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.JWKSURI = types.StringValue(jwksURI)
+	config.Thumbprint = types.StringValue(thumbprints[len(thumbprints)-1])
+	config.CertificateThumbprints = thumbprintList
+	config.ID = types.StringValue(issuerURL)
+
+	tflog.Trace(ctx, "Read OIDC thumbprint data source", map[string]any{
+		"url":        issuerURL,
+		"jwks_uri":   jwksURI,
+		"thumbprint": config.Thumbprint.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// discoverOIDCThumbprints reads the discovery document of an identity
+// provider and returns its jwks_uri and the SHA-1 thumbprints of the
+// certificate chain presented by the jwks_uri host, server certificate first.
+func discoverOIDCThumbprints(ctx context.Context, httpClient *http.Client, issuerURL string) (string, []string, error) {
+	configURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	body, _, err := oidcDiscoveryGet(ctx, httpClient, configURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return "", nil, fmt.Errorf("failed to parse discovery document %s: %w", configURL, err)
+	}
+	if discovery.JWKSURI == "" {
+		return "", nil, fmt.Errorf("discovery document %s has no jwks_uri", configURL)
+	}
+
+	_, state, err := oidcDiscoveryGet(ctx, httpClient, discovery.JWKSURI)
+	if err != nil {
+		return "", nil, err
+	}
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return "", nil, fmt.Errorf("jwks_uri %s is not served over HTTPS", discovery.JWKSURI)
+	}
+
+	thumbprints := make([]string, 0, len(state.PeerCertificates))
+	for _, cert := range state.PeerCertificates {
+		sum := sha1.Sum(cert.Raw) //nolint:gosec // OIDC provider thumbprints are SHA-1 by definition
+		thumbprints = append(thumbprints, hex.EncodeToString(sum[:]))
+	}
+	return discovery.JWKSURI, thumbprints, nil
+}
+
+// oidcDiscoveryGet sends a GET request to the identity provider and returns
+// the response body and TLS connection state.
+func oidcDiscoveryGet(ctx context.Context, httpClient *http.Client, target string) ([]byte, *tls.ConnectionState, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request to %s failed: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response of %s: %w", target, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("GET %s returned status %d", target, resp.StatusCode)
+	}
+	return body, resp.TLS, nil
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // OIDC provider thumbprints are SHA-1 by definition
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverOIDCThumbprints(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/test/.well-known/openid-configuration":
+			_, _ = fmt.Fprintf(w, `{"issuer":"%s/realms/test","jwks_uri":"%s/realms/test/certs"}`, server.URL, server.URL)
+		case "/realms/test/certs":
+			_, _ = w.Write([]byte(`{"keys":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	jwksURI, thumbprints, err := discoverOIDCThumbprints(context.Background(), server.Client(), server.URL+"/realms/test/")
+	if err != nil {
+		t.Fatalf("discoverOIDCThumbprints returned unexpected error: %s", err)
+	}
+	if jwksURI != server.URL+"/realms/test/certs" {
+		t.Errorf("unexpected jwks_uri %q", jwksURI)
+	}
+
+	sum := sha1.Sum(server.Certificate().Raw) //nolint:gosec // OIDC provider thumbprints are SHA-1 by definition
+	expected := hex.EncodeToString(sum[:])
+	if len(thumbprints) != 1 || thumbprints[0] != expected {
+		t.Errorf("expected thumbprints [%s], got %v", expected, thumbprints)
+	}
+
+	if _, _, err := discoverOIDCThumbprints(context.Background(), server.Client(), server.URL+"/unknown"); err == nil {
+		t.Error("expected an error for an issuer without a discovery document")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -26,10 +29,12 @@ type PolicyDocumentDataSource struct{}
 
 // PolicyDocumentDataSourceModel describes the data source data model.
 type PolicyDocumentDataSourceModel struct {
-	Version    types.String           `tfsdk:"version"`
-	PolicyID   types.String           `tfsdk:"policy_id"`
-	Statements []PolicyStatementModel `tfsdk:"statement"`
-	JSON       types.String           `tfsdk:"json"`
+	Version            types.String           `tfsdk:"version"`
+	PolicyID           types.String           `tfsdk:"policy_id"`
+	SourceDocuments    types.List             `tfsdk:"source_documents"`
+	OverrideStatements types.List             `tfsdk:"override_statements"`
+	Statements         []PolicyStatementModel `tfsdk:"statement"`
+	JSON               types.String           `tfsdk:"json"`
 }
 
 // PolicyStatementModel describes a policy statement.
@@ -67,7 +72,11 @@ func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.Sc
 		MarkdownDescription: "Generates an IAM policy document in JSON format for use with resources that " +
 			"require policies, such as `radosgw_iam_role`, `radosgw_iam_role_policy`, `radosgw_s3_bucket_policy`, " +
 			"and `radosgw_sns_topic_policy`. " +
-			"This data source allows you to define policies using HCL instead of writing raw JSON.",
+			"This data source allows you to define policies using HCL instead of writing raw JSON.\n\n" +
+			"Policy variables such as `${aws:PrincipalTag/project}` must be written as `&{aws:PrincipalTag/project}` " +
+			"in resources, principals and condition values, since Terraform would otherwise interpolate them. " +
+			"This is typically needed for attribute-based access control (ABAC) with role session tags, where the " +
+			"trust policy allows `sts:TagSession` and permission policies compare `aws:PrincipalTag` keys.",
 
 		Attributes: map[string]schema.Attribute{
 			"version": schema.StringAttribute{
@@ -81,6 +90,20 @@ func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.Sc
 				MarkdownDescription: "Optional identifier for the policy.",
 				Optional:            true,
 			},
+			"source_documents": schema.ListAttribute{
+				MarkdownDescription: "JSON policy documents whose statements are merged into the generated document, " +
+					"for example the `json` of another `radosgw_iam_policy_document`. A statement of a later document " +
+					"replaces a statement of an earlier document with the same `Sid`.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"override_statements": schema.ListAttribute{
+				MarkdownDescription: "JSON policy documents, or single JSON statements, whose statements replace the " +
+					"statements of `source_documents` and `statement` blocks with the same `Sid`. Statements with a new " +
+					"or no `Sid` are appended.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"json": schema.StringAttribute{
 				MarkdownDescription: "The generated IAM policy document in JSON format.",
 				Computed:            true,
@@ -104,7 +127,7 @@ func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.Sc
 							},
 						},
 						"actions": schema.SetAttribute{
-							MarkdownDescription: "List of actions that this statement applies to (e.g., `s3:GetObject`, `s3:*`).",
+							MarkdownDescription: "List of actions that this statement applies to (e.g., `s3:GetObject`, `s3:*`, `sts:TagSession`).",
 							Optional:            true,
 							ElementType:         types.StringType,
 						},
@@ -114,9 +137,10 @@ func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.Sc
 							ElementType:         types.StringType,
 						},
 						"resources": schema.SetAttribute{
-							MarkdownDescription: "List of resources that this statement applies to (e.g., `arn:aws:s3:::bucket/*`).",
-							Optional:            true,
-							ElementType:         types.StringType,
+							MarkdownDescription: "List of resources that this statement applies to (e.g., `arn:aws:s3:::bucket/*`). " +
+								"Use `&{...}` for policy variables, e.g. `arn:aws:s3:::&{aws:PrincipalTag/project}/*`.",
+							Optional:    true,
+							ElementType: types.StringType,
 						},
 						"not_resources": schema.SetAttribute{
 							MarkdownDescription: "List of resources that this statement does NOT apply to.",
@@ -166,11 +190,11 @@ func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.Sc
 										Required:            true,
 									},
 									"variable": schema.StringAttribute{
-										MarkdownDescription: "Context key to evaluate (e.g., `aws:username`, `s3:prefix`).",
+										MarkdownDescription: "Context key to evaluate (e.g., `aws:username`, `s3:prefix`, `aws:RequestTag/project`, `aws:TagKeys`).",
 										Required:            true,
 									},
 									"values": schema.ListAttribute{
-										MarkdownDescription: "Values to compare against the context key.",
+										MarkdownDescription: "Values to compare against the context key. Use `&{...}` for policy variables, e.g. `&{aws:PrincipalTag/project}`.",
 										Required:            true,
 										ElementType:         types.StringType,
 									},
@@ -196,125 +220,85 @@ func (d *PolicyDocumentDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	var sources, overrides []string
+	resp.Diagnostics.Append(data.SourceDocuments.ElementsAs(ctx, &sources, true)...)
+	resp.Diagnostics.Append(data.OverrideStatements.ElementsAs(ctx, &overrides, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build the policy document
 	policy := make(map[string]any)
 
-	// Set version (default to 2012-10-17)
-	version := "2012-10-17"
+	// Set version: the configured one, then the one of the first source
+	// document, then 2012-10-17
+	version := ""
 	if !data.Version.IsNull() && data.Version.ValueString() != "" {
 		version = data.Version.ValueString()
 	}
-	policy["Version"] = version
 
 	// Set policy ID if provided
 	if !data.PolicyID.IsNull() && data.PolicyID.ValueString() != "" {
 		policy["Id"] = data.PolicyID.ValueString()
 	}
 
-	// Build statements
-	if len(data.Statements) > 0 {
-		statements := make([]map[string]any, 0, len(data.Statements))
-
-		for _, stmt := range data.Statements {
-			statement := make(map[string]any)
-
-			// Sid
-			if !stmt.Sid.IsNull() && stmt.Sid.ValueString() != "" {
-				statement["Sid"] = stmt.Sid.ValueString()
-			}
-
-			// Effect (default to Allow)
-			effect := "Allow"
-			if !stmt.Effect.IsNull() && stmt.Effect.ValueString() != "" {
-				effect = stmt.Effect.ValueString()
-			}
-			statement["Effect"] = effect
-
-			// Actions
-			if !stmt.Actions.IsNull() {
-				var actions []string
-				resp.Diagnostics.Append(stmt.Actions.ElementsAs(ctx, &actions, false)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if len(actions) > 0 {
-					statement["Action"] = actions
-				}
-			}
-
-			// NotActions
-			if !stmt.NotActions.IsNull() {
-				var notActions []string
-				resp.Diagnostics.Append(stmt.NotActions.ElementsAs(ctx, &notActions, false)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if len(notActions) > 0 {
-					statement["NotAction"] = notActions
-				}
-			}
-
-			// Resources
-			if !stmt.Resources.IsNull() {
-				var resources []string
-				resp.Diagnostics.Append(stmt.Resources.ElementsAs(ctx, &resources, false)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if len(resources) > 0 {
-					statement["Resource"] = resources
-				}
-			}
+	// Merge the statements, from the lowest to the highest precedence:
+	// source documents, statement blocks and override statements
+	var merged policyStatements
+	for i, source := range sources {
+		document, statements, err := parsePolicyDocumentStatements(source)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_documents").AtListIndex(i),
+				"Invalid Source Document",
+				fmt.Sprintf("The source document is not a valid policy document: %s", err.Error()),
+			)
+			return
+		}
+		if version == "" {
+			version, _ = document["Version"].(string)
+		}
+		merged.merge(statements)
+	}
 
-			// NotResources
-			if !stmt.NotResources.IsNull() {
-				var notResources []string
-				resp.Diagnostics.Append(stmt.NotResources.ElementsAs(ctx, &notResources, false)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if len(notResources) > 0 {
-					statement["NotResource"] = notResources
-				}
-			}
+	for _, stmt := range data.Statements {
+		statement := d.buildStatement(ctx, stmt, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		merged.merge([]map[string]any{statement})
+	}
 
-			// Principals
-			if len(stmt.Principals) > 0 {
-				principals := d.buildPrincipals(ctx, stmt.Principals, &resp.Diagnostics)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if principals != nil {
-					statement["Principal"] = principals
-				}
-			}
+	for i, override := range overrides {
+		_, statements, err := parsePolicyDocumentStatements(override)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("override_statements").AtListIndex(i),
+				"Invalid Override Statement",
+				fmt.Sprintf("The override is neither a valid policy document nor a valid statement: %s", err.Error()),
+			)
+			return
+		}
+		merged.merge(statements)
+	}
 
-			// NotPrincipals
-			if len(stmt.NotPrincipals) > 0 {
-				notPrincipals := d.buildPrincipals(ctx, stmt.NotPrincipals, &resp.Diagnostics)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if notPrincipals != nil {
-					statement["NotPrincipal"] = notPrincipals
-				}
-			}
+	if version == "" {
+		version = "2012-10-17"
+	}
+	policy["Version"] = version
 
-			// Conditions
-			if len(stmt.Conditions) > 0 {
-				conditions := d.buildConditions(ctx, stmt.Conditions, &resp.Diagnostics)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				if conditions != nil {
-					statement["Condition"] = conditions
-				}
-			}
+	if len(merged) > 0 {
+		policy["Statement"] = []map[string]any(merged)
+	}
 
-			statements = append(statements, statement)
+	for i, statement := range merged {
+		for _, operator := range unsupportedConditionOperators(statement) {
+			resp.Diagnostics.AddWarning(
+				"Condition Operator Not Supported by RadosGW",
+				fmt.Sprintf("Statement %d uses the condition operator %q, which RadosGW does not implement. "+
+					"RadosGW rejects the policy or never matches the condition.", i+1, operator),
+			)
 		}
-
-		policy["Statement"] = statements
 	}
 
 	// Generate JSON
@@ -332,6 +316,106 @@ func (d *PolicyDocumentDataSource) Read(ctx context.Context, req datasource.Read
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// buildStatement builds the JSON statement of a statement block.
+func (d *PolicyDocumentDataSource) buildStatement(ctx context.Context, stmt PolicyStatementModel, diags *diag.Diagnostics) map[string]any {
+	statement := make(map[string]any)
+
+	// Sid
+	if !stmt.Sid.IsNull() && stmt.Sid.ValueString() != "" {
+		statement["Sid"] = stmt.Sid.ValueString()
+	}
+
+	// Effect (default to Allow)
+	effect := "Allow"
+	if !stmt.Effect.IsNull() && stmt.Effect.ValueString() != "" {
+		effect = stmt.Effect.ValueString()
+	}
+	statement["Effect"] = effect
+
+	// Actions
+	if !stmt.Actions.IsNull() {
+		var actions []string
+		diags.Append(stmt.Actions.ElementsAs(ctx, &actions, false)...)
+		if diags.HasError() {
+			return nil
+		}
+		if len(actions) > 0 {
+			statement["Action"] = actions
+		}
+	}
+
+	// NotActions
+	if !stmt.NotActions.IsNull() {
+		var notActions []string
+		diags.Append(stmt.NotActions.ElementsAs(ctx, &notActions, false)...)
+		if diags.HasError() {
+			return nil
+		}
+		if len(notActions) > 0 {
+			statement["NotAction"] = notActions
+		}
+	}
+
+	// Resources
+	if !stmt.Resources.IsNull() {
+		var resources []string
+		diags.Append(stmt.Resources.ElementsAs(ctx, &resources, false)...)
+		if diags.HasError() {
+			return nil
+		}
+		if len(resources) > 0 {
+			statement["Resource"] = expandPolicyVariables(resources)
+		}
+	}
+
+	// NotResources
+	if !stmt.NotResources.IsNull() {
+		var notResources []string
+		diags.Append(stmt.NotResources.ElementsAs(ctx, &notResources, false)...)
+		if diags.HasError() {
+			return nil
+		}
+		if len(notResources) > 0 {
+			statement["NotResource"] = expandPolicyVariables(notResources)
+		}
+	}
+
+	// Principals
+	if len(stmt.Principals) > 0 {
+		principals := d.buildPrincipals(ctx, stmt.Principals, diags)
+		if diags.HasError() {
+			return nil
+		}
+		if principals != nil {
+			statement["Principal"] = principals
+		}
+	}
+
+	// NotPrincipals
+	if len(stmt.NotPrincipals) > 0 {
+		notPrincipals := d.buildPrincipals(ctx, stmt.NotPrincipals, diags)
+		if diags.HasError() {
+			return nil
+		}
+		if notPrincipals != nil {
+			statement["NotPrincipal"] = notPrincipals
+		}
+	}
+
+	// Conditions
+	if len(stmt.Conditions) > 0 {
+		conditions := d.buildConditions(ctx, stmt.Conditions, diags)
+		if diags.HasError() {
+			return nil
+		}
+		if conditions != nil {
+			statement["Condition"] = conditions
+		}
+	}
+
+	return statement
+}
+
 func (d *PolicyDocumentDataSource) buildPrincipals(ctx context.Context, principals []PolicyPrincipalModel, diags *diag.Diagnostics) any {
 	// Check for wildcard principal
 	for _, p := range principals {
@@ -350,6 +434,7 @@ func (d *PolicyDocumentDataSource) buildPrincipals(ctx context.Context, principa
 			return nil
 		}
 
+		identifiers = expandPolicyVariables(identifiers)
 		principalType := p.Type.ValueString()
 
 		// If only one identifier, use string; otherwise use array
@@ -385,6 +470,8 @@ func (d *PolicyDocumentDataSource) buildConditions(ctx context.Context, conditio
 			return nil
 		}
 
+		values = expandPolicyVariables(values)
+
 		if _, ok := conditionMap[test]; !ok {
 			conditionMap[test] = make(map[string]any)
 		}
@@ -413,3 +500,129 @@ func (d *PolicyDocumentDataSource) buildConditions(ctx context.Context, conditio
 
 	return result
 }
+
+// expandPolicyVariables converts the `&{...}` syntax into IAM policy variables
+// (`${...}`). Terraform interpolates `${...}` itself, so policy variables such
+// as `${aws:PrincipalTag/project}` cannot be written directly in HCL.
+func expandPolicyVariables(values []string) []string {
+	expanded := make([]string, len(values))
+	for i, value := range values {
+		expanded[i] = strings.ReplaceAll(value, "&{", "${")
+	}
+	return expanded
+}
+
+// policyStatements are the statements of a generated policy document.
+type policyStatements []map[string]any
+
+// merge adds statements to the document. A statement replaces the statement
+// with the same Sid in place; statements without a Sid are appended.
+func (p *policyStatements) merge(statements []map[string]any) {
+	for _, statement := range statements {
+		sid, _ := statement["Sid"].(string)
+		index := -1
+		if sid != "" {
+			index = slices.IndexFunc(*p, func(existing map[string]any) bool { return existing["Sid"] == sid })
+		}
+		if index >= 0 {
+			(*p)[index] = statement
+		} else {
+			*p = append(*p, statement)
+		}
+	}
+}
+
+// parsePolicyDocumentStatements parses a JSON policy document, or a single
+// JSON statement, and returns the document and its statements.
+func parsePolicyDocumentStatements(document string) (map[string]any, []map[string]any, error) {
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		return nil, nil, err
+	}
+
+	raw, ok := parsed["Statement"]
+	if !ok {
+		if _, ok := parsed["Effect"]; !ok {
+			return nil, nil, fmt.Errorf("neither Statement nor Effect is set")
+		}
+		return nil, []map[string]any{parsed}, nil
+	}
+
+	// Statement is either a single statement or a list of statements
+	switch statement := raw.(type) {
+	case map[string]any:
+		return parsed, []map[string]any{statement}, nil
+	case []any:
+		statements := make([]map[string]any, 0, len(statement))
+		for _, element := range statement {
+			s, ok := element.(map[string]any)
+			if !ok {
+				return nil, nil, fmt.Errorf("statements must be JSON objects")
+			}
+			statements = append(statements, s)
+		}
+		return parsed, statements, nil
+	}
+	return nil, nil, fmt.Errorf("statement must be a JSON object or a list of JSON objects")
+}
+
+// rgwConditionOperators are the condition operators implemented by the
+// RadosGW policy engine. Each can be suffixed with IfExists.
+var rgwConditionOperators = map[string]bool{
+	"StringEquals":              true,
+	"StringNotEquals":           true,
+	"StringEqualsIgnoreCase":    true,
+	"StringNotEqualsIgnoreCase": true,
+	"StringLike":                true,
+	"StringNotLike":             true,
+	"NumericEquals":             true,
+	"NumericNotEquals":          true,
+	"NumericLessThan":           true,
+	"NumericLessThanEquals":     true,
+	"NumericGreaterThan":        true,
+	"NumericGreaterThanEquals":  true,
+	"DateEquals":                true,
+	"DateNotEquals":             true,
+	"DateLessThan":              true,
+	"DateLessThanEquals":        true,
+	"DateGreaterThan":           true,
+	"DateGreaterThanEquals":     true,
+	"Bool":                      true,
+	"BinaryEquals":              true,
+	"IpAddress":                 true,
+	"NotIpAddress":              true,
+	"ArnEquals":                 true,
+	"ArnNotEquals":              true,
+	"ArnLike":                   true,
+	"ArnNotLike":                true,
+	"Null":                      true,
+}
+
+// rgwSetConditionOperators are the operators RadosGW accepts with the
+// ForAllValues: and ForAnyValue: set qualifiers.
+var rgwSetConditionOperators = map[string]bool{
+	"StringEquals":           true,
+	"StringEqualsIgnoreCase": true,
+	"StringLike":             true,
+}
+
+// unsupportedConditionOperators returns the condition operators of a
+// statement that RadosGW does not implement, sorted.
+func unsupportedConditionOperators(statement map[string]any) []string {
+	conditions, _ := statement["Condition"].(map[string]any)
+
+	var unsupported []string
+	for _, operator := range sortedKeys(conditions) {
+		base := strings.TrimSuffix(operator, "IfExists")
+		qualifier, rest, qualified := strings.Cut(base, ":")
+		switch {
+		case !qualified:
+			if !rgwConditionOperators[base] {
+				unsupported = append(unsupported, operator)
+			}
+		case qualifier != "ForAllValues" && qualifier != "ForAnyValue", !rgwSetConditionOperators[rest]:
+			unsupported = append(unsupported, operator)
+		}
+	}
+	return unsupported
+}
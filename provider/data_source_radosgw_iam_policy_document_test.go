@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -57,6 +58,89 @@ func TestAccRadosgwIAMPolicyDocumentDataSource_withCondition(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMPolicyDocumentDataSource_sessionTags(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMPolicyDocumentDataSourceConfig_sessionTags(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_policy_document.test", "json",
+						`{"Statement":[{"Action":["sts:TagSession"],"Condition":{"StringEquals":{"aws:RequestTag/team":"${aws:PrincipalTag/team}"}},"Effect":"Allow","Principal":{"AWS":"*"}}],"Version":"2012-10-17"}`),
+					resource.TestCheckResourceAttr("data.radosgw_iam_policy_document.abac", "json",
+						`{"Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Resource":["arn:aws:s3:::${aws:PrincipalTag/team}/*"]}],"Version":"2012-10-17"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMPolicyDocumentDataSource_merge(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMPolicyDocumentDataSourceConfig_merge(),
+				Check: resource.ComposeTestCheckFunc(
+					// The statement block replaces the source statement with the
+					// same Sid in place, the override replaces the statement block
+					resource.TestCheckResourceAttr("data.radosgw_iam_policy_document.test", "json",
+						`{"Statement":[{"Action":["s3:ListBucket"],"Effect":"Allow","Resource":["arn:aws:s3:::bucket"],"Sid":"List"},`+
+							`{"Action":"s3:*","Effect":"Deny","Resource":"arn:aws:s3:::bucket/*","Sid":"Read"},`+
+							`{"Action":["s3:PutObject"],"Effect":"Allow","Resource":["arn:aws:s3:::bucket/*"]}],"Version":"2012-10-17"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestUnsupportedConditionOperators(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		operators []string
+		expected  []string
+	}{
+		"supported":            {operators: []string{"StringEquals", "ArnLike", "Null", "IpAddress"}},
+		"if exists":            {operators: []string{"StringLikeIfExists", "NumericLessThanIfExists"}},
+		"set qualifier":        {operators: []string{"ForAnyValue:StringLike", "ForAllValues:StringEquals"}},
+		"unknown operator":     {operators: []string{"StringEquals", "StringStartsWith"}, expected: []string{"StringStartsWith"}},
+		"unsupported set":      {operators: []string{"ForAnyValue:NumericEquals"}, expected: []string{"ForAnyValue:NumericEquals"}},
+		"unknown qualifier":    {operators: []string{"ForEachValue:StringEquals"}, expected: []string{"ForEachValue:StringEquals"}},
+		"no condition":         {},
+		"sorted unsupported":   {operators: []string{"Zeta", "Alpha"}, expected: []string{"Alpha", "Zeta"}},
+		"unknown if exists":    {operators: []string{"StringStartsWithIfExists"}, expected: []string{"StringStartsWithIfExists"}},
+		"set with if exists":   {operators: []string{"ForAllValues:StringLikeIfExists"}},
+		"case sensitive match": {operators: []string{"stringequals"}, expected: []string{"stringequals"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			statement := map[string]any{"Effect": "Allow"}
+			if len(testCase.operators) > 0 {
+				conditions := map[string]any{}
+				for _, operator := range testCase.operators {
+					conditions[operator] = map[string]any{"aws:username": "user"}
+				}
+				statement["Condition"] = conditions
+			}
+
+			unsupported := unsupportedConditionOperators(statement)
+			if !slices.Equal(unsupported, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, unsupported)
+			}
+		})
+	}
+}
+
 // Test configurations
 
 func testAccRadosgwIAMPolicyDocumentDataSourceConfig_basic() string {
@@ -137,3 +221,73 @@ data "radosgw_iam_policy_document" "test" {
 }
 `
 }
+
+func testAccRadosgwIAMPolicyDocumentDataSourceConfig_sessionTags() string {
+	return providerConfig() + `
+data "radosgw_iam_policy_document" "test" {
+  statement {
+    principals {
+      type        = "AWS"
+      identifiers = ["*"]
+    }
+
+    actions = ["sts:TagSession"]
+
+    condition {
+      test     = "StringEquals"
+      variable = "aws:RequestTag/team"
+      values   = ["&{aws:PrincipalTag/team}"]
+    }
+  }
+}
+
+data "radosgw_iam_policy_document" "abac" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::&{aws:PrincipalTag/team}/*"]
+  }
+}
+`
+}
+
+func testAccRadosgwIAMPolicyDocumentDataSourceConfig_merge() string {
+	return `
+data "radosgw_iam_policy_document" "source" {
+  statement {
+    sid       = "List"
+    actions   = ["s3:ListBucket"]
+    resources = ["arn:aws:s3:::bucket"]
+  }
+
+  statement {
+    sid       = "Read"
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::bucket/*"]
+  }
+}
+
+data "radosgw_iam_policy_document" "test" {
+  source_documents = [data.radosgw_iam_policy_document.source.json]
+
+  statement {
+    sid       = "Read"
+    actions   = ["s3:GetObject", "s3:GetObjectVersion"]
+    resources = ["arn:aws:s3:::bucket/*"]
+  }
+
+  statement {
+    actions   = ["s3:PutObject"]
+    resources = ["arn:aws:s3:::bucket/*"]
+  }
+
+  override_statements = [
+    jsonencode({
+      Sid      = "Read"
+      Effect   = "Deny"
+      Action   = "s3:*"
+      Resource = "arn:aws:s3:::bucket/*"
+    }),
+  ]
+}
+`
+}
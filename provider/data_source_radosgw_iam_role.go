@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -36,6 +39,14 @@ type RoleDataSourceModel struct {
 	ARN                types.String `tfsdk:"arn"`
 	CreateDate         types.String `tfsdk:"create_date"`
 	UniqueID           types.String `tfsdk:"unique_id"`
+	InlinePolicyNames  types.Set    `tfsdk:"inline_policy_names"`
+	TrustPrincipals    types.List   `tfsdk:"trust_principals"`
+}
+
+// trustPrincipalAttrTypes are the attribute types of a trust principal.
+var trustPrincipalAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"identifiers": types.SetType{ElemType: types.StringType},
 }
 
 func (d *RoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -80,6 +91,29 @@ func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Stable and unique string identifying the role.",
 				Computed:            true,
 			},
+			"inline_policy_names": schema.SetAttribute{
+				MarkdownDescription: "The names of the inline policies of the role.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"trust_principals": schema.ListNestedAttribute{
+				MarkdownDescription: "The principals allowed to assume the role by the `Allow` statements of the trust " +
+					"policy, grouped by principal type and sorted by type. A `\"*\"` principal is reported with type `*`.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The principal type, for example `AWS` or `Federated`.",
+							Computed:            true,
+						},
+						"identifiers": schema.SetAttribute{
+							MarkdownDescription: "The principal identifiers, for example user ARNs or OIDC provider ARNs.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -99,12 +133,7 @@ func (d *RoleDataSource) Configure(ctx context.Context, req datasource.Configure
 	}
 
 	d.client = client
-	d.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	d.iamClient = client.IAM
 }
 
 func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -174,6 +203,45 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		config.Description = types.StringNull()
 	}
 
+	policyNames, err := listRolePolicies(ctx, d.iamClient, roleName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Role",
+			fmt.Sprintf("Could not list inline policies of role %s: %s", roleName, err.Error()),
+		)
+		return
+	}
+	inlinePolicyNames, diags := types.SetValueFrom(ctx, types.StringType, policyNames)
+	resp.Diagnostics.Append(diags...)
+
+	principals, err := parseTrustPrincipals(assumeRolePolicy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Trust Policy",
+			fmt.Sprintf("Could not parse the trust policy of role %s: %s", roleName, err.Error()),
+		)
+		return
+	}
+	trustPrincipals := make([]attr.Value, 0, len(principals))
+	for _, principalType := range sortedKeys(principals) {
+		identifiers, diags := types.SetValueFrom(ctx, types.StringType, principals[principalType])
+		resp.Diagnostics.Append(diags...)
+		trustPrincipal, diags := types.ObjectValue(trustPrincipalAttrTypes, map[string]attr.Value{
+			"type":        types.StringValue(principalType),
+			"identifiers": identifiers,
+		})
+		resp.Diagnostics.Append(diags...)
+		trustPrincipals = append(trustPrincipals, trustPrincipal)
+	}
+	trustPrincipalsValue, diags := types.ListValue(types.ObjectType{AttrTypes: trustPrincipalAttrTypes}, trustPrincipals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.InlinePolicyNames = inlinePolicyNames
+	config.TrustPrincipals = trustPrincipalsValue
+
 	tflog.Trace(ctx, "Read role data source", map[string]any{
 		"name": role.RoleName,
 		"arn":  role.Arn,
@@ -181,3 +249,66 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
+
+// parseTrustPrincipals returns the identifiers of the principals allowed by
+// the Allow statements of a trust policy, keyed by principal type. A "*"
+// principal is returned with the "*" type.
+func parseTrustPrincipals(policy string) (map[string][]string, error) {
+	var document struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, err
+	}
+
+	type statement struct {
+		Effect    string          `json:"Effect"`
+		Principal json.RawMessage `json:"Principal"`
+	}
+	// A policy with a single statement may omit the array
+	var statements []statement
+	if err := json.Unmarshal(document.Statement, &statements); err != nil {
+		var single statement
+		if err := json.Unmarshal(document.Statement, &single); err != nil {
+			return nil, fmt.Errorf("invalid Statement: %w", err)
+		}
+		statements = []statement{single}
+	}
+
+	principals := map[string][]string{}
+	for _, stmt := range statements {
+		if stmt.Effect != "Allow" || len(stmt.Principal) == 0 {
+			continue
+		}
+
+		var wildcard string
+		if err := json.Unmarshal(stmt.Principal, &wildcard); err == nil {
+			identifiers := principals["*"]
+			if !slices.Contains(identifiers, wildcard) {
+				principals["*"] = append(identifiers, wildcard)
+			}
+			continue
+		}
+
+		var byType map[string]json.RawMessage
+		if err := json.Unmarshal(stmt.Principal, &byType); err != nil {
+			return nil, fmt.Errorf("invalid Principal: %w", err)
+		}
+		for principalType, raw := range byType {
+			var identifiers []string
+			if err := json.Unmarshal(raw, &identifiers); err != nil {
+				var identifier string
+				if err := json.Unmarshal(raw, &identifier); err != nil {
+					return nil, fmt.Errorf("invalid %s principal: %w", principalType, err)
+				}
+				identifiers = []string{identifier}
+			}
+			for _, identifier := range identifiers {
+				if !slices.Contains(principals[principalType], identifier) {
+					principals[principalType] = append(principals[principalType], identifier)
+				}
+			}
+		}
+	}
+	return principals, nil
+}
@@ -7,6 +7,63 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+func TestParseTrustPrincipals(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy      string
+		expected    map[string][]string
+		expectError bool
+	}{
+		"single statement": {
+			policy:   `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam:::user/alice"},"Action":"sts:AssumeRole"}}`,
+			expected: map[string][]string{"AWS": {"arn:aws:iam:::user/alice"}},
+		},
+		"grouped by type": {
+			policy: `{"Statement":[
+				{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam:::user/alice","arn:aws:iam:::user/bob"]},"Action":"sts:AssumeRole"},
+				{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam:::oidc-provider/idp.example.com","AWS":"arn:aws:iam:::user/alice"},"Action":"sts:AssumeRoleWithWebIdentity"}
+			]}`,
+			expected: map[string][]string{
+				"AWS":       {"arn:aws:iam:::user/alice", "arn:aws:iam:::user/bob"},
+				"Federated": {"arn:aws:iam:::oidc-provider/idp.example.com"},
+			},
+		},
+		"wildcard": {
+			policy:   `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}]}`,
+			expected: map[string][]string{"*": {"*"}},
+		},
+		"deny ignored": {
+			policy:   `{"Statement":[{"Effect":"Deny","Principal":{"AWS":"*"},"Action":"sts:AssumeRole"}]}`,
+			expected: map[string][]string{},
+		},
+		"invalid": {
+			policy:      `{"Statement":"nope"}`,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			principals, err := parseTrustPrincipals(testCase.policy)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if fmt.Sprint(principals) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, principals)
+			}
+		})
+	}
+}
+
 func TestAccRadosgwIAMRoleDataSource_basic(t *testing.T) {
 	t.Parallel()
 
@@ -23,6 +80,10 @@ func TestAccRadosgwIAMRoleDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair("data.radosgw_iam_role.test", "name", "radosgw_iam_role.test", "name"),
 					resource.TestCheckResourceAttrPair("data.radosgw_iam_role.test", "arn", "radosgw_iam_role.test", "arn"),
 					resource.TestCheckResourceAttrSet("data.radosgw_iam_role.test", "assume_role_policy"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_role.test", "inline_policy_names.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_role.test", "trust_principals.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_role.test", "trust_principals.0.type", "AWS"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_iam_role.test", "trust_principals.0.identifiers.*", "*"),
 				),
 			},
 		},
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -53,6 +55,10 @@ type rolesListXML struct {
 	Members []roleXML `xml:"member"`
 }
 
+// listRolesPageSize is the number of roles requested per ListRoles page.
+// RadosGW may return fewer roles per page than requested.
+const listRolesPageSize = 1000
+
 func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_iam_roles"
 }
@@ -72,7 +78,8 @@ func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			},
 			"path_prefix": schema.StringAttribute{
 				MarkdownDescription: "Path prefix for filtering the results. For example, `/application_abc/` would return all roles " +
-					"whose path starts with `/application_abc/`. Defaults to `/` if not specified.",
+					"whose path starts with `/application_abc/`. Defaults to `/` if not specified. All pages of results are " +
+					"fetched, regardless of the page size of the server.",
 				Optional: true,
 			},
 			"names": schema.SetAttribute{
@@ -108,12 +115,7 @@ func (d *RolesDataSource) Configure(ctx context.Context, req datasource.Configur
 	}
 
 	d.client = client
-	d.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	d.iamClient = client.IAM
 }
 
 func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -126,41 +128,15 @@ func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	tflog.Debug(ctx, "Reading RadosGW roles data source")
 
-	// Build request parameters
-	params := url.Values{}
-	params.Set("Action", "ListRoles")
-
-	if !config.PathPrefix.IsNull() && config.PathPrefix.ValueString() != "" {
-		params.Set("PathPrefix", config.PathPrefix.ValueString())
-	}
-
-	// Get all roles (handle pagination if needed)
-	var allRoles []roleXML
-	for {
-		body, err := d.iamClient.DoRequest(ctx, params, "iam")
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Reading RadosGW Roles",
-				fmt.Sprintf("Could not list roles: %s", err.Error()),
-			)
-			return
-		}
-
-		var response listRolesResponseXML
-		if err := xml.Unmarshal(body, &response); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Response",
-				fmt.Sprintf("Could not parse ListRoles response: %s", err.Error()),
-			)
-			return
-		}
-
-		allRoles = append(allRoles, response.Result.Roles.Members...)
+	pathPrefix := config.PathPrefix.ValueString()
 
-		if !response.Result.IsTruncated {
-			break
-		}
-		params.Set("Marker", response.Result.Marker)
+	allRoles, err := listRoles(ctx, d.iamClient, pathPrefix)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Roles",
+			fmt.Sprintf("Could not list roles: %s", err.Error()),
+		)
+		return
 	}
 
 	// Filter by regex if provided
@@ -219,3 +195,45 @@ func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
+
+// listRoles returns all roles whose path starts with pathPrefix, following
+// the ListRoles pagination markers.
+func listRoles(ctx context.Context, iamClient *IAMClient, pathPrefix string) ([]roleXML, error) {
+	params := url.Values{}
+	params.Set("Action", "ListRoles")
+	params.Set("MaxItems", strconv.Itoa(listRolesPageSize))
+	if pathPrefix != "" {
+		params.Set("PathPrefix", pathPrefix)
+	}
+
+	var roles []roleXML
+	seenMarkers := map[string]bool{}
+	for {
+		var response listRolesResponseXML
+		if err := iamClient.DoRequestXML(ctx, params, "iam", &response); err != nil {
+			return nil, err
+		}
+
+		// Releases that ignore PathPrefix return every role
+		for _, role := range response.Result.Roles.Members {
+			if strings.HasPrefix(role.Path, pathPrefix) {
+				roles = append(roles, role)
+			}
+		}
+
+		marker := response.Result.Marker
+		if !response.Result.IsTruncated {
+			return roles, nil
+		}
+		if marker == "" || seenMarkers[marker] {
+			return nil, fmt.Errorf("the ListRoles response is truncated but has no new marker after %d roles", len(roles))
+		}
+		seenMarkers[marker] = true
+
+		tflog.Debug(ctx, "Fetching next page of roles", map[string]any{
+			"marker": marker,
+			"roles":  len(roles),
+		})
+		params.Set("Marker", marker)
+	}
+}
@@ -2,11 +2,108 @@ package provider
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+// listRolesPage renders a ListRoles response page.
+func listRolesPage(roles []string, marker string) string {
+	var b strings.Builder
+	b.WriteString("<ListRolesResponse><ListRolesResult><Roles>")
+	for _, role := range roles {
+		path, name, _ := strings.Cut(role, ":")
+		fmt.Fprintf(&b, "<member><Path>%s</Path><RoleName>%s</RoleName><Arn>arn:aws:iam:::role%s%s</Arn></member>", path, name, path, name)
+	}
+	fmt.Fprintf(&b, "</Roles><IsTruncated>%t</IsTruncated><Marker>%s</Marker></ListRolesResult></ListRolesResponse>", marker != "", marker)
+	return b.String()
+}
+
+func TestListRoles(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		pages       map[string]string
+		pathPrefix  string
+		expected    []string
+		expectError bool
+	}{
+		"single page": {
+			pages:    map[string]string{"": listRolesPage([]string{"/:a", "/app/:b"}, "")},
+			expected: []string{"a", "b"},
+		},
+		"several pages": {
+			pages: map[string]string{
+				"":   listRolesPage([]string{"/:a"}, "m1"),
+				"m1": listRolesPage([]string{"/:b"}, "m2"),
+				"m2": listRolesPage([]string{"/:c"}, ""),
+			},
+			expected: []string{"a", "b", "c"},
+		},
+		"path prefix ignored by server": {
+			pages:      map[string]string{"": listRolesPage([]string{"/:a", "/app/:b", "/application/:c"}, "")},
+			pathPrefix: "/app/",
+			expected:   []string{"b"},
+		},
+		"truncated without marker": {
+			pages: map[string]string{
+				"": "<ListRolesResponse><ListRolesResult><Roles></Roles><IsTruncated>true</IsTruncated></ListRolesResult></ListRolesResponse>",
+			},
+			expectError: true,
+		},
+		"repeated marker": {
+			pages: map[string]string{
+				"":   listRolesPage([]string{"/:a"}, "m1"),
+				"m1": listRolesPage([]string{"/:b"}, "m1"),
+			},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			iamClient := NewIAMClient("http://rgw.example.com", "AKEY", "SKEY", &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if err := req.ParseForm(); err != nil {
+					return nil, err
+				}
+				if req.PostForm.Get("MaxItems") != strconv.Itoa(listRolesPageSize) {
+					t.Errorf("expected MaxItems %d, got %q", listRolesPageSize, req.PostForm.Get("MaxItems"))
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(testCase.pages[req.PostForm.Get("Marker")])),
+				}, nil
+			})})
+
+			roles, err := listRoles(testCtx, iamClient, testCase.pathPrefix)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			names := make([]string, 0, len(roles))
+			for _, role := range roles {
+				names = append(names, role.RoleName)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected roles %v, got %v", testCase.expected, names)
+			}
+		})
+	}
+}
+
 func TestAccRadosgwIAMRolesDataSource_basic(t *testing.T) {
 	t.Parallel()
 
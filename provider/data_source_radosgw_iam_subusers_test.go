@@ -36,7 +36,7 @@ func TestAccRadosgwIAMSubusersDataSource_multiple(t *testing.T) {
 	userID := randomName("tf-acc-user")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_MultipleUserCredentials) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
 		Steps: []resource.TestStep{
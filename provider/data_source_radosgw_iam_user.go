@@ -36,6 +36,7 @@ type UserDataSourceModel struct {
 	DefaultPlacement    types.String `tfsdk:"default_placement"`
 	DefaultStorageClass types.String `tfsdk:"default_storage_class"`
 	Type                types.String `tfsdk:"type"`
+	AccountID           types.String `tfsdk:"account_id"`
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -85,7 +86,11 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "The user type (e.g., 'rgw', 'ldap').",
+				MarkdownDescription: "The user type (e.g., 'rgw', 'ldap', 'root').",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the account the user belongs to. Empty if the user is not part of an account.",
 				Computed:            true,
 			},
 		},
@@ -135,7 +140,7 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading RadosGW User",
-			fmt.Sprintf("Could not read user %s: %s", userID, err.Error()),
+			fmt.Sprintf("Could not read user %s: %s", userID, adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -151,6 +156,7 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	config.DefaultPlacement = types.StringValue(user.DefaultPlacement)
 	config.DefaultStorageClass = types.StringValue(user.DefaultStorageClass)
 	config.Type = types.StringValue(user.Type)
+	config.AccountID = types.StringValue(user.AccountID)
 
 	tflog.Trace(ctx, "Read user data source", map[string]any{
 		"user_id":      user.ID,
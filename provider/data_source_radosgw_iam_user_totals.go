@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserTotalsDataSource{}
+
+func NewIAMUserTotalsDataSource() datasource.DataSource {
+	return &UserTotalsDataSource{}
+}
+
+// UserTotalsDataSource defines the data source implementation.
+type UserTotalsDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// UserTotalsDataSourceModel describes the data source data model.
+type UserTotalsDataSourceModel struct {
+	UserID           types.String `tfsdk:"user_id"`
+	Tenant           types.String `tfsdk:"tenant"`
+	SyncStats        types.Bool   `tfsdk:"sync_stats"`
+	MaxConcurrency   types.Int64  `tfsdk:"max_concurrency"`
+	Users            types.Map    `tfsdk:"users"`
+	TotalBytes       types.Int64  `tfsdk:"total_bytes"`
+	TotalBytesActual types.Int64  `tfsdk:"total_bytes_actual"`
+	TotalObjects     types.Int64  `tfsdk:"total_objects"`
+	BucketCount      types.Int64  `tfsdk:"bucket_count"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// userTotals are the storage statistics of a single user.
+type userTotals struct {
+	UserID string `json:"user_id"`
+	Tenant string `json:"tenant"`
+	Stats  struct {
+		Size       int64 `json:"size"`
+		SizeActual int64 `json:"size_actual"`
+		NumObjects int64 `json:"num_objects"`
+	} `json:"stats"`
+	BucketCount int64 `json:"-"`
+}
+
+// userTotalsAttrTypes are the attribute types of a single entry of users.
+var userTotalsAttrTypes = map[string]attr.Type{
+	"user_id":            types.StringType,
+	"tenant":             types.StringType,
+	"total_bytes":        types.Int64Type,
+	"total_bytes_actual": types.Int64Type,
+	"total_objects":      types.Int64Type,
+	"bucket_count":       types.Int64Type,
+}
+
+func (d *UserTotalsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_user_totals"
+}
+
+func (d *UserTotalsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the storage statistics of RadosGW users: bytes and objects stored and the number of " +
+			"buckets owned, per user and summed over all users. Reads a single user with `user_id`, the users of a " +
+			"tenant with `tenant`, or all users when neither is set.\n\n" +
+			"RadosGW updates the user statistics asynchronously from the bucket statistics, so they can lag behind " +
+			"recent writes; set `sync_stats` to recalculate them first. The Admin Ops API does not report when the " +
+			"statistics were last synchronized, which is only shown by `radosgw-admin user stats`.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a single user to read. For users in a tenant, use the format `tenant$user_id`. Conflicts with `tenant`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("tenant")),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "Only read the users of this tenant. Conflicts with `user_id`.",
+				Optional:            true,
+			},
+			"sync_stats": schema.BoolAttribute{
+				MarkdownDescription: "Whether to recalculate the statistics of every user from its buckets before reading " +
+					"them. This is slow for users with many buckets. Default is false.",
+				Optional: true,
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users read in parallel. Default is %d.", defaultUsersDetailConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "The statistics of every user, keyed by the user ID in the format `tenant$user_id` for users in a tenant.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "The user ID.",
+							Computed:            true,
+						},
+						"tenant": schema.StringAttribute{
+							MarkdownDescription: "The tenant of the user.",
+							Computed:            true,
+						},
+						"total_bytes": schema.Int64Attribute{
+							MarkdownDescription: "The total size of the objects of the user in bytes.",
+							Computed:            true,
+						},
+						"total_bytes_actual": schema.Int64Attribute{
+							MarkdownDescription: "The total size of the objects of the user in bytes, with every object rounded up to 4 KiB.",
+							Computed:            true,
+						},
+						"total_objects": schema.Int64Attribute{
+							MarkdownDescription: "The number of objects of the user.",
+							Computed:            true,
+						},
+						"bucket_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of buckets owned by the user.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The sum of `total_bytes` over all users.",
+				Computed:            true,
+			},
+			"total_bytes_actual": schema.Int64Attribute{
+				MarkdownDescription: "The sum of `total_bytes_actual` over all users.",
+				Computed:            true,
+			},
+			"total_objects": schema.Int64Attribute{
+				MarkdownDescription: "The sum of `total_objects` over all users.",
+				Computed:            true,
+			},
+			"bucket_count": schema.Int64Attribute{
+				MarkdownDescription: "The sum of `bucket_count` over all users.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserTotalsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *UserTotalsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserTotalsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userIDs []string
+	id := "radosgw-user-totals"
+	if !config.UserID.IsNull() {
+		userIDs = []string{config.UserID.ValueString()}
+		id = config.UserID.ValueString()
+	} else {
+		if !config.Tenant.IsNull() {
+			id = config.Tenant.ValueString()
+		}
+		users, err := d.client.Admin.GetUsers(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing RadosGW Users",
+				fmt.Sprintf("Could not list users: %s", err.Error()),
+			)
+			return
+		}
+		for _, userID := range *users {
+			if !config.Tenant.IsNull() && !strings.HasPrefix(userID, config.Tenant.ValueString()+"$") {
+				continue
+			}
+			userIDs = append(userIDs, userID)
+		}
+		sort.Strings(userIDs)
+	}
+
+	concurrency := defaultUsersDetailConcurrency
+	if !config.MaxConcurrency.IsNull() {
+		concurrency = int(config.MaxConcurrency.ValueInt64())
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW user totals data source", map[string]any{
+		"users":       len(userIDs),
+		"concurrency": concurrency,
+	})
+
+	totals, errs := d.fetchUserTotals(ctx, userIDs, config.SyncStats.ValueBool(), concurrency)
+	for i, err := range errs {
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading RadosGW User Statistics",
+				fmt.Sprintf("Could not read the statistics of user %s: %s", userIDs[i], err.Error()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var totalBytes, totalBytesActual, totalObjects, bucketCount int64
+	users := make(map[string]attr.Value, len(userIDs))
+	for i, userID := range userIDs {
+		user := totals[i]
+		userValue, diags := types.ObjectValue(userTotalsAttrTypes, map[string]attr.Value{
+			"user_id":            types.StringValue(user.UserID),
+			"tenant":             types.StringValue(user.Tenant),
+			"total_bytes":        types.Int64Value(user.Stats.Size),
+			"total_bytes_actual": types.Int64Value(user.Stats.SizeActual),
+			"total_objects":      types.Int64Value(user.Stats.NumObjects),
+			"bucket_count":       types.Int64Value(user.BucketCount),
+		})
+		resp.Diagnostics.Append(diags...)
+		users[userID] = userValue
+
+		totalBytes += user.Stats.Size
+		totalBytesActual += user.Stats.SizeActual
+		totalObjects += user.Stats.NumObjects
+		bucketCount += user.BucketCount
+	}
+
+	usersValue, diags := types.MapValue(types.ObjectType{AttrTypes: userTotalsAttrTypes}, users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Users = usersValue
+	config.TotalBytes = types.Int64Value(totalBytes)
+	config.TotalBytesActual = types.Int64Value(totalBytesActual)
+	config.TotalObjects = types.Int64Value(totalObjects)
+	config.BucketCount = types.Int64Value(bucketCount)
+	config.ID = types.StringValue(id)
+
+	tflog.Trace(ctx, "Read user totals data source", map[string]any{
+		"users":         len(users),
+		"total_bytes":   totalBytes,
+		"total_objects": totalObjects,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// fetchUserTotals reads the statistics and bucket count of the given users
+// with at most concurrency users in flight. Results are returned in the order
+// of userIDs.
+func (d *UserTotalsDataSource) fetchUserTotals(ctx context.Context, userIDs []string, syncStats bool, concurrency int) ([]userTotals, []error) {
+	totals := make([]userTotals, len(userIDs))
+	errs := make([]error, len(userIDs))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func(i int, userID string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			totals[i], errs[i] = d.getUserTotals(ctx, userID, syncStats)
+		}(i, userID)
+	}
+	wg.Wait()
+
+	return totals, errs
+}
+
+// getUserTotals reads the statistics of a user and counts its buckets.
+func (d *UserTotalsDataSource) getUserTotals(ctx context.Context, userID string, syncStats bool) (userTotals, error) {
+	params := url.Values{
+		"uid":   {userID},
+		"stats": {"true"},
+	}
+	if syncStats {
+		params.Set("sync", "true")
+	}
+
+	body, err := d.adminClient.DoRequest(ctx, http.MethodGet, "/user", params)
+	if err != nil {
+		return userTotals{}, err
+	}
+
+	var totals userTotals
+	if err := json.Unmarshal(body, &totals); err != nil {
+		return userTotals{}, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	buckets, err := d.client.Admin.ListUsersBuckets(ctx, userID)
+	if err != nil {
+		return userTotals{}, fmt.Errorf("failed to list buckets: %w", err)
+	}
+	totals.BucketCount = int64(len(buckets))
+
+	return totals, nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMUserTotalsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserTotalsDataSourceConfig_basic(userID, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "id", userID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "users.%", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", fmt.Sprintf("users.%s.bucket_count", userID), "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", fmt.Sprintf("users.%s.total_objects", userID), "0"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "bucket_count", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "total_bytes", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUserTotalsDataSource_tenant(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tfaccuser")
+	// Tenant names in RadosGW cannot contain hyphens, use alphanumeric only
+	tenant := "tfacc" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroyWithTenant,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserTotalsDataSourceConfig_tenant(userID, tenant),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "id", tenant),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "users.%", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", fmt.Sprintf("users.%s$%s.tenant", tenant, userID), tenant),
+					resource.TestCheckResourceAttr("data.radosgw_iam_user_totals.test", "bucket_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMUserTotalsDataSourceConfig_basic(userID, bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Totals"
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_link" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  uid           = radosgw_iam_user.test.user_id
+  unlink_to_uid = "admin"
+}
+
+data "radosgw_iam_user_totals" "test" {
+  user_id    = radosgw_iam_user.test.user_id
+  sync_stats = true
+
+  depends_on = [radosgw_s3_bucket_link.test]
+}
+`, userID, bucketName)
+}
+
+func testAccRadosgwIAMUserTotalsDataSourceConfig_tenant(userID, tenant string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Tenant User for Totals"
+  tenant       = %q
+}
+
+data "radosgw_iam_user_totals" "test" {
+  tenant = radosgw_iam_user.test.tenant
+}
+`, userID, tenant)
+}
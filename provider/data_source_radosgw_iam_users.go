@@ -2,12 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,9 +33,26 @@ type UsersDataSource struct {
 
 // UsersDataSourceModel describes the data source data model.
 type UsersDataSourceModel struct {
-	NameRegex types.String `tfsdk:"name_regex"`
-	UserIDs   types.Set    `tfsdk:"user_ids"`
-	ID        types.String `tfsdk:"id"`
+	NameRegex      types.String `tfsdk:"name_regex"`
+	Tenant         types.String `tfsdk:"tenant"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	NamesOnly      types.Bool   `tfsdk:"names_only"`
+	IncludeDetails types.Bool   `tfsdk:"include_details"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	UserIDs        types.Set    `tfsdk:"user_ids"`
+	Users          types.Map    `tfsdk:"users"`
+	Details        types.List   `tfsdk:"details"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// userSummaryAttrTypes are the attribute types of an entry of details.
+var userSummaryAttrTypes = map[string]attr.Type{
+	"user_id":      types.StringType,
+	"tenant":       types.StringType,
+	"display_name": types.StringType,
+	"email":        types.StringType,
+	"suspended":    types.BoolType,
+	"max_buckets":  types.Int64Type,
 }
 
 func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,7 +62,9 @@ func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Retrieves a list of RadosGW user IDs. " +
-			"Use this data source to get all users or filter them by a regex pattern.",
+			"Use this data source to get all users or filter them by a regex pattern. " +
+			"Set `names_only` to false to also fetch the details of every matching user; " +
+			"the users are then fetched concurrently, which keeps reads fast on clusters with many users.",
 
 		Attributes: map[string]schema.Attribute{
 			"name_regex": schema.StringAttribute{
@@ -49,11 +74,75 @@ func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 					stringvalidator.RegexMatches(regexp.MustCompile(`.*`), "must be a valid regex pattern"),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "Only return the users of this tenant. Set it to an empty string to only return users without a tenant.",
+				Optional:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return the users whose ID, without the tenant, starts with this prefix.",
+				Optional:            true,
+			},
+			"names_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether only the user IDs are returned. When false, the details of every matching user " +
+					"are fetched and returned in `users`. Default is true.",
+				Optional: true,
+			},
+			"include_details": schema.BoolAttribute{
+				MarkdownDescription: "Whether a summary of every matching user is returned in `details`, " +
+					"for example to audit suspended users across the cluster. Default is false.",
+				Optional: true,
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users fetched in parallel when `names_only` is false or `include_details` is true. Default is %d.", defaultUsersDetailConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
 			"user_ids": schema.SetAttribute{
 				MarkdownDescription: "Set of user IDs matching the filter criteria. If no filter is specified, all user IDs are returned.",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "The details of the matching users, keyed by user ID. Null when `names_only` is true.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userDetailSchemaAttributes(),
+				},
+			},
+			"details": schema.ListNestedAttribute{
+				MarkdownDescription: "A summary of the matching users, sorted by user ID. Null unless `include_details` is true.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "The user ID. Users in a tenant use the format `tenant$user_id`.",
+							Computed:            true,
+						},
+						"tenant": schema.StringAttribute{
+							MarkdownDescription: "The tenant to which the user belongs.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the user.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the user.",
+							Computed:            true,
+						},
+						"suspended": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is suspended.",
+							Computed:            true,
+						},
+						"max_buckets": schema.Int64Attribute{
+							MarkdownDescription: "The maximum number of buckets the user can own.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The data source identifier.",
 				Computed:            true,
@@ -99,11 +188,10 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Filter by regex if provided
-	var filteredUsers []string
+	var re *regexp.Regexp
 	if !config.NameRegex.IsNull() && config.NameRegex.ValueString() != "" {
 		pattern := config.NameRegex.ValueString()
-		re, err := regexp.Compile(pattern)
+		re, err = regexp.Compile(pattern)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Regex Pattern",
@@ -111,24 +199,14 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			)
 			return
 		}
+	}
 
-		for _, userID := range *users {
-			if re.MatchString(userID) {
-				filteredUsers = append(filteredUsers, userID)
-			}
-		}
+	filteredUsers := filterUserIDs(*users, config.Tenant, config.NamePrefix.ValueString(), re)
 
-		tflog.Debug(ctx, "Filtered users by regex", map[string]any{
-			"pattern":       pattern,
-			"total_users":   len(*users),
-			"matched_users": len(filteredUsers),
-		})
-	} else {
-		filteredUsers = *users
-		tflog.Debug(ctx, "Returning all users", map[string]any{
-			"total_users": len(filteredUsers),
-		})
-	}
+	tflog.Debug(ctx, "Filtered users", map[string]any{
+		"total_users":   len(*users),
+		"matched_users": len(filteredUsers),
+	})
 
 	// Convert to set
 	userIDSet, diags := types.SetValueFrom(ctx, types.StringType, filteredUsers)
@@ -138,7 +216,142 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	config.UserIDs = userIDSet
+	config.Users = types.MapNull(types.ObjectType{AttrTypes: userDetailAttrTypes})
+	config.Details = types.ListNull(types.ObjectType{AttrTypes: userSummaryAttrTypes})
+
+	withUsers := !config.NamesOnly.IsNull() && !config.NamesOnly.ValueBool()
+	if withUsers || config.IncludeDetails.ValueBool() {
+		fetched, diags := d.readUserDetails(ctx, filteredUsers, config.MaxConcurrency)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if withUsers {
+			usersValue, diags := userDetailsMapValue(fetched)
+			resp.Diagnostics.Append(diags...)
+			config.Users = usersValue
+		}
+		if config.IncludeDetails.ValueBool() {
+			detailsValue, diags := userSummariesListValue(fetched)
+			resp.Diagnostics.Append(diags...)
+			config.Details = detailsValue
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	config.ID = types.StringValue("radosgw-users")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
+
+// readUserDetails fetches the given users concurrently and returns them
+// keyed by user ID. Users deleted since they were listed are skipped.
+func (d *UsersDataSource) readUserDetails(ctx context.Context, userIDs []string, maxConcurrency types.Int64) (map[string]admin.User, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	concurrency := defaultUsersDetailConcurrency
+	if !maxConcurrency.IsNull() {
+		concurrency = int(maxConcurrency.ValueInt64())
+	}
+
+	tflog.Debug(ctx, "Fetching user details", map[string]any{
+		"users":       len(userIDs),
+		"concurrency": concurrency,
+	})
+
+	results := fetchUsers(ctx, d.client.Admin, userIDs, concurrency)
+
+	users := make(map[string]admin.User, len(userIDs))
+	for i, userID := range userIDs {
+		result := results[i]
+		if result.err != nil {
+			if errors.Is(result.err, admin.ErrNoSuchUser) {
+				tflog.Debug(ctx, "User deleted while reading users data source, skipping", map[string]any{
+					"user_id": userID,
+				})
+				continue
+			}
+			diags.AddError(
+				"Error Reading RadosGW User",
+				fmt.Sprintf("Could not read user %s: %s", userID, result.err.Error()),
+			)
+			continue
+		}
+		users[userID] = result.user
+	}
+	return users, diags
+}
+
+// userDetailsMapValue returns the users as the users attribute value.
+func userDetailsMapValue(users map[string]admin.User) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make(map[string]attr.Value, len(users))
+	for userID, user := range users {
+		userValue, objDiags := userDetailObjectValue(user)
+		diags.Append(objDiags...)
+		values[userID] = userValue
+	}
+
+	usersValue, mapDiags := types.MapValue(types.ObjectType{AttrTypes: userDetailAttrTypes}, values)
+	diags.Append(mapDiags...)
+	return usersValue, diags
+}
+
+// userSummariesListValue returns the users as the details attribute value,
+// sorted by user ID.
+func userSummariesListValue(users map[string]admin.User) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(users))
+	for _, userID := range sortedKeys(users) {
+		user := users[userID]
+
+		maxBuckets := int64(0)
+		if user.MaxBuckets != nil {
+			maxBuckets = int64(*user.MaxBuckets)
+		}
+
+		summary, objDiags := types.ObjectValue(userSummaryAttrTypes, map[string]attr.Value{
+			"user_id":      types.StringValue(userID),
+			"tenant":       types.StringValue(user.Tenant),
+			"display_name": types.StringValue(user.DisplayName),
+			"email":        types.StringValue(user.Email),
+			"suspended":    types.BoolValue(user.Suspended != nil && *user.Suspended != 0),
+			"max_buckets":  types.Int64Value(maxBuckets),
+		})
+		diags.Append(objDiags...)
+		values = append(values, summary)
+	}
+
+	detailsValue, listDiags := types.ListValue(types.ObjectType{AttrTypes: userSummaryAttrTypes}, values)
+	diags.Append(listDiags...)
+	return detailsValue, diags
+}
+
+// filterUserIDs returns the user IDs of the given tenant (when tenant is not
+// null) whose name, without the tenant, starts with namePrefix and whose ID
+// matches re (when not nil).
+func filterUserIDs(userIDs []string, tenant types.String, namePrefix string, re *regexp.Regexp) []string {
+	filtered := []string{}
+	for _, userID := range userIDs {
+		userTenant, name, found := strings.Cut(userID, "$")
+		if !found {
+			userTenant, name = "", userID
+		}
+
+		if !tenant.IsNull() && userTenant != tenant.ValueString() {
+			continue
+		}
+		if !strings.HasPrefix(name, namePrefix) {
+			continue
+		}
+		if re != nil && !re.MatchString(userID) {
+			continue
+		}
+		filtered = append(filtered, userID)
+	}
+	return filtered
+}
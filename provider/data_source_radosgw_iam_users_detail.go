@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDetailDataSource{}
+
+func NewIAMUsersDetailDataSource() datasource.DataSource {
+	return &UsersDetailDataSource{}
+}
+
+// UsersDetailDataSource defines the data source implementation.
+type UsersDetailDataSource struct {
+	client *RadosgwClient
+}
+
+// UsersDetailDataSourceModel describes the data source data model.
+type UsersDetailDataSourceModel struct {
+	UserIDs        types.Set    `tfsdk:"user_ids"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	IgnoreMissing  types.Bool   `tfsdk:"ignore_missing"`
+	Users          types.Map    `tfsdk:"users"`
+	MissingUserIDs types.Set    `tfsdk:"missing_user_ids"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// defaultUsersDetailConcurrency is the number of users fetched in parallel
+// when max_concurrency is not set.
+const defaultUsersDetailConcurrency = 10
+
+// userDetailAttrTypes are the attribute types of a single entry of users.
+var userDetailAttrTypes = map[string]attr.Type{
+	"user_id":               types.StringType,
+	"display_name":          types.StringType,
+	"email":                 types.StringType,
+	"tenant":                types.StringType,
+	"max_buckets":           types.Int64Type,
+	"suspended":             types.BoolType,
+	"op_mask":               types.StringType,
+	"default_placement":     types.StringType,
+	"default_storage_class": types.StringType,
+	"type":                  types.StringType,
+	"account_id":            types.StringType,
+}
+
+func (d *UsersDetailDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_users_detail"
+}
+
+func (d *UsersDetailDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the details of many RadosGW users in a single read. " +
+			"The users are fetched concurrently and returned as a map keyed by the requested user ID, " +
+			"which can be used directly in `for_each`. Prefer it over one `radosgw_iam_user` data source " +
+			"per user when syncing hundreds of users.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_ids": schema.SetAttribute{
+				MarkdownDescription: "The user IDs to look up. For users in a tenant, use the format `tenant$user_id`.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users fetched in parallel. Default is %d.", defaultUsersDetailConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"ignore_missing": schema.BoolAttribute{
+				MarkdownDescription: "Whether users that do not exist are skipped instead of failing the read. " +
+					"Skipped users are listed in `missing_user_ids`. Default is false.",
+				Optional: true,
+			},
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "The users found, keyed by the requested user ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userDetailSchemaAttributes(),
+				},
+			},
+			"missing_user_ids": schema.SetAttribute{
+				MarkdownDescription: "The requested user IDs that do not exist. Always empty unless `ignore_missing` is enabled.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// userDetailSchemaAttributes returns the attributes of a single user entry,
+// shared by the data sources that return user details.
+func userDetailSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"user_id": schema.StringAttribute{
+			MarkdownDescription: "The user ID.",
+			Computed:            true,
+		},
+		"display_name": schema.StringAttribute{
+			MarkdownDescription: "The display name of the user.",
+			Computed:            true,
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "The email address of the user.",
+			Computed:            true,
+		},
+		"tenant": schema.StringAttribute{
+			MarkdownDescription: "The tenant to which the user belongs.",
+			Computed:            true,
+		},
+		"max_buckets": schema.Int64Attribute{
+			MarkdownDescription: "The maximum number of buckets the user can own.",
+			Computed:            true,
+		},
+		"suspended": schema.BoolAttribute{
+			MarkdownDescription: "Whether the user is suspended.",
+			Computed:            true,
+		},
+		"op_mask": schema.StringAttribute{
+			MarkdownDescription: "The operation mask for the user (e.g., 'read, write, delete').",
+			Computed:            true,
+		},
+		"default_placement": schema.StringAttribute{
+			MarkdownDescription: "The default placement for the user's buckets.",
+			Computed:            true,
+		},
+		"default_storage_class": schema.StringAttribute{
+			MarkdownDescription: "The default storage class for the user's objects.",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The user type (e.g., 'rgw', 'ldap', 'root').",
+			Computed:            true,
+		},
+		"account_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the account the user belongs to. Empty if the user is not part of an account.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *UsersDetailDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// userFetchResult holds the outcome of fetching a single user.
+type userFetchResult struct {
+	user admin.User
+	err  error
+}
+
+func (d *UsersDetailDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UsersDetailDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userIDs []string
+	resp.Diagnostics.Append(config.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(userIDs)
+
+	concurrency := defaultUsersDetailConcurrency
+	if !config.MaxConcurrency.IsNull() {
+		concurrency = int(config.MaxConcurrency.ValueInt64())
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW users detail data source", map[string]any{
+		"users":       len(userIDs),
+		"concurrency": concurrency,
+	})
+
+	results := fetchUsers(ctx, d.client.Admin, userIDs, concurrency)
+
+	users := make(map[string]attr.Value, len(userIDs))
+	missing := []string{}
+	for i, userID := range userIDs {
+		result := results[i]
+		if result.err != nil {
+			if errors.Is(result.err, admin.ErrNoSuchUser) {
+				if config.IgnoreMissing.ValueBool() {
+					missing = append(missing, userID)
+					continue
+				}
+				resp.Diagnostics.AddError(
+					"User Not Found",
+					fmt.Sprintf("User with ID %q does not exist.", userID),
+				)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading RadosGW User",
+				fmt.Sprintf("Could not read user %s: %s", userID, result.err.Error()),
+			)
+			continue
+		}
+
+		userValue, diags := userDetailObjectValue(result.user)
+		resp.Diagnostics.Append(diags...)
+		users[userID] = userValue
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usersValue, diags := types.MapValue(types.ObjectType{AttrTypes: userDetailAttrTypes}, users)
+	resp.Diagnostics.Append(diags...)
+	missingValue, diags := types.SetValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Users = usersValue
+	config.MissingUserIDs = missingValue
+	config.ID = types.StringValue("radosgw-users-detail")
+
+	tflog.Trace(ctx, "Read users detail data source", map[string]any{
+		"found":   len(users),
+		"missing": len(missing),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// userDetailObjectValue converts a user into a users entry.
+func userDetailObjectValue(user admin.User) (attr.Value, diag.Diagnostics) {
+	maxBuckets := int64(0)
+	if user.MaxBuckets != nil {
+		maxBuckets = int64(*user.MaxBuckets)
+	}
+	suspended := user.Suspended != nil && *user.Suspended != 0
+
+	return types.ObjectValue(userDetailAttrTypes, map[string]attr.Value{
+		"user_id":               types.StringValue(user.ID),
+		"display_name":          types.StringValue(user.DisplayName),
+		"email":                 types.StringValue(user.Email),
+		"tenant":                types.StringValue(user.Tenant),
+		"max_buckets":           types.Int64Value(maxBuckets),
+		"suspended":             types.BoolValue(suspended),
+		"op_mask":               types.StringValue(user.OpMask),
+		"default_placement":     types.StringValue(user.DefaultPlacement),
+		"default_storage_class": types.StringValue(user.DefaultStorageClass),
+		"type":                  types.StringValue(user.Type),
+		"account_id":            types.StringValue(user.AccountID),
+	})
+}
+
+// fetchUsers fetches the given users with at most concurrency requests in
+// flight. Results are returned in the order of userIDs.
+func fetchUsers(ctx context.Context, api *admin.API, userIDs []string, concurrency int) []userFetchResult {
+	results := make([]userFetchResult, len(userIDs))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func(i int, userID string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			user, err := api.GetUser(ctx, admin.User{ID: userID})
+			results[i] = userFetchResult{user: user, err: err}
+		}(i, userID)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMUsersDetailDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID1 := randomName("tf-acc-user")
+	userID2 := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUsersDetailDataSourceConfig_basic(userID1, userID2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", "users.%", "2"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", fmt.Sprintf("users.%s.user_id", userID1), userID1),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", fmt.Sprintf("users.%s.display_name", userID1), "First User"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", fmt.Sprintf("users.%s.email", userID2), userID2+"@example.com"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", fmt.Sprintf("users.%s.suspended", userID2), "false"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", "missing_user_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUsersDetailDataSource_missing(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	missingID := randomName("tf-acc-missing")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRadosgwIAMUsersDetailDataSourceConfig_missing(userID, missingID, false),
+				ExpectError: regexp.MustCompile("User Not Found"),
+			},
+			{
+				Config: testAccRadosgwIAMUsersDetailDataSourceConfig_missing(userID, missingID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", "users.%", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", fmt.Sprintf("users.%s.user_id", userID), userID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_detail.test", "missing_user_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_iam_users_detail.test", "missing_user_ids.*", missingID),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMUsersDetailDataSourceConfig_basic(userID1, userID2 string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "first" {
+  user_id      = %[1]q
+  display_name = "First User"
+}
+
+resource "radosgw_iam_user" "second" {
+  user_id      = %[2]q
+  display_name = "Second User"
+  email        = "%[2]s@example.com"
+}
+
+data "radosgw_iam_users_detail" "test" {
+  user_ids        = [radosgw_iam_user.first.user_id, radosgw_iam_user.second.user_id]
+  max_concurrency = 2
+}
+`, userID1, userID2)
+}
+
+func testAccRadosgwIAMUsersDetailDataSourceConfig_missing(userID, missingID string, ignoreMissing bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Existing User"
+}
+
+data "radosgw_iam_users_detail" "test" {
+  user_ids       = [radosgw_iam_user.test.user_id, %q]
+  ignore_missing = %t
+}
+`, userID, missingID, ignoreMissing)
+}
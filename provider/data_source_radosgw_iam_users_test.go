@@ -2,11 +2,51 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+func TestFilterUserIDs(t *testing.T) {
+	t.Parallel()
+
+	userIDs := []string{"alice", "app-bob", "acme$app-carol", "acme$dave", "other$app-erin"}
+
+	testCases := map[string]struct {
+		tenant     types.String
+		namePrefix string
+		regex      string
+		expected   []string
+	}{
+		"all":               {tenant: types.StringNull(), expected: userIDs},
+		"no tenant":         {tenant: types.StringValue(""), expected: []string{"alice", "app-bob"}},
+		"tenant":            {tenant: types.StringValue("acme"), expected: []string{"acme$app-carol", "acme$dave"}},
+		"prefix":            {tenant: types.StringNull(), namePrefix: "app-", expected: []string{"app-bob", "acme$app-carol", "other$app-erin"}},
+		"tenant and prefix": {tenant: types.StringValue("acme"), namePrefix: "app-", expected: []string{"acme$app-carol"}},
+		"prefix and regex":  {tenant: types.StringNull(), namePrefix: "app-", regex: "^other", expected: []string{"other$app-erin"}},
+		"no match":          {tenant: types.StringValue("missing"), expected: []string{}},
+		"prefix not tenant": {tenant: types.StringNull(), namePrefix: "acme", expected: []string{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var re *regexp.Regexp
+			if testCase.regex != "" {
+				re = regexp.MustCompile(testCase.regex)
+			}
+
+			filtered := filterUserIDs(userIDs, testCase.tenant, testCase.namePrefix, re)
+			if fmt.Sprint(filtered) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, filtered)
+			}
+		})
+	}
+}
+
 func TestAccRadosgwIAMUsersDataSource_basic(t *testing.T) {
 	t.Parallel()
 
@@ -27,6 +67,39 @@ func TestAccRadosgwIAMUsersDataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMUsersDataSource_details(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUsersDataSourceConfig_details(userID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.test", "user_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.test", "users.%", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.test", "users."+userID+".user_id", userID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.test", "users."+userID+".display_name", "Test User for Users Data Source"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.test", "users."+userID+".max_buckets", "42"),
+					resource.TestCheckNoResourceAttr("data.radosgw_iam_users.names", "users.%"),
+					resource.TestCheckNoResourceAttr("data.radosgw_iam_users.names", "details.#"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "user_ids.#", "1"),
+					resource.TestCheckNoResourceAttr("data.radosgw_iam_users.summary", "users.%"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "details.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "details.0.user_id", userID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "details.0.tenant", ""),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "details.0.suspended", "false"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users.summary", "details.0.max_buckets", "42"),
+				),
+			},
+		},
+	})
+}
+
 // Test configurations
 
 func testAccRadosgwIAMUsersDataSourceConfig_basic(userID string) string {
@@ -41,3 +114,35 @@ data "radosgw_iam_users" "test" {
 }
 `, userID)
 }
+
+func testAccRadosgwIAMUsersDataSourceConfig_details(userID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %[1]q
+  display_name = "Test User for Users Data Source"
+  max_buckets  = 42
+}
+
+data "radosgw_iam_users" "test" {
+  name_regex      = "^%[1]s$"
+  names_only      = false
+  max_concurrency = 4
+
+  depends_on = [radosgw_iam_user.test]
+}
+
+data "radosgw_iam_users" "names" {
+  name_regex = "^%[1]s$"
+
+  depends_on = [radosgw_iam_user.test]
+}
+
+data "radosgw_iam_users" "summary" {
+  tenant          = ""
+  name_prefix     = %[1]q
+  include_details = true
+
+  depends_on = [radosgw_iam_user.test]
+}
+`, userID)
+}
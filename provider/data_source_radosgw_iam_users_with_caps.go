@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersWithCapsDataSource{}
+
+func NewIAMUsersWithCapsDataSource() datasource.DataSource {
+	return &UsersWithCapsDataSource{}
+}
+
+// UsersWithCapsDataSource defines the data source implementation.
+type UsersWithCapsDataSource struct {
+	client *RadosgwClient
+}
+
+// UsersWithCapsDataSourceModel describes the data source data model.
+type UsersWithCapsDataSourceModel struct {
+	CapType        types.String `tfsdk:"cap_type"`
+	Perm           types.String `tfsdk:"perm"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	UserIDs        types.Set    `tfsdk:"user_ids"`
+	Users          types.Map    `tfsdk:"users"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (d *UsersWithCapsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_users_with_caps"
+}
+
+func (d *UsersWithCapsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the RadosGW users that hold a given administrative capability, for example every user " +
+			"with `users=*`. Use it to audit which identities have admin capabilities.\n\n" +
+			"~> **Note:** RadosGW has no API to search users by capability: the data source lists all users and " +
+			"fetches each of them, so a read makes one request per user. Use `max_concurrency` to tune the load " +
+			"put on the cluster.",
+
+		Attributes: map[string]schema.Attribute{
+			"cap_type": schema.StringAttribute{
+				MarkdownDescription: "The capability type to look for (e.g., `users`, `buckets`, `metadata`).",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(validCapTypes...),
+				},
+			},
+			"perm": schema.StringAttribute{
+				MarkdownDescription: "The permission the users must hold on `cap_type`: `read`, `write` or `*`. " +
+					"A user with `*` matches `read` and `write`; `*` only matches users with full access. " +
+					"When unset, users with any permission on `cap_type` are returned.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(validPerms...),
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users fetched in parallel. Default is %d.", defaultUsersDetailConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"user_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the users holding the capability. Users in a tenant use the format `tenant$user_id`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"users": schema.MapAttribute{
+				MarkdownDescription: "The permission each matching user holds on `cap_type`, keyed by user ID.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UsersWithCapsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersWithCapsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UsersWithCapsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capType := config.CapType.ValueString()
+	perm := config.Perm.ValueString()
+
+	concurrency := defaultUsersDetailConcurrency
+	if !config.MaxConcurrency.IsNull() {
+		concurrency = int(config.MaxConcurrency.ValueInt64())
+	}
+
+	allUsers, err := d.client.Admin.GetUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Users",
+			fmt.Sprintf("Could not list users: %s", err.Error()),
+		)
+		return
+	}
+
+	var userIDs []string
+	if allUsers != nil {
+		userIDs = *allUsers
+	}
+	sort.Strings(userIDs)
+
+	tflog.Debug(ctx, "Reading RadosGW users with caps data source", map[string]any{
+		"cap_type":    capType,
+		"perm":        perm,
+		"users":       len(userIDs),
+		"concurrency": concurrency,
+	})
+
+	results := fetchUsers(ctx, d.client.Admin, userIDs, concurrency)
+
+	matches := map[string]string{}
+	for i, userID := range userIDs {
+		result := results[i]
+		if result.err != nil {
+			// The user was deleted after the list was built
+			if errors.Is(result.err, admin.ErrNoSuchUser) {
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading RadosGW User",
+				fmt.Sprintf("Could not read user %s: %s", userID, result.err.Error()),
+			)
+			continue
+		}
+
+		if granted, ok := userCapPerm(result.user.Caps, capType, perm); ok {
+			matches[userID] = granted
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userIDsValue, diags := types.SetValueFrom(ctx, types.StringType, sortedKeys(matches))
+	resp.Diagnostics.Append(diags...)
+	usersValue, diags := types.MapValueFrom(ctx, types.StringType, matches)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.UserIDs = userIDsValue
+	config.Users = usersValue
+	config.ID = types.StringValue("radosgw-users-with-caps")
+
+	tflog.Trace(ctx, "Read users with caps data source", map[string]any{
+		"cap_type": capType,
+		"matches":  len(matches),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// userCapPerm returns the permission held on capType and whether it grants
+// perm. A "*" permission grants read and write; an empty perm matches any
+// permission.
+func userCapPerm(caps []admin.UserCapSpec, capType, perm string) (string, bool) {
+	for _, c := range caps {
+		if c.Type != capType {
+			continue
+		}
+		return c.Perm, perm == "" || c.Perm == "*" || c.Perm == perm
+	}
+	return "", false
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUserCapPerm(t *testing.T) {
+	t.Parallel()
+
+	caps := []admin.UserCapSpec{
+		{Type: "users", Perm: "*"},
+		{Type: "buckets", Perm: "read"},
+	}
+
+	testCases := map[string]struct {
+		capType       string
+		perm          string
+		expectGranted string
+		expectMatch   bool
+	}{
+		"any permission":        {capType: "buckets", expectGranted: "read", expectMatch: true},
+		"exact permission":      {capType: "buckets", perm: "read", expectGranted: "read", expectMatch: true},
+		"missing permission":    {capType: "buckets", perm: "write", expectGranted: "read"},
+		"full access required":  {capType: "buckets", perm: "*", expectGranted: "read"},
+		"full access for read":  {capType: "users", perm: "read", expectGranted: "*", expectMatch: true},
+		"full access for write": {capType: "users", perm: "write", expectGranted: "*", expectMatch: true},
+		"full access":           {capType: "users", perm: "*", expectGranted: "*", expectMatch: true},
+		"missing type":          {capType: "metadata"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			granted, match := userCapPerm(caps, testCase.capType, testCase.perm)
+			if granted != testCase.expectGranted {
+				t.Errorf("expected granted permission %q, got %q", testCase.expectGranted, granted)
+			}
+			if match != testCase.expectMatch {
+				t.Errorf("expected match %t, got %t", testCase.expectMatch, match)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwIAMUsersWithCapsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	fullUserID := randomName("tf-acc-user")
+	readUserID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUsersWithCapsDataSourceConfig_basic(fullUserID, readUserID),
+				Check: resource.ComposeTestCheckFunc(
+					// Any permission
+					resource.TestCheckTypeSetElemAttr("data.radosgw_iam_users_with_caps.any", "user_ids.*", fullUserID),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_iam_users_with_caps.any", "user_ids.*", readUserID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_with_caps.any", fmt.Sprintf("users.%s", fullUserID), "*"),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_with_caps.any", fmt.Sprintf("users.%s", readUserID), "read"),
+					// Write permission only matches the user with full access
+					resource.TestCheckTypeSetElemAttr("data.radosgw_iam_users_with_caps.write", "user_ids.*", fullUserID),
+					resource.TestCheckResourceAttr("data.radosgw_iam_users_with_caps.write", fmt.Sprintf("users.%s", fullUserID), "*"),
+					resource.TestCheckNoResourceAttr("data.radosgw_iam_users_with_caps.write", fmt.Sprintf("users.%s", readUserID)),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMUsersWithCapsDataSourceConfig_basic(fullUserID, readUserID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "full" {
+  user_id      = %[1]q
+  display_name = "Full Usage User"
+}
+
+resource "radosgw_iam_user_caps" "full" {
+  user_id = radosgw_iam_user.full.user_id
+
+  caps = [
+    {
+      type = "usage"
+      perm = "*"
+    }
+  ]
+}
+
+resource "radosgw_iam_user" "read" {
+  user_id      = %[2]q
+  display_name = "Read Usage User"
+}
+
+resource "radosgw_iam_user_caps" "read" {
+  user_id = radosgw_iam_user.read.user_id
+
+  caps = [
+    {
+      type = "usage"
+      perm = "read"
+    }
+  ]
+}
+
+data "radosgw_iam_users_with_caps" "any" {
+  cap_type = "usage"
+
+  depends_on = [radosgw_iam_user_caps.full, radosgw_iam_user_caps.read]
+}
+
+data "radosgw_iam_users_with_caps" "write" {
+  cap_type        = "usage"
+  perm            = "write"
+  max_concurrency = 4
+
+  depends_on = [radosgw_iam_user_caps.full, radosgw_iam_user_caps.read]
+}
+`, fullUserID, readUserID)
+}
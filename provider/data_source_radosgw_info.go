@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &InfoDataSource{}
+
+func NewInfoDataSource() datasource.DataSource {
+	return &InfoDataSource{}
+}
+
+// InfoDataSource exposes the cluster and zone the provider is connected to.
+type InfoDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// InfoDataSourceModel describes the data source data model.
+type InfoDataSourceModel struct {
+	FSID             types.String `tfsdk:"fsid"`
+	StorageBackend   types.String `tfsdk:"storage_backend"`
+	Zone             types.String `tfsdk:"zone"`
+	Zonegroup        types.String `tfsdk:"zonegroup"`
+	RealmID          types.String `tfsdk:"realm_id"`
+	ZoneIsMaster     types.Bool   `tfsdk:"zone_is_master"`
+	CephRelease      types.String `tfsdk:"ceph_release"`
+	CephMajorVersion types.Int64  `tfsdk:"ceph_major_version"`
+	ID               types.String `tfsdk:"id"`
+}
+
+func (d *InfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_info"
+}
+
+func (d *InfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves information about the Ceph cluster and the RadosGW zone the provider is " +
+			"connected to: the cluster FSID from the Admin API `info` endpoint, the zone, zonegroup and realm from " +
+			"the current period, and the Ceph release reported by the gateway.\n\n" +
+			"The release is read from the `Server` response header (for example `Ceph Object Gateway (squid)`). " +
+			"`ceph_release` and `ceph_major_version` are null when the gateway does not report it, which is the " +
+			"case when `rgw_server_header` hides it or a proxy rewrites the header.",
+
+		Attributes: map[string]schema.Attribute{
+			"fsid": schema.StringAttribute{
+				MarkdownDescription: "The FSID of the Ceph cluster.",
+				Computed:            true,
+			},
+			"storage_backend": schema.StringAttribute{
+				MarkdownDescription: "The name of the storage backend (e.g., 'rados').",
+				Computed:            true,
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone served by the endpoint.",
+				Computed:            true,
+			},
+			"zonegroup": schema.StringAttribute{
+				MarkdownDescription: "The name of the zonegroup containing the zone. Empty when no realm is configured.",
+				Computed:            true,
+			},
+			"realm_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the realm. Empty when no realm is configured.",
+				Computed:            true,
+			},
+			"zone_is_master": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is the master zone of the realm. Always true when no realm is configured.",
+				Computed:            true,
+			},
+			"ceph_release": schema.StringAttribute{
+				MarkdownDescription: "The Ceph release name in lowercase (e.g., 'squid', 'tentacle').",
+				Computed:            true,
+			},
+			"ceph_major_version": schema.Int64Attribute{
+				MarkdownDescription: "The Ceph major version of the release (e.g., 19 for Squid).",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The FSID of the Ceph cluster.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *InfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *InfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config InfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW info data source")
+
+	info, err := d.client.Admin.GetInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Info",
+			fmt.Sprintf("Could not read cluster info: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(info.InfoSpec.StorageBackends) == 0 {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Info",
+			"The cluster info does not report any storage backend.",
+		)
+		return
+	}
+	backend := info.InfoSpec.StorageBackends[0]
+
+	status, err := d.adminClient.GetZoneStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Zone Status",
+			fmt.Sprintf("Could not read zone status: %s", err.Error()),
+		)
+		return
+	}
+
+	release, err := detectCephRelease(ctx, d.adminClient.HTTPClient, d.adminClient.Endpoint)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Detecting Ceph Release",
+			fmt.Sprintf("Could not detect the Ceph release: %s", err.Error()),
+		)
+		return
+	}
+
+	config.FSID = types.StringValue(backend.ClusterID)
+	config.StorageBackend = types.StringValue(backend.Name)
+	config.Zone = types.StringValue(status.ZoneName)
+	config.Zonegroup = types.StringValue(status.ZonegroupName)
+	config.RealmID = types.StringValue(status.RealmID)
+	config.ZoneIsMaster = types.BoolValue(status.IsMaster)
+	config.CephRelease = types.StringNull()
+	config.CephMajorVersion = types.Int64Null()
+	if version := parseCephVersion(release); version != CephVersion_Unknown {
+		config.CephRelease = types.StringValue(release)
+		config.CephMajorVersion = types.Int64Value(int64(version))
+	}
+	config.ID = types.StringValue(backend.ClusterID)
+
+	tflog.Trace(ctx, "Read RadosGW info data source", map[string]any{
+		"fsid":         backend.ClusterID,
+		"zone":         status.ZoneName,
+		"ceph_release": release,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
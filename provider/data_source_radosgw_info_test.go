@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwInfoDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwInfoDataSourceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.radosgw_info.test", "fsid"),
+					resource.TestCheckResourceAttr("data.radosgw_info.test", "storage_backend", "rados"),
+					resource.TestCheckResourceAttrSet("data.radosgw_info.test", "zone"),
+					resource.TestCheckResourceAttr("data.radosgw_info.test", "zone_is_master", "true"),
+					resource.TestCheckResourceAttrPair("data.radosgw_info.test", "id", "data.radosgw_info.test", "fsid"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwInfoDataSourceConfig_basic() string {
+	return providerConfig() + `
+data "radosgw_info" "test" {}
+`
+}
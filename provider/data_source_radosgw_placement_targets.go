@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PlacementTargetsDataSource{}
+
+func NewPlacementTargetsDataSource() datasource.DataSource {
+	return &PlacementTargetsDataSource{}
+}
+
+// PlacementTargetsDataSource lists the placement targets of a zonegroup.
+type PlacementTargetsDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// PlacementTargetsDataSourceModel describes the data source data model.
+type PlacementTargetsDataSourceModel struct {
+	Zonegroup        types.String `tfsdk:"zonegroup"`
+	DefaultPlacement types.String `tfsdk:"default_placement"`
+	Names            types.List   `tfsdk:"names"`
+	PlacementTargets types.List   `tfsdk:"placement_targets"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// placementTargetAttrTypes are the attribute types of a single entry of
+// placement_targets.
+var placementTargetAttrTypes = map[string]attr.Type{
+	"name":            types.StringType,
+	"tags":            types.ListType{ElemType: types.StringType},
+	"storage_classes": types.ListType{ElemType: types.StringType},
+}
+
+// zonegroupMapJSON is the subset of the zonegroup map returned by
+// GET /admin/config?type=zonegroup-map that describes placement targets.
+type zonegroupMapJSON struct {
+	Zonegroups []struct {
+		Name     string   `json:"name"`
+		IsMaster jsonBool `json:"is_master"`
+		Zones    []struct {
+			Name string `json:"name"`
+		} `json:"zones"`
+		PlacementTargets []struct {
+			Name           string   `json:"name"`
+			Tags           []string `json:"tags"`
+			StorageClasses []string `json:"storage_classes"`
+		} `json:"placement_targets"`
+		DefaultPlacement string `json:"default_placement"`
+	} `json:"zonegroups"`
+}
+
+func (d *PlacementTargetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_placement_targets"
+}
+
+func (d *PlacementTargetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the placement targets of a zonegroup and their storage classes, from the zonegroup map " +
+			"of the Admin API. Use it to reference the `default_placement` of users or the `storage_class` of lifecycle " +
+			"transitions instead of hard-coding them.",
+
+		Attributes: map[string]schema.Attribute{
+			"zonegroup": schema.StringAttribute{
+				MarkdownDescription: "The name of the zonegroup. Defaults to the zonegroup of the zone served by the provider endpoint.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"default_placement": schema.StringAttribute{
+				MarkdownDescription: "The name of the default placement target of the zonegroup.",
+				Computed:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of the placement targets, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"placement_targets": schema.ListNestedAttribute{
+				MarkdownDescription: "The placement targets, in the same order as `names`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the placement target.",
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "The tags a user needs in `placement_tags` to use the placement target.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"storage_classes": schema.ListAttribute{
+							MarkdownDescription: "The storage classes of the placement target, sorted.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the zonegroup.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PlacementTargetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *PlacementTargetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PlacementTargetsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW placement targets data source", map[string]any{
+		"zonegroup": config.Zonegroup.ValueString(),
+	})
+
+	params := url.Values{}
+	params.Set("type", "zonegroup-map")
+
+	body, header, err := d.adminClient.doRequest(ctx, http.MethodGet, "/config", params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Zonegroup Map",
+			fmt.Sprintf("Could not read the zonegroup map: %s", err.Error()),
+		)
+		return
+	}
+
+	var zonegroupMap zonegroupMapJSON
+	if err := json.Unmarshal(body, &zonegroupMap); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse the zonegroup map: %s", err.Error()),
+		)
+		return
+	}
+
+	// Without an explicit zonegroup, use the one containing the zone that
+	// served the request, falling back to the master zonegroup
+	zoneName := zoneNameFromRequestID(header.Get("X-Amz-Request-Id"))
+	index, masterIndex := -1, -1
+	for i, zonegroup := range zonegroupMap.Zonegroups {
+		if !config.Zonegroup.IsNull() && !config.Zonegroup.IsUnknown() {
+			if zonegroup.Name == config.Zonegroup.ValueString() {
+				index = i
+			}
+			continue
+		}
+		if zonegroup.IsMaster {
+			masterIndex = i
+		}
+		for _, zone := range zonegroup.Zones {
+			if zone.Name == zoneName {
+				index = i
+			}
+		}
+	}
+	if index == -1 {
+		index = masterIndex
+	}
+	if index == -1 {
+		resp.Diagnostics.AddError(
+			"Zonegroup Not Found",
+			fmt.Sprintf("Zonegroup %q does not exist.", config.Zonegroup.ValueString()),
+		)
+		return
+	}
+	zonegroup := zonegroupMap.Zonegroups[index]
+
+	targets := zonegroup.PlacementTargets
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	names := make([]string, 0, len(targets))
+	targetValues := make([]attr.Value, 0, len(targets))
+	for _, target := range targets {
+		names = append(names, target.Name)
+
+		tags := target.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		storageClasses := append([]string{}, target.StorageClasses...)
+		sort.Strings(storageClasses)
+
+		tagsValue, diags := types.ListValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(diags...)
+		storageClassesValue, diags := types.ListValueFrom(ctx, types.StringType, storageClasses)
+		resp.Diagnostics.Append(diags...)
+
+		targetValue, diags := types.ObjectValue(placementTargetAttrTypes, map[string]attr.Value{
+			"name":            types.StringValue(target.Name),
+			"tags":            tagsValue,
+			"storage_classes": storageClassesValue,
+		})
+		resp.Diagnostics.Append(diags...)
+		targetValues = append(targetValues, targetValue)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namesValue, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	targetsValue, diags := types.ListValue(types.ObjectType{AttrTypes: placementTargetAttrTypes}, targetValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Zonegroup = types.StringValue(zonegroup.Name)
+	config.DefaultPlacement = types.StringValue(zonegroup.DefaultPlacement)
+	config.Names = namesValue
+	config.PlacementTargets = targetsValue
+	config.ID = types.StringValue(zonegroup.Name)
+
+	tflog.Trace(ctx, "Read placement targets", map[string]any{
+		"zonegroup":         zonegroup.Name,
+		"placement_targets": len(targets),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwPlacementTargetsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwPlacementTargetsDataSourceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.radosgw_placement_targets.test", "zonegroup"),
+					resource.TestCheckResourceAttr("data.radosgw_placement_targets.test", "default_placement", "default-placement"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_placement_targets.test", "names.*", "default-placement"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.radosgw_placement_targets.test", "placement_targets.*", map[string]string{
+						"name": "default-placement",
+					}),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_placement_targets.test", "placement_targets.0.storage_classes.*", "STANDARD"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwPlacementTargetsDataSource_notFound(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRadosgwPlacementTargetsDataSourceConfig_zonegroup("tf-acc-missing"),
+				ExpectError: regexp.MustCompile("Zonegroup Not Found"),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwPlacementTargetsDataSourceConfig_basic() string {
+	return providerConfig() + `
+data "radosgw_placement_targets" "test" {}
+`
+}
+
+func testAccRadosgwPlacementTargetsDataSourceConfig_zonegroup(zonegroup string) string {
+	return providerConfig() + fmt.Sprintf(`
+data "radosgw_placement_targets" "test" {
+  zonegroup = %q
+}
+`, zonegroup)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -57,6 +59,9 @@ func (d *BucketDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			"bucket": schema.StringAttribute{
 				MarkdownDescription: "The name of the bucket to look up.",
 				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{tenantQualified: true},
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier of the bucket assigned by RadosGW.",
@@ -167,6 +172,12 @@ func (d *BucketDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	_, name := splitBucketID(config.Bucket.ValueString())
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), name)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bucketName := config.Bucket.ValueString()
 
 	tflog.Debug(ctx, "Reading RadosGW bucket", map[string]any{
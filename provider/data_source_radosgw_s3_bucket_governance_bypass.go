@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketGovernanceBypassDataSource{}
+
+func NewS3BucketGovernanceBypassDataSource() datasource.DataSource {
+	return &BucketGovernanceBypassDataSource{}
+}
+
+// BucketGovernanceBypassDataSource predicts whether the provider credentials
+// can remove objects under governance retention from a bucket.
+type BucketGovernanceBypassDataSource struct {
+	client *RadosgwClient
+}
+
+// BucketGovernanceBypassDataSourceModel describes the data source data model.
+type BucketGovernanceBypassDataSourceModel struct {
+	Bucket               types.String `tfsdk:"bucket"`
+	ObjectLockEnabled    types.Bool   `tfsdk:"object_lock_enabled"`
+	DefaultRetentionMode types.String `tfsdk:"default_retention_mode"`
+	CallerUserID         types.String `tfsdk:"caller_user_id"`
+	IsBucketOwner        types.Bool   `tfsdk:"is_bucket_owner"`
+	AdminPurgeAllowed    types.Bool   `tfsdk:"admin_purge_allowed"`
+	PolicyAllowsBypass   types.Bool   `tfsdk:"policy_allows_bypass"`
+	PolicyDeniesBypass   types.Bool   `tfsdk:"policy_denies_bypass"`
+	CanBypassGovernance  types.Bool   `tfsdk:"can_bypass_governance"`
+	ID                   types.String `tfsdk:"id"`
+}
+
+// bypassGovernanceAction is the IAM action required to bypass governance retention.
+const bypassGovernanceAction = "s3:BypassGovernanceRetention"
+
+func (d *BucketGovernanceBypassDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_governance_bypass"
+}
+
+func (d *BucketGovernanceBypassDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Reports whether the provider credentials can bypass governance mode retention on a bucket with ` +
+			`S3 Object Lock, so the outcome of destroying a locked bucket can be checked during plan ` +
+			`(e.g. in a ` + "`precondition`" + `) instead of failing at delete time.
+
+Two independent paths are reported:
+
+- ` + "`admin_purge_allowed`" + `: the credentials hold the ` + "`buckets=write`" + ` (or ` + "`buckets=*`" + `) capability, so ` +
+			"`radosgw_s3_bucket`" + ` with ` + "`force_destroy = true`" + ` can purge the bucket through the Admin API.
+- ` + "`can_bypass_governance`" + `: S3 deletes with the ` + "`x-amz-bypass-governance-retention`" + ` header are expected to ` +
+			`succeed, because the bucket policy allows ` + "`s3:BypassGovernanceRetention`" + ` for the caller, or the caller ` +
+			`owns the bucket and no policy statement denies it.
+
+~> **Note:** The bucket policy is evaluated on a best-effort basis. Statements with conditions are ignored when they ` +
+			`allow the action and honored when they deny it, and identity policies attached to the caller are not evaluated. ` +
+			`Objects in compliance mode can never be deleted before their retention expires.`,
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket to check.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{tenantQualified: true},
+				},
+			},
+			"object_lock_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether S3 Object Lock is enabled for the bucket.",
+				Computed:            true,
+			},
+			"default_retention_mode": schema.StringAttribute{
+				MarkdownDescription: "The default retention mode of the bucket (`GOVERNANCE` or `COMPLIANCE`). Null when no default retention is configured.",
+				Computed:            true,
+			},
+			"caller_user_id": schema.StringAttribute{
+				MarkdownDescription: "The user ID the provider credentials belong to.",
+				Computed:            true,
+			},
+			"is_bucket_owner": schema.BoolAttribute{
+				MarkdownDescription: "Whether the caller owns the bucket.",
+				Computed:            true,
+			},
+			"admin_purge_allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the caller holds the capabilities required to purge the bucket through the Admin API.",
+				Computed:            true,
+			},
+			"policy_allows_bypass": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bucket policy explicitly allows `s3:BypassGovernanceRetention` for the caller.",
+				Computed:            true,
+			},
+			"policy_denies_bypass": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bucket policy denies `s3:BypassGovernanceRetention` for the caller.",
+				Computed:            true,
+			},
+			"can_bypass_governance": schema.BoolAttribute{
+				MarkdownDescription: "Whether S3 deletes that bypass governance retention are expected to succeed.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name (same as `bucket`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketGovernanceBypassDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketGovernanceBypassDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketGovernanceBypassDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, name := splitBucketID(config.Bucket.ValueString())
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), name)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := config.Bucket.ValueString()
+
+	tflog.Debug(ctx, "Checking governance bypass", map[string]any{
+		"bucket": bucket,
+	})
+
+	bucketInfo, err := d.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucket})
+	if err != nil {
+		if isBucketNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Bucket Not Found",
+				fmt.Sprintf("Bucket %q does not exist.", bucket),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket",
+			fmt.Sprintf("Could not read bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	// Resolve the user owning the provider credentials
+	caller, err := d.client.Admin.GetUser(ctx, admin.User{
+		Keys: []admin.UserKeySpec{{AccessKey: d.client.Admin.AccessKey}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Caller",
+			fmt.Sprintf("Could not resolve the user of the provider credentials: %s", err.Error()),
+		)
+		return
+	}
+
+	config.ObjectLockEnabled = types.BoolValue(bucketInfo.ObjectLockEnabled)
+	config.DefaultRetentionMode = types.StringNull()
+	if bucketInfo.ObjectLockEnabled {
+		mode, err := d.getDefaultRetentionMode(ctx, bucket)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Object Lock Configuration",
+				fmt.Sprintf("Could not read object lock configuration for bucket %s: %s", bucket, err.Error()),
+			)
+			return
+		}
+		if mode != "" {
+			config.DefaultRetentionMode = types.StringValue(mode)
+		}
+	}
+
+	callerID := caller.ID
+	if caller.Tenant != "" {
+		callerID = caller.Tenant + "$" + caller.ID
+	}
+	isOwner := bucketInfo.Owner == callerID || bucketInfo.Owner == caller.ID
+
+	policy, err := d.getBucketPolicy(ctx, bucket)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Policy",
+			fmt.Sprintf("Could not read bucket policy for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+	allows, denies := evaluateGovernanceBypass(policy, bucket, callerPrincipals(caller))
+
+	config.CallerUserID = types.StringValue(callerID)
+	config.IsBucketOwner = types.BoolValue(isOwner)
+	config.AdminPurgeAllowed = types.BoolValue(hasCapPerm(caller.Caps, "buckets", "write"))
+	config.PolicyAllowsBypass = types.BoolValue(allows)
+	config.PolicyDeniesBypass = types.BoolValue(denies)
+	config.CanBypassGovernance = types.BoolValue(!denies && (allows || isOwner))
+	config.ID = types.StringValue(bucket)
+
+	tflog.Debug(ctx, "Checked governance bypass", map[string]any{
+		"bucket":                bucket,
+		"caller":                callerID,
+		"can_bypass_governance": config.CanBypassGovernance.ValueBool(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// getDefaultRetentionMode returns the default retention mode of a bucket, or
+// an empty string when no default retention is configured.
+func (d *BucketGovernanceBypassDataSource) getDefaultRetentionMode(ctx context.Context, bucket string) (string, error) {
+	output, err := d.client.S3.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ObjectLockConfigurationNotFoundError" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if output.ObjectLockConfiguration == nil ||
+		output.ObjectLockConfiguration.Rule == nil ||
+		output.ObjectLockConfiguration.Rule.DefaultRetention == nil {
+		return "", nil
+	}
+
+	return string(output.ObjectLockConfiguration.Rule.DefaultRetention.Mode), nil
+}
+
+// getBucketPolicy returns the bucket policy document, or an empty string when
+// no policy is attached.
+func (d *BucketGovernanceBypassDataSource) getBucketPolicy(ctx context.Context, bucket string) (string, error) {
+	output, err := d.client.S3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucketPolicy" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return aws.ToString(output.Policy), nil
+}
+
+// hasCapPerm reports whether the caps grant the given permission for a
+// capability type. A `*` permission grants both read and write.
+func hasCapPerm(caps []admin.UserCapSpec, capType, perm string) bool {
+	for _, c := range caps {
+		if c.Type != capType {
+			continue
+		}
+		for _, p := range strings.Split(c.Perm, ",") {
+			p = strings.TrimSpace(p)
+			if p == "*" || p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callerPrincipals returns the principal identifiers that match the given
+// user in a bucket policy.
+func callerPrincipals(user admin.User) []string {
+	principals := []string{
+		fmt.Sprintf("arn:aws:iam::%s:user/%s", user.Tenant, user.ID),
+	}
+	if user.AccountID != "" {
+		principals = append(principals,
+			user.AccountID,
+			fmt.Sprintf("arn:aws:iam::%s:root", user.AccountID),
+			fmt.Sprintf("arn:aws:iam::%s:user/%s", user.AccountID, user.DisplayName),
+		)
+	}
+	return principals
+}
+
+// policyStatement is a statement of a policy document, with the elements
+// that can be either a string or a list kept raw.
+type policyStatement struct {
+	Sid          string          `json:"Sid"`
+	Effect       string          `json:"Effect"`
+	Principal    json.RawMessage `json:"Principal"`
+	NotPrincipal json.RawMessage `json:"NotPrincipal"`
+	Action       json.RawMessage `json:"Action"`
+	NotAction    json.RawMessage `json:"NotAction"`
+	Resource     json.RawMessage `json:"Resource"`
+	NotResource  json.RawMessage `json:"NotResource"`
+	Condition    json.RawMessage `json:"Condition"`
+}
+
+// evaluateGovernanceBypass reports whether a bucket policy allows or denies
+// s3:BypassGovernanceRetention on objects of the bucket for any of the given
+// principals.
+func evaluateGovernanceBypass(policyJSON, bucket string, principals []string) (allows, denies bool) {
+	if policyJSON == "" {
+		return false, false
+	}
+
+	_, statements, err := parsePolicyDocument(policyJSON)
+	if err != nil {
+		return false, false
+	}
+
+	// Retention applies to objects, so match against an arbitrary object key
+	objectArn := fmt.Sprintf("arn:aws:s3:::%s/object", bucket)
+
+	for _, stmt := range statements {
+		if !statementMatches(stmt.Action, stmt.NotAction, bypassGovernanceAction, true) ||
+			!statementMatches(stmt.Resource, stmt.NotResource, objectArn, false) ||
+			!principalMatches(stmt, principals) {
+			continue
+		}
+
+		hasCondition := len(stmt.Condition) > 0 && string(stmt.Condition) != "null"
+		switch stmt.Effect {
+		case "Deny":
+			denies = true
+		case "Allow":
+			if !hasCondition {
+				allows = true
+			}
+		}
+	}
+
+	return allows, denies
+}
+
+// statementMatches reports whether the value matches an Action/Resource
+// element, honoring the NotAction/NotResource form. A statement without
+// either element matches everything.
+func statementMatches(element, notElement json.RawMessage, value string, caseInsensitive bool) bool {
+	if len(element) > 0 {
+		return anyPatternMatches(policyStringList(element), value, caseInsensitive)
+	}
+	if len(notElement) > 0 {
+		return !anyPatternMatches(policyStringList(notElement), value, caseInsensitive)
+	}
+	return true
+}
+
+// principalMatches reports whether the statement applies to any of the
+// given principals.
+func principalMatches(stmt policyStatement, principals []string) bool {
+	if len(stmt.Principal) > 0 {
+		return principalElementMatches(stmt.Principal, principals)
+	}
+	if len(stmt.NotPrincipal) > 0 {
+		return !principalElementMatches(stmt.NotPrincipal, principals)
+	}
+	return false
+}
+
+// principalElementMatches matches a Principal element, which is either "*"
+// or a map of principal types to identifiers.
+func principalElementMatches(element json.RawMessage, principals []string) bool {
+	var wildcard string
+	if err := json.Unmarshal(element, &wildcard); err == nil {
+		return wildcard == "*"
+	}
+
+	var byType map[string]json.RawMessage
+	if err := json.Unmarshal(element, &byType); err != nil {
+		return false
+	}
+	for _, identifiers := range byType {
+		for _, identifier := range policyStringList(identifiers) {
+			if identifier == "*" {
+				return true
+			}
+			for _, principal := range principals {
+				if identifier == principal {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// policyStringList decodes a policy element that is either a string or a
+// list of strings.
+func policyStringList(element json.RawMessage) []string {
+	var single string
+	if err := json.Unmarshal(element, &single); err == nil {
+		return []string{single}
+	}
+	var list []string
+	if err := json.Unmarshal(element, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// anyPatternMatches reports whether any of the IAM wildcard patterns (`*` and
+// `?`) matches the value.
+func anyPatternMatches(patterns []string, value string, caseInsensitive bool) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*"), `\?`, ".") + "$"
+		if caseInsensitive {
+			expr = "(?i)" + expr
+		}
+		if matched, err := regexp.MatchString(expr, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketGovernanceBypassDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketGovernanceBypassDataSourceConfig_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "object_lock_enabled", "true"),
+					resource.TestCheckResourceAttrSet("data.radosgw_s3_bucket_governance_bypass.test", "caller_user_id"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "is_bucket_owner", "true"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "admin_purge_allowed", "true"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "policy_allows_bypass", "false"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "policy_denies_bypass", "false"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "can_bypass_governance", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketGovernanceBypassDataSource_policyDeny(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketGovernanceBypassDataSourceConfig_policyDeny(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "is_bucket_owner", "true"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "policy_denies_bypass", "true"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_governance_bypass.test", "can_bypass_governance", "false"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketGovernanceBypassDataSourceConfig_basic(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket              = %q
+  object_lock_enabled = true
+  force_destroy       = true
+}
+
+data "radosgw_s3_bucket_governance_bypass" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3BucketGovernanceBypassDataSourceConfig_policyDeny(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket              = %[1]q
+  object_lock_enabled = true
+  force_destroy       = true
+}
+
+resource "radosgw_s3_bucket_policy" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "DenyGovernanceBypass"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = ["s3:BypassGovernanceRetention"]
+        Resource  = ["arn:aws:s3:::%[1]s/*"]
+      }
+    ]
+  })
+}
+
+data "radosgw_s3_bucket_governance_bypass" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+
+  depends_on = [radosgw_s3_bucket_policy.test]
+}
+`, bucketName)
+}
@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketMultipartUploadsDataSource{}
+
+func NewS3BucketMultipartUploadsDataSource() datasource.DataSource {
+	return &BucketMultipartUploadsDataSource{}
+}
+
+// BucketMultipartUploadsDataSource lists the in-progress multipart uploads of
+// a bucket.
+type BucketMultipartUploadsDataSource struct {
+	client *RadosgwClient
+}
+
+// BucketMultipartUploadsDataSourceModel describes the data source data model.
+type BucketMultipartUploadsDataSourceModel struct {
+	Bucket        types.String `tfsdk:"bucket"`
+	Tenant        types.String `tfsdk:"tenant"`
+	Prefix        types.String `tfsdk:"prefix"`
+	OlderThanDays types.Int64  `tfsdk:"older_than_days"`
+	MaxUploads    types.Int64  `tfsdk:"max_uploads"`
+	Uploads       types.List   `tfsdk:"uploads"`
+	IsTruncated   types.Bool   `tfsdk:"is_truncated"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// Limits of the number of uploads returned by the data source, which are
+// stored in the Terraform state like the keys of radosgw_s3_bucket_objects.
+const (
+	defaultBucketMultipartUploadsMax = 1000
+	maxBucketMultipartUploadsMax     = 10000
+)
+
+// bucketMultipartUploadAttrTypes are the attribute types of a single entry of
+// uploads.
+var bucketMultipartUploadAttrTypes = map[string]attr.Type{
+	"key":           types.StringType,
+	"upload_id":     types.StringType,
+	"initiated":     types.StringType,
+	"age_days":      types.Int64Type,
+	"initiator":     types.StringType,
+	"storage_class": types.StringType,
+}
+
+func (d *BucketMultipartUploadsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_multipart_uploads"
+}
+
+func (d *BucketMultipartUploadsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the in-progress multipart uploads of a bucket with `ListMultipartUploads`, in key order. " +
+			"Uploads that are never completed or aborted keep their parts stored and count against quotas, so use it to " +
+			"audit stale uploads, e.g. in a `check` block verifying that the `abort_incomplete_multipart_upload` rule of " +
+			"`radosgw_s3_bucket_lifecycle_configuration` is effective.\n\n" +
+			"~> **Note:** The listing is stored in the Terraform state and runs on every refresh. It stops after " +
+			"`max_uploads` uploads and sets `is_truncated`.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket to list.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list uploads of objects whose key starts with this prefix.",
+				Optional:            true,
+			},
+			"older_than_days": schema.Int64Attribute{
+				MarkdownDescription: "Only list uploads initiated more than this many days ago. Set it to the " +
+					"`days_after_initiation` of the lifecycle rule aborting incomplete uploads (plus a day of slack for " +
+					"the lifecycle processing) to list the uploads the rule missed.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_uploads": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of uploads to return. Default is %d, at most %d.",
+					defaultBucketMultipartUploadsMax, maxBucketMultipartUploadsMax),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxBucketMultipartUploadsMax),
+				},
+			},
+			"uploads": schema.ListNestedAttribute{
+				MarkdownDescription: "The listed multipart uploads.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key of the object being uploaded.",
+							Computed:            true,
+						},
+						"upload_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the upload.",
+							Computed:            true,
+						},
+						"initiated": schema.StringAttribute{
+							MarkdownDescription: "The time the upload was initiated in RFC3339 format.",
+							Computed:            true,
+						},
+						"age_days": schema.Int64Attribute{
+							MarkdownDescription: "The number of full days since the upload was initiated.",
+							Computed:            true,
+						},
+						"initiator": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who initiated the upload.",
+							Computed:            true,
+						},
+						"storage_class": schema.StringAttribute{
+							MarkdownDescription: "The storage class of the upload.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"is_truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether the listing stopped at `max_uploads` with more matching uploads left.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketMultipartUploadsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketMultipartUploadsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketMultipartUploadsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), config.Bucket.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(config.Tenant.ValueString(), config.Bucket.ValueString())
+
+	maxUploads := int64(defaultBucketMultipartUploadsMax)
+	if !config.MaxUploads.IsNull() {
+		maxUploads = config.MaxUploads.ValueInt64()
+	}
+
+	now := time.Now().UTC()
+	var initiatedBefore time.Time
+	if !config.OlderThanDays.IsNull() {
+		initiatedBefore = now.AddDate(0, 0, -int(config.OlderThanDays.ValueInt64()))
+	}
+
+	tflog.Debug(ctx, "Listing bucket multipart uploads", map[string]any{
+		"bucket":          bucket,
+		"prefix":          config.Prefix.ValueString(),
+		"older_than_days": config.OlderThanDays.ValueInt64(),
+		"max_uploads":     maxUploads,
+	})
+
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+	}
+	if config.Prefix.ValueString() != "" {
+		input.Prefix = aws.String(config.Prefix.ValueString())
+	}
+
+	listed, truncated, err := listMultipartUploads(ctx, d.client.S3, input, int(maxUploads), initiatedBefore)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Multipart Uploads",
+			fmt.Sprintf("Could not list multipart uploads of bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	uploads := make([]attr.Value, 0, len(listed))
+	for _, upload := range listed {
+		initiated := ""
+		ageDays := int64(0)
+		if upload.Initiated != nil {
+			initiated = upload.Initiated.Format(time.RFC3339)
+			ageDays = int64(now.Sub(*upload.Initiated) / (24 * time.Hour))
+		}
+		initiator := ""
+		if upload.Initiator != nil {
+			initiator = aws.ToString(upload.Initiator.ID)
+		}
+		// RadosGW omits the storage class of uploads in the default one
+		storageClass := string(upload.StorageClass)
+		if storageClass == "" {
+			storageClass = "STANDARD"
+		}
+
+		uploadValue, diags := types.ObjectValue(bucketMultipartUploadAttrTypes, map[string]attr.Value{
+			"key":           types.StringValue(aws.ToString(upload.Key)),
+			"upload_id":     types.StringValue(aws.ToString(upload.UploadId)),
+			"initiated":     types.StringValue(initiated),
+			"age_days":      types.Int64Value(ageDays),
+			"initiator":     types.StringValue(initiator),
+			"storage_class": types.StringValue(storageClass),
+		})
+		resp.Diagnostics.Append(diags...)
+
+		uploads = append(uploads, uploadValue)
+	}
+
+	uploadsValue, diags := types.ListValue(types.ObjectType{AttrTypes: bucketMultipartUploadAttrTypes}, uploads)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Uploads = uploadsValue
+	config.IsTruncated = types.BoolValue(truncated)
+	config.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Listed bucket multipart uploads", map[string]any{
+		"bucket":       bucket,
+		"uploads":      len(uploads),
+		"is_truncated": truncated,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// listMultipartUploads lists the multipart uploads matching input, skipping
+// uploads initiated after initiatedBefore unless it is zero, and stopping once
+// maxUploads uploads were collected. truncated reports whether matching
+// uploads were left out.
+func listMultipartUploads(ctx context.Context, client *s3.Client, input *s3.ListMultipartUploadsInput, maxUploads int, initiatedBefore time.Time) ([]s3types.MultipartUpload, bool, error) {
+	uploads := []s3types.MultipartUpload{}
+
+	paginator := s3.NewListMultipartUploadsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, upload := range page.Uploads {
+			if !initiatedBefore.IsZero() && (upload.Initiated == nil || !upload.Initiated.Before(initiatedBefore)) {
+				continue
+			}
+			if len(uploads) == maxUploads {
+				return uploads, true, nil
+			}
+			uploads = append(uploads, upload)
+		}
+	}
+
+	return uploads, false, nil
+}
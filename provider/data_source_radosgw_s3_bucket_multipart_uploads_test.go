@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// listMultipartUploadsPage renders a ListMultipartUploads response page of
+// uploads initiated on the given days of January 2024, keyed by object key.
+func listMultipartUploadsPage(uploads map[string]int, nextKeyMarker string) string {
+	var b strings.Builder
+	b.WriteString("<ListMultipartUploadsResult>")
+	for _, key := range sortedKeys(uploads) {
+		fmt.Fprintf(&b, "<Upload><Key>%s</Key><UploadId>2~%s</UploadId><Initiated>2024-01-%02dT00:00:00.000Z</Initiated>"+
+			"<Initiator><ID>alice</ID></Initiator></Upload>", key, key, uploads[key])
+	}
+	if nextKeyMarker != "" {
+		fmt.Fprintf(&b, "<IsTruncated>true</IsTruncated><NextKeyMarker>%s</NextKeyMarker><NextUploadIdMarker>2~%s</NextUploadIdMarker>",
+			nextKeyMarker, nextKeyMarker)
+	} else {
+		b.WriteString("<IsTruncated>false</IsTruncated>")
+	}
+	b.WriteString("</ListMultipartUploadsResult>")
+	return b.String()
+}
+
+func TestListMultipartUploads(t *testing.T) {
+	t.Parallel()
+
+	// Pages served for an empty key marker, then key marker "b"
+	pages := map[string]string{
+		"":  listMultipartUploadsPage(map[string]int{"a": 1, "b": 20}, "b"),
+		"b": listMultipartUploadsPage(map[string]int{"c": 2}, ""),
+	}
+
+	testCases := map[string]struct {
+		maxUploads       int
+		initiatedBefore  time.Time
+		expectKeys       []string
+		expectTruncation bool
+	}{
+		"all":          {maxUploads: 10, expectKeys: []string{"a", "b", "c"}},
+		"exact":        {maxUploads: 3, expectKeys: []string{"a", "b", "c"}},
+		"truncated":    {maxUploads: 2, expectKeys: []string{"a", "b"}, expectTruncation: true},
+		"older":        {maxUploads: 10, initiatedBefore: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), expectKeys: []string{"a", "c"}},
+		"older capped": {maxUploads: 1, initiatedBefore: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), expectKeys: []string{"a"}, expectTruncation: true},
+		"none older":   {maxUploads: 10, initiatedBefore: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), expectKeys: []string{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := s3.New(s3.Options{
+				Region:       "default",
+				BaseEndpoint: aws.String("http://rgw.example.com"),
+				UsePathStyle: true,
+				Credentials:  credentials.NewStaticCredentialsProvider("AKEY", "SKEY", ""),
+				HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader(pages[req.URL.Query().Get("key-marker")])),
+					}, nil
+				})},
+			})
+
+			input := &s3.ListMultipartUploadsInput{Bucket: aws.String("bucket")}
+			uploads, truncated, err := listMultipartUploads(context.Background(), client, input, testCase.maxUploads, testCase.initiatedBefore)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			keys := make([]string, 0, len(uploads))
+			for _, upload := range uploads {
+				keys = append(keys, aws.ToString(upload.Key))
+			}
+			if strings.Join(keys, ",") != strings.Join(testCase.expectKeys, ",") {
+				t.Errorf("expected keys %v, got %v", testCase.expectKeys, keys)
+			}
+			if truncated != testCase.expectTruncation {
+				t.Errorf("expected truncation %t, got %t", testCase.expectTruncation, truncated)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3BucketMultipartUploadsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketMultipartUploadsDataSourceConfig_bucket(bucketName),
+			},
+			// testAccPutBucketObjects leaves one multipart upload incomplete
+			{
+				PreConfig: func() {
+					if err := testAccPutBucketObjects(bucketName, 1); err != nil {
+						t.Fatalf("error uploading objects: %s", err)
+					}
+				},
+				Config: testAccRadosgwS3BucketMultipartUploadsDataSourceConfig_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_multipart_uploads.all", "uploads.#", "1"),
+					resource.TestCheckResourceAttrSet("data.radosgw_s3_bucket_multipart_uploads.all", "uploads.0.upload_id"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_multipart_uploads.all", "uploads.0.age_days", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_multipart_uploads.all", "is_truncated", "false"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_multipart_uploads.stale", "uploads.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketMultipartUploadsDataSourceConfig_bucket(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket        = %q
+  force_destroy = true
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3BucketMultipartUploadsDataSourceConfig_basic(bucketName string) string {
+	return testAccRadosgwS3BucketMultipartUploadsDataSourceConfig_bucket(bucketName) + `
+data "radosgw_s3_bucket_multipart_uploads" "all" {
+  bucket = radosgw_s3_bucket.test.bucket
+}
+
+data "radosgw_s3_bucket_multipart_uploads" "stale" {
+  bucket          = radosgw_s3_bucket.test.bucket
+  older_than_days = 7
+}
+`
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &S3BucketNotificationDataSource{}
+
+func NewS3BucketNotificationDataSource() datasource.DataSource {
+	return &S3BucketNotificationDataSource{}
+}
+
+// S3BucketNotificationDataSource retrieves the notification configuration of an S3 bucket.
+type S3BucketNotificationDataSource struct {
+	client *RadosgwClient
+}
+
+// S3BucketNotificationDataSourceModel describes the data source data model.
+type S3BucketNotificationDataSourceModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+	Topic  types.List   `tfsdk:"topic"`
+	ID     types.String `tfsdk:"id"`
+}
+
+func (d *S3BucketNotificationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_notification"
+}
+
+func (d *S3BucketNotificationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the notification configuration of an S3 bucket in RadosGW. " +
+			"Use this data source to discover notifications configured outside of Terraform, " +
+			"for example before importing them with `radosgw_s3_bucket_notification`. " +
+			"`topic` is empty when the bucket has no notifications.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"topic": schema.ListNestedAttribute{
+				MarkdownDescription: "The SNS topic notifications configured on the bucket.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The identifier of the notification configuration.",
+							Computed:            true,
+						},
+						"topic_arn": schema.StringAttribute{
+							MarkdownDescription: "The ARN of the SNS topic notifications are published to.",
+							Computed:            true,
+						},
+						"events": schema.SetAttribute{
+							MarkdownDescription: "The S3 event types that trigger notifications.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"filter_prefix": schema.StringAttribute{
+							MarkdownDescription: "The object key name prefix filter. Null when not set.",
+							Computed:            true,
+						},
+						"filter_suffix": schema.StringAttribute{
+							MarkdownDescription: "The object key name suffix filter. Null when not set.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name, prefixed with the tenant when set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *S3BucketNotificationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *S3BucketNotificationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config S3BucketNotificationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), config.Bucket.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(config.Tenant.ValueString(), config.Bucket.ValueString())
+
+	tflog.Debug(ctx, "Reading S3 bucket notification", map[string]any{
+		"bucket": bucket,
+	})
+
+	output, err := d.client.S3.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "NoSuchBucket" {
+			resp.Diagnostics.AddError(
+				"Bucket Not Found",
+				fmt.Sprintf("Bucket %q does not exist.", bucket),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Notification",
+			fmt.Sprintf("Could not read notification configuration from bucket %q: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	if len(output.TopicConfigurations) == 0 {
+		config.Topic = types.ListValueMust(topicConfigurationObjectType(), []attr.Value{})
+	} else {
+		topicList, diags := flattenTopicConfigurations(ctx, output.TopicConfigurations)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		config.Topic = topicList
+	}
+
+	config.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Read S3 bucket notification", map[string]any{
+		"bucket": bucket,
+		"topics": len(output.TopicConfigurations),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
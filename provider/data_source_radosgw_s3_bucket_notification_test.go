@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketNotificationDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	topicName := randomName("tf-acc-topic")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketNotificationDataSourceConfig_basic(bucketName, topicName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_notification.test", "id", bucketName),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_notification.test", "topic.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_notification.test", "topic.0.id", "uploads"),
+					resource.TestCheckResourceAttrPair("data.radosgw_s3_bucket_notification.test", "topic.0.topic_arn", "radosgw_sns_topic.test", "arn"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_notification.test", "topic.0.filter_prefix", "uploads/"),
+					resource.TestCheckTypeSetElemAttr("data.radosgw_s3_bucket_notification.test", "topic.0.events.*", "s3:ObjectCreated:*"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketNotificationDataSource_empty(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketNotificationDataSourceConfig_empty(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_notification.test", "topic.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketNotificationDataSourceConfig_basic(bucketName, topicName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_sns_topic" "test" {
+  name          = %q
+  push_endpoint = "http://localhost:10900"
+}
+
+resource "radosgw_s3_bucket_notification" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+
+  topic {
+    id            = "uploads"
+    topic_arn     = radosgw_sns_topic.test.arn
+    events        = ["s3:ObjectCreated:*"]
+    filter_prefix = "uploads/"
+  }
+}
+
+data "radosgw_s3_bucket_notification" "test" {
+  bucket = radosgw_s3_bucket_notification.test.bucket
+}
+`, bucketName, topicName)
+}
+
+func testAccRadosgwS3BucketNotificationDataSourceConfig_empty(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_s3_bucket_notification" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+}
+`, bucketName)
+}
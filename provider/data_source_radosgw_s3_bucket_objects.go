@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketObjectsDataSource{}
+
+func NewS3BucketObjectsDataSource() datasource.DataSource {
+	return &BucketObjectsDataSource{}
+}
+
+// BucketObjectsDataSource lists the objects of a bucket.
+type BucketObjectsDataSource struct {
+	client *RadosgwClient
+}
+
+// BucketObjectsDataSourceModel describes the data source data model.
+type BucketObjectsDataSourceModel struct {
+	Bucket            types.String `tfsdk:"bucket"`
+	Tenant            types.String `tfsdk:"tenant"`
+	Prefix            types.String `tfsdk:"prefix"`
+	Delimiter         types.String `tfsdk:"delimiter"`
+	StartAfter        types.String `tfsdk:"start_after"`
+	MaxKeys           types.Int64  `tfsdk:"max_keys"`
+	ErrorOnTruncation types.Bool   `tfsdk:"error_on_truncation"`
+	Keys              types.List   `tfsdk:"keys"`
+	Objects           types.List   `tfsdk:"objects"`
+	CommonPrefixes    types.List   `tfsdk:"common_prefixes"`
+	IsTruncated       types.Bool   `tfsdk:"is_truncated"`
+	ID                types.String `tfsdk:"id"`
+}
+
+// Limits of the number of keys returned by the data source. The listing is
+// stored in the Terraform state, so it is capped well below what a bucket
+// can hold.
+const (
+	defaultBucketObjectsMaxKeys = 1000
+	maxBucketObjectsMaxKeys     = 10000
+)
+
+// bucketObjectAttrTypes are the attribute types of a single entry of objects.
+var bucketObjectAttrTypes = map[string]attr.Type{
+	"key":           types.StringType,
+	"size":          types.Int64Type,
+	"etag":          types.StringType,
+	"last_modified": types.StringType,
+	"storage_class": types.StringType,
+}
+
+func (d *BucketObjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_objects"
+}
+
+func (d *BucketObjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the objects of a bucket with `ListObjectsV2`, in lexicographical key order. " +
+			"Use it to iterate over the objects of small configuration buckets or to check that an object exists.\n\n" +
+			"~> **Note:** The listing is stored in the Terraform state and runs on every refresh. It stops after " +
+			"`max_keys` keys and sets `is_truncated`; narrow it with `prefix` and `delimiter` rather than raising " +
+			"`max_keys` for large buckets, or set `error_on_truncation` to fail instead of returning a partial listing.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket to list.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list objects whose key starts with this prefix.",
+				Optional:            true,
+			},
+			"delimiter": schema.StringAttribute{
+				MarkdownDescription: "A character used to group keys, typically `/`. Keys that contain the delimiter " +
+					"after the prefix are rolled up into `common_prefixes` instead of being listed.",
+				Optional: true,
+			},
+			"start_after": schema.StringAttribute{
+				MarkdownDescription: "Only list objects whose key sorts after this key.",
+				Optional:            true,
+			},
+			"max_keys": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of keys and common prefixes to return. "+
+					"Default is %d, at most %d.", defaultBucketObjectsMaxKeys, maxBucketObjectsMaxKeys),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxBucketObjectsMaxKeys),
+				},
+			},
+			"error_on_truncation": schema.BoolAttribute{
+				MarkdownDescription: "Whether the read fails when the bucket holds more than `max_keys` matching " +
+					"objects, instead of returning the first `max_keys`. Default is false.",
+				Optional: true,
+			},
+			"keys": schema.ListAttribute{
+				MarkdownDescription: "The keys of the listed objects.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"objects": schema.ListNestedAttribute{
+				MarkdownDescription: "The listed objects.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The object key.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The size of the object in bytes.",
+							Computed:            true,
+						},
+						"etag": schema.StringAttribute{
+							MarkdownDescription: "The entity tag of the object, without quotes.",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: "The last modification time of the object in RFC3339 format.",
+							Computed:            true,
+						},
+						"storage_class": schema.StringAttribute{
+							MarkdownDescription: "The storage class of the object.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"common_prefixes": schema.ListAttribute{
+				MarkdownDescription: "The key prefixes rolled up by `delimiter`. Always empty unless `delimiter` is set.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"is_truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether the listing stopped at `max_keys` with more matching objects left.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketObjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketObjectsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), config.Bucket.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(config.Tenant.ValueString(), config.Bucket.ValueString())
+
+	maxKeys := int64(defaultBucketObjectsMaxKeys)
+	if !config.MaxKeys.IsNull() {
+		maxKeys = config.MaxKeys.ValueInt64()
+	}
+
+	tflog.Debug(ctx, "Listing bucket objects", map[string]any{
+		"bucket":    bucket,
+		"prefix":    config.Prefix.ValueString(),
+		"delimiter": config.Delimiter.ValueString(),
+		"max_keys":  maxKeys,
+	})
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if config.Prefix.ValueString() != "" {
+		input.Prefix = aws.String(config.Prefix.ValueString())
+	}
+	if config.Delimiter.ValueString() != "" {
+		input.Delimiter = aws.String(config.Delimiter.ValueString())
+	}
+	if config.StartAfter.ValueString() != "" {
+		input.StartAfter = aws.String(config.StartAfter.ValueString())
+	}
+
+	listed, commonPrefixes, truncated, err := listBucketObjects(ctx, d.client.S3, input, int(maxKeys))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Objects",
+			fmt.Sprintf("Could not list objects of bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	if truncated && config.ErrorOnTruncation.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Too Many Objects",
+			fmt.Sprintf("Bucket %s holds more than %d matching objects. Narrow the listing with prefix or delimiter, "+
+				"or raise max_keys.", bucket, maxKeys),
+		)
+		return
+	}
+
+	keys := make([]string, 0, len(listed))
+	objects := make([]attr.Value, 0, len(listed))
+	for _, object := range listed {
+		lastModified := ""
+		if object.LastModified != nil {
+			lastModified = object.LastModified.Format(time.RFC3339)
+		}
+		// RadosGW omits the storage class of objects in the default one
+		storageClass := string(object.StorageClass)
+		if storageClass == "" {
+			storageClass = "STANDARD"
+		}
+
+		objectValue, diags := types.ObjectValue(bucketObjectAttrTypes, map[string]attr.Value{
+			"key":           types.StringValue(aws.ToString(object.Key)),
+			"size":          types.Int64Value(aws.ToInt64(object.Size)),
+			"etag":          types.StringValue(strings.Trim(aws.ToString(object.ETag), `"`)),
+			"last_modified": types.StringValue(lastModified),
+			"storage_class": types.StringValue(storageClass),
+		})
+		resp.Diagnostics.Append(diags...)
+
+		keys = append(keys, aws.ToString(object.Key))
+		objects = append(objects, objectValue)
+	}
+
+	keysValue, diags := types.ListValueFrom(ctx, types.StringType, keys)
+	resp.Diagnostics.Append(diags...)
+	objectsValue, diags := types.ListValue(types.ObjectType{AttrTypes: bucketObjectAttrTypes}, objects)
+	resp.Diagnostics.Append(diags...)
+	commonPrefixesValue, diags := types.ListValueFrom(ctx, types.StringType, commonPrefixes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Keys = keysValue
+	config.Objects = objectsValue
+	config.CommonPrefixes = commonPrefixesValue
+	config.IsTruncated = types.BoolValue(truncated)
+	config.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Listed bucket objects", map[string]any{
+		"bucket":          bucket,
+		"keys":            len(keys),
+		"common_prefixes": len(commonPrefixes),
+		"is_truncated":    truncated,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// listBucketObjects lists the objects and common prefixes matching input,
+// stopping once maxKeys entries were collected. truncated reports whether
+// matching entries were left out.
+func listBucketObjects(ctx context.Context, client *s3.Client, input *s3.ListObjectsV2Input, maxKeys int) ([]s3types.Object, []string, bool, error) {
+	objects := []s3types.Object{}
+	commonPrefixes := []string{}
+
+	// Do not fetch more keys per page than can be returned
+	input.MaxKeys = aws.Int32(int32(min(maxKeys, 1000)))
+
+	paginator := s3.NewListObjectsV2Paginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		for _, object := range page.Contents {
+			if len(objects)+len(commonPrefixes) == maxKeys {
+				return objects, commonPrefixes, true, nil
+			}
+			objects = append(objects, object)
+		}
+		for _, prefix := range page.CommonPrefixes {
+			if len(objects)+len(commonPrefixes) == maxKeys {
+				return objects, commonPrefixes, true, nil
+			}
+			commonPrefixes = append(commonPrefixes, aws.ToString(prefix.Prefix))
+		}
+
+		// Further pages mean the listing stopped early
+		if len(objects)+len(commonPrefixes) == maxKeys {
+			return objects, commonPrefixes, paginator.HasMorePages(), nil
+		}
+	}
+
+	return objects, commonPrefixes, false, nil
+}
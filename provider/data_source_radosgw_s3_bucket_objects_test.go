@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// listObjectsPage renders a ListObjectsV2 response page.
+func listObjectsPage(keys, prefixes []string, nextToken string) string {
+	var b strings.Builder
+	b.WriteString("<ListBucketResult>")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>1</Size><ETag>\"abc\"</ETag></Contents>", key)
+	}
+	for _, prefix := range prefixes {
+		fmt.Fprintf(&b, "<CommonPrefixes><Prefix>%s</Prefix></CommonPrefixes>", prefix)
+	}
+	if nextToken != "" {
+		fmt.Fprintf(&b, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", nextToken)
+	} else {
+		b.WriteString("<IsTruncated>false</IsTruncated>")
+	}
+	b.WriteString("</ListBucketResult>")
+	return b.String()
+}
+
+func TestListBucketObjects(t *testing.T) {
+	t.Parallel()
+
+	// Pages served for an empty continuation token, then token "2"
+	pages := map[string]string{
+		"":  listObjectsPage([]string{"a", "b"}, []string{"dir1/"}, "2"),
+		"2": listObjectsPage([]string{"c"}, nil, ""),
+	}
+
+	testCases := map[string]struct {
+		maxKeys          int
+		expectKeys       []string
+		expectPrefixes   []string
+		expectTruncation bool
+	}{
+		"all":             {maxKeys: 10, expectKeys: []string{"a", "b", "c"}, expectPrefixes: []string{"dir1/"}},
+		"exact":           {maxKeys: 4, expectKeys: []string{"a", "b", "c"}, expectPrefixes: []string{"dir1/"}},
+		"within page":     {maxKeys: 1, expectKeys: []string{"a"}, expectPrefixes: []string{}, expectTruncation: true},
+		"before prefixes": {maxKeys: 2, expectKeys: []string{"a", "b"}, expectPrefixes: []string{}, expectTruncation: true},
+		"end of page":     {maxKeys: 3, expectKeys: []string{"a", "b"}, expectPrefixes: []string{"dir1/"}, expectTruncation: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := s3.New(s3.Options{
+				Region:       "default",
+				BaseEndpoint: aws.String("http://rgw.example.com"),
+				UsePathStyle: true,
+				Credentials:  credentials.NewStaticCredentialsProvider("AKEY", "SKEY", ""),
+				HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader(pages[req.URL.Query().Get("continuation-token")])),
+					}, nil
+				})},
+			})
+
+			input := &s3.ListObjectsV2Input{Bucket: aws.String("bucket")}
+			objects, prefixes, truncated, err := listBucketObjects(context.Background(), client, input, testCase.maxKeys)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			keys := make([]string, 0, len(objects))
+			for _, object := range objects {
+				keys = append(keys, aws.ToString(object.Key))
+			}
+			if fmt.Sprint(keys) != fmt.Sprint(testCase.expectKeys) {
+				t.Errorf("expected keys %v, got %v", testCase.expectKeys, keys)
+			}
+			if fmt.Sprint(prefixes) != fmt.Sprint(testCase.expectPrefixes) {
+				t.Errorf("expected common prefixes %v, got %v", testCase.expectPrefixes, prefixes)
+			}
+			if truncated != testCase.expectTruncation {
+				t.Errorf("expected truncated %t, got %t", testCase.expectTruncation, truncated)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3BucketObjectsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketObjectsDataSourceConfig_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_objects.test", "id", bucketName),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_objects.test", "keys.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_objects.test", "objects.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_objects.test", "common_prefixes.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_objects.test", "is_truncated", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketObjectsDataSource_invalidMaxKeys(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+data "radosgw_s3_bucket_objects" "test" {
+  bucket   = "some-bucket"
+  max_keys = 100000
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketObjectsDataSourceConfig_basic(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_s3_bucket_objects" "test" {
+  bucket              = radosgw_s3_bucket.test.bucket
+  prefix              = "configs/"
+  delimiter           = "/"
+  max_keys            = 100
+  error_on_truncation = true
+}
+`, bucketName)
+}
@@ -2,14 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -28,9 +33,37 @@ type BucketPolicyDataSource struct {
 
 // BucketPolicyDataSourceModel describes the data source data model.
 type BucketPolicyDataSourceModel struct {
-	Bucket types.String `tfsdk:"bucket"`
-	Policy types.String `tfsdk:"policy"`
-	ID     types.String `tfsdk:"id"`
+	Bucket     types.String `tfsdk:"bucket"`
+	Policy     types.String `tfsdk:"policy"`
+	Version    types.String `tfsdk:"version"`
+	Statements types.List   `tfsdk:"statements"`
+	ID         types.String `tfsdk:"id"`
+}
+
+// policyPrincipalAttrTypes are the attribute types of a decoded principal.
+var policyPrincipalAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"identifiers": types.ListType{ElemType: types.StringType},
+}
+
+// policyConditionAttrTypes are the attribute types of a decoded condition.
+var policyConditionAttrTypes = map[string]attr.Type{
+	"test":     types.StringType,
+	"variable": types.StringType,
+	"values":   types.ListType{ElemType: types.StringType},
+}
+
+// policyStatementAttrTypes are the attribute types of a decoded statement.
+var policyStatementAttrTypes = map[string]attr.Type{
+	"sid":            types.StringType,
+	"effect":         types.StringType,
+	"actions":        types.ListType{ElemType: types.StringType},
+	"not_actions":    types.ListType{ElemType: types.StringType},
+	"resources":      types.ListType{ElemType: types.StringType},
+	"not_resources":  types.ListType{ElemType: types.StringType},
+	"principals":     types.ListType{ElemType: types.ObjectType{AttrTypes: policyPrincipalAttrTypes}},
+	"not_principals": types.ListType{ElemType: types.ObjectType{AttrTypes: policyPrincipalAttrTypes}},
+	"conditions":     types.ListType{ElemType: types.ObjectType{AttrTypes: policyConditionAttrTypes}},
 }
 
 func (d *BucketPolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,17 +72,117 @@ func (d *BucketPolicyDataSource) Metadata(ctx context.Context, req datasource.Me
 
 func (d *BucketPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Retrieves the IAM policy document attached to an S3 bucket in RadosGW.",
+		MarkdownDescription: "Retrieves the IAM policy document attached to an S3 bucket in RadosGW, both as JSON and " +
+			"decoded into statements. Use `statements` to assert rules on the policy, such as no statement allowing the " +
+			"`*` principal, without parsing the JSON.",
 
 		Attributes: map[string]schema.Attribute{
 			"bucket": schema.StringAttribute{
 				MarkdownDescription: "The name of the bucket to retrieve the policy for.",
 				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{tenantQualified: true},
+				},
 			},
 			"policy": schema.StringAttribute{
 				MarkdownDescription: "The IAM bucket policy document in JSON format.",
 				Computed:            true,
 			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The `Version` of the policy document.",
+				Computed:            true,
+			},
+			"statements": schema.ListNestedAttribute{
+				MarkdownDescription: "The statements of the policy, in document order. Elements that can be a string or a list are always lists.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							MarkdownDescription: "The statement ID. Empty if not set.",
+							Computed:            true,
+						},
+						"effect": schema.StringAttribute{
+							MarkdownDescription: "The effect of the statement: `Allow` or `Deny`.",
+							Computed:            true,
+						},
+						"actions": schema.ListAttribute{
+							MarkdownDescription: "The actions the statement applies to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"not_actions": schema.ListAttribute{
+							MarkdownDescription: "The actions the statement does not apply to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"resources": schema.ListAttribute{
+							MarkdownDescription: "The resources the statement applies to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"not_resources": schema.ListAttribute{
+							MarkdownDescription: "The resources the statement does not apply to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"principals": schema.ListNestedAttribute{
+							MarkdownDescription: "The principals the statement applies to, sorted by type.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "The principal type, e.g. `AWS` or `Federated`. `*` for the anonymous principal.",
+										Computed:            true,
+									},
+									"identifiers": schema.ListAttribute{
+										MarkdownDescription: "The principal identifiers. `[\"*\"]` for the anonymous principal.",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+								},
+							},
+						},
+						"not_principals": schema.ListNestedAttribute{
+							MarkdownDescription: "The principals the statement does not apply to, sorted by type.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "The principal type, e.g. `AWS` or `Federated`. `*` for the anonymous principal.",
+										Computed:            true,
+									},
+									"identifiers": schema.ListAttribute{
+										MarkdownDescription: "The principal identifiers. `[\"*\"]` for the anonymous principal.",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+								},
+							},
+						},
+						"conditions": schema.ListNestedAttribute{
+							MarkdownDescription: "The conditions of the statement, sorted by test and variable.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"test": schema.StringAttribute{
+										MarkdownDescription: "The condition operator, e.g. `StringEquals`.",
+										Computed:            true,
+									},
+									"variable": schema.StringAttribute{
+										MarkdownDescription: "The context key, e.g. `aws:SourceIp`.",
+										Computed:            true,
+									},
+									"values": schema.ListAttribute{
+										MarkdownDescription: "The values compared against the context key.",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The bucket name (same as `bucket`).",
 				Computed:            true,
@@ -83,6 +216,12 @@ func (d *BucketPolicyDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	_, name := splitBucketID(config.Bucket.ValueString())
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), name)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bucket := config.Bucket.ValueString()
 
 	tflog.Debug(ctx, "Reading S3 bucket policy", map[string]any{
@@ -137,8 +276,163 @@ func (d *BucketPolicyDataSource) Read(ctx context.Context, req datasource.ReadRe
 		normalizedPolicy = *output.Policy
 	}
 
+	version, statements, err := parsePolicyDocument(*output.Policy)
+	if err != nil {
+		// Keep the raw policy usable even if it cannot be decoded
+		resp.Diagnostics.AddWarning(
+			"Could Not Decode Bucket Policy",
+			fmt.Sprintf("The policy of bucket %q could not be decoded, statements is empty: %s", bucket, err.Error()),
+		)
+	}
+
+	statementsValue, diags := policyStatementsValue(statements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config.Policy = types.StringValue(normalizedPolicy)
+	config.Version = types.StringValue(version)
+	config.Statements = statementsValue
 	config.ID = types.StringValue(bucket)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
+
+// parsePolicyDocument returns the version and the statements of a policy
+// document. Statement can be a single object or an array.
+func parsePolicyDocument(policyJSON string) (string, []policyStatement, error) {
+	var policy struct {
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+		return "", nil, err
+	}
+	if len(policy.Statement) == 0 {
+		return policy.Version, nil, nil
+	}
+
+	var statements []policyStatement
+	if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+		var single policyStatement
+		if err := json.Unmarshal(policy.Statement, &single); err != nil {
+			return "", nil, err
+		}
+		statements = []policyStatement{single}
+	}
+	return policy.Version, statements, nil
+}
+
+// policyStatementsValue converts decoded statements into the value of the
+// statements attribute.
+func policyStatementsValue(statements []policyStatement) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	statementType := types.ObjectType{AttrTypes: policyStatementAttrTypes}
+
+	values := make([]attr.Value, 0, len(statements))
+	for _, stmt := range statements {
+		value, d := types.ObjectValue(policyStatementAttrTypes, map[string]attr.Value{
+			"sid":            types.StringValue(stmt.Sid),
+			"effect":         types.StringValue(stmt.Effect),
+			"actions":        policyStringListValue(stmt.Action),
+			"not_actions":    policyStringListValue(stmt.NotAction),
+			"resources":      policyStringListValue(stmt.Resource),
+			"not_resources":  policyStringListValue(stmt.NotResource),
+			"principals":     policyPrincipalsValue(stmt.Principal, &diags),
+			"not_principals": policyPrincipalsValue(stmt.NotPrincipal, &diags),
+			"conditions":     policyConditionsValue(stmt.Condition, &diags),
+		})
+		diags.Append(d...)
+		values = append(values, value)
+	}
+	if diags.HasError() {
+		return types.ListNull(statementType), diags
+	}
+
+	list, d := types.ListValue(statementType, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// policyStringListValue converts an element that is either a string or a
+// list of strings into a list value.
+func policyStringListValue(element json.RawMessage) types.List {
+	values := []attr.Value{}
+	for _, s := range policyStringList(element) {
+		values = append(values, types.StringValue(s))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// policyPrincipalsValue converts a Principal element, either "*" or a map of
+// principal types to identifiers, into a list value sorted by type.
+func policyPrincipalsValue(element json.RawMessage, diags *diag.Diagnostics) types.List {
+	principalType := types.ObjectType{AttrTypes: policyPrincipalAttrTypes}
+	values := []attr.Value{}
+
+	var wildcard string
+	if err := json.Unmarshal(element, &wildcard); err == nil {
+		value, d := types.ObjectValue(policyPrincipalAttrTypes, map[string]attr.Value{
+			"type":        types.StringValue(wildcard),
+			"identifiers": types.ListValueMust(types.StringType, []attr.Value{types.StringValue(wildcard)}),
+		})
+		diags.Append(d...)
+		return types.ListValueMust(principalType, append(values, value))
+	}
+
+	var byType map[string]json.RawMessage
+	if err := json.Unmarshal(element, &byType); err == nil {
+		for _, typ := range sortedKeys(byType) {
+			value, d := types.ObjectValue(policyPrincipalAttrTypes, map[string]attr.Value{
+				"type":        types.StringValue(typ),
+				"identifiers": policyStringListValue(byType[typ]),
+			})
+			diags.Append(d...)
+			values = append(values, value)
+		}
+	}
+	return types.ListValueMust(principalType, values)
+}
+
+// policyConditionsValue converts a Condition element into a list value
+// sorted by test and variable. Non-string values are kept in their JSON form.
+func policyConditionsValue(element json.RawMessage, diags *diag.Diagnostics) types.List {
+	conditionType := types.ObjectType{AttrTypes: policyConditionAttrTypes}
+	values := []attr.Value{}
+
+	var byTest map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(element, &byTest); err == nil {
+		for _, test := range sortedKeys(byTest) {
+			for _, variable := range sortedKeys(byTest[test]) {
+				value, d := types.ObjectValue(policyConditionAttrTypes, map[string]attr.Value{
+					"test":     types.StringValue(test),
+					"variable": types.StringValue(variable),
+					"values":   policyConditionValues(byTest[test][variable]),
+				})
+				diags.Append(d...)
+				values = append(values, value)
+			}
+		}
+	}
+	return types.ListValueMust(conditionType, values)
+}
+
+// policyConditionValues converts the values of a condition, a scalar or a
+// list of scalars, into a list value.
+func policyConditionValues(element json.RawMessage) types.List {
+	var list []json.RawMessage
+	if err := json.Unmarshal(element, &list); err != nil {
+		list = []json.RawMessage{element}
+	}
+
+	values := []attr.Value{}
+	for _, item := range list {
+		var s string
+		if err := json.Unmarshal(item, &s); err != nil {
+			s = string(item)
+		}
+		values = append(values, types.StringValue(s))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -22,12 +23,106 @@ func TestAccRadosgwS3BucketPolicyDataSource_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "bucket", bucketName),
 					resource.TestCheckResourceAttrSet("data.radosgw_s3_bucket_policy.test", "policy"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "version", "2012-10-17"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.sid", "PublicReadGetObject"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.effect", "Allow"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.actions.0", "s3:GetObject"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.resources.0", "arn:aws:s3:::"+bucketName+"/*"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.principals.0.type", "*"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_policy.test", "statements.0.principals.0.identifiers.0", "*"),
 				),
 			},
 		},
 	})
 }
 
+func TestPolicyStatementsValue(t *testing.T) {
+	t.Parallel()
+
+	policy := `{
+  "Version": "2012-10-17",
+  "Statement": {
+    "Sid": "DenyInsecure",
+    "Effect": "Deny",
+    "Principal": {"AWS": ["arn:aws:iam:::user/b", "arn:aws:iam:::user/a"], "Federated": "accounts.google.com"},
+    "NotAction": "s3:GetObject",
+    "Resource": ["arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"],
+    "Condition": {"Bool": {"aws:SecureTransport": false}, "IpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}
+  }
+}`
+
+	version, statements, err := parsePolicyDocument(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if version != "2012-10-17" {
+		t.Errorf("expected version 2012-10-17, got %q", version)
+	}
+
+	value, diags := policyStatementsValue(statements)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var decoded []struct {
+		Sid          string   `tfsdk:"sid"`
+		Effect       string   `tfsdk:"effect"`
+		Actions      []string `tfsdk:"actions"`
+		NotActions   []string `tfsdk:"not_actions"`
+		Resources    []string `tfsdk:"resources"`
+		NotResources []string `tfsdk:"not_resources"`
+		Principals   []struct {
+			Type        string   `tfsdk:"type"`
+			Identifiers []string `tfsdk:"identifiers"`
+		} `tfsdk:"principals"`
+		NotPrincipals []struct {
+			Type        string   `tfsdk:"type"`
+			Identifiers []string `tfsdk:"identifiers"`
+		} `tfsdk:"not_principals"`
+		Conditions []struct {
+			Test     string   `tfsdk:"test"`
+			Variable string   `tfsdk:"variable"`
+			Values   []string `tfsdk:"values"`
+		} `tfsdk:"conditions"`
+	}
+	if diags := value.ElementsAs(context.Background(), &decoded, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(decoded) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(decoded))
+	}
+	stmt := decoded[0]
+	if stmt.Sid != "DenyInsecure" || stmt.Effect != "Deny" {
+		t.Errorf("unexpected sid/effect: %q/%q", stmt.Sid, stmt.Effect)
+	}
+	if len(stmt.Actions) != 0 || len(stmt.NotActions) != 1 || stmt.NotActions[0] != "s3:GetObject" {
+		t.Errorf("unexpected actions: %v / %v", stmt.Actions, stmt.NotActions)
+	}
+	if len(stmt.Resources) != 2 {
+		t.Errorf("expected 2 resources, got %v", stmt.Resources)
+	}
+	if len(stmt.Principals) != 2 || stmt.Principals[0].Type != "AWS" || stmt.Principals[1].Type != "Federated" {
+		t.Fatalf("unexpected principals: %+v", stmt.Principals)
+	}
+	if len(stmt.Principals[0].Identifiers) != 2 || stmt.Principals[0].Identifiers[0] != "arn:aws:iam:::user/b" {
+		t.Errorf("expected identifiers in document order, got %v", stmt.Principals[0].Identifiers)
+	}
+	if len(stmt.NotPrincipals) != 0 {
+		t.Errorf("expected no not_principals, got %+v", stmt.NotPrincipals)
+	}
+	if len(stmt.Conditions) != 2 {
+		t.Fatalf("expected 2 conditions, got %+v", stmt.Conditions)
+	}
+	if stmt.Conditions[0].Test != "Bool" || stmt.Conditions[0].Values[0] != "false" {
+		t.Errorf("unexpected first condition: %+v", stmt.Conditions[0])
+	}
+	if stmt.Conditions[1].Variable != "aws:SourceIp" || stmt.Conditions[1].Values[0] != "10.0.0.0/8" {
+		t.Errorf("unexpected second condition: %+v", stmt.Conditions[1])
+	}
+}
+
 // Test configurations
 
 func testAccRadosgwS3BucketPolicyDataSourceConfig_basic(bucketName string) string {
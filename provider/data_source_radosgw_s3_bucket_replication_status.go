@@ -0,0 +1,427 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketReplicationStatusDataSource{}
+
+func NewS3BucketReplicationStatusDataSource() datasource.DataSource {
+	return &BucketReplicationStatusDataSource{}
+}
+
+// BucketReplicationStatusDataSource reports how far the zone of the provider
+// endpoint has replicated a bucket from the other zones of its zonegroup.
+type BucketReplicationStatusDataSource struct {
+	client *RadosgwClient
+}
+
+// BucketReplicationStatusDataSourceModel describes the data source data model.
+type BucketReplicationStatusDataSourceModel struct {
+	Bucket      types.String `tfsdk:"bucket"`
+	Tenant      types.String `tfsdk:"tenant"`
+	Zone        types.String `tfsdk:"zone"`
+	CaughtUp    types.Bool   `tfsdk:"caught_up"`
+	SourceZones types.List   `tfsdk:"source_zones"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// bucketReplicationSourceAttrTypes are the attribute types of a single entry
+// of source_zones.
+var bucketReplicationSourceAttrTypes = map[string]attr.Type{
+	"zone":          types.StringType,
+	"zone_id":       types.StringType,
+	"state":         types.StringType,
+	"shards":        types.Int64Type,
+	"behind_shards": types.ListType{ElemType: types.Int64Type},
+	"caught_up":     types.BoolType,
+	"oldest_sync":   types.StringType,
+}
+
+// Bucket shard sync states reported by RadosGW, from the least to the most
+// advanced.
+const (
+	bucketSyncStateStopped     = "stopped"
+	bucketSyncStateInit        = "init"
+	bucketSyncStateFull        = "full-sync"
+	bucketSyncStateIncremental = "incremental-sync"
+)
+
+// bucketShardSyncInfo is the sync status of a bucket shard, as returned by
+// GET /admin/log?type=bucket-index&status.
+type bucketShardSyncInfo struct {
+	Status    string `json:"status"`
+	IncMarker struct {
+		Position  string `json:"position"`
+		Timestamp string `json:"timestamp"`
+	} `json:"inc_marker"`
+}
+
+// bucketIndexLogInfo is the subset of GET /admin/log?type=bucket-index&info
+// needed to know the last bucket index log entry of every shard.
+type bucketIndexLogInfo struct {
+	MaxMarker   string `json:"max_marker"`
+	SyncStopped bool   `json:"syncstopped"`
+}
+
+// bucketSourceStatus is the replication status of a bucket from one source
+// zone.
+type bucketSourceStatus struct {
+	Zone         string
+	ZoneID       string
+	State        string
+	Shards       int
+	BehindShards []int
+	OldestSync   time.Time
+}
+
+func (d *BucketReplicationStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_replication_status"
+}
+
+func (d *BucketReplicationStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports how far the zone of the provider endpoint has replicated a bucket from the other " +
+			"zones of its zonegroup in a multisite deployment. Use it in `precondition` blocks to only cut over " +
+			"applications, or remove a zone, once the bucket is caught up.\n\n" +
+			"For every source zone, the sync status of each bucket index shard is read from the local zone and its " +
+			"position compared with the last bucket index log entry of the shard, read from the first endpoint of the " +
+			"source zone in the period. A shard is behind while it is not in incremental sync or has not reached that " +
+			"entry. The provider credentials must be valid in every zone and have the `bilog=read` and `zone=read` " +
+			"capabilities.\n\n" +
+			"Gateways without a realm have no source zones and always report the bucket as caught up.\n\n" +
+			"~> **Note:** The status is a snapshot taken on every read. Writes to the bucket after the read are not " +
+			"accounted for, so stop writes in the source zones before relying on `caught_up` for a cutover.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone serving the provider endpoint, which the bucket is replicated to.",
+				Computed:            true,
+			},
+			"caught_up": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bucket is caught up with every source zone.",
+				Computed:            true,
+			},
+			"source_zones": schema.ListNestedAttribute{
+				MarkdownDescription: "The replication status of the bucket from every other zone of the zonegroup, " +
+					"in zone name order.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"zone": schema.StringAttribute{
+							MarkdownDescription: "The name of the source zone.",
+							Computed:            true,
+						},
+						"zone_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the source zone.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The least advanced sync state of the bucket shards: `stopped`, " +
+								"`init`, `full-sync` or `incremental-sync`.",
+							Computed: true,
+						},
+						"shards": schema.Int64Attribute{
+							MarkdownDescription: "The number of bucket index shards.",
+							Computed:            true,
+						},
+						"behind_shards": schema.ListAttribute{
+							MarkdownDescription: "The IDs of the shards that are behind the source zone.",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
+						"caught_up": schema.BoolAttribute{
+							MarkdownDescription: "Whether no shard is behind the source zone.",
+							Computed:            true,
+						},
+						"oldest_sync": schema.StringAttribute{
+							MarkdownDescription: "The time of the oldest change replicated last by a shard in RFC3339 " +
+								"format, an upper bound of the replication lag. Empty until every shard replicated a change.",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketReplicationStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketReplicationStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketReplicationStatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), config.Bucket.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant := config.Tenant.ValueString()
+	bucket := s3BucketName(tenant, config.Bucket.ValueString())
+
+	tflog.Debug(ctx, "Reading bucket replication status", map[string]any{
+		"bucket": bucket,
+	})
+
+	adminClient := NewAdminClient(d.client.Admin)
+	zoneName, sources, err := adminClient.GetBucketReplicationStatus(ctx, tenant, config.Bucket.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Replication Status",
+			fmt.Sprintf("Could not read replication status of bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	caughtUp := true
+	sourceValues := make([]attr.Value, 0, len(sources))
+	for _, source := range sources {
+		behind := make([]int64, 0, len(source.BehindShards))
+		for _, shard := range source.BehindShards {
+			behind = append(behind, int64(shard))
+		}
+		behindValue, diags := types.ListValueFrom(ctx, types.Int64Type, behind)
+		resp.Diagnostics.Append(diags...)
+
+		oldestSync := ""
+		if !source.OldestSync.IsZero() {
+			oldestSync = source.OldestSync.UTC().Format(time.RFC3339)
+		}
+
+		sourceValue, diags := types.ObjectValue(bucketReplicationSourceAttrTypes, map[string]attr.Value{
+			"zone":          types.StringValue(source.Zone),
+			"zone_id":       types.StringValue(source.ZoneID),
+			"state":         types.StringValue(source.State),
+			"shards":        types.Int64Value(int64(source.Shards)),
+			"behind_shards": behindValue,
+			"caught_up":     types.BoolValue(len(behind) == 0),
+			"oldest_sync":   types.StringValue(oldestSync),
+		})
+		resp.Diagnostics.Append(diags...)
+
+		sourceValues = append(sourceValues, sourceValue)
+		caughtUp = caughtUp && len(behind) == 0
+	}
+
+	sourcesValue, diags := types.ListValue(types.ObjectType{AttrTypes: bucketReplicationSourceAttrTypes}, sourceValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Zone = types.StringValue(zoneName)
+	config.CaughtUp = types.BoolValue(caughtUp)
+	config.SourceZones = sourcesValue
+	config.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Read bucket replication status", map[string]any{
+		"bucket":       bucket,
+		"source_zones": len(sources),
+		"caught_up":    caughtUp,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// GetBucketReplicationStatus returns the name of the zone serving the
+// endpoint and the replication status of a bucket from every other zone of
+// its zonegroup, in zone name order. The bucket index log of each source zone
+// is read from the first endpoint of the zone, with the same credentials.
+// Requires the bilog=read and zone=read capabilities.
+func (c *AdminClient) GetBucketReplicationStatus(ctx context.Context, tenant, bucket string) (string, []bucketSourceStatus, error) {
+	period, zoneName, err := c.getPeriod(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if period == nil {
+		return zoneName, []bucketSourceStatus{}, nil
+	}
+
+	// The bucket index log endpoints take the bucket as tenant/bucket
+	bucketKey := bucket
+	if tenant != "" {
+		bucketKey = tenant + "/" + bucket
+	}
+
+	sources := []bucketSourceStatus{}
+	for _, zonegroup := range period.PeriodMap.Zonegroups {
+		if !slices.ContainsFunc(zonegroup.Zones, func(zone periodZone) bool { return zone.Name == zoneName }) {
+			continue
+		}
+
+		for _, zone := range zonegroup.Zones {
+			if zone.Name == zoneName {
+				continue
+			}
+			if len(zone.Endpoints) == 0 {
+				return "", nil, fmt.Errorf("source zone %q has no endpoints in the period", zone.Name)
+			}
+
+			params := url.Values{}
+			params.Set("type", "bucket-index")
+			params.Set("bucket", bucketKey)
+			params.Set("source-zone", zone.ID)
+			body, err := c.DoRequest(ctx, http.MethodGet, "/log?status", params)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to read sync status from zone %q: %w", zone.Name, err)
+			}
+			var shards []bucketShardSyncInfo
+			if err := json.Unmarshal(body, &shards); err != nil {
+				return "", nil, fmt.Errorf("failed to parse sync status from zone %q: %w", zone.Name, err)
+			}
+
+			remote := *c
+			remote.Endpoint = strings.TrimSuffix(zone.Endpoints[0], "/")
+			params = url.Values{}
+			params.Set("type", "bucket-index")
+			params.Set("bucket-instance", bucketKey)
+			body, err = remote.DoRequest(ctx, http.MethodGet, "/log?info", params)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to read bucket index log of zone %q at %s: %w", zone.Name, remote.Endpoint, err)
+			}
+			var info bucketIndexLogInfo
+			if err := json.Unmarshal(body, &info); err != nil {
+				return "", nil, fmt.Errorf("failed to parse bucket index log of zone %q: %w", zone.Name, err)
+			}
+
+			source, err := bucketSourceStatusFromShards(shards, info)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid bucket index log of zone %q: %w", zone.Name, err)
+			}
+			source.Zone = zone.Name
+			source.ZoneID = zone.ID
+			sources = append(sources, source)
+		}
+	}
+
+	slices.SortFunc(sources, func(a, b bucketSourceStatus) int {
+		return strings.Compare(a.Zone, b.Zone)
+	})
+	return zoneName, sources, nil
+}
+
+// bucketSourceStatusFromShards compares the sync status of the bucket shards
+// with the bucket index log of the source zone. A shard is behind while it is
+// not in incremental sync or its position is before the last log entry of the
+// source shard. Markers are zero-padded and compare as strings.
+func bucketSourceStatusFromShards(shards []bucketShardSyncInfo, info bucketIndexLogInfo) (bucketSourceStatus, error) {
+	maxMarkers, err := parseBucketShardMarkers(info.MaxMarker)
+	if err != nil {
+		return bucketSourceStatus{}, err
+	}
+
+	source := bucketSourceStatus{
+		State:        bucketSyncStateIncremental,
+		Shards:       len(shards),
+		BehindShards: []int{},
+	}
+	if info.SyncStopped {
+		source.State = bucketSyncStateStopped
+	}
+
+	states := []string{bucketSyncStateStopped, bucketSyncStateInit, bucketSyncStateFull, bucketSyncStateIncremental}
+	neverSynced := false
+	for shard, status := range shards {
+		if slices.Index(states, status.Status) < slices.Index(states, source.State) {
+			source.State = status.Status
+		}
+
+		if status.Status != bucketSyncStateIncremental || status.IncMarker.Position < maxMarkers[shard] {
+			source.BehindShards = append(source.BehindShards, shard)
+		}
+
+		// Shards that never replicated a change report the zero time of Ceph
+		timestamp, err := time.Parse(time.RFC3339Nano, status.IncMarker.Timestamp)
+		if err != nil || timestamp.Unix() <= 0 {
+			neverSynced = true
+			continue
+		}
+		if source.OldestSync.IsZero() || timestamp.Before(source.OldestSync) {
+			source.OldestSync = timestamp
+		}
+	}
+	if neverSynced {
+		source.OldestSync = time.Time{}
+	}
+
+	return source, nil
+}
+
+// parseBucketShardMarkers parses the max_marker of a bucket index log, which
+// lists the marker of every shard as "<shard>#<marker>" separated by commas,
+// or is a single marker for buckets without shards.
+func parseBucketShardMarkers(maxMarker string) (map[int]string, error) {
+	markers := map[int]string{}
+	if maxMarker == "" {
+		return markers, nil
+	}
+	if !strings.Contains(maxMarker, "#") {
+		markers[0] = maxMarker
+		return markers, nil
+	}
+
+	for _, entry := range strings.Split(maxMarker, ",") {
+		shardID, marker, ok := strings.Cut(entry, "#")
+		if !ok {
+			return nil, fmt.Errorf("invalid shard marker %q", entry)
+		}
+		shard, err := strconv.Atoi(shardID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard marker %q", entry)
+		}
+		markers[shard] = marker
+	}
+	return markers, nil
+}
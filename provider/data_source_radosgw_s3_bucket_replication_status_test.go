@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestParseBucketShardMarkers(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		maxMarker   string
+		expected    map[int]string
+		expectError bool
+	}{
+		"empty":     {maxMarker: "", expected: map[int]string{}},
+		"unsharded": {maxMarker: "00000000005.12.6", expected: map[int]string{0: "00000000005.12.6"}},
+		"sharded": {
+			maxMarker: "0#00000000002.4.6,1#,2#00000000010.20.6",
+			expected:  map[int]string{0: "00000000002.4.6", 1: "", 2: "00000000010.20.6"},
+		},
+		"invalid shard": {maxMarker: "a#00000000002.4.6,1#x", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			markers, err := parseBucketShardMarkers(testCase.maxMarker)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !maps.Equal(markers, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, markers)
+			}
+		})
+	}
+}
+
+func TestBucketSourceStatusFromShards(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		shards         string
+		info           bucketIndexLogInfo
+		expectedState  string
+		expectedBehind []int
+		expectedOldest string
+	}{
+		"caught up": {
+			shards: `[
+  {"status": "incremental-sync", "inc_marker": {"position": "00000000002.4.6", "timestamp": "2024-05-01T10:00:00.000000Z"}},
+  {"status": "incremental-sync", "inc_marker": {"position": "00000000010.20.6", "timestamp": "2024-05-01T09:00:00.000000Z"}}
+]`,
+			info:           bucketIndexLogInfo{MaxMarker: "0#00000000002.4.6,1#00000000010.20.6"},
+			expectedState:  bucketSyncStateIncremental,
+			expectedBehind: []int{},
+			expectedOldest: "2024-05-01T09:00:00Z",
+		},
+		"shard behind": {
+			shards: `[
+  {"status": "incremental-sync", "inc_marker": {"position": "00000000002.4.6", "timestamp": "2024-05-01T10:00:00.000000Z"}},
+  {"status": "incremental-sync", "inc_marker": {"position": "00000000009.18.6", "timestamp": "2024-05-01T09:00:00.000000Z"}}
+]`,
+			info:           bucketIndexLogInfo{MaxMarker: "0#00000000002.4.6,1#00000000010.20.6"},
+			expectedState:  bucketSyncStateIncremental,
+			expectedBehind: []int{1},
+			expectedOldest: "2024-05-01T09:00:00Z",
+		},
+		"full sync": {
+			shards: `[
+  {"status": "full-sync", "inc_marker": {"position": "", "timestamp": "0.000000"}},
+  {"status": "incremental-sync", "inc_marker": {"position": "", "timestamp": "0.000000"}}
+]`,
+			info:           bucketIndexLogInfo{MaxMarker: ""},
+			expectedState:  bucketSyncStateFull,
+			expectedBehind: []int{0},
+		},
+		"stopped": {
+			shards:         `[{"status": "incremental-sync", "inc_marker": {"position": "00000000005.12.6", "timestamp": "2024-05-01T10:00:00.000000Z"}}]`,
+			info:           bucketIndexLogInfo{MaxMarker: "00000000005.12.6", SyncStopped: true},
+			expectedState:  bucketSyncStateStopped,
+			expectedBehind: []int{},
+			expectedOldest: "2024-05-01T10:00:00Z",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var shards []bucketShardSyncInfo
+			if err := json.Unmarshal([]byte(testCase.shards), &shards); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			source, err := bucketSourceStatusFromShards(shards, testCase.info)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if source.State != testCase.expectedState {
+				t.Errorf("expected state %q, got %q", testCase.expectedState, source.State)
+			}
+			if source.Shards != len(shards) {
+				t.Errorf("expected %d shards, got %d", len(shards), source.Shards)
+			}
+			if !slices.Equal(source.BehindShards, testCase.expectedBehind) {
+				t.Errorf("expected behind shards %v, got %v", testCase.expectedBehind, source.BehindShards)
+			}
+			oldest := ""
+			if !source.OldestSync.IsZero() {
+				oldest = source.OldestSync.Format(time.RFC3339)
+			}
+			if oldest != testCase.expectedOldest {
+				t.Errorf("expected oldest sync %q, got %q", testCase.expectedOldest, oldest)
+			}
+		})
+	}
+}
+
+func TestAdminClientGetBucketReplicationStatus_failover(t *testing.T) {
+	t.Parallel()
+
+	period := `{
+  "realm_id": "realm-id",
+  "master_zone": "zone-a-id",
+  "period_map": {
+    "zonegroups": [
+      {
+        "name": "eu",
+        "zones": [
+          {"id": "zone-a-id", "name": "eu-west-1", "endpoints": ["http://rgw1:7480", "http://rgw2:7480"]},
+          {"id": "zone-b-id", "name": "eu-west-2", "endpoints": ["http://rgw-eu-west-2:7480/"]}
+        ]
+      }
+    ]
+  }
+}`
+	status := `[{"status": "incremental-sync", "inc_marker": {"position": "00000000005.12.6", "timestamp": "2024-05-01T10:00:00.000000Z"}}]`
+
+	var mu sync.Mutex
+	var requests []string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests = append(requests, req.URL.Host+req.URL.Path)
+		mu.Unlock()
+
+		body := ""
+		switch {
+		case req.URL.Path == "/admin/realm/period":
+			body = period
+		case req.URL.Query().Has("status"):
+			body = status
+		case req.URL.Query().Has("info") && req.URL.Host == "rgw-eu-west-2:7480":
+			body = `{"max_marker": "00000000007.14.6", "syncstopped": false}`
+		case req.URL.Query().Has("info"):
+			// The bucket index log of the local zone, which is caught up
+			body = `{"max_marker": "00000000005.12.6", "syncstopped": false}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Amz-Request-Id": []string{"tx000001a2b3c4d5e6f7a8b-0065a1b2c3-1234-eu-west-1"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+
+	transport, err := newFailoverTransport(base, []string{"http://rgw1:7480", "http://rgw2:7480"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &AdminClient{
+		Endpoint:   "http://rgw1:7480",
+		AccessKey:  "AKEY",
+		SecretKey:  "SKEY",
+		HTTPClient: &http.Client{Transport: transport},
+		Signer:     v4.NewSigner(),
+	}
+
+	zoneName, sources, err := client.GetBucketReplicationStatus(testCtx, "", "assets")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if zoneName != "eu-west-1" {
+		t.Errorf("expected zone eu-west-1, got %q", zoneName)
+	}
+	if len(sources) != 1 || sources[0].Zone != "eu-west-2" {
+		t.Fatalf("expected the source zone eu-west-2, got %+v", sources)
+	}
+	if !slices.Equal(sources[0].BehindShards, []int{0}) {
+		t.Errorf("expected shard 0 to be behind the source zone, got %v", sources[0].BehindShards)
+	}
+
+	// The local requests are spread over the gateways of the local zone and
+	// the bucket index log is read from the source zone
+	expected := []string{"rgw1:7480/admin/realm/period", "rgw2:7480/admin/log", "rgw-eu-west-2:7480/admin/log"}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestAccRadosgwS3BucketReplicationStatusDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_s3_bucket_replication_status" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+}
+`, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_replication_status.test", "id", bucketName),
+					resource.TestCheckResourceAttrSet("data.radosgw_s3_bucket_replication_status.test", "zone"),
+					resource.TestCheckResourceAttrSet("data.radosgw_s3_bucket_replication_status.test", "caught_up"),
+				),
+			},
+		},
+	})
+}
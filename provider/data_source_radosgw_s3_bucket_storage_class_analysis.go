@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketStorageClassAnalysisDataSource{}
+
+func NewS3BucketStorageClassAnalysisDataSource() datasource.DataSource {
+	return &BucketStorageClassAnalysisDataSource{}
+}
+
+// BucketStorageClassAnalysisDataSource aggregates the objects of a bucket per
+// storage class.
+type BucketStorageClassAnalysisDataSource struct {
+	client *RadosgwClient
+}
+
+// BucketStorageClassAnalysisDataSourceModel describes the data source data model.
+type BucketStorageClassAnalysisDataSourceModel struct {
+	Bucket         types.String `tfsdk:"bucket"`
+	Tenant         types.String `tfsdk:"tenant"`
+	Prefix         types.String `tfsdk:"prefix"`
+	MaxObjects     types.Int64  `tfsdk:"max_objects"`
+	StorageClasses types.Map    `tfsdk:"storage_classes"`
+	ObjectCount    types.Int64  `tfsdk:"object_count"`
+	Size           types.Int64  `tfsdk:"size"`
+	IsSampled      types.Bool   `tfsdk:"is_sampled"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// defaultStorageClassAnalysisMaxObjects is the number of objects scanned
+// when max_objects is not set.
+const defaultStorageClassAnalysisMaxObjects = 100000
+
+// storageClassStatsAttrTypes are the attribute types of a single entry of
+// storage_classes.
+var storageClassStatsAttrTypes = map[string]attr.Type{
+	"object_count": types.Int64Type,
+	"size":         types.Int64Type,
+}
+
+// storageClassStats holds the aggregated objects of one storage class.
+type storageClassStats struct {
+	objectCount int64
+	size        int64
+}
+
+func (d *BucketStorageClassAnalysisDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_storage_class_analysis"
+}
+
+func (d *BucketStorageClassAnalysisDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Aggregates the object count and size of a bucket per storage class, by listing the " +
+			"current object versions with `ListObjectsV2`. Use it to tune lifecycle transition rules.\n\n" +
+			"Large buckets are sampled: the listing stops after `max_objects` objects and `is_sampled` is set. " +
+			"The listing runs on every refresh, so keep `max_objects` in proportion to the bucket size.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket to analyze.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Only analyze objects whose key starts with this prefix.",
+				Optional:            true,
+			},
+			"max_objects": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of objects to scan. Default is %d.", defaultStorageClassAnalysisMaxObjects),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"storage_classes": schema.MapNestedAttribute{
+				MarkdownDescription: "The scanned objects aggregated per storage class, keyed by storage class name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"object_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of objects in the storage class.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The total size of the objects in the storage class in bytes.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"object_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of scanned objects.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The total size of the scanned objects in bytes.",
+				Computed:            true,
+			},
+			"is_sampled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the listing stopped at `max_objects` before reaching the end of the bucket.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketStorageClassAnalysisDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketStorageClassAnalysisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketStorageClassAnalysisDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), config.Bucket.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(config.Tenant.ValueString(), config.Bucket.ValueString())
+
+	maxObjects := int64(defaultStorageClassAnalysisMaxObjects)
+	if !config.MaxObjects.IsNull() {
+		maxObjects = config.MaxObjects.ValueInt64()
+	}
+
+	tflog.Debug(ctx, "Analyzing bucket storage classes", map[string]any{
+		"bucket":      bucket,
+		"prefix":      config.Prefix.ValueString(),
+		"max_objects": maxObjects,
+	})
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if !config.Prefix.IsNull() && config.Prefix.ValueString() != "" {
+		input.Prefix = aws.String(config.Prefix.ValueString())
+	}
+
+	stats := map[string]*storageClassStats{}
+	var objectCount, size int64
+	sampled := false
+
+	paginator := s3.NewListObjectsV2Paginator(d.client.S3, input)
+	for paginator.HasMorePages() && objectCount < maxObjects {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing Objects",
+				fmt.Sprintf("Could not list objects of bucket %s: %s", bucket, err.Error()),
+			)
+			return
+		}
+
+		for _, object := range page.Contents {
+			if objectCount == maxObjects {
+				sampled = true
+				break
+			}
+
+			// RadosGW omits the storage class of objects in the default one
+			storageClass := string(object.StorageClass)
+			if storageClass == "" {
+				storageClass = "STANDARD"
+			}
+
+			classStats, ok := stats[storageClass]
+			if !ok {
+				classStats = &storageClassStats{}
+				stats[storageClass] = classStats
+			}
+			classStats.objectCount++
+			classStats.size += aws.ToInt64(object.Size)
+
+			objectCount++
+			size += aws.ToInt64(object.Size)
+		}
+	}
+
+	// Further pages mean the scan stopped early
+	if objectCount == maxObjects && paginator.HasMorePages() {
+		sampled = true
+	}
+
+	storageClasses := make(map[string]attr.Value, len(stats))
+	for storageClass, classStats := range stats {
+		classValue, diags := types.ObjectValue(storageClassStatsAttrTypes, map[string]attr.Value{
+			"object_count": types.Int64Value(classStats.objectCount),
+			"size":         types.Int64Value(classStats.size),
+		})
+		resp.Diagnostics.Append(diags...)
+		storageClasses[storageClass] = classValue
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClassesValue, diags := types.MapValue(types.ObjectType{AttrTypes: storageClassStatsAttrTypes}, storageClasses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.StorageClasses = storageClassesValue
+	config.ObjectCount = types.Int64Value(objectCount)
+	config.Size = types.Int64Value(size)
+	config.IsSampled = types.BoolValue(sampled)
+	config.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Analyzed bucket storage classes", map[string]any{
+		"bucket":       bucket,
+		"object_count": objectCount,
+		"is_sampled":   sampled,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketStorageClassAnalysisDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketStorageClassAnalysisDataSourceConfig_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_storage_class_analysis.test", "id", bucketName),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_storage_class_analysis.test", "storage_classes.%", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_storage_class_analysis.test", "object_count", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_storage_class_analysis.test", "size", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_bucket_storage_class_analysis.test", "is_sampled", "false"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketStorageClassAnalysisDataSourceConfig_basic(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_s3_bucket_storage_class_analysis" "test" {
+  bucket      = radosgw_s3_bucket.test.bucket
+  prefix      = "logs/"
+  max_objects = 1000
+}
+`, bucketName)
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketsDataSource{}
+
+func NewS3BucketsDataSource() datasource.DataSource {
+	return &BucketsDataSource{}
+}
+
+// BucketsDataSource lists the buckets of the cluster through the Admin API.
+type BucketsDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// BucketsDataSourceModel describes the data source data model.
+type BucketsDataSourceModel struct {
+	Owner     types.String `tfsdk:"owner"`
+	Tenant    types.String `tfsdk:"tenant"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Names     types.List   `tfsdk:"names"`
+	Buckets   types.List   `tfsdk:"buckets"`
+	ID        types.String `tfsdk:"id"`
+}
+
+// bucketSummaryAttrTypes are the attribute types of a single entry of buckets.
+var bucketSummaryAttrTypes = map[string]attr.Type{
+	"bucket":         types.StringType,
+	"tenant":         types.StringType,
+	"owner":          types.StringType,
+	"id":             types.StringType,
+	"creation_time":  types.StringType,
+	"zonegroup":      types.StringType,
+	"placement_rule": types.StringType,
+	"num_objects":    types.Int64Type,
+	"size":           types.Int64Type,
+}
+
+func (d *BucketsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_buckets"
+}
+
+func (d *BucketsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the buckets of the whole cluster using the Admin API, optionally filtered by owner, " +
+			"tenant or a name pattern. Use it to write policies or cleanup modules over existing buckets.",
+
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Only return buckets owned by this user ID. For users in a tenant, use the format `tenant$user_id`.",
+				Optional:            true,
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "Only return buckets of this tenant. Use an empty string for buckets without a tenant.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "A regex pattern to filter bucket names. Only buckets whose name matches the pattern will be returned.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of the matching buckets, sorted. Buckets of a tenant are returned as `tenant/bucket`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"buckets": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching buckets, in the same order as `names`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bucket": schema.StringAttribute{
+							MarkdownDescription: "The name of the bucket.",
+							Computed:            true,
+						},
+						"tenant": schema.StringAttribute{
+							MarkdownDescription: "The tenant the bucket belongs to.",
+							Computed:            true,
+						},
+						"owner": schema.StringAttribute{
+							MarkdownDescription: "The user ID of the bucket owner.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the bucket assigned by RadosGW.",
+							Computed:            true,
+						},
+						"creation_time": schema.StringAttribute{
+							MarkdownDescription: "The creation time of the bucket in RFC3339 format.",
+							Computed:            true,
+						},
+						"zonegroup": schema.StringAttribute{
+							MarkdownDescription: "The zonegroup ID where the bucket is located.",
+							Computed:            true,
+						},
+						"placement_rule": schema.StringAttribute{
+							MarkdownDescription: "The placement rule for the bucket.",
+							Computed:            true,
+						},
+						"num_objects": schema.Int64Attribute{
+							MarkdownDescription: "The number of objects in the bucket.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The total size of the objects in the bucket in bytes.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BucketsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *BucketsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config BucketsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var re *regexp.Regexp
+	if !config.NameRegex.IsNull() && config.NameRegex.ValueString() != "" {
+		pattern := config.NameRegex.ValueString()
+		var err error
+		re, err = regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Regex Pattern",
+				fmt.Sprintf("Could not compile regex pattern %q: %s", pattern, err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW buckets data source", map[string]any{
+		"owner":      config.Owner.ValueString(),
+		"tenant":     config.Tenant.ValueString(),
+		"name_regex": config.NameRegex.ValueString(),
+	})
+
+	// A single stats request returns every bucket with its usage, which
+	// go-ceph only exposes one bucket at a time
+	params := url.Values{}
+	params.Set("stats", "true")
+	if !config.Owner.IsNull() && config.Owner.ValueString() != "" {
+		params.Set("uid", config.Owner.ValueString())
+	}
+
+	body, err := d.adminClient.DoRequest(ctx, http.MethodGet, "/bucket", params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Buckets",
+			fmt.Sprintf("Could not list buckets: %s", err.Error()),
+		)
+		return
+	}
+
+	var buckets []admin.Bucket
+	if err := json.Unmarshal(body, &buckets); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse bucket list: %s", err.Error()),
+		)
+		return
+	}
+
+	var filtered []admin.Bucket
+	for _, bucket := range buckets {
+		if !config.Tenant.IsNull() && bucket.Tenant != config.Tenant.ValueString() {
+			continue
+		}
+		if re != nil && !re.MatchString(bucket.Bucket) {
+			continue
+		}
+		filtered = append(filtered, bucket)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return adminBucketName(filtered[i].Tenant, filtered[i].Bucket) < adminBucketName(filtered[j].Tenant, filtered[j].Bucket)
+	})
+
+	names := make([]string, 0, len(filtered))
+	bucketValues := make([]attr.Value, 0, len(filtered))
+	for _, bucket := range filtered {
+		names = append(names, adminBucketName(bucket.Tenant, bucket.Bucket))
+
+		creationTime := types.StringNull()
+		if bucket.CreationTime != nil {
+			creationTime = types.StringValue(bucket.CreationTime.Format("2006-01-02T15:04:05Z07:00"))
+		}
+
+		var numObjects, size int64
+		if bucket.Usage.RgwMain.NumObjects != nil {
+			numObjects = int64(*bucket.Usage.RgwMain.NumObjects)
+		}
+		if bucket.Usage.RgwMain.Size != nil {
+			size = int64(*bucket.Usage.RgwMain.Size)
+		}
+
+		bucketValue, diags := types.ObjectValue(bucketSummaryAttrTypes, map[string]attr.Value{
+			"bucket":         types.StringValue(bucket.Bucket),
+			"tenant":         types.StringValue(bucket.Tenant),
+			"owner":          types.StringValue(bucket.Owner),
+			"id":             types.StringValue(bucket.ID),
+			"creation_time":  creationTime,
+			"zonegroup":      types.StringValue(bucket.Zonegroup),
+			"placement_rule": types.StringValue(bucket.PlacementRule),
+			"num_objects":    types.Int64Value(numObjects),
+			"size":           types.Int64Value(size),
+		})
+		resp.Diagnostics.Append(diags...)
+		bucketValues = append(bucketValues, bucketValue)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namesValue, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	bucketsValue, diags := types.ListValue(types.ObjectType{AttrTypes: bucketSummaryAttrTypes}, bucketValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Names = namesValue
+	config.Buckets = bucketsValue
+	config.ID = types.StringValue("radosgw-buckets")
+
+	tflog.Debug(ctx, "Filtered buckets", map[string]any{
+		"total_buckets":   len(buckets),
+		"matched_buckets": len(filtered),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
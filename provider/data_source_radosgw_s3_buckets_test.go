@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketsDataSourceConfig_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "names.0", bucketName),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "buckets.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "buckets.0.bucket", bucketName),
+					resource.TestCheckResourceAttrPair("data.radosgw_s3_buckets.test", "buckets.0.owner", "radosgw_s3_bucket.test", "owner"),
+					resource.TestCheckResourceAttrPair("data.radosgw_s3_buckets.test", "buckets.0.id", "radosgw_s3_bucket.test", "id"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "buckets.0.num_objects", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "buckets.0.size", "0"),
+					resource.TestCheckResourceAttrSet("data.radosgw_s3_buckets.test", "buckets.0.creation_time"),
+					// Filtering by owner keeps the bucket
+					resource.TestCheckTypeSetElemAttr("data.radosgw_s3_buckets.owner", "names.*", bucketName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketsDataSource_noMatch(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+data "radosgw_s3_buckets" "test" {
+  name_regex = "^tf-acc-no-such-bucket-[0-9]+$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "names.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_s3_buckets.test", "buckets.#", "0"),
+				),
+			},
+			{
+				Config: providerConfig() + `
+data "radosgw_s3_buckets" "test" {
+  name_regex = "["
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Regex Pattern"),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketsDataSourceConfig_basic(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+data "radosgw_s3_buckets" "test" {
+  name_regex = "^${radosgw_s3_bucket.test.bucket}$"
+  tenant     = ""
+}
+
+data "radosgw_s3_buckets" "owner" {
+  owner = radosgw_s3_bucket.test.owner
+}
+`, bucketName)
+}
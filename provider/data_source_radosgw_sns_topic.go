@@ -155,12 +155,7 @@ func (d *SNSTopicDataSource) Configure(ctx context.Context, req datasource.Confi
 	}
 
 	d.client = client
-	d.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	d.iamClient = client.IAM
 }
 
 func (d *SNSTopicDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -184,7 +179,7 @@ func (d *SNSTopicDataSource) Read(ctx context.Context, req datasource.ReadReques
 	params.Set("Action", "GetTopicAttributes")
 	params.Set("TopicArn", topicARN)
 
-	body, err := d.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := d.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		if isSNSTopicNotFound(err) {
 			resp.Diagnostics.AddError(
@@ -19,7 +19,7 @@ func TestAccRadosgwSNSTopicDataSource_basic(t *testing.T) {
 		resource.TestCheckResourceAttr("data.radosgw_sns_topic.test", "persistent", "false"),
 	}
 	// User is only returned by GetTopicAttributes on Squid+
-	if !getCephVersion().LessThan(CephVersion_Squid) {
+	if FeatureSupported(CephFeature_TopicAttributes, getCephVersion()) {
 		checks = append(checks,
 			resource.TestCheckResourceAttrSet("data.radosgw_sns_topic.test", "user"),
 		)
@@ -44,7 +44,7 @@ func TestAccRadosgwSNSTopicDataSource_persistent(t *testing.T) {
 	topicName := randomName("tf-acc-ds-topic")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_TopicAttributes) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwSNSTopicDestroy,
 		Steps: []resource.TestStep{
@@ -73,7 +73,7 @@ func TestAccRadosgwSNSTopicDataSource_amqp(t *testing.T) {
 	topicName := randomName("tf-acc-ds-topic")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_TopicAttributes) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwSNSTopicDestroy,
 		Steps: []resource.TestStep{
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SNSTopicsDataSource{}
+
+func NewSNSTopicsDataSource() datasource.DataSource {
+	return &SNSTopicsDataSource{}
+}
+
+// SNSTopicsDataSource defines the data source implementation.
+type SNSTopicsDataSource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// SNSTopicsDataSourceModel describes the data source data model.
+type SNSTopicsDataSourceModel struct {
+	NameRegex types.String          `tfsdk:"name_regex"`
+	Names     types.Set             `tfsdk:"names"`
+	ARNs      types.Set             `tfsdk:"arns"`
+	Topics    []SNSTopicsEntryModel `tfsdk:"topics"`
+	ID        types.String          `tfsdk:"id"`
+}
+
+// SNSTopicsEntryModel describes a single topic returned by the data source.
+type SNSTopicsEntryModel struct {
+	Name         types.String `tfsdk:"name"`
+	ARN          types.String `tfsdk:"arn"`
+	User         types.String `tfsdk:"user"`
+	PushEndpoint types.String `tfsdk:"push_endpoint"`
+	OpaqueData   types.String `tfsdk:"opaque_data"`
+	Persistent   types.Bool   `tfsdk:"persistent"`
+}
+
+// XML response structures for ListTopics. Unlike AWS, RadosGW returns the
+// attributes of each topic along with its ARN.
+type listTopicsResponseXML struct {
+	XMLName xml.Name         `xml:"ListTopicsResponse"`
+	Result  listTopicsResult `xml:"ListTopicsResult"`
+}
+
+type listTopicsResult struct {
+	Topics struct {
+		Members []snsTopicXML `xml:"member"`
+	} `xml:"Topics"`
+	NextToken string `xml:"NextToken"`
+}
+
+type snsTopicXML struct {
+	User       string `xml:"User"`
+	Name       string `xml:"Name"`
+	TopicArn   string `xml:"TopicArn"`
+	OpaqueData string `xml:"OpaqueData"`
+	EndPoint   struct {
+		EndpointAddress string `xml:"EndpointAddress"`
+		Persistent      bool   `xml:"Persistent"`
+	} `xml:"EndPoint"`
+}
+
+func (d *SNSTopicsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sns_topics"
+}
+
+func (d *SNSTopicsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the SNS topics visible to the provider credentials in RadosGW. " +
+			"Use this data source to discover topics and push endpoints created outside of Terraform, " +
+			"for example before importing them with `radosgw_sns_topic`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "A regex pattern to filter topic names. Only topics whose name matches the pattern will be returned.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"names": schema.SetAttribute{
+				MarkdownDescription: "Set of topic names matching the filter criteria.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"arns": schema.SetAttribute{
+				MarkdownDescription: "Set of topic ARNs matching the filter criteria.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"topics": schema.ListNestedAttribute{
+				MarkdownDescription: "The topics matching the filter criteria, sorted by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the topic.",
+							Computed:            true,
+						},
+						"arn": schema.StringAttribute{
+							MarkdownDescription: "The ARN of the topic.",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The RadosGW user that owns the topic.",
+							Computed:            true,
+						},
+						"push_endpoint": schema.StringAttribute{
+							MarkdownDescription: "The push endpoint URL of the topic. Null when the topic has no endpoint.",
+							Computed:            true,
+						},
+						"opaque_data": schema.StringAttribute{
+							MarkdownDescription: "Opaque data attached to the topic.",
+							Computed:            true,
+						},
+						"persistent": schema.BoolAttribute{
+							MarkdownDescription: "Whether the topic is persistent.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SNSTopicsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.iamClient = client.IAM
+}
+
+func (d *SNSTopicsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config SNSTopicsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW SNS topics data source")
+
+	var re *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		var err error
+		re, err = regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Regex Pattern",
+				fmt.Sprintf("Could not compile regex pattern %q: %s", config.NameRegex.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	params := url.Values{}
+	params.Set("Action", "ListTopics")
+
+	// Get all topics (NextToken is only returned by Squid and later)
+	var allTopics []snsTopicXML
+	for {
+		body, err := d.iamClient.DoRequest(ctx, params, "sns")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading SNS Topics",
+				fmt.Sprintf("Could not list SNS topics: %s", err.Error()),
+			)
+			return
+		}
+
+		var response listTopicsResponseXML
+		if err := xml.Unmarshal(body, &response); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Parsing Response",
+				fmt.Sprintf("Could not parse ListTopics response: %s", err.Error()),
+			)
+			return
+		}
+
+		allTopics = append(allTopics, response.Result.Topics.Members...)
+
+		if response.Result.NextToken == "" {
+			break
+		}
+		params.Set("NextToken", response.Result.NextToken)
+	}
+
+	byName := make(map[string]snsTopicXML, len(allTopics))
+	for _, topic := range allTopics {
+		if re != nil && !re.MatchString(topic.Name) {
+			continue
+		}
+		byName[topic.Name] = topic
+	}
+
+	names := sortedKeys(byName)
+	arns := make([]string, 0, len(names))
+	topics := make([]SNSTopicsEntryModel, 0, len(names))
+	for _, name := range names {
+		topic := byName[name]
+		arns = append(arns, topic.TopicArn)
+
+		entry := SNSTopicsEntryModel{
+			Name:         types.StringValue(topic.Name),
+			ARN:          types.StringValue(topic.TopicArn),
+			User:         types.StringValue(topic.User),
+			PushEndpoint: types.StringNull(),
+			OpaqueData:   types.StringNull(),
+			Persistent:   types.BoolValue(topic.EndPoint.Persistent),
+		}
+		if topic.EndPoint.EndpointAddress != "" {
+			entry.PushEndpoint = types.StringValue(topic.EndPoint.EndpointAddress)
+		}
+		if topic.OpaqueData != "" {
+			entry.OpaqueData = types.StringValue(topic.OpaqueData)
+		}
+		topics = append(topics, entry)
+	}
+
+	tflog.Debug(ctx, "Listed SNS topics", map[string]any{
+		"total_topics":   len(allTopics),
+		"matched_topics": len(names),
+	})
+
+	namesSet, diags := types.SetValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	arnsSet, diags := types.SetValueFrom(ctx, types.StringType, arns)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Names = namesSet
+	config.ARNs = arnsSet
+	config.Topics = topics
+	config.ID = types.StringValue("radosgw-sns-topics")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
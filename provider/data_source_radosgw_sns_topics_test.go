@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwSNSTopicsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	topicName := randomName("tf-acc-ds-topics")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwSNSTopicDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwSNSTopicsDataSourceConfig_basic(topicName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_sns_topics.test", "names.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("data.radosgw_sns_topics.test", "names.*", "radosgw_sns_topic.test", "name"),
+					resource.TestCheckTypeSetElemAttrPair("data.radosgw_sns_topics.test", "arns.*", "radosgw_sns_topic.test", "arn"),
+					resource.TestCheckResourceAttr("data.radosgw_sns_topics.test", "topics.#", "1"),
+					resource.TestCheckResourceAttr("data.radosgw_sns_topics.test", "topics.0.name", topicName),
+					resource.TestCheckResourceAttr("data.radosgw_sns_topics.test", "topics.0.push_endpoint", "http://localhost:10902"),
+					resource.TestCheckResourceAttr("data.radosgw_sns_topics.test", "topics.0.opaque_data", "test-opaque"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwSNSTopicsDataSourceConfig_basic(topicName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_sns_topic" "test" {
+  name          = %[1]q
+  push_endpoint = "http://localhost:10902"
+  opaque_data   = "test-opaque"
+}
+
+data "radosgw_sns_topics" "test" {
+  name_regex = "^%[1]s$"
+
+  depends_on = [radosgw_sns_topic.test]
+}
+`, topicName)
+}
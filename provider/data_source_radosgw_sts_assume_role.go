@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssumeRoleDataSource{}
+
+func NewSTSAssumeRoleDataSource() datasource.DataSource {
+	return &AssumeRoleDataSource{}
+}
+
+// AssumeRoleDataSource defines the data source implementation.
+type AssumeRoleDataSource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// AssumeRoleDataSourceModel describes the data source data model.
+type AssumeRoleDataSourceModel struct {
+	RoleArn           types.String `tfsdk:"role_arn"`
+	RoleSessionName   types.String `tfsdk:"role_session_name"`
+	DurationSeconds   types.Int64  `tfsdk:"duration_seconds"`
+	Policy            types.String `tfsdk:"policy"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TransitiveTagKeys types.Set    `tfsdk:"transitive_tag_keys"`
+	AssumedRoleArn    types.String `tfsdk:"assumed_role_arn"`
+	AssumedRoleID     types.String `tfsdk:"assumed_role_id"`
+	AccessKeyID       types.String `tfsdk:"access_key_id"`
+	SecretAccessKey   types.String `tfsdk:"secret_access_key"`
+	SessionToken      types.String `tfsdk:"session_token"`
+	Expiration        types.String `tfsdk:"expiration"`
+	ID                types.String `tfsdk:"id"`
+}
+
+// XML response structures for STS AssumeRole API
+type assumeRoleResponseXML struct {
+	XMLName xml.Name         `xml:"AssumeRoleResponse"`
+	Result  assumeRoleResult `xml:"AssumeRoleResult"`
+}
+
+type assumeRoleResult struct {
+	Credentials     stsCredentialsXML `xml:"Credentials"`
+	AssumedRoleUser struct {
+		Arn           string `xml:"Arn"`
+		AssumedRoleId string `xml:"AssumedRoleId"`
+	} `xml:"AssumedRoleUser"`
+}
+
+type stsCredentialsXML struct {
+	AccessKeyId     string `xml:"AccessKeyId"`
+	SecretAccessKey string `xml:"SecretAccessKey"`
+	SessionToken    string `xml:"SessionToken"`
+	Expiration      string `xml:"Expiration"`
+}
+
+func (d *AssumeRoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sts_assume_role"
+}
+
+func (d *AssumeRoleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assumes a RadosGW role with the provider credentials using STS `AssumeRole`. " +
+			"The main use is validating trust policies end-to-end during plan: the read fails if the " +
+			"trust policy does not allow the provider credentials to assume the role.\n\n" +
+			"~> **Note:** The temporary credentials are stored in the Terraform state and are refreshed on every " +
+			"plan. Prefer short `duration_seconds` values and treat the state as sensitive.",
+
+		Attributes: map[string]schema.Attribute{
+			"role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the role to assume.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role_session_name": schema.StringAttribute{
+				MarkdownDescription: "An identifier for the assumed role session.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"duration_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The duration of the session in seconds, between 900 and the role's `max_session_duration`. " +
+					"Defaults to 3600 on the RadosGW side.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(900, 43200),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "An inline session policy (in JSON format) that further restricts the permissions of the session.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Session tags to pass. The trust policy must allow `sts:TagSession` for the caller, " +
+					"and the tags are available to policies as `aws:PrincipalTag/<key>`.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"transitive_tag_keys": schema.SetAttribute{
+				MarkdownDescription: "Keys of `tags` that persist when the session is used to assume another role.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"assumed_role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the assumed role session.",
+				Computed:            true,
+			},
+			"assumed_role_id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the assumed role session.",
+				Computed:            true,
+			},
+			"access_key_id": schema.StringAttribute{
+				MarkdownDescription: "The access key of the temporary credentials.",
+				Computed:            true,
+			},
+			"secret_access_key": schema.StringAttribute{
+				MarkdownDescription: "The secret key of the temporary credentials.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"session_token": schema.StringAttribute{
+				MarkdownDescription: "The session token of the temporary credentials.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "The time the temporary credentials expire, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the assumed role session (same as `assumed_role_arn`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AssumeRoleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.iamClient = client.IAM
+}
+
+func (d *AssumeRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config AssumeRoleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleArn := config.RoleArn.ValueString()
+
+	tflog.Debug(ctx, "Assuming role", map[string]any{
+		"role_arn":          roleArn,
+		"role_session_name": config.RoleSessionName.ValueString(),
+	})
+
+	params := url.Values{}
+	params.Set("Action", "AssumeRole")
+	params.Set("Version", "2011-06-15")
+	params.Set("RoleArn", roleArn)
+	params.Set("RoleSessionName", config.RoleSessionName.ValueString())
+	if !config.DurationSeconds.IsNull() {
+		params.Set("DurationSeconds", strconv.FormatInt(config.DurationSeconds.ValueInt64(), 10))
+	}
+	if !config.Policy.IsNull() && config.Policy.ValueString() != "" {
+		params.Set("Policy", config.Policy.ValueString())
+	}
+
+	if !config.Tags.IsNull() {
+		tags := make(map[string]string)
+		resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Sort the keys so the request is deterministic
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			params.Set(fmt.Sprintf("Tags.member.%d.Key", i+1), k)
+			params.Set(fmt.Sprintf("Tags.member.%d.Value", i+1), tags[k])
+		}
+	}
+
+	if !config.TransitiveTagKeys.IsNull() {
+		var keys []string
+		resp.Diagnostics.Append(config.TransitiveTagKeys.ElementsAs(ctx, &keys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			params.Set(fmt.Sprintf("TransitiveTagKeys.member.%d", i+1), k)
+		}
+	}
+
+	body, err := d.iamClient.DoRequest(ctx, params, "sts")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Assuming Role",
+			fmt.Sprintf("Could not assume role %s: %s", roleArn, err.Error()),
+		)
+		return
+	}
+
+	var response assumeRoleResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse AssumeRole response: %s", err.Error()),
+		)
+		return
+	}
+
+	result := response.Result
+	config.AssumedRoleArn = types.StringValue(result.AssumedRoleUser.Arn)
+	config.AssumedRoleID = types.StringValue(result.AssumedRoleUser.AssumedRoleId)
+	config.AccessKeyID = types.StringValue(result.Credentials.AccessKeyId)
+	config.SecretAccessKey = types.StringValue(result.Credentials.SecretAccessKey)
+	config.SessionToken = types.StringValue(result.Credentials.SessionToken)
+	config.Expiration = types.StringValue(result.Credentials.Expiration)
+	config.ID = types.StringValue(result.AssumedRoleUser.Arn)
+
+	tflog.Trace(ctx, "Assumed role", map[string]any{
+		"role_arn":         roleArn,
+		"assumed_role_arn": result.AssumedRoleUser.Arn,
+		"expiration":       result.Credentials.Expiration,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwSTSAssumeRoleDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	roleName := randomName("tf-acc-role")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			// The user and its keys must exist before the user provider can
+			// be configured
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName),
+			},
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_basic(userID, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.radosgw_sts_assume_role.test", "role_arn", "radosgw_iam_role.test", "arn"),
+					resource.TestMatchResourceAttr("data.radosgw_sts_assume_role.test", "assumed_role_arn", regexp.MustCompile(`:assumed-role/`+roleName+`/tf-acc-session$`)),
+					resource.TestCheckResourceAttrSet("data.radosgw_sts_assume_role.test", "access_key_id"),
+					resource.TestCheckResourceAttrSet("data.radosgw_sts_assume_role.test", "secret_access_key"),
+					resource.TestCheckResourceAttrSet("data.radosgw_sts_assume_role.test", "session_token"),
+					resource.TestCheckResourceAttrSet("data.radosgw_sts_assume_role.test", "expiration"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwSTSAssumeRoleDataSource_trustPolicyDenied(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	roleName := randomName("tf-acc-role")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName),
+			},
+			// A trust policy naming another principal must fail the read
+			{
+				Config:      testAccRadosgwSTSAssumeRoleDataSourceConfig_denied(userID, roleName),
+				ExpectError: regexp.MustCompile(`Error Assuming Role`),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+// testAccRadosgwSTSAssumeRoleDataSourceConfig_setup creates a user that is
+// allowed to call sts:AssumeRole and a role trusting that user.
+func testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName string) string {
+	return testAccRadosgwSTSAssumeRoleDataSourceConfig_role(userID, roleName, `"arn:aws:iam:::user/${radosgw_iam_user.test.user_id}"`)
+}
+
+func testAccRadosgwSTSAssumeRoleDataSourceConfig_role(userID, roleName, principal string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %[1]q
+  display_name = "STS Test User"
+}
+
+resource "radosgw_iam_access_key" "test" {
+  user_id = radosgw_iam_user.test.user_id
+}
+
+resource "radosgw_iam_user_policy" "test" {
+  user = radosgw_iam_user.test.user_id
+  name = "assume-role"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["sts:AssumeRole"]
+        Resource = ["*"]
+      }
+    ]
+  })
+}
+
+resource "radosgw_iam_role" "test" {
+  name = %[2]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = { AWS = [%[3]s] }
+        Action    = ["sts:AssumeRole"]
+      }
+    ]
+  })
+}
+`, userID, roleName, principal)
+}
+
+const testAccRadosgwSTSAssumeRoleDataSourceUserProvider = `
+provider "radosgw" {
+  alias      = "user"
+  access_key = radosgw_iam_access_key.test.access_key
+  secret_key = radosgw_iam_access_key.test.secret_key
+}
+`
+
+func testAccRadosgwSTSAssumeRoleDataSourceConfig_basic(userID, roleName string) string {
+	return testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName) + testAccRadosgwSTSAssumeRoleDataSourceUserProvider + `
+data "radosgw_sts_assume_role" "test" {
+  provider          = radosgw.user
+  role_arn          = radosgw_iam_role.test.arn
+  role_session_name = "tf-acc-session"
+  duration_seconds  = 900
+
+  depends_on = [radosgw_iam_user_policy.test]
+}
+`
+}
+
+func testAccRadosgwSTSAssumeRoleDataSourceConfig_denied(userID, roleName string) string {
+	return testAccRadosgwSTSAssumeRoleDataSourceConfig_role(userID, roleName, `"arn:aws:iam:::user/someone-else"`) + testAccRadosgwSTSAssumeRoleDataSourceUserProvider + `
+data "radosgw_sts_assume_role" "test" {
+  provider          = radosgw.user
+  role_arn          = radosgw_iam_role.test.arn
+  role_session_name = "tf-acc-session"
+
+  depends_on = [radosgw_iam_user_policy.test]
+}
+`
+}
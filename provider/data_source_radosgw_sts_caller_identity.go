@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CallerIdentityDataSource{}
+
+func NewSTSCallerIdentityDataSource() datasource.DataSource {
+	return &CallerIdentityDataSource{}
+}
+
+// CallerIdentityDataSource defines the data source implementation.
+type CallerIdentityDataSource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// CallerIdentityDataSourceModel describes the data source data model.
+type CallerIdentityDataSourceModel struct {
+	ARN       types.String `tfsdk:"arn"`
+	AccountID types.String `tfsdk:"account_id"`
+	UserID    types.String `tfsdk:"user_id"`
+	ID        types.String `tfsdk:"id"`
+}
+
+// XML response structures for STS GetCallerIdentity API
+type getCallerIdentityResponseXML struct {
+	XMLName xml.Name                `xml:"GetCallerIdentityResponse"`
+	Result  getCallerIdentityResult `xml:"GetCallerIdentityResult"`
+}
+
+type getCallerIdentityResult struct {
+	Arn     string `xml:"Arn"`
+	UserId  string `xml:"UserId"`
+	Account string `xml:"Account"`
+}
+
+func (d *CallerIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sts_caller_identity"
+}
+
+func (d *CallerIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the identity of the credentials configured in the provider, using STS " +
+			"`GetCallerIdentity` against the RadosGW endpoint. Use it to build ARNs for policies and trust " +
+			"relationships without hard coding the account or user.\n\n" +
+			"~> **Note:** RadosGW releases that do not implement `GetCallerIdentity` are supported by resolving " +
+			"the user that owns the access key through the Admin API instead, which requires the `users=read` capability.",
+
+		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				MarkdownDescription: "ARN of the user the credentials belong to.",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The account ID (or tenant) the user belongs to. Empty for users without an account or tenant.",
+				Computed:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the user.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the caller (same as `arn`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CallerIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.iamClient = client.IAM
+}
+
+func (d *CallerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CallerIdentityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading caller identity")
+
+	params := url.Values{}
+	params.Set("Action", "GetCallerIdentity")
+	params.Set("Version", "2011-06-15")
+
+	var identity getCallerIdentityResult
+	body, err := d.iamClient.DoRequest(ctx, params, "sts")
+	switch {
+	case err == nil:
+		var response getCallerIdentityResponseXML
+		if err := xml.Unmarshal(body, &response); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Parsing Response",
+				fmt.Sprintf("Could not parse GetCallerIdentity response: %s", err.Error()),
+			)
+			return
+		}
+		identity = response.Result
+	case isUnsupportedActionError(err):
+		tflog.Debug(ctx, "GetCallerIdentity not supported, resolving caller through the Admin API", map[string]any{
+			"error": err.Error(),
+		})
+		identity, err = d.lookupCaller(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Caller Identity",
+				fmt.Sprintf("Could not resolve the user of the provider credentials: %s", err.Error()),
+			)
+			return
+		}
+	default:
+		resp.Diagnostics.AddError(
+			"Error Reading Caller Identity",
+			fmt.Sprintf("Could not call GetCallerIdentity: %s", err.Error()),
+		)
+		return
+	}
+
+	config.ARN = types.StringValue(identity.Arn)
+	config.AccountID = types.StringValue(identity.Account)
+	config.UserID = types.StringValue(identity.UserId)
+	config.ID = types.StringValue(identity.Arn)
+
+	tflog.Trace(ctx, "Read caller identity", map[string]any{
+		"arn": identity.Arn,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// lookupCaller resolves the user owning the provider access key through the
+// Admin API and builds the identity RadosGW would report for it.
+func (d *CallerIdentityDataSource) lookupCaller(ctx context.Context) (getCallerIdentityResult, error) {
+	user, err := d.client.Admin.GetUser(ctx, admin.User{
+		Keys: []admin.UserKeySpec{{AccessKey: d.client.Admin.AccessKey}},
+	})
+	if err != nil {
+		return getCallerIdentityResult{}, err
+	}
+
+	// Account users are addressed by their name within the account, all
+	// other users by their ID within the tenant
+	if user.AccountID != "" {
+		return getCallerIdentityResult{
+			Arn:     fmt.Sprintf("arn:aws:iam::%s:user/%s", user.AccountID, user.DisplayName),
+			UserId:  user.ID,
+			Account: user.AccountID,
+		}, nil
+	}
+
+	return getCallerIdentityResult{
+		Arn:     fmt.Sprintf("arn:aws:iam::%s:user/%s", user.Tenant, user.ID),
+		UserId:  user.ID,
+		Account: user.Tenant,
+	}, nil
+}
+
+// isUnsupportedActionError reports whether an STS or IAM error indicates that
+// the action is not implemented by the RadosGW release.
+func isUnsupportedActionError(err error) bool {
+	var iamErr *IAMError
+	if !errors.As(err, &iamErr) {
+		return false
+	}
+	switch iamErr.Code {
+	case "MethodNotAllowed", "InvalidAction", "NotImplemented", "InvalidRequest":
+		return true
+	}
+	return false
+}
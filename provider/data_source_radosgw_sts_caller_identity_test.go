@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwSTSCallerIdentityDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwSTSCallerIdentityDataSourceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.radosgw_sts_caller_identity.current", "arn", regexp.MustCompile(`^arn:aws:iam::[^:]*:user/.+$`)),
+					resource.TestCheckResourceAttrSet("data.radosgw_sts_caller_identity.current", "user_id"),
+					resource.TestCheckResourceAttrPair("data.radosgw_sts_caller_identity.current", "id", "data.radosgw_sts_caller_identity.current", "arn"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwSTSCallerIdentityDataSourceConfig_basic() string {
+	return providerConfig() + `
+data "radosgw_sts_caller_identity" "current" {}
+`
+}
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsageDataSource{}
+
+func NewUsageDataSource() datasource.DataSource {
+	return &UsageDataSource{}
+}
+
+// UsageDataSource reports bandwidth usage through the Admin API.
+type UsageDataSource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// UsageDataSourceModel describes the data source data model.
+type UsageDataSourceModel struct {
+	UserID             types.String `tfsdk:"user_id"`
+	Bucket             types.String `tfsdk:"bucket"`
+	StartTime          types.String `tfsdk:"start_time"`
+	EndTime            types.String `tfsdk:"end_time"`
+	Categories         types.Set    `tfsdk:"categories"`
+	Entries            types.List   `tfsdk:"entries"`
+	Summary            types.List   `tfsdk:"summary"`
+	TotalOps           types.Int64  `tfsdk:"total_ops"`
+	TotalSuccessfulOps types.Int64  `tfsdk:"total_successful_ops"`
+	TotalBytesSent     types.Int64  `tfsdk:"total_bytes_sent"`
+	TotalBytesReceived types.Int64  `tfsdk:"total_bytes_received"`
+	ID                 types.String `tfsdk:"id"`
+}
+
+// usageTimeFormat is the time format of the start and end parameters of the
+// usage endpoint.
+const usageTimeFormat = "2006-01-02 15:04:05"
+
+// usageEntryAttrTypes are the attribute types of a single entry of entries.
+var usageEntryAttrTypes = map[string]attr.Type{
+	"user":           types.StringType,
+	"bucket":         types.StringType,
+	"time":           types.StringType,
+	"ops":            types.Int64Type,
+	"successful_ops": types.Int64Type,
+	"bytes_sent":     types.Int64Type,
+	"bytes_received": types.Int64Type,
+}
+
+// usageSummaryAttrTypes are the attribute types of a single entry of summary.
+var usageSummaryAttrTypes = map[string]attr.Type{
+	"user":           types.StringType,
+	"ops":            types.Int64Type,
+	"successful_ops": types.Int64Type,
+	"bytes_sent":     types.Int64Type,
+	"bytes_received": types.Int64Type,
+}
+
+func (d *UsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage"
+}
+
+func (d *UsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the bandwidth usage logged by RadosGW through the Admin API `usage` endpoint, " +
+			"optionally filtered by user, bucket, time range and operation category. Usage is aggregated per hour.\n\n" +
+			"~> **Note:** RadosGW only logs usage when `rgw_enable_usage_log` is enabled. The data source requires the `usage=read` capability.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only report usage of this user. For users in a tenant, use the format `tenant$user_id`.",
+				Optional:            true,
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Only report usage of this bucket.",
+				Optional:            true,
+				Validators: []validator.String{
+					bucketNameValidator{tenantQualified: true},
+				},
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only report usage logged at or after this time, in RFC 3339 format.",
+				Optional:            true,
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only report usage logged before this time, in RFC 3339 format.",
+				Optional:            true,
+			},
+			"categories": schema.SetAttribute{
+				MarkdownDescription: "Only report these operation categories (for example `put_obj`, `get_obj`, `list_bucket`).",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "Usage per user, bucket and hour, with all matching categories added up.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The user the usage belongs to.",
+							Computed:            true,
+						},
+						"bucket": schema.StringAttribute{
+							MarkdownDescription: "The bucket the usage belongs to. Empty for operations outside a bucket.",
+							Computed:            true,
+						},
+						"time": schema.StringAttribute{
+							MarkdownDescription: "The start of the hour the usage was logged in, in RFC 3339 format.",
+							Computed:            true,
+						},
+						"ops": schema.Int64Attribute{
+							MarkdownDescription: "The number of operations.",
+							Computed:            true,
+						},
+						"successful_ops": schema.Int64Attribute{
+							MarkdownDescription: "The number of successful operations.",
+							Computed:            true,
+						},
+						"bytes_sent": schema.Int64Attribute{
+							MarkdownDescription: "The number of bytes sent by RadosGW.",
+							Computed:            true,
+						},
+						"bytes_received": schema.Int64Attribute{
+							MarkdownDescription: "The number of bytes received by RadosGW.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"summary": schema.ListNestedAttribute{
+				MarkdownDescription: "Usage per user over the whole time range.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The user the usage belongs to.",
+							Computed:            true,
+						},
+						"ops": schema.Int64Attribute{
+							MarkdownDescription: "The number of operations.",
+							Computed:            true,
+						},
+						"successful_ops": schema.Int64Attribute{
+							MarkdownDescription: "The number of successful operations.",
+							Computed:            true,
+						},
+						"bytes_sent": schema.Int64Attribute{
+							MarkdownDescription: "The number of bytes sent by RadosGW.",
+							Computed:            true,
+						},
+						"bytes_received": schema.Int64Attribute{
+							MarkdownDescription: "The number of bytes received by RadosGW.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"total_ops": schema.Int64Attribute{
+				MarkdownDescription: "The number of operations of all reported users.",
+				Computed:            true,
+			},
+			"total_successful_ops": schema.Int64Attribute{
+				MarkdownDescription: "The number of successful operations of all reported users.",
+				Computed:            true,
+			},
+			"total_bytes_sent": schema.Int64Attribute{
+				MarkdownDescription: "The number of bytes sent to all reported users.",
+				Computed:            true,
+			},
+			"total_bytes_received": schema.Int64Attribute{
+				MarkdownDescription: "The number of bytes received from all reported users.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The data source identifier.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.adminClient = NewAdminClient(client.Admin)
+}
+
+func (d *UsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UsageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, name := splitBucketID(config.Bucket.ValueString())
+	d.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), name)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// go-ceph does not support the bucket and categories filters
+	params := url.Values{}
+	params.Set("show-entries", "true")
+	params.Set("show-summary", "true")
+	if !config.UserID.IsNull() && config.UserID.ValueString() != "" {
+		params.Set("uid", config.UserID.ValueString())
+	}
+	if !config.Bucket.IsNull() && config.Bucket.ValueString() != "" {
+		params.Set("bucket", config.Bucket.ValueString())
+	}
+
+	for name, value := range map[string]types.String{"start": config.StartTime, "end": config.EndTime} {
+		if value.IsNull() || value.ValueString() == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Time",
+				fmt.Sprintf("Could not parse %s time %q as RFC 3339: %s", name, value.ValueString(), err.Error()),
+			)
+			return
+		}
+		params.Set(name, t.UTC().Format(usageTimeFormat))
+	}
+
+	if !config.Categories.IsNull() {
+		var categories []string
+		resp.Diagnostics.Append(config.Categories.ElementsAs(ctx, &categories, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(categories)
+		params.Set("categories", strings.Join(categories, ","))
+	}
+
+	tflog.Debug(ctx, "Reading RadosGW usage", map[string]any{
+		"user_id":    config.UserID.ValueString(),
+		"bucket":     config.Bucket.ValueString(),
+		"start_time": config.StartTime.ValueString(),
+		"end_time":   config.EndTime.ValueString(),
+	})
+
+	body, err := d.adminClient.DoRequest(ctx, http.MethodGet, "/usage", params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Usage",
+			fmt.Sprintf("Could not read usage: %s", err.Error()),
+		)
+		return
+	}
+
+	var usage admin.Usage
+	if err := json.Unmarshal(body, &usage); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse usage: %s", err.Error()),
+		)
+		return
+	}
+
+	entries := []attr.Value{}
+	for _, entry := range usage.Entries {
+		for _, bucket := range entry.Buckets {
+			var ops, successfulOps, bytesSent, bytesReceived uint64
+			for _, category := range bucket.Categories {
+				ops += category.Ops
+				successfulOps += category.SuccessfulOps
+				bytesSent += category.BytesSent
+				bytesReceived += category.BytesReceived
+			}
+
+			entryValue, diags := types.ObjectValue(usageEntryAttrTypes, map[string]attr.Value{
+				"user":           types.StringValue(entry.User),
+				"bucket":         types.StringValue(bucket.Bucket),
+				"time":           types.StringValue(time.Unix(int64(bucket.Epoch), 0).UTC().Format(time.RFC3339)),
+				"ops":            types.Int64Value(int64(ops)),
+				"successful_ops": types.Int64Value(int64(successfulOps)),
+				"bytes_sent":     types.Int64Value(int64(bytesSent)),
+				"bytes_received": types.Int64Value(int64(bytesReceived)),
+			})
+			resp.Diagnostics.Append(diags...)
+			entries = append(entries, entryValue)
+		}
+	}
+
+	summary := []attr.Value{}
+	var totalOps, totalSuccessfulOps, totalBytesSent, totalBytesReceived uint64
+	for _, user := range usage.Summary {
+		summaryValue, diags := types.ObjectValue(usageSummaryAttrTypes, map[string]attr.Value{
+			"user":           types.StringValue(user.User),
+			"ops":            types.Int64Value(int64(user.Total.Ops)),
+			"successful_ops": types.Int64Value(int64(user.Total.SuccessfulOps)),
+			"bytes_sent":     types.Int64Value(int64(user.Total.BytesSent)),
+			"bytes_received": types.Int64Value(int64(user.Total.BytesReceived)),
+		})
+		resp.Diagnostics.Append(diags...)
+		summary = append(summary, summaryValue)
+
+		totalOps += user.Total.Ops
+		totalSuccessfulOps += user.Total.SuccessfulOps
+		totalBytesSent += user.Total.BytesSent
+		totalBytesReceived += user.Total.BytesReceived
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entriesValue, diags := types.ListValue(types.ObjectType{AttrTypes: usageEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	summaryValue, diags := types.ListValue(types.ObjectType{AttrTypes: usageSummaryAttrTypes}, summary)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Entries = entriesValue
+	config.Summary = summaryValue
+	config.TotalOps = types.Int64Value(int64(totalOps))
+	config.TotalSuccessfulOps = types.Int64Value(int64(totalSuccessfulOps))
+	config.TotalBytesSent = types.Int64Value(int64(totalBytesSent))
+	config.TotalBytesReceived = types.Int64Value(int64(totalBytesReceived))
+	config.ID = types.StringValue("radosgw-usage")
+
+	tflog.Trace(ctx, "Read RadosGW usage", map[string]any{
+		"entries": len(entries),
+		"users":   len(summary),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
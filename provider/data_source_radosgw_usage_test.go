@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwUsageDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// A new user has no logged usage yet
+				Config: testAccRadosgwUsageDataSourceConfig_user(userID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.radosgw_usage.test", "entries.#", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_usage.test", "total_ops", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_usage.test", "total_bytes_sent", "0"),
+					resource.TestCheckResourceAttr("data.radosgw_usage.test", "total_bytes_received", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwUsageDataSource_invalidTime(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+data "radosgw_usage" "test" {
+  start_time = "2024-01-01 00:00:00"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Time"),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwUsageDataSourceConfig_user(userID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Usage Data Source"
+}
+
+data "radosgw_usage" "test" {
+  user_id    = radosgw_iam_user.test.user_id
+  start_time = "2024-01-01T00:00:00Z"
+  categories = ["put_obj", "get_obj"]
+}
+`, userID)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultPresignedURLExpiresIn is the validity of presigned URLs in seconds
+// when expires_in is not set.
+const defaultPresignedURLExpiresIn = 3600
+
+// maxPresignedURLExpiresIn is the longest validity SigV4 allows, 7 days.
+const maxPresignedURLExpiresIn = 7 * 24 * 3600
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &S3PresignedURLEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &S3PresignedURLEphemeralResource{}
+
+func NewS3PresignedURLEphemeralResource() ephemeral.EphemeralResource {
+	return &S3PresignedURLEphemeralResource{}
+}
+
+// S3PresignedURLEphemeralResource defines the ephemeral resource implementation.
+type S3PresignedURLEphemeralResource struct {
+	client *RadosgwClient
+}
+
+// S3PresignedURLEphemeralResourceModel describes the ephemeral resource data model.
+type S3PresignedURLEphemeralResourceModel struct {
+	Bucket     types.String `tfsdk:"bucket"`
+	Tenant     types.String `tfsdk:"tenant"`
+	Key        types.String `tfsdk:"key"`
+	Method     types.String `tfsdk:"method"`
+	ExpiresIn  types.Int64  `tfsdk:"expires_in"`
+	URL        types.String `tfsdk:"url"`
+	Expiration types.String `tfsdk:"expiration"`
+}
+
+func (r *S3PresignedURLEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_presigned_url"
+}
+
+func (r *S3PresignedURLEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a presigned URL to download (`GET`) or upload (`PUT`) an object with the S3 " +
+			"credentials of the provider. The URL can be handed to bootstrap scripts or instances that have no " +
+			"credentials of their own, and is never stored in the plan or state.\n\n" +
+			"Presigning happens locally: the object is not checked for existence and the URL is valid for as long " +
+			"as the credentials of the provider are.\n\n" +
+			"~> **Note:** Ephemeral resources require Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the object.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "The HTTP method the URL is signed for. Valid values: `GET`, `PUT`. Default is `GET`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPut),
+				},
+			},
+			"expires_in": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The validity of the URL in seconds, up to %d (7 days). Default is `%d`.",
+					maxPresignedURLExpiresIn, defaultPresignedURLExpiresIn),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxPresignedURLExpiresIn),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The presigned URL.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "The time the URL expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *S3PresignedURLEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *S3PresignedURLEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data S3PresignedURLEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	method := http.MethodGet
+	if !data.Method.IsNull() {
+		method = data.Method.ValueString()
+	}
+	expiresIn := int64(defaultPresignedURLExpiresIn)
+	if !data.ExpiresIn.IsNull() {
+		expiresIn = data.ExpiresIn.ValueInt64()
+	}
+	expires := time.Duration(expiresIn) * time.Second
+
+	tflog.Debug(ctx, "Presigning object URL", map[string]any{
+		"bucket":     bucket,
+		"key":        data.Key.ValueString(),
+		"method":     method,
+		"expires_in": expiresIn,
+	})
+
+	signingTime := time.Now().UTC()
+	presigned, err := presignObjectURL(ctx, r.client.S3, method, bucket, data.Key.ValueString(), expires)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Presigning URL",
+			fmt.Sprintf("Could not presign %s URL for object %s in bucket %s: %s", method, data.Key.ValueString(), bucket, err.Error()),
+		)
+		return
+	}
+
+	data.Method = types.StringValue(method)
+	data.ExpiresIn = types.Int64Value(expiresIn)
+	data.URL = types.StringValue(presigned.URL)
+	data.Expiration = types.StringValue(signingTime.Add(expires).Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// presignObjectURL presigns a GetObject or PutObject request.
+func presignObjectURL(ctx context.Context, client *s3.Client, method, bucket, key string, expires time.Duration) (*v4.PresignedHTTPRequest, error) {
+	presignClient := s3PresignClient(client, expires)
+
+	if method == http.MethodPut {
+		return presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+	}
+	return presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestPresignObjectURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		method       string
+		signingName  string
+		expectPrefix string
+		expectScope  string
+	}{
+		"get": {
+			method:       http.MethodGet,
+			expectPrefix: "http://rgw.example.com/acme:data/config/app.json?",
+			expectScope:  "AKEY%2F",
+		},
+		"put": {
+			method:       http.MethodPut,
+			expectPrefix: "http://rgw.example.com/acme:data/config/app.json?",
+			expectScope:  "%2Fzg1%2Fs3%2Faws4_request",
+		},
+		"custom signing name": {
+			method:       http.MethodGet,
+			signingName:  "custom",
+			expectPrefix: "http://rgw.example.com/acme:data/config/app.json?",
+			expectScope:  "%2Fzg1%2Fcustom%2Faws4_request",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := s3.New(s3.Options{
+				Region:       "zg1",
+				BaseEndpoint: aws.String("http://rgw.example.com"),
+				UsePathStyle: true,
+				Credentials:  credentials.NewStaticCredentialsProvider("AKEY", "SKEY", ""),
+			}, func(o *s3.Options) {
+				if testCase.signingName != "" {
+					o.HTTPSignerV4 = signingNameSigner{HTTPSignerV4: o.HTTPSignerV4, signingName: testCase.signingName}
+				}
+			})
+
+			presigned, err := presignObjectURL(testCtx, client, testCase.method, "acme:data", "config/app.json", 10*time.Minute)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if presigned.Method != testCase.method {
+				t.Errorf("expected method %s, got %s", testCase.method, presigned.Method)
+			}
+			// The SDK escapes the tenant separator of the bucket name
+			url := strings.Replace(presigned.URL, "acme%3Adata", "acme:data", 1)
+			if !strings.HasPrefix(url, testCase.expectPrefix) {
+				t.Errorf("expected URL to start with %q, got %q", testCase.expectPrefix, presigned.URL)
+			}
+			if !strings.Contains(presigned.URL, "X-Amz-Expires=600") {
+				t.Errorf("expected a validity of 600 seconds, got %q", presigned.URL)
+			}
+			if !strings.Contains(presigned.URL, testCase.expectScope) {
+				t.Errorf("expected credential scope %q, got %q", testCase.expectScope, presigned.URL)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3PresignedURLEphemeralResource_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		CheckDestroy: testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			// Ephemeral values never reach the state, so the step only
+			// checks that the URLs can be presigned
+			{
+				Config: testAccRadosgwS3PresignedURLEphemeralResourceConfig_basic(bucketName),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3PresignedURLEphemeralResourceConfig_basic(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+ephemeral "radosgw_s3_presigned_url" "get" {
+  bucket = radosgw_s3_bucket.test.bucket
+  key    = "config/app.json"
+}
+
+ephemeral "radosgw_s3_presigned_url" "put" {
+  bucket     = radosgw_s3_bucket.test.bucket
+  key        = "config/app.json"
+  method     = "PUT"
+  expires_in = 300
+}
+`, bucketName)
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &STSSessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &STSSessionEphemeralResource{}
+
+func NewSTSSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &STSSessionEphemeralResource{}
+}
+
+// STSSessionEphemeralResource defines the ephemeral resource implementation.
+type STSSessionEphemeralResource struct {
+	iamClient *IAMClient
+}
+
+// STSSessionEphemeralResourceModel describes the ephemeral resource data model.
+type STSSessionEphemeralResourceModel struct {
+	RoleArn          types.String `tfsdk:"role_arn"`
+	RoleSessionName  types.String `tfsdk:"role_session_name"`
+	WebIdentityToken types.String `tfsdk:"web_identity_token"`
+	DurationSeconds  types.Int64  `tfsdk:"duration_seconds"`
+	Policy           types.String `tfsdk:"policy"`
+	AssumedRoleArn   types.String `tfsdk:"assumed_role_arn"`
+	AccessKeyID      types.String `tfsdk:"access_key_id"`
+	SecretAccessKey  types.String `tfsdk:"secret_access_key"`
+	SessionToken     types.String `tfsdk:"session_token"`
+	Expiration       types.String `tfsdk:"expiration"`
+}
+
+// XML response structure for STS AssumeRoleWithWebIdentity API
+type assumeRoleWithWebIdentityResponseXML struct {
+	XMLName xml.Name         `xml:"AssumeRoleWithWebIdentityResponse"`
+	Result  assumeRoleResult `xml:"AssumeRoleWithWebIdentityResult"`
+}
+
+func (r *STSSessionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sts_session"
+}
+
+func (r *STSSessionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Obtains temporary credentials for a RadosGW role, using STS `AssumeRoleWithWebIdentity` when " +
+			"`web_identity_token` is set and `AssumeRole` with the provider credentials otherwise. The credentials " +
+			"can be passed to other providers or to write-only arguments and are never stored in the plan or state.\n\n" +
+			"~> **Note:** Ephemeral resources require Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the role to assume.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role_session_name": schema.StringAttribute{
+				MarkdownDescription: "An identifier for the assumed role session.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"web_identity_token": schema.StringAttribute{
+				MarkdownDescription: "An OpenID Connect token issued by a provider registered with `radosgw_iam_openid_connect_provider`. " +
+					"When set, the role is assumed with `AssumeRoleWithWebIdentity` and the trust policy must allow " +
+					"`sts:AssumeRoleWithWebIdentity` for the token issuer.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"duration_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The duration of the session in seconds, between 900 and the role's `max_session_duration`. " +
+					"Defaults to 3600 on the RadosGW side.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(900, 43200),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "An inline session policy (in JSON format) that further restricts the permissions of the session.",
+				Optional:            true,
+			},
+			"assumed_role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the assumed role session.",
+				Computed:            true,
+			},
+			"access_key_id": schema.StringAttribute{
+				MarkdownDescription: "The access key of the temporary credentials.",
+				Computed:            true,
+			},
+			"secret_access_key": schema.StringAttribute{
+				MarkdownDescription: "The secret key of the temporary credentials.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"session_token": schema.StringAttribute{
+				MarkdownDescription: "The session token of the temporary credentials.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "The time the temporary credentials expire, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *STSSessionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.iamClient = client.IAM
+}
+
+func (r *STSSessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data STSSessionEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleArn := data.RoleArn.ValueString()
+	action := "AssumeRole"
+	if !data.WebIdentityToken.IsNull() {
+		action = "AssumeRoleWithWebIdentity"
+	}
+
+	tflog.Debug(ctx, "Opening STS session", map[string]any{
+		"action":            action,
+		"role_arn":          roleArn,
+		"role_session_name": data.RoleSessionName.ValueString(),
+	})
+
+	params := url.Values{}
+	params.Set("Action", action)
+	params.Set("Version", "2011-06-15")
+	params.Set("RoleArn", roleArn)
+	params.Set("RoleSessionName", data.RoleSessionName.ValueString())
+	if !data.WebIdentityToken.IsNull() {
+		params.Set("WebIdentityToken", data.WebIdentityToken.ValueString())
+	}
+	if !data.DurationSeconds.IsNull() {
+		params.Set("DurationSeconds", strconv.FormatInt(data.DurationSeconds.ValueInt64(), 10))
+	}
+	if !data.Policy.IsNull() && data.Policy.ValueString() != "" {
+		params.Set("Policy", data.Policy.ValueString())
+	}
+
+	body, err := r.iamClient.DoRequest(ctx, params, "sts")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Assuming Role",
+			fmt.Sprintf("Could not assume role %s with %s: %s", roleArn, action, err.Error()),
+		)
+		return
+	}
+
+	// Both actions return the same result under a different element name
+	var result assumeRoleResult
+	if action == "AssumeRoleWithWebIdentity" {
+		var response assumeRoleWithWebIdentityResponseXML
+		err = xml.Unmarshal(body, &response)
+		result = response.Result
+	} else {
+		var response assumeRoleResponseXML
+		err = xml.Unmarshal(body, &response)
+		result = response.Result
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse %s response: %s", action, err.Error()),
+		)
+		return
+	}
+
+	data.AssumedRoleArn = types.StringValue(result.AssumedRoleUser.Arn)
+	data.AccessKeyID = types.StringValue(result.Credentials.AccessKeyId)
+	data.SecretAccessKey = types.StringValue(result.Credentials.SecretAccessKey)
+	data.SessionToken = types.StringValue(result.Credentials.SessionToken)
+	data.Expiration = types.StringValue(result.Credentials.Expiration)
+
+	tflog.Trace(ctx, "Opened STS session", map[string]any{
+		"role_arn":         roleArn,
+		"assumed_role_arn": result.AssumedRoleUser.Arn,
+		"expiration":       result.Credentials.Expiration,
+	})
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRadosgwSTSSessionEphemeralResource_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	roleName := randomName("tf-acc-role")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		CheckDestroy: testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			// The user and its keys must exist before the user provider can
+			// be configured
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName),
+			},
+			// Ephemeral values never reach the state, so the step only
+			// checks that the session can be opened
+			{
+				Config: testAccRadosgwSTSSessionEphemeralResourceConfig_basic(userID, roleName),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwSTSSessionEphemeralResource_trustPolicyDenied(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	roleName := randomName("tf-acc-role")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		CheckDestroy: testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName),
+			},
+			// A trust policy naming another principal must fail the open
+			{
+				Config: testAccRadosgwSTSAssumeRoleDataSourceConfig_role(userID, roleName, `"arn:aws:iam:::user/someone-else"`) +
+					testAccRadosgwSTSAssumeRoleDataSourceUserProvider + testAccRadosgwSTSSessionEphemeralResourceConfig_session,
+				ExpectError: regexp.MustCompile(`Error Assuming Role`),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwSTSSessionEphemeralResourceConfig_basic(userID, roleName string) string {
+	return testAccRadosgwSTSAssumeRoleDataSourceConfig_setup(userID, roleName) + testAccRadosgwSTSAssumeRoleDataSourceUserProvider +
+		testAccRadosgwSTSSessionEphemeralResourceConfig_session
+}
+
+const testAccRadosgwSTSSessionEphemeralResourceConfig_session = `
+ephemeral "radosgw_sts_session" "test" {
+  provider          = radosgw.user
+  role_arn          = radosgw_iam_role.test.arn
+  role_session_name = "tf-acc-session"
+  duration_seconds  = 900
+
+  depends_on = [radosgw_iam_user_policy.test]
+}
+`
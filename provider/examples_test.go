@@ -0,0 +1,646 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// The examples tests load every HCL file under examples/ and check it
+// against the provider schema without a RadosGW cluster:
+//   - every argument and nested block must exist in the schema, and every
+//     required argument must be set;
+//   - the provider, resource, data source and ephemeral resource validators
+//     must pass;
+//   - the plan of every resource is compared to a golden file in
+//     testdata/examples, so renamed attributes and changed defaults show up
+//     as a diff before release.
+//
+// Expressions referencing other objects, and functions not available here,
+// are planned as unknown values. After an intended schema change, update the
+// golden files with:
+//
+//	go test ./provider/ -run TestExamples -update
+
+// updateGolden rewrites the golden files instead of comparing against them.
+var updateGolden = flag.Bool("update", false, "update the golden files of the examples tests")
+
+const (
+	examplesDir       = "../examples"
+	examplesGoldenDir = "testdata/examples"
+
+	// exampleUnknownValue stands for values known after apply in golden files.
+	exampleUnknownValue = "(known after apply)"
+)
+
+// exampleMetaArguments are the arguments and blocks handled by Terraform
+// itself rather than by the provider.
+var exampleMetaArguments = map[string]bool{
+	"count":       true,
+	"depends_on":  true,
+	"for_each":    true,
+	"provider":    true,
+	"lifecycle":   true,
+	"provisioner": true,
+	"connection":  true,
+	"alias":       true,
+}
+
+// exampleEvalContext provides the functions used by the examples. Calls to
+// other functions are planned as unknown values.
+var exampleEvalContext = &hcl.EvalContext{
+	Functions: map[string]function.Function{
+		"jsondecode": stdlib.JSONDecodeFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"length":     stdlib.LengthFunc,
+		"tolist":     stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	},
+}
+
+// exampleBlock is a provider, resource, data or ephemeral block of an example
+// file.
+type exampleBlock struct {
+	Kind     string
+	TypeName string
+	Name     string
+	Body     *hclsyntax.Body
+}
+
+// Address returns the address of the block as used in Terraform plans.
+func (b exampleBlock) Address() string {
+	switch b.Kind {
+	case "data":
+		return "data." + b.TypeName + "." + b.Name
+	case "ephemeral":
+		return "ephemeral." + b.TypeName + "." + b.Name
+	case "provider":
+		return "provider." + b.TypeName
+	}
+	return b.TypeName + "." + b.Name
+}
+
+func TestExamples_coverage(t *testing.T) {
+	t.Parallel()
+
+	schemas := testExamplesProviderSchema(t)
+
+	for typeName := range schemas.ResourceSchemas {
+		file := filepath.Join(examplesDir, "resources", typeName, "resource.tf")
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("resource %s has no example: %s", typeName, err)
+		}
+	}
+	for typeName := range schemas.DataSourceSchemas {
+		file := filepath.Join(examplesDir, "data-sources", typeName, "data-source.tf")
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("data source %s has no example: %s", typeName, err)
+		}
+	}
+	for typeName := range schemas.EphemeralResourceSchemas {
+		file := filepath.Join(examplesDir, "ephemeral-resources", typeName, "ephemeral-resource.tf")
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("ephemeral resource %s has no example: %s", typeName, err)
+		}
+	}
+}
+
+func TestExamples_schema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New("test")())()
+	schemas := testExamplesProviderSchema(t)
+
+	for _, file := range testExamplesFiles(t) {
+		t.Run(file, func(t *testing.T) {
+			for _, block := range testExamplesLoad(t, file) {
+				schema := testExamplesBlockSchema(schemas, block)
+				if schema == nil {
+					t.Errorf("%s: unknown type %s", block.Address(), block.TypeName)
+					continue
+				}
+
+				config, err := exampleBlockValue(block.Body, schema.Block)
+				if err != nil {
+					t.Errorf("%s: %s", block.Address(), err)
+					continue
+				}
+
+				value, err := tfprotov6.NewDynamicValue(schema.ValueType(), config)
+				if err != nil {
+					t.Fatalf("%s: %s", block.Address(), err)
+				}
+
+				var diags []*tfprotov6.Diagnostic
+				switch block.Kind {
+				case "provider":
+					resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{Config: &value})
+					if err != nil {
+						t.Fatalf("%s: %s", block.Address(), err)
+					}
+					diags = resp.Diagnostics
+				case "resource":
+					resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: block.TypeName, Config: &value})
+					if err != nil {
+						t.Fatalf("%s: %s", block.Address(), err)
+					}
+					diags = resp.Diagnostics
+				case "data":
+					resp, err := server.ValidateDataResourceConfig(ctx, &tfprotov6.ValidateDataResourceConfigRequest{TypeName: block.TypeName, Config: &value})
+					if err != nil {
+						t.Fatalf("%s: %s", block.Address(), err)
+					}
+					diags = resp.Diagnostics
+				case "ephemeral":
+					resp, err := server.ValidateEphemeralResourceConfig(ctx, &tfprotov6.ValidateEphemeralResourceConfigRequest{TypeName: block.TypeName, Config: &value})
+					if err != nil {
+						t.Fatalf("%s: %s", block.Address(), err)
+					}
+					diags = resp.Diagnostics
+				}
+				testExamplesCheckDiagnostics(t, block, diags)
+			}
+		})
+	}
+}
+
+func TestExamples_golden(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New("test")())()
+	schemas := testExamplesProviderSchema(t)
+
+	for _, file := range testExamplesFiles(t) {
+		t.Run(file, func(t *testing.T) {
+			planned := map[string]any{}
+
+			for _, block := range testExamplesLoad(t, file) {
+				if block.Kind != "resource" {
+					continue
+				}
+				schema := schemas.ResourceSchemas[block.TypeName]
+				if schema == nil {
+					// Reported by TestExamples_schema
+					continue
+				}
+
+				config, err := exampleBlockValue(block.Body, schema.Block)
+				if err != nil {
+					// Reported by TestExamples_schema
+					continue
+				}
+
+				schemaType := schema.ValueType()
+				configValue, err := tfprotov6.NewDynamicValue(schemaType, config)
+				if err != nil {
+					t.Fatalf("%s: %s", block.Address(), err)
+				}
+				priorState, err := tfprotov6.NewDynamicValue(schemaType, tftypes.NewValue(schemaType, nil))
+				if err != nil {
+					t.Fatalf("%s: %s", block.Address(), err)
+				}
+
+				resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+					TypeName:         block.TypeName,
+					PriorState:       &priorState,
+					ProposedNewState: &configValue,
+					Config:           &configValue,
+				})
+				if err != nil {
+					t.Fatalf("%s: %s", block.Address(), err)
+				}
+				testExamplesCheckDiagnostics(t, block, resp.Diagnostics)
+				if resp.PlannedState == nil {
+					continue
+				}
+
+				plannedState, err := resp.PlannedState.Unmarshal(schemaType)
+				if err != nil {
+					t.Fatalf("%s: %s", block.Address(), err)
+				}
+				planned[block.Address()] = exampleGoldenValue(plannedState)
+			}
+
+			if len(planned) == 0 {
+				return
+			}
+
+			got, err := json.MarshalIndent(planned, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join(examplesGoldenDir, filepath.Dir(file)+".json")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %s", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("plan of %s does not match %s (run with -update if the change is intended)\n\ngot:\n%s", file, golden, got)
+			}
+		})
+	}
+}
+
+// Helper functions
+
+// testExamplesProviderSchema returns the provider, resource and data source
+// schemas as sent to Terraform.
+func testExamplesProviderSchema(t *testing.T) *tfprotov6.GetProviderSchemaResponse {
+	t.Helper()
+
+	server := providerserver.NewProtocol6(New("test")())()
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("getting provider schema: %s", err)
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("getting provider schema: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+	return resp
+}
+
+// testExamplesFiles returns the HCL files under examples/, relative to it.
+func testExamplesFiles(t *testing.T) []string {
+	t.Helper()
+
+	var files []string
+	err := filepath.WalkDir(examplesDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".tf" {
+			return nil
+		}
+		rel, err := filepath.Rel(examplesDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("listing examples: %s", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no examples found in %s", examplesDir)
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// testExamplesLoad parses an example file and returns its blocks for this
+// provider. Blocks of other providers, variables, outputs and locals are
+// skipped.
+func testExamplesLoad(t *testing.T, file string) []exampleBlock {
+	t.Helper()
+
+	src, err := os.ReadFile(filepath.Join(examplesDir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, diags := hclsyntax.ParseConfig(src, file, hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parsing %s: %s", file, diags.Error())
+	}
+
+	var blocks []exampleBlock
+	for _, block := range parsed.Body.(*hclsyntax.Body).Blocks {
+		switch {
+		case block.Type == "provider" && len(block.Labels) == 1 && block.Labels[0] == "radosgw":
+			blocks = append(blocks, exampleBlock{Kind: "provider", TypeName: "radosgw", Body: block.Body})
+		case (block.Type == "resource" || block.Type == "data" || block.Type == "ephemeral") && len(block.Labels) == 2 && strings.HasPrefix(block.Labels[0], "radosgw_"):
+			blocks = append(blocks, exampleBlock{Kind: block.Type, TypeName: block.Labels[0], Name: block.Labels[1], Body: block.Body})
+		}
+	}
+	return blocks
+}
+
+// testExamplesBlockSchema returns the schema of an example block, or nil if
+// the type does not exist.
+func testExamplesBlockSchema(schemas *tfprotov6.GetProviderSchemaResponse, block exampleBlock) *tfprotov6.Schema {
+	switch block.Kind {
+	case "provider":
+		return schemas.Provider
+	case "resource":
+		return schemas.ResourceSchemas[block.TypeName]
+	case "data":
+		return schemas.DataSourceSchemas[block.TypeName]
+	case "ephemeral":
+		return schemas.EphemeralResourceSchemas[block.TypeName]
+	}
+	return nil
+}
+
+// testExamplesCheckDiagnostics fails the test for every error diagnostic.
+func testExamplesCheckDiagnostics(t *testing.T, block exampleBlock, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s: %s", block.Address(), diag.Summary, diag.Detail)
+		}
+	}
+}
+
+// exampleBlockValue converts the body of an example block into a config
+// value of the given schema. Unsupported arguments and blocks, computed-only
+// arguments and missing required arguments are reported as errors.
+func exampleBlockValue(body *hclsyntax.Body, schema *tfprotov6.SchemaBlock) (tftypes.Value, error) {
+	var errs []error
+	values := map[string]tftypes.Value{}
+
+	attributes := map[string]*tfprotov6.SchemaAttribute{}
+	for _, attribute := range schema.Attributes {
+		attributes[attribute.Name] = attribute
+	}
+	blockTypes := map[string]*tfprotov6.SchemaNestedBlock{}
+	for _, blockType := range schema.BlockTypes {
+		blockTypes[blockType.TypeName] = blockType
+	}
+
+	for name, attr := range body.Attributes {
+		if exampleMetaArguments[name] {
+			continue
+		}
+		attribute, ok := attributes[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unsupported argument %q", attr.SrcRange, name))
+			continue
+		}
+		if attribute.Computed && !attribute.Optional && !attribute.Required {
+			errs = append(errs, fmt.Errorf("%s: argument %q is read-only", attr.SrcRange, name))
+			continue
+		}
+
+		value, err := exampleExpressionValue(attr.Expr, attribute.ValueType())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: argument %q: %w", attr.SrcRange, name, err))
+			continue
+		}
+		values[name] = value
+	}
+
+	nested := map[string][]tftypes.Value{}
+	dynamic := map[string]bool{}
+	for _, block := range body.Blocks {
+		if exampleMetaArguments[block.Type] {
+			continue
+		}
+		typeName := block.Type
+		if typeName == "dynamic" && len(block.Labels) == 1 {
+			typeName = block.Labels[0]
+		}
+		blockType, ok := blockTypes[typeName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unsupported block %q", block.TypeRange, typeName))
+			continue
+		}
+		if block.Type == "dynamic" {
+			dynamic[typeName] = true
+			continue
+		}
+
+		value, err := exampleBlockValue(block.Body, blockType.Block)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		nested[typeName] = append(nested[typeName], value)
+	}
+
+	for name, attribute := range attributes {
+		if _, ok := values[name]; ok {
+			continue
+		}
+		if attribute.Required {
+			errs = append(errs, fmt.Errorf("%s: missing required argument %q", body.SrcRange, name))
+		}
+		values[name] = tftypes.NewValue(attribute.ValueType(), nil)
+	}
+
+	for typeName, blockType := range blockTypes {
+		valueType := blockType.ValueType()
+		elems := nested[typeName]
+
+		switch {
+		case dynamic[typeName]:
+			values[typeName] = tftypes.NewValue(valueType, tftypes.UnknownValue)
+		case blockType.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle || blockType.Nesting == tfprotov6.SchemaNestedBlockNestingModeGroup:
+			if len(elems) > 1 {
+				errs = append(errs, fmt.Errorf("%s: too many %q blocks", body.SrcRange, typeName))
+			}
+			if len(elems) == 0 {
+				values[typeName] = tftypes.NewValue(valueType, nil)
+			} else {
+				values[typeName] = elems[0]
+			}
+		default:
+			if int64(len(elems)) < blockType.MinItems {
+				errs = append(errs, fmt.Errorf("%s: at least %d %q blocks are required", body.SrcRange, blockType.MinItems, typeName))
+			}
+			if blockType.MaxItems > 0 && int64(len(elems)) > blockType.MaxItems {
+				errs = append(errs, fmt.Errorf("%s: at most %d %q blocks are allowed", body.SrcRange, blockType.MaxItems, typeName))
+			}
+			if elems == nil {
+				elems = []tftypes.Value{}
+			}
+			values[typeName] = tftypes.NewValue(valueType, elems)
+		}
+	}
+
+	return tftypes.NewValue(schema.ValueType(), values), errors.Join(errs...)
+}
+
+// exampleExpressionValue evaluates an expression into a value of the given
+// type. Expressions referencing other objects are unknown until apply.
+func exampleExpressionValue(expr hclsyntax.Expression, typ tftypes.Type) (tftypes.Value, error) {
+	if len(expr.Variables()) > 0 {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+
+	value, diags := expr.Value(exampleEvalContext)
+	if diags.HasErrors() {
+		// Functions only Terraform provides cannot be evaluated here
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+	return exampleCtyValue(value, typ)
+}
+
+// exampleCtyValue converts an HCL value into a value of the given type.
+func exampleCtyValue(value cty.Value, typ tftypes.Type) (tftypes.Value, error) {
+	if !value.IsWhollyKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+	if value.IsNull() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		converted, err := convert.Convert(value, cty.String)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, converted.AsString()), nil
+	case typ.Is(tftypes.Number):
+		converted, err := convert.Convert(value, cty.Number)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, converted.AsBigFloat()), nil
+	case typ.Is(tftypes.Bool):
+		converted, err := convert.Convert(value, cty.Bool)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, converted.True()), nil
+	}
+
+	if !value.CanIterateElements() {
+		return tftypes.Value{}, fmt.Errorf("expected %s, got %s", typ, value.Type().FriendlyName())
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.List, tftypes.Set:
+		var elemType tftypes.Type
+		if list, ok := typ.(tftypes.List); ok {
+			elemType = list.ElementType
+		} else {
+			elemType = typ.(tftypes.Set).ElementType
+		}
+		if value.Type().IsMapType() || value.Type().IsObjectType() {
+			return tftypes.Value{}, fmt.Errorf("expected %s, got %s", typ, value.Type().FriendlyName())
+		}
+
+		elems := []tftypes.Value{}
+		for it := value.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			converted, err := exampleCtyValue(elem, elemType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems = append(elems, converted)
+		}
+		return tftypes.NewValue(typ, elems), nil
+
+	case tftypes.Map:
+		elems := map[string]tftypes.Value{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			converted, err := exampleCtyValue(elem, typ.ElementType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[key.AsString()] = converted
+		}
+		return tftypes.NewValue(typ, elems), nil
+
+	case tftypes.Object:
+		if !value.Type().IsMapType() && !value.Type().IsObjectType() {
+			return tftypes.Value{}, fmt.Errorf("expected %s, got %s", typ, value.Type().FriendlyName())
+		}
+
+		attributes := map[string]tftypes.Value{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			attrType, ok := typ.AttributeTypes[key.AsString()]
+			if !ok {
+				return tftypes.Value{}, fmt.Errorf("unsupported attribute %q", key.AsString())
+			}
+			converted, err := exampleCtyValue(elem, attrType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			attributes[key.AsString()] = converted
+		}
+		for name, attrType := range typ.AttributeTypes {
+			if _, ok := attributes[name]; !ok {
+				attributes[name] = tftypes.NewValue(attrType, nil)
+			}
+		}
+		return tftypes.NewValue(typ, attributes), nil
+	}
+
+	return tftypes.Value{}, fmt.Errorf("unsupported type %s", typ)
+}
+
+// exampleGoldenValue converts a planned value into its golden file form.
+func exampleGoldenValue(value tftypes.Value) any {
+	if !value.IsKnown() {
+		return exampleUnknownValue
+	}
+	if value.IsNull() {
+		return nil
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		return s
+	case typ.Is(tftypes.Number):
+		var f big.Float
+		_ = value.As(&f)
+		return json.Number(f.Text('f', -1))
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return b
+	}
+
+	switch typ.(type) {
+	case tftypes.Map, tftypes.Object:
+		var elems map[string]tftypes.Value
+		_ = value.As(&elems)
+		result := map[string]any{}
+		for key, elem := range elems {
+			result[key] = exampleGoldenValue(elem)
+		}
+		return result
+	default:
+		var elems []tftypes.Value
+		_ = value.As(&elems)
+		result := []any{}
+		for _, elem := range elems {
+			result = append(result, exampleGoldenValue(elem))
+		}
+		return result
+	}
+}
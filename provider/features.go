@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// CephFeature names a RadosGW capability that only some of the supported Ceph
+// releases provide.
+type CephFeature string
+
+// RadosGW capabilities depending on the Ceph release.
+const (
+	// Accounts, account quotas, IAM groups and the "accounts" capability type.
+	CephFeature_Accounts CephFeature = "accounts"
+	// Deactivating access keys with the active flag of the Admin API.
+	CephFeature_AccessKeyStatus CephFeature = "access_key_status"
+	// Managing several keys or subusers of the same user, which Reef handles
+	// unreliably.
+	CephFeature_MultipleUserCredentials CephFeature = "multiple_user_credentials"
+	// Topic policies set with SetTopicAttributes.
+	CephFeature_TopicPolicy CephFeature = "topic_policy"
+	// The owner and connection attributes returned by GetTopicAttributes.
+	CephFeature_TopicAttributes CephFeature = "topic_attributes"
+	// Bucket notifications pointing at several topics, or updated in place.
+	CephFeature_MultipleNotificationTopics CephFeature = "multiple_notification_topics"
+	// Lifecycle filters on the object size.
+	CephFeature_LifecycleObjectSize CephFeature = "lifecycle_object_size"
+	// The S3 bucket ownership controls operations.
+	CephFeature_BucketOwnershipControls CephFeature = "bucket_ownership_controls"
+	// The S3 bucket logging operations.
+	CephFeature_BucketLogging CephFeature = "bucket_logging"
+	// Updating the client IDs and thumbprints of OpenID Connect providers.
+	CephFeature_OIDCProviderUpdate CephFeature = "oidc_provider_update"
+)
+
+// cephFeatureMinVersions is the feature matrix: the first Ceph release
+// providing each feature. Resources check it at plan time and acceptance
+// tests use it to skip the features the tested release lacks.
+var cephFeatureMinVersions = map[CephFeature]CephVersion{
+	CephFeature_Accounts:                   CephVersion_Squid,
+	CephFeature_AccessKeyStatus:            CephVersion_Squid,
+	CephFeature_MultipleUserCredentials:    CephVersion_Squid,
+	CephFeature_TopicPolicy:                CephVersion_Squid,
+	CephFeature_TopicAttributes:            CephVersion_Squid,
+	CephFeature_MultipleNotificationTopics: CephVersion_Squid,
+	CephFeature_LifecycleObjectSize:        CephVersion_Squid,
+	CephFeature_BucketOwnershipControls:    CephVersion_Squid,
+	CephFeature_BucketLogging:              CephVersion_Tentacle,
+	CephFeature_OIDCProviderUpdate:         CephVersion_Tentacle,
+}
+
+// MinVersion returns the first Ceph release providing the feature, or
+// CephVersion_Unknown for features missing from the matrix.
+func (f CephFeature) MinVersion() CephVersion {
+	return cephFeatureMinVersions[f]
+}
+
+// FeatureSupported reports whether Ceph version provides feature. It returns
+// true when the version is unknown, so that a failed detection never blocks
+// an operation the cluster may support, and for features missing from the
+// matrix, which every supported release provides.
+func FeatureSupported(feature CephFeature, version CephVersion) bool {
+	return version == CephVersion_Unknown || version.GreaterThanOrEqual(feature.MinVersion())
+}
+
+// supportsFeature reports whether the cluster provides feature.
+func (c *RadosgwClient) supportsFeature(feature CephFeature) bool {
+	return FeatureSupported(feature, c.CephVersion)
+}
+
+// addCephFeatureError reports that operation needs feature, which the Ceph
+// version of the cluster does not provide.
+func (c *RadosgwClient) addCephFeatureError(diags *diag.Diagnostics, operation string, feature CephFeature) {
+	c.addCephVersionError(diags, operation, feature.MinVersion())
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFeatureSupported(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		feature  CephFeature
+		version  CephVersion
+		expected bool
+	}{
+		"older":             {feature: CephFeature_Accounts, version: CephVersion_Reef, expected: false},
+		"same":              {feature: CephFeature_Accounts, version: CephVersion_Squid, expected: true},
+		"newer":             {feature: CephFeature_Accounts, version: CephVersion_Tentacle, expected: true},
+		"unknown version":   {feature: CephFeature_Accounts, version: CephVersion_Unknown, expected: true},
+		"unknown feature":   {feature: CephFeature("unknown"), version: CephVersion_Reef, expected: true},
+		"tentacle on squid": {feature: CephFeature_BucketLogging, version: CephVersion_Squid, expected: false},
+		"client":            {feature: CephFeature_OIDCProviderUpdate, version: CephVersion_Tentacle, expected: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if supported := FeatureSupported(testCase.feature, testCase.version); supported != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, supported)
+			}
+
+			client := &RadosgwClient{CephVersion: testCase.version}
+			if supported := client.supportsFeature(testCase.feature); supported != testCase.expected {
+				t.Errorf("expected client support %t, got %t", testCase.expected, supported)
+			}
+		})
+	}
+}
+
+// TestFeatureMatrix checks that the matrix only names releases the provider
+// knows, and that the lookup tables of operations and capability types point
+// at features of the matrix.
+func TestFeatureMatrix(t *testing.T) {
+	t.Parallel()
+
+	for feature, version := range cephFeatureMinVersions {
+		if version.LessThanOrEqual(CephVersion_Reef) || version.GreaterThan(CephVersion_Tentacle) {
+			t.Errorf("feature %s requires unexpected Ceph version %s", feature, version)
+		}
+	}
+
+	for operation, feature := range s3OperationFeatures {
+		if _, ok := cephFeatureMinVersions[feature]; !ok {
+			t.Errorf("S3 operation %s requires feature %s missing from the matrix", operation, feature)
+		}
+	}
+	for capType, feature := range capTypeFeatures {
+		if _, ok := cephFeatureMinVersions[feature]; !ok {
+			t.Errorf("capability type %s requires feature %s missing from the matrix", capType, feature)
+		}
+	}
+}
+
+// TestAccCephVersion_detected checks that the cluster under test runs the
+// release CEPH_VERSION names, so that a test run against the wrong daemon does
+// not silently skip or fail the tests of the features of that release.
+func TestAccCephVersion_detected(t *testing.T) {
+	t.Parallel()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	expected := parseCephVersion(os.Getenv("CEPH_VERSION"))
+	if expected == CephVersion_Unknown {
+		t.Skip("Skipping test: CEPH_VERSION is not set")
+	}
+
+	detected := detectedCephVersion()
+	if detected == CephVersion_Unknown {
+		t.Skip("Skipping test: RadosGW does not report its release in the Server header")
+	}
+	if detected != expected {
+		t.Fatalf("CEPH_VERSION is %s, but RadosGW runs Ceph %s", expected, detected)
+	}
+
+	for feature := range cephFeatureMinVersions {
+		t.Logf("feature %s supported: %t", feature, FeatureSupported(feature, detected))
+	}
+}
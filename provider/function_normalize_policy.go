@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizePolicyFunction{}
+
+func NewNormalizePolicyFunction() function.Function {
+	return &NormalizePolicyFunction{}
+}
+
+// NormalizePolicyFunction re-encodes a policy document in canonical form.
+type NormalizePolicyFunction struct{}
+
+func (f *NormalizePolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_policy"
+}
+
+func (f *NormalizePolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a policy document",
+		MarkdownDescription: "Re-encodes an IAM, bucket or topic policy document in the canonical form the provider " +
+			"compares policies in: compact JSON with sorted keys, `Statement` as a list, `Action` and `Resource` " +
+			"(and their `Not` forms) as lists, and principal identifiers as a string when there is only one. Use it " +
+			"to compare a policy read from RadosGW, which collapses single-element lists, with a configured one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "policy",
+				MarkdownDescription: "The JSON policy document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var policy string
+
+	resp.Error = req.Arguments.Get(ctx, &policy)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeIAMPolicyJSON(policy)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid policy document: %s", err.Error()))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizePolicyFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy      string
+		expected    string
+		expectError bool
+	}{
+		"collapsed": {
+			policy: `{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:GetObject",
+				"Principal": {"AWS": ["arn:aws:iam::acme:user/alice"]}, "Resource": "arn:aws:s3::acme:data/*"}}`,
+			expected: `{"Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::acme:user/alice"},` +
+				`"Resource":["arn:aws:s3::acme:data/*"]}],"Version":"2012-10-17"}`,
+		},
+		"canonical": {
+			policy:   `{"Statement":[{"Action":["s3:*"],"Effect":"Deny","Principal":"*","Resource":["*"]}]}`,
+			expected: `{"Statement":[{"Action":["s3:*"],"Effect":"Deny","Principal":"*","Resource":["*"]}]}`,
+		},
+		"invalid": {policy: `{"Statement":`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.policy)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			(&NormalizePolicyFunction{}).Run(testCtx, req, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if normalized := resp.Result.Value().(types.String).ValueString(); normalized != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, normalized)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwNormalizePolicyFunction_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "policy" {
+  value = provider::radosgw::normalize_policy(jsonencode({
+    Statement = { Effect = "Allow", Action = "s3:GetObject", Resource = "*" }
+  }))
+}
+`,
+				Check: resource.TestCheckOutput("policy", `{"Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Resource":["*"]}]}`),
+			},
+		},
+	})
+}
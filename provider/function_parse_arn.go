@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseARNFunction{}
+
+func NewParseARNFunction() function.Function {
+	return &ParseARNFunction{}
+}
+
+// ParseARNFunction splits an ARN into its components.
+type ParseARNFunction struct{}
+
+// parsedARNAttrTypes are the attribute types of the object returned by
+// parse_arn.
+var parsedARNAttrTypes = map[string]attr.Type{
+	"partition": types.StringType,
+	"service":   types.StringType,
+	"region":    types.StringType,
+	"tenant":    types.StringType,
+	"resource":  types.StringType,
+}
+
+func (f *ParseARNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_arn"
+}
+
+func (f *ParseARNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an ARN into its components",
+		MarkdownDescription: "Parses an ARN such as `arn:aws:iam::acme:user/alice` into an object with the `partition`, " +
+			"`service`, `region`, `tenant` and `resource` attributes. RadosGW places the tenant, or the account ID of " +
+			"account resources, in the account field of both IAM and S3 ARNs, so `tenant` is empty for resources " +
+			"without a tenant.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "arn",
+				MarkdownDescription: "The ARN to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedARNAttrTypes,
+		},
+	}
+}
+
+func (f *ParseARNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(parsedARNAttrTypes, map[string]attr.Value{
+		"partition": types.StringValue(parsed.Partition),
+		"service":   types.StringValue(parsed.Service),
+		"region":    types.StringValue(parsed.Region),
+		"tenant":    types.StringValue(parsed.AccountID),
+		"resource":  types.StringValue(parsed.Resource),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseARNFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		arn         string
+		expected    map[string]string
+		expectError bool
+	}{
+		"tenant user": {
+			arn:      "arn:aws:iam::acme:user/alice",
+			expected: map[string]string{"partition": "aws", "service": "iam", "region": "", "tenant": "acme", "resource": "user/alice"},
+		},
+		"object": {
+			arn:      "arn:aws:s3:::data/logs/*",
+			expected: map[string]string{"partition": "aws", "service": "s3", "region": "", "tenant": "", "resource": "data/logs/*"},
+		},
+		"topic": {
+			arn:      "arn:aws:sns:default::events",
+			expected: map[string]string{"partition": "aws", "service": "sns", "region": "default", "tenant": "", "resource": "events"},
+		},
+		"invalid": {arn: "user/alice", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.arn)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(parsedARNAttrTypes))}
+			(&ParseARNFunction{}).Run(testCtx, req, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			attributes := resp.Result.Value().(types.Object).Attributes()
+			for key, expected := range testCase.expected {
+				if value := attributes[key].(types.String).ValueString(); value != expected {
+					t.Errorf("expected %s %q, got %q", key, expected, value)
+				}
+			}
+		})
+	}
+}
+
+func TestAccRadosgwParseARNFunction_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "tenant" {
+  value = provider::radosgw::parse_arn("arn:aws:iam::acme:user/alice").tenant
+}
+`,
+				Check: resource.TestCheckOutput("tenant", "acme"),
+			},
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TenantBucketNameFunction{}
+
+func NewTenantBucketNameFunction() function.Function {
+	return &TenantBucketNameFunction{}
+}
+
+// TenantBucketNameFunction builds the S3 name of a bucket of a tenant.
+type TenantBucketNameFunction struct{}
+
+func (f *TenantBucketNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tenant_bucket_name"
+}
+
+func (f *TenantBucketNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the S3 name of a tenanted bucket",
+		MarkdownDescription: "Returns the name S3 clients of other tenants use to address a bucket, `tenant:bucket`, " +
+			"or the bucket name alone when the tenant is empty.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "tenant",
+				MarkdownDescription: "The tenant of the bucket, or an empty string for buckets without a tenant.",
+			},
+			function.StringParameter{
+				Name:                "bucket",
+				MarkdownDescription: "The name of the bucket.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TenantBucketNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tenant, bucket string
+
+	resp.Error = req.Arguments.Get(ctx, &tenant, &bucket)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateBucketName(bucket, false); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("bucket name %q %s", bucket, err.Error()))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, s3BucketName(tenant, bucket))
+}
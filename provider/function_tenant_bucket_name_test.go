@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTenantBucketNameFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tenant      string
+		bucket      string
+		expected    string
+		expectError bool
+	}{
+		"tenant":         {tenant: "acme", bucket: "data", expected: "acme:data"},
+		"no tenant":      {bucket: "data", expected: "data"},
+		"invalid bucket": {tenant: "acme", bucket: "acme:data", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+				types.StringValue(testCase.tenant),
+				types.StringValue(testCase.bucket),
+			})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			(&TenantBucketNameFunction{}).Run(testCtx, req, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if name := resp.Result.Value().(types.String).ValueString(); name != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, name)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwTenantBucketNameFunction_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "bucket" {
+  value = provider::radosgw::tenant_bucket_name("acme", "data")
+}
+`,
+				Check: resource.TestCheckOutput("bucket", "acme:data"),
+			},
+		},
+	})
+}
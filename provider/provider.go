@@ -4,24 +4,45 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsretry "github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure RadosgwProvider satisfies various provider interfaces.
 var _ provider.Provider = &RadosgwProvider{}
+var _ provider.ProviderWithEphemeralResources = &RadosgwProvider{}
+var _ provider.ProviderWithFunctions = &RadosgwProvider{}
+var _ provider.ProviderWithValidateConfig = &RadosgwProvider{}
 
 // RadosgwProvider defines the provider implementation.
 type RadosgwProvider struct {
@@ -33,18 +54,70 @@ type RadosgwProvider struct {
 
 // RadosgwProviderModel describes the provider data model.
 type RadosgwProviderModel struct {
-	Endpoint              types.String `tfsdk:"endpoint"`
-	AccessKey             types.String `tfsdk:"access_key"`
-	SecretKey             types.String `tfsdk:"secret_key"`
-	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
-	RootCACertificate     types.String `tfsdk:"root_ca_certificate"`
-	RootCACertificateFile types.String `tfsdk:"root_ca_certificate_file"`
+	Endpoint                   types.String               `tfsdk:"endpoint"`
+	Endpoints                  types.List                 `tfsdk:"endpoints"`
+	AccessKey                  types.String               `tfsdk:"access_key"`
+	SecretKey                  types.String               `tfsdk:"secret_key"`
+	TLSInsecureSkipVerify      types.Bool                 `tfsdk:"tls_insecure_skip_verify"`
+	RootCACertificate          types.String               `tfsdk:"root_ca_certificate"`
+	RootCACertificateFile      types.String               `tfsdk:"root_ca_certificate_file"`
+	CephVersion                types.String               `tfsdk:"ceph_version"`
+	MaxConcurrentAdminRequests types.Int64                `tfsdk:"max_concurrent_admin_requests"`
+	MaxRetries                 types.Int64                `tfsdk:"max_retries"`
+	RetryMaxBackoff            types.String               `tfsdk:"retry_max_backoff"`
+	RequestTimeout             types.String               `tfsdk:"request_timeout"`
+	StrictBucketNames          types.Bool                 `tfsdk:"strict_bucket_names"`
+	S3AddressingStyle          types.String               `tfsdk:"s3_addressing_style"`
+	Region                     types.String               `tfsdk:"region"`
+	SigningName                types.String               `tfsdk:"signing_name"`
+	AssumeRole                 []ProviderAssumeRoleModel  `tfsdk:"assume_role"`
+	DefaultTags                []ProviderDefaultTagsModel `tfsdk:"default_tags"`
 }
 
-// RadosgwClient holds both admin and S3 clients
+// ProviderAssumeRoleModel describes the assume_role block of the provider.
+type ProviderAssumeRoleModel struct {
+	RoleArn     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+	Duration    types.String `tfsdk:"duration"`
+}
+
+// ProviderDefaultTagsModel describes the default_tags block of the provider.
+type ProviderDefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// defaultAssumeRoleSessionName is the session name used by the provider
+// assume_role block when none is configured.
+const defaultAssumeRoleSessionName = "terraform-provider-radosgw"
+
+// defaultS3Region is the SigV4 region of S3 requests when region is not set.
+// RadosGW accepts any region unless it is configured to check it.
+const defaultS3Region = "default"
+
+// defaultSigningName is the SigV4 signing name of S3, IAM, STS and SNS
+// requests when signing_name is not set, as RadosGW expects.
+const defaultSigningName = "s3"
+
+// RadosgwClient holds the admin, S3 and IAM clients
 type RadosgwClient struct {
 	Admin *admin.API
 	S3    *s3.Client
+	IAM   *IAMClient
+
+	// CephVersion is the major release of the cluster, detected once at
+	// Configure time. It is CephVersion_Unknown when detection failed.
+	CephVersion CephVersion
+
+	// StrictBucketNames enforces DNS compliant bucket names at plan time,
+	// as RadosGW does unless rgw_relaxed_s3_bucket_names is enabled.
+	StrictBucketNames bool
+
+	// DefaultTags are the tags of the provider default_tags block, merged
+	// into the tags of the resources managing tags. DefaultTagsUnknown is set
+	// while they depend on values known only after apply.
+	DefaultTags        map[string]string
+	DefaultTagsUnknown bool
 }
 
 func (p *RadosgwProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -62,22 +135,47 @@ The RadosGW user configured in this provider requires specific capabilities to m
 
 | Capability | Resources |
 |------------|-----------|
-| ` + "`users=*`" + ` | ` + "`radosgw_iam_user`" + `, ` + "`radosgw_iam_subuser`" + `, ` + "`radosgw_iam_access_key`" + `, ` + "`radosgw_iam_user_caps`" + `, ` + "`radosgw_iam_quota`" + `, ` + "`radosgw_iam_user`" + `, ` + "`radosgw_iam_users`" + ` |
-| ` + "`buckets=*`" + ` | ` + "`radosgw_s3_bucket`" + `, ` + "`radosgw_s3_bucket_link`" + `, ` + "`radosgw_s3_bucket_acl`" + `, ` + "`radosgw_s3_bucket_policy`" + `, ` + "`radosgw_s3_bucket_lifecycle_configuration`" + ` |
+| ` + "`users=*`" + ` | ` + "`radosgw_admin_caps`" + `, ` + "`radosgw_iam_user`" + `, ` + "`radosgw_iam_subuser`" + `, ` + "`radosgw_iam_access_key`" + `, ` + "`radosgw_iam_user_caps`" + `, ` + "`radosgw_iam_quota`" + `, ` + "`radosgw_swift_tempurl_key`" + `, ` + "`radosgw_iam_user`" + `, ` + "`radosgw_iam_users`" + `, ` + "`radosgw_iam_users_detail`" + `, ` + "`radosgw_s3_bucket_governance_bypass`" + `, ` + "`radosgw_sts_caller_identity`" + ` |
+| ` + "`buckets=*`" + ` | ` + "`radosgw_s3_bucket`" + `, ` + "`radosgw_s3_bucket_link`" + `, ` + "`radosgw_s3_bucket_bulk_link`" + `, ` + "`radosgw_s3_bucket_quota`" + `, ` + "`radosgw_s3_bucket_acl`" + `, ` + "`radosgw_s3_bucket_policy`" + `, ` + "`radosgw_s3_bucket_ownership_controls`" + `, ` + "`radosgw_s3_bucket_server_side_encryption_configuration`" + `, ` + "`radosgw_s3_bucket_lifecycle_configuration`" + `, ` + "`radosgw_s3_bucket_governance_bypass`" + `, ` + "`radosgw_s3_buckets`" + ` |
 | ` + "`oidc-provider=*`" + ` | ` + "`radosgw_iam_openid_connect_provider`" + ` |
-| ` + "`roles=*`" + ` | ` + "`radosgw_iam_role`" + `, ` + "`radosgw_iam_role_policy`" + `, ` + "`radosgw_iam_roles`" + ` |
-| ` + "`metadata=*`" + ` | ` + "`radosgw_iam_users`" + ` |
+| ` + "`roles=*`" + ` | ` + "`radosgw_iam_role`" + `, ` + "`radosgw_iam_role_policy`" + `, ` + "`radosgw_iam_role_policies_exclusive`" + `, ` + "`radosgw_iam_role_policy_attachment`" + `, ` + "`radosgw_iam_roles`" + ` |
+| ` + "`metadata=*`" + ` | ` + "`radosgw_iam_users`" + `, ` + "`radosgw_drift_marker`" + `, ` + "`radosgw_iam_mfa_device`" + `, ` + "`radosgw_iam_user_attributes`" + ` |
+| ` + "`user-policy=*`" + ` | ` + "`radosgw_iam_user_policy`" + `, ` + "`radosgw_iam_user_policy_attachment`" + `, ` + "`radosgw_iam_policy`" + ` |
+| ` + "`accounts=*`" + ` | ` + "`radosgw_iam_account`" + `, ` + "`radosgw_iam_account_quota`" + ` |
+| ` + "`info=read`" + ` | ` + "`radosgw_info`" + `, ` + "`radosgw_health`" + ` (optional for ` + "`radosgw_health`" + `, the Admin API is reported as reachable without it) |
+| ` + "`usage=read`" + ` | ` + "`radosgw_usage`" + ` |
+| ` + "`ratelimit=*`" + ` | ` + "`radosgw_ratelimit`" + ` |
+| ` + "`zone=read`" + ` | ` + "`radosgw_info`" + `, ` + "`radosgw_placement_targets`" + `, ` + "`radosgw_s3_bucket`" + `, ` + "`radosgw_s3_bucket_replication_status`" + ` (optional for ` + "`radosgw_s3_bucket`" + `, for ` + "`is_read_only`" + ` and ` + "`zone_is_master`" + ` and for explaining write errors on secondary zones) |
+| ` + "`bilog=read`" + ` | ` + "`radosgw_s3_bucket_replication_status`" + ` |
 
 To grant all required capabilities to a user:
 
 ` + "```bash" + `
-radosgw-admin caps add --uid=admin --caps="buckets=*;metadata=*;oidc-provider=*;roles=*;users=*"
+radosgw-admin caps add --uid=admin --caps="accounts=*;bilog=read;buckets=*;info=read;metadata=*;oidc-provider=*;ratelimit=*;roles=*;usage=read;user-policy=*;users=*;zone=read"
+` + "```" + `
+
+Alternatively, grant only ` + "`users=*`" + ` and let the ` + "`radosgw_admin_caps`" + ` resource grant the rest:
+
+` + "```bash" + `
+radosgw-admin caps add --uid=admin --caps="users=*"
 ` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "RadosGW endpoint URL. Can be set via the `RADOSGW_ENDPOINT` environment variable.",
+				MarkdownDescription: "RadosGW endpoint URL in the form `scheme://host[:port][/path]`, e.g. `https://rgw.example.com` or `http://[2001:db8::1]:7480`. IPv6 addresses must be enclosed in brackets. Conflicts with `endpoints`. Can be set via the `RADOSGW_ENDPOINT` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("endpoints")),
+				},
+			},
+			"endpoints": schema.ListAttribute{
+				MarkdownDescription: "RadosGW endpoint URLs of several gateways of the same cluster, in the same form as `endpoint`, for highly available deployments. Requests are spread over the endpoints in round-robin order; when the connection to an endpoint fails, the request is sent to the next endpoint and the failed one is skipped for 30 seconds, so that applies survive the restart of a single gateway. The endpoints must only differ by scheme, host and port. Conflicts with `endpoint`. Can be set via the `RADOSGW_ENDPOINTS` environment variable as a comma-separated list.",
 				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
 			},
 			"access_key": schema.StringAttribute{
 				MarkdownDescription: "RadosGW access key. Can be set via the `RADOSGW_ACCESS_KEY` environment variable.",
@@ -100,7 +198,135 @@ radosgw-admin caps add --uid=admin --caps="buckets=*;metadata=*;oidc-provider=*;
 				MarkdownDescription: "Path to a PEM-encoded root CA certificate file to use for TLS verification. Can be set via the `RADOSGW_ROOT_CA_CERTIFICATE_FILE` environment variable.",
 				Optional:            true,
 			},
+			"ceph_version": schema.StringAttribute{
+				MarkdownDescription: "The Ceph release of the cluster, as a name or major version, e.g. `squid` or `19`. By default the release is detected from the `Server` header returned by RadosGW, and resources reject operations the release does not support at plan time. Set it when the header is hidden by `rgw_server_header` or a proxy. Can be set via the `RADOSGW_CEPH_VERSION` environment variable.",
+				Optional:            true,
+			},
+			"max_concurrent_admin_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of Admin API requests in flight at once, across all resources. Lower it when large applies make RadosGW answer with `ConcurrentModification` or `503 Service Unavailable` errors. Admin API requests failing with these errors are retried with exponential backoff and jitter as configured by `max_retries` and `retry_max_backoff`. Can be set via the `RADOSGW_MAX_CONCURRENT_ADMIN_REQUESTS` environment variable. Default is unlimited.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of times a request failing with a retryable error is retried, for the Admin, IAM and S3 APIs. Set it to `0` to fail fast, e.g. in CI. Can be set via the `RADOSGW_MAX_RETRIES` environment variable. Default is `%d` for the Admin and IAM APIs and `%d` for the S3 API, the default of the AWS SDK.", defaultMaxRetries, awsretry.DefaultMaxAttempts-1),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The maximum delay between two attempts of a request as a Go duration string, e.g. `30s`. The delay grows exponentially up to this value. Can be set via the `RADOSGW_RETRY_MAX_BACKOFF` environment variable. Default is `%s` for the Admin and IAM APIs and `%s` for the S3 API, the default of the AWS SDK.", defaultRetryMaxBackoff, awsretry.DefaultMaxBackoff),
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The timeout of every single attempt of a request as a Go duration string, e.g. `2m`. Raise it for slow multisite clusters. Can be set via the `RADOSGW_REQUEST_TIMEOUT` environment variable. Default is no timeout.",
+				Optional:            true,
+			},
+			"strict_bucket_names": schema.BoolAttribute{
+				MarkdownDescription: "Whether bucket names are validated against the DNS compliant naming rules RadosGW enforces by default: 3 to 63 lowercase letters, numbers, hyphens and periods, starting and ending with a letter or number. Set it to `false` when the cluster runs with `rgw_relaxed_s3_bucket_names` enabled, to allow up to 255 letters of any case, numbers, hyphens, underscores and periods. Can be set via the `RADOSGW_STRICT_BUCKET_NAMES` environment variable. Default is `true`.",
+				Optional:            true,
+			},
+			"s3_addressing_style": schema.StringAttribute{
+				MarkdownDescription: "The addressing style of S3 requests. Valid values: `path` (default), which puts the bucket in the URL path (`https://rgw.example.com/bucket`), and `virtual`, which puts it in the host name (`https://bucket.rgw.example.com`). Use `virtual` for clusters fronted by wildcard DNS and configured with `rgw_dns_name`, for example when bucket policies rely on `aws:Referer` conditions. Virtual-host style requires DNS compliant bucket names and cannot address buckets of other tenants. Resources that accept `s3_access_key` can override it with their own `s3_addressing_style`. Can be set via the `RADOSGW_S3_ADDRESSING_STYLE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(s3AddressingStylePath, s3AddressingStyleVirtual),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The SigV4 region S3, IAM, STS and SNS requests are signed for, typically the name of the zonegroup (`rgw_zonegroup`) for clusters that check the region of signatures. By default S3 requests are signed for the `default` region and IAM, STS and SNS requests for an empty region. Admin API requests are always signed for the `default` region. Can be set via the `RADOSGW_REGION` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"signing_name": schema.StringAttribute{
+				MarkdownDescription: "The SigV4 signing name (service) S3, IAM, STS and SNS requests are signed with. Only change it for gateways behind a proxy that expects a different signing name. Can be set via the `RADOSGW_SIGNING_NAME` environment variable. Default is `" + defaultSigningName + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.ListNestedBlock{
+				MarkdownDescription: "Tags applied to every resource of the provider managing tags, currently " +
+					"`radosgw_s3_object_tagging`. The tags of a resource take precedence over default tags with the same key. " +
+					"The merged tags are exposed in the `tags_all` attribute of the resources.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Map of tag key to value.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"assume_role": schema.ListNestedBlock{
+				MarkdownDescription: "Assume a role with STS `AssumeRole` and use the temporary credentials for all S3 and IAM " +
+					"calls. The configured `access_key` and `secret_key` are only used to assume the role and for Admin API " +
+					"calls, which RadosGW authorizes through user capabilities that role sessions do not carry.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"role_arn": schema.StringAttribute{
+							MarkdownDescription: "The ARN of the role to assume.",
+							Required:            true,
+						},
+						"session_name": schema.StringAttribute{
+							MarkdownDescription: "The session name to use when assuming the role. Default is `" + defaultAssumeRoleSessionName + "`.",
+							Optional:            true,
+						},
+						"external_id": schema.StringAttribute{
+							MarkdownDescription: "The external ID to pass when assuming the role, if the trust policy requires one.",
+							Optional:            true,
+						},
+						"duration": schema.StringAttribute{
+							MarkdownDescription: "The duration of the role session as a Go duration string, e.g. `1h` or `30m`. " +
+								"Must be between `15m` and the role's `max_session_duration`. Defaults to one hour on the RadosGW side. " +
+								"The provider assumes the role again shortly before the session expires, so applies may run longer.",
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *RadosgwProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var assumeRole types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("assume_role"), &assumeRole)...)
+	if resp.Diagnostics.HasError() || assumeRole.IsNull() || assumeRole.IsUnknown() {
+		return
+	}
+
+	var assumeRoles []ProviderAssumeRoleModel
+	resp.Diagnostics.Append(assumeRole.ElementsAs(ctx, &assumeRoles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, role := range assumeRoles {
+		if role.Duration.IsNull() || role.Duration.IsUnknown() || role.Duration.ValueString() == "" {
+			continue
+		}
+		if _, err := parseAssumeRoleDuration(role.Duration.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("assume_role").AtListIndex(i).AtName("duration"),
+				"Invalid Assume Role Duration",
+				fmt.Sprintf("The duration of the role session must be a Go duration of at least 15m such as 1h: %s.", err.Error()),
+			)
+		}
 	}
 }
 
@@ -115,15 +341,56 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	// Check environment variables
 	endpoint := os.Getenv("RADOSGW_ENDPOINT")
+	var endpoints []string
+	if v := os.Getenv("RADOSGW_ENDPOINTS"); v != "" {
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
 	accessKey := os.Getenv("RADOSGW_ACCESS_KEY")
 	secretKey := os.Getenv("RADOSGW_SECRET_KEY")
 	tlsInsecureSkipVerify := os.Getenv("RADOSGW_TLS_INSECURE_SKIP_VERIFY") == "true"
 	rootCACertificate := os.Getenv("RADOSGW_ROOT_CA_CERTIFICATE")
 	rootCACertificateFile := os.Getenv("RADOSGW_ROOT_CA_CERTIFICATE_FILE")
+	cephVersion := os.Getenv("RADOSGW_CEPH_VERSION")
+	maxConcurrentAdminRequests := int64(0)
+	if v := os.Getenv("RADOSGW_MAX_CONCURRENT_ADMIN_REQUESTS"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_admin_requests"),
+				"Invalid Maximum Concurrent Admin Requests",
+				fmt.Sprintf("The RADOSGW_MAX_CONCURRENT_ADMIN_REQUESTS environment variable must be a positive integer, got %q.", v),
+			)
+			return
+		}
+		maxConcurrentAdminRequests = parsed
+	}
+	maxRetries := os.Getenv("RADOSGW_MAX_RETRIES")
+	retryMaxBackoff := os.Getenv("RADOSGW_RETRY_MAX_BACKOFF")
+	requestTimeout := os.Getenv("RADOSGW_REQUEST_TIMEOUT")
+	strictBucketNames := os.Getenv("RADOSGW_STRICT_BUCKET_NAMES") != "false"
+	s3AddressingStyle := os.Getenv("RADOSGW_S3_ADDRESSING_STYLE")
+	region := os.Getenv("RADOSGW_REGION")
+	signingName := os.Getenv("RADOSGW_SIGNING_NAME")
 
 	// Override with config values if provided
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
+		endpoints = nil
+	}
+	if !config.Endpoints.IsNull() {
+		resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if len(endpoints) > 0 {
+		endpoint = endpoints[0]
+	} else {
+		endpoints = []string{endpoint}
 	}
 	if !config.AccessKey.IsNull() {
 		accessKey = config.AccessKey.ValueString()
@@ -140,6 +407,36 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 	if !config.RootCACertificateFile.IsNull() {
 		rootCACertificateFile = config.RootCACertificateFile.ValueString()
 	}
+	if !config.CephVersion.IsNull() {
+		cephVersion = config.CephVersion.ValueString()
+	}
+	if !config.MaxConcurrentAdminRequests.IsNull() {
+		maxConcurrentAdminRequests = config.MaxConcurrentAdminRequests.ValueInt64()
+	}
+	if !config.MaxRetries.IsNull() {
+		maxRetries = strconv.FormatInt(config.MaxRetries.ValueInt64(), 10)
+	}
+	if !config.RetryMaxBackoff.IsNull() {
+		retryMaxBackoff = config.RetryMaxBackoff.ValueString()
+	}
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = config.RequestTimeout.ValueString()
+	}
+	if !config.StrictBucketNames.IsNull() {
+		strictBucketNames = config.StrictBucketNames.ValueBool()
+	}
+	if !config.S3AddressingStyle.IsNull() {
+		s3AddressingStyle = config.S3AddressingStyle.ValueString()
+	}
+	if !config.Region.IsNull() {
+		region = config.Region.ValueString()
+	}
+	if !config.SigningName.IsNull() {
+		signingName = config.SigningName.ValueString()
+	}
+	if signingName == "" {
+		signingName = defaultSigningName
+	}
 
 	// Validate required fields
 	if endpoint == "" {
@@ -172,10 +469,52 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if s3AddressingStyle == "" {
+		s3AddressingStyle = s3AddressingStylePath
+	}
+	if s3AddressingStyle != s3AddressingStylePath && s3AddressingStyle != s3AddressingStyleVirtual {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("s3_addressing_style"),
+			"Invalid S3 Addressing Style",
+			fmt.Sprintf("The RADOSGW_S3_ADDRESSING_STYLE environment variable must be %q or %q, got %q.",
+				s3AddressingStylePath, s3AddressingStyleVirtual, s3AddressingStyle),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retry, diags := parseRetryConfig(maxRetries, retryMaxBackoff, requestTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	for i, e := range endpoints {
+		normalizedEndpoint, err := normalizeEndpoint(e)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid RadosGW Endpoint",
+				fmt.Sprintf("The RadosGW endpoint %q is not a valid URL: %s. "+
+					"The endpoint must have the form scheme://host[:port][/path], for example "+
+					"https://rgw.example.com, http://10.0.0.1:7480 or http://[2001:db8::1]:7480.", e, err.Error()),
+			)
+			return
+		}
+		endpoints[i] = normalizedEndpoint
+	}
+	if err := checkEndpointPaths(endpoints); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Invalid RadosGW Endpoints",
+			err.Error(),
+		)
+		return
+	}
+	endpoint = endpoints[0]
+
 	ctx = tflog.SetField(ctx, "radosgw_endpoint", endpoint)
 	ctx = tflog.SetField(ctx, "radosgw_access_key", accessKey)
 	ctx = tflog.SetField(ctx, "radosgw_secret_key", secretKey)
@@ -231,17 +570,46 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	// Create custom HTTP transport with TLS config
-	httpTransport := &http.Transport{
+	var httpTransport http.RoundTripper = &http.Transport{
 		TLSClientConfig: tlsConfig,
 	}
 
-	// Create custom HTTP client
+	// Spread the requests of all clients over the gateways
+	if len(endpoints) > 1 {
+		failover, err := newFailoverTransport(httpTransport, endpoints)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoints"),
+				"Invalid RadosGW Endpoints",
+				err.Error(),
+			)
+			return
+		}
+		httpTransport = failover
+		tflog.Debug(ctx, "Using endpoint failover", map[string]any{
+			"endpoints": endpoints,
+		})
+	}
+
+	// Create custom HTTP client, the S3 client retries on its own
 	httpClient := &http.Client{
 		Transport: httpTransport,
+		Timeout:   retry.RequestTimeout,
+	}
+
+	// The Admin API clients share a throttled transport that also retries
+	// ConcurrentModification and overload errors
+	adminHTTPClient := &http.Client{
+		Transport: newRetryTransport(httpTransport, int(maxConcurrentAdminRequests), retry),
+	}
+
+	// The IAM client retries the same errors without throttling
+	iamHTTPClient := &http.Client{
+		Transport: newRetryTransport(httpTransport, 0, retry),
 	}
 
 	// Create Admin API client
-	adminClient, err := admin.New(endpoint, accessKey, secretKey, httpClient)
+	adminClient, err := admin.New(endpoint, accessKey, secretKey, adminHTTPClient)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create RadosGW Admin API Client",
@@ -252,23 +620,109 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Create IAM client, which also performs STS calls
+	iamClient := NewIAMClient(endpoint, accessKey, secretKey, iamHTTPClient)
+	iamClient.Region = region
+	iamClient.SigningName = signingName
+
+	// Switch the S3 and IAM clients to temporary role credentials, which are
+	// refreshed by assuming the role again before they expire
+	var s3Credentials aws.CredentialsProvider = credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
+	if len(config.AssumeRole) > 0 {
+		roleCredentials := newAssumeRoleCredentials(iamClient, config.AssumeRole[0])
+		creds, err := roleCredentials.Retrieve(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("assume_role"),
+				"Unable to Assume Role",
+				fmt.Sprintf("The provider could not assume role %s: %s", config.AssumeRole[0].RoleArn.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		ctx = tflog.SetField(ctx, "radosgw_session_token", creds.SessionToken)
+		ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "radosgw_session_token")
+		tflog.Debug(ctx, "Assumed role for S3 and IAM calls", map[string]any{
+			"role_arn":   config.AssumeRole[0].RoleArn.ValueString(),
+			"expiration": creds.Expires,
+		})
+
+		s3Credentials = roleCredentials
+		iamClient = NewIAMClient(endpoint, "", "", iamHTTPClient)
+		iamClient.Credentials = roleCredentials
+		iamClient.Region = region
+		iamClient.SigningName = signingName
+	}
+
 	// Create S3 client with custom endpoint and HTTP client
+	s3Region := region
+	if s3Region == "" {
+		s3Region = defaultS3Region
+	}
 	s3Client := s3.NewFromConfig(aws.Config{
-		Region:      "default",
-		Credentials: credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+		Region:      s3Region,
+		Credentials: s3Credentials,
 		HTTPClient:  httpClient,
+		Retryer:     newS3Retryer(maxRetries, retryMaxBackoff, retry),
 	}, func(o *s3.Options) {
 		o.BaseEndpoint = &endpoint
-		o.UsePathStyle = true
+		o.UsePathStyle = s3AddressingStyle == s3AddressingStylePath
+		if signingName != defaultSigningName {
+			o.HTTPSignerV4 = signingNameSigner{HTTPSignerV4: o.HTTPSignerV4, signingName: signingName}
+		}
+	})
+
+	// Detect the Ceph release once, so that resources can reject or work
+	// around unsupported operations at plan time
+	version := CephVersion_Unknown
+	if cephVersion != "" {
+		version = parseCephVersion(cephVersion)
+		if version == CephVersion_Unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ceph_version"),
+				"Invalid Ceph Version",
+				fmt.Sprintf("The Ceph version %q is neither a known release name nor a major version number.", cephVersion),
+			)
+			return
+		}
+	} else {
+		release, err := detectCephRelease(ctx, httpClient, endpoint)
+		if err != nil {
+			tflog.Warn(ctx, "Could not detect the Ceph release, version checks are disabled", map[string]any{
+				"error": err.Error(),
+			})
+		}
+		version = parseCephVersion(release)
+	}
+
+	tflog.Debug(ctx, "Using Ceph version", map[string]any{
+		"ceph_version": version.String(),
 	})
 
 	client := &RadosgwClient{
-		Admin: adminClient,
-		S3:    s3Client,
+		Admin:             adminClient,
+		S3:                s3Client,
+		IAM:               iamClient,
+		CephVersion:       version,
+		StrictBucketNames: strictBucketNames,
+	}
+
+	if len(config.DefaultTags) > 0 {
+		defaultTags := config.DefaultTags[0].Tags
+		if defaultTags.IsUnknown() || slices.ContainsFunc(slices.Collect(maps.Values(defaultTags.Elements())), attr.Value.IsUnknown) {
+			client.DefaultTagsUnknown = true
+		} else {
+			client.DefaultTags = map[string]string{}
+			resp.Diagnostics.Append(defaultTags.ElementsAs(ctx, &client.DefaultTags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 
 	tflog.Info(ctx, "Configured RadosGW provider", map[string]any{
 		"success": true,
@@ -278,20 +732,43 @@ func (p *RadosgwProvider) Configure(ctx context.Context, req provider.ConfigureR
 func (p *RadosgwProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewIAMUserResource,
+		NewIAMAccountResource,
+		NewIAMAccountQuotaResource,
 		NewIAMQuotaResource,
+		NewRatelimitResource,
 		NewIAMUserCapsResource,
+		NewAdminCapsResource,
 		NewIAMSubuserResource,
+		NewIAMSubusersResource,
+		NewIAMMFADeviceResource,
+		NewIAMUserAttributesResource,
 		NewIAMOIDCProviderResource,
 		NewIAMAcessKeyResource,
 		NewIAMRoleResource,
 		NewIAMRolePolicyResource,
+		NewIAMRolePoliciesExclusiveResource,
+		NewIAMRolePolicyAttachmentResource,
+		NewIAMUserPolicyResource,
+		NewIAMUserPolicyAttachmentResource,
+		NewIAMPolicyResource,
+		NewIAMGroupResource,
+		NewIAMGroupMembershipResource,
+		NewIAMGroupPolicyResource,
 		NewS3BucketLinkResource,
+		NewS3BucketBulkLinkResource,
+		NewAdminRawResource,
 		NewS3BucketResource,
 		NewS3BucketAclResource,
 		NewS3BucketNotificationResource,
 		NewS3BucketPolicyResource,
+		NewS3BucketOwnershipControlsResource,
+		NewS3BucketServerSideEncryptionConfigurationResource,
+		NewSwiftTempURLKeyResource,
+		NewS3BucketLoggingResource,
 		NewS3BucketLifecycleResource,
 		NewS3BucketWebsiteConfigurationResource,
+		NewS3BucketQuotaResource,
+		NewS3ObjectTaggingResource,
 		NewSNSTopicResource,
 		NewSNSTopicPolicyResource,
 	}
@@ -300,18 +777,54 @@ func (p *RadosgwProvider) Resources(ctx context.Context) []func() resource.Resou
 func (p *RadosgwProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewIAMPolicyDocumentDataSource,
+		NewARNDataSource,
 		NewIAMOIDCProviderDataSource,
+		NewIAMOIDCThumbprintDataSource,
 		NewIAMUserDataSource,
 		NewIAMUsersDataSource,
+		NewIAMUsersDetailDataSource,
+		NewIAMUserTotalsDataSource,
 		NewIAMRoleDataSource,
 		NewIAMRolesDataSource,
 		NewIAMAccessKeysDataSource,
 		NewIAMUserCapsDataSource,
+		NewIAMUsersWithCapsDataSource,
 		NewIAMSubusersDataSource,
 		NewIAMQuotaDataSource,
 		NewS3BucketDataSource,
+		NewS3BucketsDataSource,
+		NewS3BucketObjectsDataSource,
+		NewS3BucketMultipartUploadsDataSource,
+		NewS3BucketReplicationStatusDataSource,
+		NewS3BucketStorageClassAnalysisDataSource,
 		NewS3BucketPolicyDataSource,
+		NewS3BucketNotificationDataSource,
+		NewS3BucketGovernanceBypassDataSource,
+		NewSTSCallerIdentityDataSource,
+		NewSTSAssumeRoleDataSource,
 		NewSNSTopicDataSource,
+		NewSNSTopicsDataSource,
+		NewDriftMarkerDataSource,
+		NewHealthDataSource,
+		NewUsageDataSource,
+		NewInfoDataSource,
+		NewPlacementTargetsDataSource,
+		NewAdminRawDataSource,
+	}
+}
+
+func (p *RadosgwProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSTSSessionEphemeralResource,
+		NewS3PresignedURLEphemeralResource,
+	}
+}
+
+func (p *RadosgwProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseARNFunction,
+		NewNormalizePolicyFunction,
+		NewTenantBucketNameFunction,
 	}
 }
 
@@ -322,3 +835,219 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// assumeRoleExpiryWindow is how long before their expiration the temporary
+// credentials of the provider assume_role block are refreshed, so that
+// requests signed just before the expiration still succeed.
+const assumeRoleExpiryWindow = 5 * time.Minute
+
+// newAssumeRoleCredentials returns a credentials provider for the provider
+// assume_role block. It assumes the role with iamClient on first use and
+// again shortly before the temporary credentials expire, so that applies
+// running longer than the role session keep working.
+func newAssumeRoleCredentials(iamClient *IAMClient, assumeRole ProviderAssumeRoleModel) *aws.CredentialsCache {
+	return aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		creds, err := assumeProviderRole(ctx, iamClient, assumeRole)
+		if err != nil {
+			return aws.Credentials{}, err
+		}
+
+		result := aws.Credentials{
+			AccessKeyID:     creds.AccessKeyId,
+			SecretAccessKey: creds.SecretAccessKey,
+			SessionToken:    creds.SessionToken,
+			Source:          "AssumeRole",
+		}
+		if expires, err := time.Parse(time.RFC3339, creds.Expiration); err == nil {
+			result.CanExpire = true
+			result.Expires = expires
+		}
+		return result, nil
+	}), func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = assumeRoleExpiryWindow
+	})
+}
+
+// parseAssumeRoleDuration parses the duration of the provider assume_role
+// block, which STS requires to be at least 15 minutes.
+func parseAssumeRoleDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	if duration < 15*time.Minute {
+		return 0, fmt.Errorf("duration must be at least 15m, got %s", duration)
+	}
+	return duration, nil
+}
+
+// assumeProviderRole calls STS AssumeRole for the provider assume_role block
+// and returns the temporary credentials.
+func assumeProviderRole(ctx context.Context, iamClient *IAMClient, assumeRole ProviderAssumeRoleModel) (stsCredentialsXML, error) {
+	sessionName := defaultAssumeRoleSessionName
+	if !assumeRole.SessionName.IsNull() && assumeRole.SessionName.ValueString() != "" {
+		sessionName = assumeRole.SessionName.ValueString()
+	}
+
+	params := url.Values{}
+	params.Set("Action", "AssumeRole")
+	params.Set("Version", "2011-06-15")
+	params.Set("RoleArn", assumeRole.RoleArn.ValueString())
+	params.Set("RoleSessionName", sessionName)
+	if !assumeRole.ExternalID.IsNull() && assumeRole.ExternalID.ValueString() != "" {
+		params.Set("ExternalId", assumeRole.ExternalID.ValueString())
+	}
+	if !assumeRole.Duration.IsNull() && assumeRole.Duration.ValueString() != "" {
+		duration, err := parseAssumeRoleDuration(assumeRole.Duration.ValueString())
+		if err != nil {
+			return stsCredentialsXML{}, err
+		}
+		params.Set("DurationSeconds", strconv.FormatInt(int64(duration.Seconds()), 10))
+	}
+
+	body, err := iamClient.DoRequest(ctx, params, "sts")
+	if err != nil {
+		return stsCredentialsXML{}, err
+	}
+
+	var response assumeRoleResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return stsCredentialsXML{}, fmt.Errorf("could not parse AssumeRole response: %w", err)
+	}
+
+	return response.Result.Credentials, nil
+}
+
+// newS3Retryer returns the retryer of the S3 client. The defaults of the SDK
+// standard retryer are kept unless max_retries or retry_max_backoff is set.
+func newS3Retryer(maxRetries, retryMaxBackoff string, retry retryConfig) func() aws.Retryer {
+	return func() aws.Retryer {
+		return awsretry.NewStandard(func(o *awsretry.StandardOptions) {
+			if maxRetries != "" {
+				o.MaxAttempts = retry.MaxRetries + 1
+			}
+			if retryMaxBackoff != "" {
+				o.MaxBackoff = retry.MaxBackoff
+			}
+		})
+	}
+}
+
+// parseRetryConfig parses the retry settings of the provider, applying the
+// defaults for empty values.
+func parseRetryConfig(maxRetries, retryMaxBackoff, requestTimeout string) (retryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	retry := retryConfig{
+		MaxRetries: defaultMaxRetries,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+
+	if maxRetries != "" {
+		parsed, err := strconv.Atoi(maxRetries)
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Maximum Retries",
+				fmt.Sprintf("The maximum number of retries must be a non-negative integer, got %q.", maxRetries),
+			)
+		}
+		retry.MaxRetries = parsed
+	}
+
+	if retryMaxBackoff != "" {
+		parsed, err := time.ParseDuration(retryMaxBackoff)
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid Retry Maximum Backoff",
+				fmt.Sprintf("The maximum retry backoff must be a positive Go duration such as 30s, got %q.", retryMaxBackoff),
+			)
+		}
+		retry.MaxBackoff = parsed
+	}
+
+	if requestTimeout != "" {
+		parsed, err := time.ParseDuration(requestTimeout)
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request timeout must be a positive Go duration such as 2m, got %q.", requestTimeout),
+			)
+		}
+		retry.RequestTimeout = parsed
+	}
+
+	return retry, diags
+}
+
+// checkEndpointPaths checks that normalized endpoints only differ by scheme
+// and host, as requests are signed for the first endpoint.
+func checkEndpointPaths(endpoints []string) error {
+	first, err := url.Parse(endpoints[0])
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints[1:] {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return err
+		}
+		if u.Path != first.Path {
+			return fmt.Errorf("all endpoints must have the same path, got %q for %s and %q for %s",
+				first.Path, endpoints[0], u.Path, endpoint)
+		}
+	}
+	return nil
+}
+
+// normalizeEndpoint validates the endpoint URL and returns it in the form
+// expected by the Admin and S3 clients: scheme://host[:port][/path] without a
+// trailing slash. IPv6 literal hosts must be enclosed in brackets.
+func normalizeEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return "", fmt.Errorf("host is missing")
+	}
+
+	// url.Parse accepts unbracketed IPv6 addresses and treats the last
+	// segment as the port, so reject them explicitly
+	if strings.Contains(host, ":") && !strings.HasPrefix(u.Host, "[") {
+		return "", fmt.Errorf("IPv6 addresses must be enclosed in brackets, e.g. [%s]", host)
+	}
+	if strings.HasPrefix(u.Host, "[") && net.ParseIP(host) == nil {
+		return "", fmt.Errorf("%q is not a valid IPv6 address", host)
+	}
+
+	if port := u.Port(); port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("port must be between 1 and 65535, got %q", port)
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return "", fmt.Errorf("port is empty")
+	}
+
+	if u.User != nil {
+		return "", fmt.Errorf("credentials must not be part of the URL, use access_key and secret_key instead")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("query parameters and fragments are not allowed")
+	}
+
+	normalized := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   strings.TrimRight(u.Path, "/"),
+	}
+	return normalized.String(), nil
+}
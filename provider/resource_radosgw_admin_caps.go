@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AdminCapsResource{}
+var _ resource.ResourceWithModifyPlan = &AdminCapsResource{}
+
+func NewAdminCapsResource() resource.Resource {
+	return &AdminCapsResource{}
+}
+
+// AdminCapsResource grants capabilities to the user of the provider
+// credentials.
+type AdminCapsResource struct {
+	client *RadosgwClient
+}
+
+// AdminCapsResourceModel describes the resource data model.
+type AdminCapsResourceModel struct {
+	Caps        types.Set    `tfsdk:"caps"`
+	UserID      types.String `tfsdk:"user_id"`
+	UserCaps    types.Set    `tfsdk:"user_caps"`
+	MissingCaps types.Set    `tfsdk:"missing_caps"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// providerRequiredCaps are the capabilities needed by all resources and data
+// sources of the provider, as listed in the provider documentation.
+var providerRequiredCaps = []admin.UserCapSpec{
+	{Type: "accounts", Perm: "*"},
+	{Type: "bilog", Perm: "read"},
+	{Type: "buckets", Perm: "*"},
+	{Type: "info", Perm: "read"},
+	{Type: "metadata", Perm: "*"},
+	{Type: "oidc-provider", Perm: "*"},
+	{Type: "ratelimit", Perm: "*"},
+	{Type: "roles", Perm: "*"},
+	{Type: "usage", Perm: "read"},
+	{Type: "user-policy", Perm: "*"},
+	{Type: "users", Perm: "*"},
+	{Type: "zone", Perm: "read"},
+}
+
+// capObjectType is the type of a single capability in a caps set.
+var capObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type": types.StringType,
+	"perm": types.StringType,
+}}
+
+func (r *AdminCapsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_caps"
+}
+
+func (r *AdminCapsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	capAttributes := map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The capability type.",
+			Computed:            true,
+		},
+		"perm": schema.StringAttribute{
+			MarkdownDescription: "The permission level: `*`, `read` or `write`.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Grants capabilities to the RadosGW user of the provider credentials, so that a new cluster can be bootstrapped without running ` + "`radosgw-admin caps add`" + ` for every capability the provider needs.
+
+The user is looked up by the provider access key. Capabilities it lacks are added; capabilities it already holds, including broader ones such as ` + "`*`" + ` where ` + "`read`" + ` is requested, are left untouched. When a capability is removed out-of-band, the next plan shows an update that grants it again.
+
+~> **Important:** Granting capabilities requires the ` + "`users=write`" + ` capability. Grant it once with ` + "`radosgw-admin caps add --uid=<user> --caps=\"users=*\"`" + `, then let this resource grant the rest.
+
+~> **Note:** Destroying this resource removes it from the state only. The capabilities are kept, as revoking them could lock the provider out of the cluster; remove them with ` + "`radosgw-admin caps rm`" + ` if needed.`,
+
+		Attributes: map[string]schema.Attribute{
+			"caps": schema.SetNestedAttribute{
+				MarkdownDescription: "The capabilities the provider user must hold. Defaults to all capabilities required by " +
+					"the provider, listed in the provider documentation, except those the detected Ceph release does not " +
+					"support (`accounts` before Squid).",
+				Optional: true,
+				Validators: []validator.Set{
+					uniqueCapTypesValidator{},
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The capability type. Valid values: `users`, `buckets`, `metadata`, `usage`, `zone`, `info`, `accounts`, `ratelimit`, `roles`, `user-policy`, `amz-cache`, `oidc-provider`, `bilog`, `mdlog`, `datalog`, `user-info-without-keys`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(validCapTypes...),
+							},
+						},
+						"perm": schema.StringAttribute{
+							MarkdownDescription: "The permission level. Valid values: `*` (full access), `read`, `write`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(validPerms...),
+							},
+						},
+					},
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the provider access key. Users in a tenant use the format `tenant$user_id`.",
+				Computed:            true,
+			},
+			"user_caps": schema.SetNestedAttribute{
+				MarkdownDescription: "All capabilities held by the provider user, including those not managed by this resource.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: capAttributes,
+				},
+			},
+			"missing_caps": schema.SetNestedAttribute{
+				MarkdownDescription: "The requested capabilities the provider user did not hold when last read. Empty after an apply.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: capAttributes,
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the provider user.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *AdminCapsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AdminCapsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AdminCapsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Capability types of newer releases are only checked when requested
+	// explicitly; the default set leaves them out
+	for _, c := range r.desiredCaps(ctx, plan.Caps, &resp.Diagnostics) {
+		if feature, ok := capTypeFeatures[c.Type]; ok && !r.client.supportsFeature(feature) {
+			r.client.addCephFeatureError(&resp.Diagnostics, fmt.Sprintf("The %q capability type", c.Type), feature)
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state AdminCapsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Capabilities removed out-of-band are granted again by an update
+	if len(state.MissingCaps.Elements()) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("missing_caps"), types.SetValueMust(capObjectType, []attr.Value{}))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_caps"), types.SetUnknown(capObjectType))...)
+	}
+}
+
+func (r *AdminCapsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AdminCapsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.grantCaps(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AdminCapsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AdminCapsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.providerUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Provider User",
+			fmt.Sprintf("Could not look up the user of the provider access key: %s", err.Error()),
+		)
+		return
+	}
+
+	desired := r.desiredCaps(ctx, state.Caps, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setUserCaps(ctx, &state, user, user.Caps, desired, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *AdminCapsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AdminCapsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.grantCaps(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AdminCapsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AdminCapsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoking the capabilities could lock the provider out of the cluster
+	tflog.Info(ctx, "Removed admin caps from state, the capabilities of the provider user are kept", map[string]any{
+		"user_id": state.UserID.ValueString(),
+	})
+}
+
+// grantCaps adds the requested capabilities the provider user lacks, and
+// stores the resulting capabilities in the model.
+func (r *AdminCapsResource) grantCaps(ctx context.Context, data *AdminCapsResourceModel, diags *diag.Diagnostics) {
+	desired := r.desiredCaps(ctx, data.Caps, diags)
+	if diags.HasError() {
+		return
+	}
+
+	user, err := r.providerUser(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Provider User",
+			fmt.Sprintf("Could not look up the user of the provider access key: %s", err.Error()),
+		)
+		return
+	}
+
+	userID := buildFullUserID(user.ID, user.Tenant)
+	held := user.Caps
+
+	missing := missingCaps(held, desired)
+	if len(missing) > 0 {
+		tflog.Debug(ctx, "Granting capabilities to provider user", map[string]any{
+			"user_id": userID,
+			"caps":    capSpecsToString(missing),
+		})
+
+		unlock := userLocks.lock(userID)
+		held, err = r.client.Admin.AddUserCap(ctx, userID, capSpecsToString(missing))
+		unlock()
+		if err != nil {
+			diags.AddError(
+				"Error Granting Capabilities",
+				fmt.Sprintf("Could not grant %s to user %s: %s. Granting capabilities requires the users=write capability, "+
+					"grant it with: radosgw-admin caps add --uid=%s --caps=\"users=*\"",
+					capSpecsToString(missing), userID, err.Error(), userID),
+			)
+			return
+		}
+	}
+
+	r.setUserCaps(ctx, data, user, held, desired, diags)
+}
+
+// setUserCaps stores the capabilities held by the provider user in the model.
+func (r *AdminCapsResource) setUserCaps(ctx context.Context, data *AdminCapsResourceModel, user admin.User, held, desired []admin.UserCapSpec, diags *diag.Diagnostics) {
+	userCaps, d := types.SetValueFrom(ctx, capObjectType, capSpecsToModels(held))
+	diags.Append(d...)
+	missing, d := types.SetValueFrom(ctx, capObjectType, capSpecsToModels(missingCaps(held, desired)))
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	userID := buildFullUserID(user.ID, user.Tenant)
+	data.UserID = types.StringValue(userID)
+	data.UserCaps = userCaps
+	data.MissingCaps = missing
+	data.ID = types.StringValue(userID)
+}
+
+// providerUser returns the user owning the provider access key.
+func (r *AdminCapsResource) providerUser(ctx context.Context) (admin.User, error) {
+	return r.client.Admin.GetUser(ctx, admin.User{
+		Keys: []admin.UserKeySpec{{AccessKey: r.client.Admin.AccessKey}},
+	})
+}
+
+// desiredCaps returns the configured capabilities, or the capabilities
+// required by the provider that the Ceph release supports.
+func (r *AdminCapsResource) desiredCaps(ctx context.Context, caps types.Set, diags *diag.Diagnostics) []admin.UserCapSpec {
+	if caps.IsNull() {
+		var desired []admin.UserCapSpec
+		for _, c := range providerRequiredCaps {
+			if feature, ok := capTypeFeatures[c.Type]; ok && !r.client.supportsFeature(feature) {
+				continue
+			}
+			desired = append(desired, c)
+		}
+		return desired
+	}
+	if caps.IsUnknown() {
+		return nil
+	}
+
+	var capModels []CapModel
+	diags.Append(caps.ElementsAs(ctx, &capModels, false)...)
+
+	desired := make([]admin.UserCapSpec, 0, len(capModels))
+	for _, c := range capModels {
+		desired = append(desired, admin.UserCapSpec{Type: c.Type.ValueString(), Perm: c.Perm.ValueString()})
+	}
+	return desired
+}
+
+// missingCaps returns the capabilities of desired that held does not grant.
+func missingCaps(held, desired []admin.UserCapSpec) []admin.UserCapSpec {
+	var missing []admin.UserCapSpec
+	for _, c := range desired {
+		if _, ok := userCapPerm(held, c.Type, c.Perm); !ok {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// capSpecsToString formats capabilities as accepted by the Admin API, for
+// example "buckets=*;users=read".
+func capSpecsToString(caps []admin.UserCapSpec) string {
+	parts := make([]string, 0, len(caps))
+	for _, c := range caps {
+		parts = append(parts, c.Type+"="+c.Perm)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+func capSpecsToModels(caps []admin.UserCapSpec) []CapModel {
+	models := make([]CapModel, 0, len(caps))
+	for _, c := range caps {
+		models = append(models, CapModel{Type: types.StringValue(c.Type), Perm: types.StringValue(c.Perm)})
+	}
+	return models
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMissingCaps(t *testing.T) {
+	t.Parallel()
+
+	held := []admin.UserCapSpec{
+		{Type: "users", Perm: "*"},
+		{Type: "buckets", Perm: "read"},
+	}
+
+	testCases := map[string]struct {
+		desired       []admin.UserCapSpec
+		expectMissing string
+	}{
+		"none":              {},
+		"held":              {desired: []admin.UserCapSpec{{Type: "buckets", Perm: "read"}}},
+		"covered by full":   {desired: []admin.UserCapSpec{{Type: "users", Perm: "read"}}},
+		"broader than held": {desired: []admin.UserCapSpec{{Type: "buckets", Perm: "*"}}, expectMissing: "buckets=*"},
+		"missing type": {
+			desired:       []admin.UserCapSpec{{Type: "zone", Perm: "read"}, {Type: "info", Perm: "read"}, {Type: "users", Perm: "*"}},
+			expectMissing: "info=read;zone=read",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			missing := capSpecsToString(missingCaps(held, testCase.desired))
+			if missing != testCase.expectMissing {
+				t.Errorf("expected missing caps %q, got %q", testCase.expectMissing, missing)
+			}
+		})
+	}
+}
+
+func TestAdminCapsModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cephVersion       CephVersion
+		capType           string
+		update            bool
+		stateMissing      string
+		expectError       bool
+		expectUnknownCaps bool
+	}{
+		"default on reef":   {cephVersion: CephVersion_Reef},
+		"accounts on reef":  {cephVersion: CephVersion_Reef, capType: "accounts", expectError: true},
+		"accounts on squid": {cephVersion: CephVersion_Squid, capType: "accounts"},
+		"no drift":          {cephVersion: CephVersion_Squid, update: true},
+		"drift":             {cephVersion: CephVersion_Squid, update: true, stateMissing: "zone=read", expectUnknownCaps: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &AdminCapsResource{client: &RadosgwClient{CephVersion: testCase.cephVersion}}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(testCtx, fwresource.SchemaRequest{}, &schemaResp)
+
+			userCaps, err := stringToCaps(testCtx, "users=*")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			emptyCaps := types.SetValueMust(capObjectType, nil)
+
+			model := AdminCapsResourceModel{
+				Caps:        types.SetNull(capObjectType),
+				UserID:      types.StringValue("admin"),
+				UserCaps:    userCaps,
+				MissingCaps: emptyCaps,
+				ID:          types.StringValue("admin"),
+			}
+			if testCase.capType != "" {
+				model.Caps, err = stringToCaps(testCtx, testCase.capType+"=*")
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if d := plan.Set(testCtx, &model); d.HasError() {
+				t.Fatalf("unexpected error: %v", d)
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(testCtx), nil)}
+			if testCase.update {
+				stateModel := model
+				if testCase.stateMissing != "" {
+					stateModel.MissingCaps, err = stringToCaps(testCtx, testCase.stateMissing)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+				}
+				if d := state.Set(testCtx, &stateModel); d.HasError() {
+					t.Fatalf("unexpected error: %v", d)
+				}
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(testCtx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+			if testCase.expectError {
+				return
+			}
+
+			var result AdminCapsResourceModel
+			if d := resp.Plan.Get(testCtx, &result); d.HasError() {
+				t.Fatalf("unexpected error: %v", d)
+			}
+			if result.UserCaps.IsUnknown() != testCase.expectUnknownCaps {
+				t.Errorf("expected unknown user_caps %t, got %s", testCase.expectUnknownCaps, result.UserCaps)
+			}
+			if len(result.MissingCaps.Elements()) != 0 {
+				t.Errorf("expected no missing caps in plan, got %s", result.MissingCaps)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwAdminCaps_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "radosgw_admin_caps" "test" {
+  caps = [
+    {
+      type = "users"
+      perm = "*"
+    },
+    {
+      type = "buckets"
+      perm = "*"
+    }
+  ]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("radosgw_admin_caps.test", "user_id"),
+					resource.TestCheckResourceAttrPair("radosgw_admin_caps.test", "id", "radosgw_admin_caps.test", "user_id"),
+					resource.TestCheckResourceAttr("radosgw_admin_caps.test", "missing_caps.#", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs("radosgw_admin_caps.test", "user_caps.*", map[string]string{
+						"type": "buckets",
+						"perm": "*",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AdminRawResource{}
+
+func NewAdminRawResource() resource.Resource {
+	return &AdminRawResource{}
+}
+
+// AdminRawResource sends arbitrary requests to the Admin Ops API.
+type AdminRawResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// AdminRawResourceModel describes the resource data model.
+type AdminRawResourceModel struct {
+	Method        types.String `tfsdk:"method"`
+	Path          types.String `tfsdk:"path"`
+	Query         types.Map    `tfsdk:"query"`
+	Body          types.String `tfsdk:"body"`
+	DestroyMethod types.String `tfsdk:"destroy_method"`
+	DestroyPath   types.String `tfsdk:"destroy_path"`
+	DestroyQuery  types.Map    `tfsdk:"destroy_query"`
+	DestroyBody   types.String `tfsdk:"destroy_body"`
+	Response      types.String `tfsdk:"response"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// adminRawMethods are the HTTP methods accepted by the Admin Ops API.
+var adminRawMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete}
+
+func (r *AdminRawResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_raw"
+}
+
+func (r *AdminRawResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Sends a raw request to the RadosGW [Admin Ops API](https://docs.ceph.com/en/latest/radosgw/adminops/) on create, and optionally another one on destroy. Use it to manage features the provider does not model yet.
+
+~> **Important:** This resource has no drift detection. The request is only sent when the resource is created or replaced, and changes made outside of Terraform are never detected. Changing ` + "`method`" + `, ` + "`path`" + `, ` + "`query`" + ` or ` + "`body`" + ` replaces the resource, which sends the destroy request first. Prefer a first-class resource whenever one exists.
+
+The requests are signed with the provider credentials, so the user needs the capabilities of the called endpoints.`,
+
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				MarkdownDescription: "The HTTP method of the create request. Valid values: `GET`, `PUT`, `POST`, `DELETE`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(adminRawMethods...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the create request relative to `/admin`, e.g. `/user` or `/bucket`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"query": schema.MapAttribute{
+				MarkdownDescription: "The query parameters of the create request. The `format` parameter is always set to `json`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The JSON body of the create request, for the endpoints that read a document from the request body " +
+					"such as `/metadata`. Use `jsonencode()` to generate it.",
+				Optional: true,
+				Validators: []validator.String{
+					adminRawBodyValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destroy_method": schema.StringAttribute{
+				MarkdownDescription: "The HTTP method of the request sent on destroy. If not set, destroying the resource only removes it from state. " +
+					"Valid values: `GET`, `PUT`, `POST`, `DELETE`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(adminRawMethods...),
+					stringvalidator.AlsoRequires(path.MatchRoot("destroy_path")),
+				},
+			},
+			"destroy_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the request sent on destroy, relative to `/admin`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("destroy_method")),
+				},
+			},
+			"destroy_query": schema.MapAttribute{
+				MarkdownDescription: "The query parameters of the request sent on destroy.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"destroy_body": schema.StringAttribute{
+				MarkdownDescription: "The JSON body of the request sent on destroy.",
+				Optional:            true,
+				Validators: []validator.String{
+					adminRawBodyValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("destroy_method")),
+				},
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The response body of the create request. JSON responses are normalized; use `jsondecode()` to read them.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The method and path of the create request.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AdminRawResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *AdminRawResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AdminRawResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := adminRawQuery(ctx, plan.Query)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := plan.Method.ValueString()
+	requestPath := adminRawPath(plan.Path.ValueString())
+
+	tflog.Debug(ctx, "Sending raw Admin API request", map[string]any{
+		"method": method,
+		"path":   requestPath,
+	})
+
+	body, err := r.adminClient.DoRequestWithBody(ctx, method, requestPath, params, adminRawBody(plan.Body))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Sending Admin API Request",
+			fmt.Sprintf("Could not send %s %s: %s", method, requestPath, err.Error()),
+		)
+		return
+	}
+
+	plan.Response = types.StringValue(adminRawResponse(body))
+	plan.ID = types.StringValue(method + " " + requestPath)
+
+	tflog.Trace(ctx, "Sent raw Admin API request", map[string]any{
+		"method": method,
+		"path":   requestPath,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AdminRawResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The outcome of an arbitrary request cannot be read back, so the state
+	// is kept as is
+}
+
+func (r *AdminRawResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AdminRawResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the destroy request can change in place, which sends nothing
+	plan.Response = state.Response
+	plan.ID = state.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AdminRawResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AdminRawResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DestroyMethod.IsNull() {
+		tflog.Debug(ctx, "No destroy request configured, removing from state")
+		return
+	}
+
+	params, diags := adminRawQuery(ctx, state.DestroyQuery)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := state.DestroyMethod.ValueString()
+	requestPath := adminRawPath(state.DestroyPath.ValueString())
+
+	tflog.Debug(ctx, "Sending raw Admin API destroy request", map[string]any{
+		"method": method,
+		"path":   requestPath,
+	})
+
+	if _, err := r.adminClient.DoRequestWithBody(ctx, method, requestPath, params, adminRawBody(state.DestroyBody)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Sending Admin API Request",
+			fmt.Sprintf("Could not send %s %s: %s", method, requestPath, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Sent raw Admin API destroy request", map[string]any{
+		"method": method,
+		"path":   requestPath,
+	})
+}
+
+// adminRawPath returns the path relative to /admin with a leading slash.
+func adminRawPath(requestPath string) string {
+	requestPath = strings.TrimPrefix(requestPath, "/admin/")
+	return "/" + strings.TrimPrefix(requestPath, "/")
+}
+
+// adminRawQuery converts a query attribute into request parameters.
+func adminRawQuery(ctx context.Context, query types.Map) (url.Values, diag.Diagnostics) {
+	params := url.Values{}
+	if query.IsNull() {
+		return params, nil
+	}
+
+	values := map[string]string{}
+	diags := query.ElementsAs(ctx, &values, false)
+	for key, value := range values {
+		params.Set(key, value)
+	}
+	return params, diags
+}
+
+// adminRawBody returns the request body of a body attribute, or nil when it
+// is not set.
+func adminRawBody(body types.String) []byte {
+	if body.IsNull() || body.ValueString() == "" {
+		return nil
+	}
+	return []byte(body.ValueString())
+}
+
+// adminRawBodyValidator validates that a request body is a JSON document.
+type adminRawBodyValidator struct{}
+
+func (v adminRawBodyValidator) Description(ctx context.Context) string {
+	return "must be a JSON document"
+}
+
+func (v adminRawBodyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v adminRawBodyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Request Body",
+			"The request body must be a JSON document. Use jsonencode() to generate it.",
+		)
+	}
+}
+
+// adminRawResponse normalizes JSON response bodies and returns any other
+// body unchanged.
+func adminRawResponse(body []byte) string {
+	normalized, err := normalizeJSONString(string(body))
+	if err != nil {
+		return string(body)
+	}
+	return normalized
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwAdminRaw_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwAdminRawConfig_basic(userID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_admin_raw.test", "id", "PUT /user"),
+					resource.TestMatchResourceAttr("radosgw_admin_raw.test", "response", regexp.MustCompile(`"user_id":"`+userID+`"`)),
+					resource.TestMatchResourceAttr("data.radosgw_admin_raw.test", "response", regexp.MustCompile(`"display_name":"Raw User"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwAdminRaw_invalidBody(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "radosgw_admin_raw" "test" {
+  method = "PUT"
+  path   = "/metadata/user"
+  body   = "{not json"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Request Body"),
+			},
+		},
+	})
+}
+
+func TestAdminRawBodyValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		body        types.String
+		expectError bool
+	}{
+		"null":     {body: types.StringNull()},
+		"unknown":  {body: types.StringUnknown()},
+		"object":   {body: types.StringValue(`{"key":"user:alice","data":{"user_id":"alice"}}`)},
+		"array":    {body: types.StringValue(`[1,2]`)},
+		"invalid":  {body: types.StringValue(`{"key":`), expectError: true},
+		"not json": {body: types.StringValue(`uid=alice`), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("body"), ConfigValue: testCase.body}
+			resp := &validator.StringResponse{}
+			adminRawBodyValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// Test configurations
+
+func testAccRadosgwAdminRawConfig_basic(userID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_admin_raw" "test" {
+  method = "PUT"
+  path   = "/user"
+  query = {
+    uid          = %[1]q
+    display-name = "Raw User"
+  }
+
+  destroy_method = "DELETE"
+  destroy_path   = "/user"
+  destroy_query = {
+    uid = %[1]q
+  }
+}
+
+data "radosgw_admin_raw" "test" {
+  path = "/user"
+  query = {
+    uid = jsondecode(radosgw_admin_raw.test.response).user_id
+  }
+}
+`, userID)
+}
@@ -2,17 +2,22 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -21,15 +26,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// keyCreationMutexes serializes key creation per user to prevent race conditions
-// when multiple auto-generated S3 keys are created in parallel.
-var keyCreationMutexes sync.Map
-
-func getKeyMutex(userID string) *sync.Mutex {
-	mutex, _ := keyCreationMutexes.LoadOrStore(userID, &sync.Mutex{})
-	return mutex.(*sync.Mutex)
-}
-
 var _ resource.Resource = &KeyResource{}
 var _ resource.ResourceWithImportState = &KeyResource{}
 var _ resource.ResourceWithModifyPlan = &KeyResource{}
@@ -39,7 +35,8 @@ func NewIAMAcessKeyResource() resource.Resource {
 }
 
 type KeyResource struct {
-	client *RadosgwClient
+	client      *RadosgwClient
+	adminClient *AdminClient
 }
 
 type KeyResourceModel struct {
@@ -50,6 +47,57 @@ type KeyResourceModel struct {
 	AccessKey types.String `tfsdk:"access_key"`
 	SecretKey types.String `tfsdk:"secret_key"`
 	Generated types.Bool   `tfsdk:"generated"`
+	Active    types.Bool   `tfsdk:"active"`
+	Triggers  types.Map    `tfsdk:"rotation_triggers"`
+}
+
+// accessKeyInfo is the active flag of an S3 key, which go-ceph does not
+// expose. RadosGW versions before Squid do not report it, and all their keys
+// are active.
+type accessKeyInfo struct {
+	AccessKey string    `json:"access_key"`
+	Active    *jsonBool `json:"active"`
+}
+
+// GetAccessKeyActive returns whether an S3 key of a user is active.
+func (c *AdminClient) GetAccessKeyActive(ctx context.Context, userID, accessKey string) (bool, error) {
+	body, err := c.DoRequest(ctx, http.MethodGet, "/user", url.Values{"uid": {userID}})
+	if err != nil {
+		return false, err
+	}
+
+	var user struct {
+		Keys []accessKeyInfo `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return false, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	for _, key := range user.Keys {
+		if key.AccessKey == accessKey {
+			return key.Active == nil || bool(*key.Active), nil
+		}
+	}
+	return false, admin.ErrNoSuchKey
+}
+
+// SetAccessKeyActive activates or deactivates an existing S3 key. RadosGW
+// modifies keys through key creation, so the secret key is sent unchanged.
+func (c *AdminClient) SetAccessKeyActive(ctx context.Context, userID, subuser, accessKey, secretKey string, active bool) error {
+	params := url.Values{
+		"uid":          {userID},
+		"key-type":     {"s3"},
+		"access-key":   {accessKey},
+		"secret-key":   {secretKey},
+		"generate-key": {"false"},
+		"active":       {strconv.FormatBool(active)},
+	}
+	if subuser != "" {
+		params.Set("subuser", subuser)
+	}
+
+	_, err := c.DoRequest(ctx, http.MethodPut, "/user?key", params)
+	return err
 }
 
 func (r *KeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +108,8 @@ func (r *KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an IAM access key for S3 or Swift access in RadosGW.\n\n" +
 			"**S3 keys:** Multiple access keys per user are supported. Keys are identified by `access_key`.\n\n" +
+			"**S3 keys for subusers:** Setting `subuser` on an S3 key binds the key to that subuser instead of the parent user, " +
+			"which allows issuing per-application S3 credentials under a single parent user.\n\n" +
 			"**Swift keys:** Only one access key per subuser is supported. Creating a new key replaces the existing one. " +
 			"Requires a `subuser` attribute.\n\n" +
 			"~> **Note:** Managing multiple S3 keys per user requires Ceph Squid (19.x) or higher. " +
@@ -81,7 +131,7 @@ func (r *KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				},
 			},
 			"subuser": schema.StringAttribute{
-				MarkdownDescription: "The subuser name (without the user prefix). Required for Swift keys, not used for S3 keys.",
+				MarkdownDescription: "The subuser name (without the user prefix). Required for Swift keys. Optional for S3 keys: when set, the S3 key is bound to the subuser `user_id:subuser`.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -118,6 +168,23 @@ func (r *KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key can be used to authenticate. Default is `true`. Deactivate an old key " +
+					"during a rotation before deleting it, so that clients still using it fail without losing the key. " +
+					"Only applicable for S3 keys. Deactivating keys requires Ceph Squid (19.x) or later.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that force replacement of the key when changed, for example a rotation date " +
+					"from the `time_rotating` resource. Combine with `create_before_destroy` to rotate keys without downtime, see below.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
 			"generated": schema.BoolAttribute{
 				MarkdownDescription: "Whether the key was auto-generated (true) or user-specified (false). Only applicable for S3 keys.",
 				Computed:            true,
@@ -144,6 +211,7 @@ func (r *KeyResource) Configure(ctx context.Context, req resource.ConfigureReque
 	}
 
 	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
 }
 
 func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -165,6 +233,13 @@ func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, re
 			)
 			return
 		}
+		if !data.Active.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Invalid Attribute Combination",
+				"active can only be set to false for S3 keys",
+			)
+			return
+		}
 	}
 
 	tflog.Debug(ctx, "Creating RadosGW key", map[string]any{
@@ -181,9 +256,9 @@ func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, re
 }
 
 func (r *KeyResource) createS3Key(ctx context.Context, data *KeyResourceModel, resp *resource.CreateResponse) {
-	userMutex := getKeyMutex(data.UserID.ValueString())
-	userMutex.Lock()
-	defer userMutex.Unlock()
+	// Serialized per user, so that the key created by this call can be told
+	// apart from keys auto-generated in parallel
+	defer userLocks.lock(data.UserID.ValueString())()
 
 	// Snapshot existing keys to identify newly created auto-generated key
 	var existingAccessKeys map[string]bool
@@ -204,6 +279,13 @@ func (r *KeyResource) createS3Key(ctx context.Context, data *KeyResourceModel, r
 		GenerateKey: &generateKey,
 	}
 
+	// S3 keys may optionally be bound to a subuser
+	keyOwner := data.UserID.ValueString()
+	if !data.SubUser.IsNull() && data.SubUser.ValueString() != "" {
+		keySpec.SubUser = data.SubUser.ValueString()
+		keyOwner = fmt.Sprintf("%s:%s", data.UserID.ValueString(), data.SubUser.ValueString())
+	}
+
 	if !data.AccessKey.IsNull() && data.AccessKey.ValueString() != "" {
 		keySpec.AccessKey = data.AccessKey.ValueString()
 	}
@@ -211,17 +293,12 @@ func (r *KeyResource) createS3Key(ctx context.Context, data *KeyResourceModel, r
 		keySpec.SecretKey = data.SecretKey.ValueString()
 	}
 
-	var keys *[]admin.UserKeySpec
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("CreateKey %s", data.UserID.ValueString()), func() error {
-		var createErr error
-		keys, createErr = r.client.Admin.CreateKey(ctx, keySpec)
-		return createErr
-	})
+	keys, err := r.client.Admin.CreateKey(ctx, keySpec)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating S3 Key",
-			fmt.Sprintf("Could not create key for user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create key for user %s: %s", data.UserID.ValueString(), adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -246,7 +323,7 @@ func (r *KeyResource) createS3Key(ctx context.Context, data *KeyResourceModel, r
 	} else {
 		for i := range *keys {
 			key := &(*keys)[i]
-			if key.User == data.UserID.ValueString() && !existingAccessKeys[key.AccessKey] {
+			if key.User == keyOwner && !existingAccessKeys[key.AccessKey] {
 				createdKey = key
 				break
 			}
@@ -261,6 +338,28 @@ func (r *KeyResource) createS3Key(ctx context.Context, data *KeyResourceModel, r
 		return
 	}
 
+	if !data.Active.ValueBool() {
+		err := r.adminClient.SetAccessKeyActive(ctx, data.UserID.ValueString(), keySpec.SubUser, createdKey.AccessKey, createdKey.SecretKey, false)
+		if err != nil {
+			// Remove the key rather than leave an active key on the user that
+			// is not tracked in state
+			detail := fmt.Sprintf("Created key for user %s but could not deactivate it: %s. The key was removed.", data.UserID.ValueString(), err.Error())
+			removeErr := r.client.Admin.RemoveKey(ctx, admin.UserKeySpec{
+				UID:       data.UserID.ValueString(),
+				SubUser:   keySpec.SubUser,
+				AccessKey: createdKey.AccessKey,
+				KeyType:   "s3",
+			})
+			if removeErr != nil {
+				detail = fmt.Sprintf("Created key %s for user %s but could not deactivate it: %s. Removing the key failed as well, "+
+					"so it is still active and must be removed manually: %s",
+					createdKey.AccessKey, data.UserID.ValueString(), err.Error(), adminError(removeErr, "users=write").Error())
+			}
+			resp.Diagnostics.AddError("Error Deactivating S3 Key", detail)
+			return
+		}
+	}
+
 	wasGenerated := data.AccessKey.IsNull() || data.AccessKey.ValueString() == ""
 
 	data.AccessKey = types.StringValue(createdKey.AccessKey)
@@ -293,17 +392,14 @@ func (r *KeyResource) createSwiftKey(ctx context.Context, data *KeyResourceModel
 		keySpec.SecretKey = data.SecretKey.ValueString()
 	}
 
-	var keys *[]admin.UserKeySpec
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("CreateKey %s", fullSubuserID), func() error {
-		var createErr error
-		keys, createErr = r.client.Admin.CreateKey(ctx, keySpec)
-		return createErr
-	})
+	unlock := userLocks.lock(data.UserID.ValueString())
+	keys, err := r.client.Admin.CreateKey(ctx, keySpec)
+	unlock()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Swift Key",
-			fmt.Sprintf("Could not create Swift key for subuser %s: %s", fullSubuserID, err.Error()),
+			fmt.Sprintf("Could not create Swift key for subuser %s: %s", fullSubuserID, adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -365,7 +461,7 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Key",
-			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -377,6 +473,10 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			swiftKey := &user.SwiftKeys[i]
 			if swiftKey.User == fullSubuserID {
 				found = true
+				// Imported keys have no secret in state yet
+				if data.SecretKey.IsNull() {
+					data.SecretKey = types.StringValue(swiftKey.SecretKey)
+				}
 				break
 			}
 		}
@@ -395,6 +495,14 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			key := &user.Keys[i]
 			if key.AccessKey == data.AccessKey.ValueString() {
 				found = true
+				// Keys bound to a subuser are owned by "user_id:subuser"
+				if subuser, ok := strings.CutPrefix(key.User, data.UserID.ValueString()+":"); ok {
+					data.SubUser = types.StringValue(subuser)
+				}
+				// Imported keys have no secret in state yet
+				if data.SecretKey.IsNull() {
+					data.SecretKey = types.StringValue(key.SecretKey)
+				}
 				break
 			}
 		}
@@ -407,6 +515,20 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			resp.State.RemoveResource(ctx)
 			return
 		}
+
+		active, err := r.adminClient.GetAccessKeyActive(ctx, data.UserID.ValueString(), data.AccessKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Key",
+				fmt.Sprintf("Could not read the active flag of the key of user %s: %s", data.UserID.ValueString(), err.Error()),
+			)
+			return
+		}
+		data.Active = types.BoolValue(active)
+	}
+
+	if data.Active.IsNull() {
+		data.Active = types.BoolValue(true)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -421,6 +543,8 @@ func (r *KeyResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	defer userLocks.lock(state.UserID.ValueString())()
+
 	// Only secret_key can be updated in place
 	if !plan.SecretKey.Equal(state.SecretKey) {
 		keyType := state.KeyType.ValueString()
@@ -442,17 +566,17 @@ func (r *KeyResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			keySpec.SubUser = state.SubUser.ValueString()
 		} else {
 			keySpec.AccessKey = state.AccessKey.ValueString()
+			if !state.SubUser.IsNull() && state.SubUser.ValueString() != "" {
+				keySpec.SubUser = state.SubUser.ValueString()
+			}
 		}
 
-		err := retryOnConcurrentModification(ctx, fmt.Sprintf("UpdateKey %s", state.ID.ValueString()), func() error {
-			_, createErr := r.client.Admin.CreateKey(ctx, keySpec)
-			return createErr
-		})
+		_, err := r.client.Admin.CreateKey(ctx, keySpec)
 
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Secret Key",
-				fmt.Sprintf("Could not update secret key: %s", err.Error()),
+				fmt.Sprintf("Could not update secret key: %s", adminError(err, "users=write").Error()),
 			)
 			return
 		}
@@ -460,6 +584,23 @@ func (r *KeyResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		tflog.Trace(ctx, "Updated secret_key in place")
 	}
 
+	// Modifying the secret may reset the active flag, so it is sent again
+	if !plan.Active.Equal(state.Active) || (!plan.SecretKey.Equal(state.SecretKey) && !plan.Active.ValueBool()) {
+		err := r.adminClient.SetAccessKeyActive(ctx, state.UserID.ValueString(), state.SubUser.ValueString(),
+			state.AccessKey.ValueString(), plan.SecretKey.ValueString(), plan.Active.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Key",
+				fmt.Sprintf("Could not set the active flag of the key: %s", err.Error()),
+			)
+			return
+		}
+
+		tflog.Trace(ctx, "Updated active flag", map[string]any{
+			"active": plan.Active.ValueBool(),
+		})
+	}
+
 	// Copy plan to state (preserving computed values that didn't change)
 	plan.ID = state.ID
 	plan.AccessKey = state.AccessKey
@@ -494,14 +635,19 @@ func (r *KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		keySpec.AccessKey = data.AccessKey.ValueString()
 	}
 
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("RemoveKey %s", data.ID.ValueString()), func() error {
-		return r.client.Admin.RemoveKey(ctx, keySpec)
-	})
+	unlock := userLocks.lock(data.UserID.ValueString())
+	err := r.client.Admin.RemoveKey(ctx, keySpec)
+	unlock()
 
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		// The user was deleted first, which removed its keys
+		addUserDeletedFirstWarning(&resp.Diagnostics, "keys", data.UserID.ValueString())
+		return
+	}
 	if err != nil && !errors.Is(err, admin.ErrNoSuchKey) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Key",
-			fmt.Sprintf("Could not delete key: %s", err.Error()),
+			fmt.Sprintf("Could not delete key: %s", adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -510,7 +656,17 @@ func (r *KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *KeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var active types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("active"), &active)...)
+	if r.client != nil && !active.IsUnknown() && !active.ValueBool() && !r.client.supportsFeature(CephFeature_AccessKeyStatus) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Deactivating access keys", CephFeature_AccessKeyStatus)
+	}
+
+	if req.State.Raw.IsNull() {
 		return
 	}
 
@@ -2,13 +2,68 @@ package provider
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ceph/go-ceph/rgw/admin"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
+func TestKeyResourceCreateS3Key_deactivateFailure(t *testing.T) {
+	t.Parallel()
+
+	var removed string
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		body := ""
+		switch {
+		case req.Method == http.MethodPut && req.URL.Query().Get("active") == "false":
+			status = http.StatusInternalServerError
+			body = `{"Code":"UnknownError"}`
+		case req.Method == http.MethodPut:
+			body = `[{"user":"alice","access_key":"AKIAINACTIVE","secret_key":"secret"}]`
+		case req.Method == http.MethodDelete:
+			removed = req.URL.RawQuery
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})}
+	api, err := admin.New("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+	if err != nil {
+		t.Fatalf("could not create admin client: %s", err)
+	}
+
+	r := &KeyResource{client: &RadosgwClient{Admin: api}, adminClient: NewAdminClient(api)}
+	data := KeyResourceModel{
+		UserID:    types.StringValue("alice"),
+		SubUser:   types.StringNull(),
+		KeyType:   types.StringValue("s3"),
+		AccessKey: types.StringValue("AKIAINACTIVE"),
+		SecretKey: types.StringValue("secret"),
+		Active:    types.BoolValue(false),
+	}
+	resp := &fwresource.CreateResponse{}
+	r.createS3Key(testCtx, &data, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	// The key must not be left active on the user without being tracked
+	for _, param := range []string{"uid=alice", "access-key=AKIAINACTIVE", "key-type=s3"} {
+		if !strings.Contains(removed, param) {
+			t.Errorf("expected key removal to contain %q, got %q", param, removed)
+		}
+	}
+}
+
 func TestAccRadosgwIAMAccessKey_basic(t *testing.T) {
 	t.Parallel()
 
@@ -57,10 +112,16 @@ func TestAccRadosgwIAMAccessKey_withSpecifiedKeys(t *testing.T) {
 				ResourceName:                         "radosgw_iam_access_key.test",
 				ImportState:                          true,
 				ImportStateVerify:                    true,
-				ImportStateVerifyIgnore:              []string{"secret_key"},
 				ImportStateId:                        "s3:" + userID + ":" + accessKey,
 				ImportStateVerifyIdentifierAttribute: "id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_access_key.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   "s3:" + userID + ":" + accessKey,
+			},
 		},
 	})
 }
@@ -73,7 +134,7 @@ func TestAccRadosgwIAMAccessKey_multiple(t *testing.T) {
 	userID := randomName("tf-acc-user")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_MultipleUserCredentials) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwIAMAccessKeyDestroy,
 		Steps: []resource.TestStep{
@@ -122,6 +183,83 @@ func TestAccRadosgwIAMAccessKey_updateSecretKey(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMAccessKey_active(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_AccessKeyStatus) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccessKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccessKeyConfig_active(userID, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccessKeyExists("radosgw_iam_access_key.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "active", "false"),
+				),
+			},
+			// Reactivate the key in place
+			{
+				Config: testAccRadosgwIAMAccessKeyConfig_active(userID, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccessKeyExists("radosgw_iam_access_key.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "radosgw_iam_access_key.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccRadosgwIAMAccessKeyImportStateIdFunc("radosgw_iam_access_key.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generated"},
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMAccessKey_rotationTriggers(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	var firstAccessKey string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccessKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccessKeyConfig_rotationTriggers(userID, "2024-01-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccessKeyExists("radosgw_iam_access_key.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "rotation_triggers.rotated", "2024-01-01"),
+					func(s *terraform.State) error {
+						firstAccessKey = s.RootModule().Resources["radosgw_iam_access_key.test"].Primary.Attributes["access_key"]
+						return nil
+					},
+				),
+			},
+			// Changing a trigger replaces the key
+			{
+				Config: testAccRadosgwIAMAccessKeyConfig_rotationTriggers(userID, "2024-04-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccessKeyExists("radosgw_iam_access_key.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "rotation_triggers.rotated", "2024-04-01"),
+					func(s *terraform.State) error {
+						accessKey := s.RootModule().Resources["radosgw_iam_access_key.test"].Primary.Attributes["access_key"]
+						if accessKey == firstAccessKey {
+							return fmt.Errorf("access key was not rotated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccRadosgwIAMAccessKey_swift(t *testing.T) {
 	t.Parallel()
 
@@ -146,7 +284,6 @@ func TestAccRadosgwIAMAccessKey_swift(t *testing.T) {
 				ResourceName:                         "radosgw_iam_access_key.swift",
 				ImportState:                          true,
 				ImportStateVerify:                    true,
-				ImportStateVerifyIgnore:              []string{"secret_key"},
 				ImportStateId:                        "swift:" + userID + ":" + subuserName,
 				ImportStateVerifyIdentifierAttribute: "id",
 			},
@@ -154,8 +291,81 @@ func TestAccRadosgwIAMAccessKey_swift(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMAccessKey_s3Subuser(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	subuserName := "app"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccessKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccessKeyConfig_s3Subuser(userID, subuserName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccessKeyExists("radosgw_iam_access_key.test"),
+					testAccCheckRadosgwIAMAccessKeyOwner("radosgw_iam_access_key.test", userID+":"+subuserName),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "key_type", "s3"),
+					resource.TestCheckResourceAttr("radosgw_iam_access_key.test", "subuser", subuserName),
+					resource.TestCheckResourceAttrSet("radosgw_iam_access_key.test", "access_key"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_access_key.test", "secret_key"),
+				),
+			},
+			// Import test - subuser is resolved from the key owner
+			{
+				ResourceName:                         "radosgw_iam_access_key.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"generated"},
+				ImportStateIdFunc:                    testAccRadosgwIAMAccessKeyImportStateIdFunc("radosgw_iam_access_key.test"),
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+		},
+	})
+}
+
 // Helper functions
 
+func testAccRadosgwIAMAccessKeyImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return "s3:" + rs.Primary.Attributes["user_id"] + ":" + rs.Primary.Attributes["access_key"], nil
+	}
+}
+
+func testAccCheckRadosgwIAMAccessKeyOwner(resourceName, expectedOwner string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		userID := rs.Primary.Attributes["user_id"]
+		accessKey := rs.Primary.Attributes["access_key"]
+
+		user, err := testAccAdminClient.GetUser(testCtx, admin.User{ID: userID})
+		if err != nil {
+			return fmt.Errorf("error fetching user %s: %s", userID, err)
+		}
+
+		for _, key := range user.Keys {
+			if key.AccessKey == accessKey {
+				if key.User != expectedOwner {
+					return fmt.Errorf("access key %s is owned by %s, expected %s", accessKey, key.User, expectedOwner)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("access key %s not found for user %s", accessKey, userID)
+	}
+}
+
 func testAccCheckRadosgwIAMAccessKeyExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -242,6 +452,61 @@ resource "radosgw_iam_access_key" "test" {
 `, userID, accessKey, secretKey)
 }
 
+func testAccRadosgwIAMAccessKeyConfig_active(userID string, active bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Access Key"
+}
+
+resource "radosgw_iam_access_key" "test" {
+  user_id = radosgw_iam_user.test.user_id
+  active  = %t
+}
+`, userID, active)
+}
+
+func testAccRadosgwIAMAccessKeyConfig_rotationTriggers(userID, rotated string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Access Key"
+}
+
+resource "radosgw_iam_access_key" "test" {
+  user_id = radosgw_iam_user.test.user_id
+
+  rotation_triggers = {
+    rotated = %q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, userID, rotated)
+}
+
+func testAccRadosgwIAMAccessKeyConfig_s3Subuser(userID, subuserName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Subuser S3 Key"
+}
+
+resource "radosgw_iam_subuser" "test" {
+  user_id = radosgw_iam_user.test.user_id
+  subuser = %q
+  access  = "read-write"
+}
+
+resource "radosgw_iam_access_key" "test" {
+  user_id = radosgw_iam_user.test.user_id
+  subuser = radosgw_iam_subuser.test.subuser
+}
+`, userID, subuserName)
+}
+
 func testAccRadosgwIAMAccessKeyConfig_multiple(userID string) string {
 	return providerConfig() + fmt.Sprintf(`
 resource "radosgw_iam_user" "test" {
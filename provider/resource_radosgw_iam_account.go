@@ -0,0 +1,529 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccountResource{}
+var _ resource.ResourceWithImportState = &AccountResource{}
+var _ resource.ResourceWithModifyPlan = &AccountResource{}
+
+// accountIDRegexp matches RadosGW account IDs: "RGW" followed by 17 digits.
+var accountIDRegexp = regexp.MustCompile(`^RGW[0-9]{17}$`)
+
+func NewIAMAccountResource() resource.Resource {
+	return &AccountResource{}
+}
+
+// AccountResource defines the resource implementation.
+type AccountResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// AccountResourceModel describes the resource data model.
+type AccountResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	AccountID     types.String `tfsdk:"account_id"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	Tenant        types.String `tfsdk:"tenant"`
+	MaxUsers      types.Int64  `tfsdk:"max_users"`
+	MaxRoles      types.Int64  `tfsdk:"max_roles"`
+	MaxGroups     types.Int64  `tfsdk:"max_groups"`
+	MaxAccessKeys types.Int64  `tfsdk:"max_access_keys"`
+	MaxBuckets    types.Int64  `tfsdk:"max_buckets"`
+	Quota         types.Object `tfsdk:"quota"`
+	BucketQuota   types.Object `tfsdk:"bucket_quota"`
+}
+
+func (r *AccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_account"
+}
+
+func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	accountLimitAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			MarkdownDescription: description + " Use `-1` for unlimited. If not set, the RadosGW default is used.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	accountQuotaAttribute := func(description string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			MarkdownDescription: description + " Managed with the `radosgw_iam_account_quota` resource.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					MarkdownDescription: "Whether the quota is enabled.",
+					Computed:            true,
+				},
+				"max_size": schema.Int64Attribute{
+					MarkdownDescription: "Maximum size in bytes. -1 means unlimited.",
+					Computed:            true,
+				},
+				"max_objects": schema.Int64Attribute{
+					MarkdownDescription: "Maximum number of objects. -1 means unlimited.",
+					Computed:            true,
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a RadosGW account. Accounts group users, roles, groups and buckets under a single " +
+			"administrative boundary, similar to AWS accounts. Users are moved into an account by setting `account_id` " +
+			"on the `radosgw_iam_user` resource.\n\n" +
+			"~> **Note:** Accounts require Ceph Squid (19.x) or higher and the `accounts=*` capability.\n\n" +
+			"~> **Note:** An account can only be deleted once all of its users, roles and buckets have been removed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The account ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The account ID in the format `RGW` followed by 17 digits (e.g., `RGW12345678901234567`). " +
+					"If not set, RadosGW generates one. Changing this value will force resource replacement.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						accountIDRegexp,
+						"must be 'RGW' followed by 17 digits",
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The account name. Must be unique within the tenant.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address associated with the account.",
+				Optional:            true,
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the account belongs to. Cannot be modified after creation.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_users":       accountLimitAttribute("The maximum number of users in the account."),
+			"max_roles":       accountLimitAttribute("The maximum number of roles in the account."),
+			"max_groups":      accountLimitAttribute("The maximum number of groups in the account."),
+			"max_access_keys": accountLimitAttribute("The maximum number of access keys per user in the account."),
+			"max_buckets":     accountLimitAttribute("The maximum number of buckets in the account."),
+			"quota":           accountQuotaAttribute("The account quota, applying to the total usage of all buckets in the account."),
+			"bucket_quota":    accountQuotaAttribute("The per-bucket quota applied to each bucket in the account."),
+		},
+	}
+}
+
+func (r *AccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *AccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Accounts were introduced in Squid
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_Accounts) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing accounts", CephFeature_Accounts)
+	}
+}
+
+func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating RadosGW account", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+		"name":       data.Name.ValueString(),
+		"tenant":     data.Tenant.ValueString(),
+	})
+
+	accountConfig := buildAccountSpec(&data)
+	if !data.AccountID.IsUnknown() {
+		accountConfig.ID = data.AccountID.ValueString()
+	}
+
+	account, err := r.adminClient.CreateAccount(ctx, accountConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating RadosGW Account",
+			fmt.Sprintf("Could not create account %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(populateAccountModel(&data, &account)...)
+
+	tflog.Trace(ctx, "Created RadosGW account", map[string]any{
+		"account_id": account.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accountID := data.ID.ValueString()
+
+	tflog.Debug(ctx, "Reading RadosGW account", map[string]any{
+		"account_id": accountID,
+	})
+
+	account, err := r.adminClient.GetAccount(ctx, accountID)
+	if err != nil {
+		if isAccountNotFoundError(err) {
+			tflog.Debug(ctx, "Account not found, removing from state", map[string]any{
+				"account_id": accountID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW Account",
+			fmt.Sprintf("Could not read account %s: %s", accountID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(populateAccountModel(&data, &account)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccountResourceModel
+	var state AccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accountID := state.ID.ValueString()
+
+	tflog.Debug(ctx, "Updating RadosGW account", map[string]any{
+		"account_id": accountID,
+	})
+
+	accountConfig := buildAccountSpec(&data)
+	accountConfig.ID = accountID
+
+	account, err := r.adminClient.ModifyAccount(ctx, accountConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating RadosGW Account",
+			fmt.Sprintf("Could not update account %s: %s", accountID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(populateAccountModel(&data, &account)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accountID := data.ID.ValueString()
+
+	tflog.Debug(ctx, "Deleting RadosGW account", map[string]any{
+		"account_id": accountID,
+	})
+
+	err := r.adminClient.DeleteAccount(ctx, accountID)
+	if err != nil && !isAccountNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting RadosGW Account",
+			fmt.Sprintf("Could not delete account %s: %s. "+
+				"Ensure all users, roles and buckets in the account have been removed first.", accountID, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted RadosGW account")
+}
+
+func (r *AccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "account_id"
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), req.ID)...)
+}
+
+// buildAccountSpec converts the configurable account attributes into an RGWAccount.
+// Limits that are unknown in the plan are left unset so RadosGW applies its defaults.
+func buildAccountSpec(data *AccountResourceModel) RGWAccount {
+	account := RGWAccount{
+		Name:   data.Name.ValueString(),
+		Email:  data.Email.ValueString(),
+		Tenant: data.Tenant.ValueString(),
+	}
+
+	limits := []struct {
+		value  types.Int64
+		target **int64
+	}{
+		{data.MaxUsers, &account.MaxUsers},
+		{data.MaxRoles, &account.MaxRoles},
+		{data.MaxGroups, &account.MaxGroups},
+		{data.MaxAccessKeys, &account.MaxAccessKeys},
+		{data.MaxBuckets, &account.MaxBuckets},
+	}
+	for _, limit := range limits {
+		if !limit.value.IsNull() && !limit.value.IsUnknown() {
+			v := limit.value.ValueInt64()
+			*limit.target = &v
+		}
+	}
+
+	return account
+}
+
+// populateAccountModel updates the model with data returned by the Admin API.
+func populateAccountModel(data *AccountResourceModel, account *RGWAccount) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(account.ID)
+	data.AccountID = types.StringValue(account.ID)
+	data.Name = types.StringValue(account.Name)
+	data.Tenant = types.StringValue(account.Tenant)
+
+	// Email is optional; keep it null when unset to avoid a diff against configuration
+	if account.Email != "" {
+		data.Email = types.StringValue(account.Email)
+	} else {
+		data.Email = types.StringNull()
+	}
+
+	data.MaxUsers = int64PointerValue(account.MaxUsers)
+	data.MaxRoles = int64PointerValue(account.MaxRoles)
+	data.MaxGroups = int64PointerValue(account.MaxGroups)
+	data.MaxAccessKeys = int64PointerValue(account.MaxAccessKeys)
+	data.MaxBuckets = int64PointerValue(account.MaxBuckets)
+
+	var d diag.Diagnostics
+	data.Quota, d = quotaSpecToObject(account.Quota)
+	diags.Append(d...)
+	data.BucketQuota, d = quotaSpecToObject(account.BucketQuota)
+	diags.Append(d...)
+
+	return diags
+}
+
+// quotaSpecToObject converts an admin.QuotaSpec into an object with
+// enabled, max_size and max_objects attributes.
+func quotaSpecToObject(quota admin.QuotaSpec) (types.Object, diag.Diagnostics) {
+	values := map[string]attr.Value{
+		"enabled":     types.BoolNull(),
+		"max_size":    types.Int64Null(),
+		"max_objects": types.Int64Null(),
+	}
+	if quota.Enabled != nil {
+		values["enabled"] = types.BoolValue(*quota.Enabled)
+	}
+	if quota.MaxSize != nil {
+		values["max_size"] = types.Int64Value(*quota.MaxSize)
+	}
+	if quota.MaxObjects != nil {
+		values["max_objects"] = types.Int64Value(*quota.MaxObjects)
+	}
+
+	return types.ObjectValue(bucketQuotaAttrTypes(), values)
+}
+
+// int64PointerValue converts an optional int64 into a types.Int64.
+func int64PointerValue(v *int64) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*v)
+}
+
+// isAccountNotFoundError checks if an error indicates the account doesn't exist.
+func isAccountNotFoundError(err error) bool {
+	return isAdminNotFoundError(err)
+}
+
+// =============================================================================
+// Account Admin API
+// =============================================================================
+
+// RGWAccount represents a RadosGW account as returned by the Admin Ops API.
+// go-ceph only provides account support in preview builds, so the provider
+// talks to the /admin/account endpoint directly.
+type RGWAccount struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	Tenant        string          `json:"tenant"`
+	MaxUsers      *int64          `json:"max_users"`
+	MaxRoles      *int64          `json:"max_roles"`
+	MaxGroups     *int64          `json:"max_groups"`
+	MaxAccessKeys *int64          `json:"max_access_keys"`
+	MaxBuckets    *int64          `json:"max_buckets"`
+	Quota         admin.QuotaSpec `json:"quota"`
+	BucketQuota   admin.QuotaSpec `json:"bucket_quota"`
+}
+
+// urlValues encodes the writable account fields as Admin Ops API parameters.
+func (a RGWAccount) urlValues() url.Values {
+	params := url.Values{}
+	if a.ID != "" {
+		params.Set("id", a.ID)
+	}
+	params.Set("name", a.Name)
+	params.Set("email", a.Email)
+	if a.Tenant != "" {
+		params.Set("tenant", a.Tenant)
+	}
+
+	limits := map[string]*int64{
+		"max-users":       a.MaxUsers,
+		"max-roles":       a.MaxRoles,
+		"max-groups":      a.MaxGroups,
+		"max-access-keys": a.MaxAccessKeys,
+		"max-buckets":     a.MaxBuckets,
+	}
+	for key, value := range limits {
+		if value != nil {
+			params.Set(key, strconv.FormatInt(*value, 10))
+		}
+	}
+
+	return params
+}
+
+func (c *AdminClient) doAccountRequest(ctx context.Context, method string, params url.Values) (RGWAccount, error) {
+	body, err := c.DoRequest(ctx, method, "/account", params)
+	if err != nil {
+		return RGWAccount{}, err
+	}
+
+	var account RGWAccount
+	if err := json.Unmarshal(body, &account); err != nil {
+		return RGWAccount{}, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	return account, nil
+}
+
+// CreateAccount creates a new RadosGW account.
+func (c *AdminClient) CreateAccount(ctx context.Context, account RGWAccount) (RGWAccount, error) {
+	return c.doAccountRequest(ctx, http.MethodPost, account.urlValues())
+}
+
+// GetAccount returns the RadosGW account with the given ID.
+func (c *AdminClient) GetAccount(ctx context.Context, accountID string) (RGWAccount, error) {
+	return c.doAccountRequest(ctx, http.MethodGet, url.Values{"id": {accountID}})
+}
+
+// ModifyAccount updates an existing RadosGW account.
+func (c *AdminClient) ModifyAccount(ctx context.Context, account RGWAccount) (RGWAccount, error) {
+	return c.doAccountRequest(ctx, http.MethodPut, account.urlValues())
+}
+
+// DeleteAccount removes the RadosGW account with the given ID.
+func (c *AdminClient) DeleteAccount(ctx context.Context, accountID string) error {
+	_, err := c.DoRequest(ctx, http.MethodDelete, "/account", url.Values{"id": {accountID}})
+	return err
+}
+
+// SetAccountQuota sets the account or per-bucket quota of a RadosGW account.
+// The quota type is either "account" (total across all buckets) or "bucket".
+func (c *AdminClient) SetAccountQuota(ctx context.Context, accountID, quotaType string, quota admin.QuotaSpec) error {
+	params := url.Values{
+		"id":         {accountID},
+		"quota-type": {quotaType},
+	}
+	if quota.Enabled != nil {
+		params.Set("enabled", strconv.FormatBool(*quota.Enabled))
+	}
+	if quota.MaxSize != nil {
+		params.Set("max-size", strconv.FormatInt(*quota.MaxSize, 10))
+	}
+	if quota.MaxObjects != nil {
+		params.Set("max-objects", strconv.FormatInt(*quota.MaxObjects, 10))
+	}
+
+	_, err := c.DoRequest(ctx, http.MethodPut, "/account?quota", params)
+	return err
+}
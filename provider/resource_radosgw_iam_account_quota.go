@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccountQuotaResource{}
+var _ resource.ResourceWithImportState = &AccountQuotaResource{}
+var _ resource.ResourceWithModifyPlan = &AccountQuotaResource{}
+
+func NewIAMAccountQuotaResource() resource.Resource {
+	return &AccountQuotaResource{}
+}
+
+// AccountQuotaResource manages account-level quotas in RadosGW.
+// There are two types of account-level quotas:
+//   - "account" quota: Limits the total storage/objects for the account across ALL its buckets
+//   - "bucket" quota: Sets a per-bucket limit that applies to EACH bucket owned by the account
+//
+// go-ceph does not provide account quota APIs, so this resource calls the
+// Admin Ops API directly.
+type AccountQuotaResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// AccountQuotaResourceModel describes the resource data model for account-level quotas.
+type AccountQuotaResourceModel struct {
+	AccountID  types.String `tfsdk:"account_id"`
+	Type       types.String `tfsdk:"type"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	MaxSize    types.Int64  `tfsdk:"max_size"`
+	MaxObjects types.Int64  `tfsdk:"max_objects"`
+}
+
+func (r *AccountQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_account_quota"
+}
+
+func (r *AccountQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages account-level quotas in RadosGW. This resource configures storage and object limits for an account created with ` + "`radosgw_iam_account`" + `.
+
+There are two types:
+
+- **Account quota** (` + "`type = \"account\"`" + `): Sets the total storage limit across ALL buckets owned by the account.
+
+- **Bucket quota** (` + "`type = \"bucket\"`" + `): Sets a per-bucket limit that applies to EACH bucket owned by the account.
+
+Upon deletion, the quota is disabled (not removed, as quotas are properties of accounts).
+
+~> **Note:** Account quotas require Ceph Squid (19.x) or higher and the ` + "`accounts=*`" + ` capability.`,
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The account ID to configure quotas for.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(accountIDRegexp, "must be 'RGW' followed by 17 digits"),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The quota type:\n" +
+					"  - `account`: Total quota across all account's buckets combined\n" +
+					"  - `bucket`: Per-bucket quota applied to each bucket the account owns",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("account", "bucket"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the quota is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"max_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size in bytes. Use `-1` for unlimited. Default: `-1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(-1),
+			},
+			"max_objects": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of objects. Use `-1` for unlimited. Default: `-1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(-1),
+			},
+		},
+	}
+}
+
+func (r *AccountQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *AccountQuotaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Account quotas were introduced in Squid along with accounts
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_Accounts) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing account quotas", CephFeature_Accounts)
+	}
+}
+
+func (r *AccountQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccountQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setQuota(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Quota",
+			fmt.Sprintf("Could not create %s quota for account %s: %s", data.Type.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccountQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Account quotas are returned as part of the account info
+	account, err := r.adminClient.GetAccount(ctx, data.AccountID.ValueString())
+	if err != nil {
+		if isAccountNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Account Quota",
+			fmt.Sprintf("Could not read %s quota for account %s: %s", data.Type.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	quotaSpec := account.Quota
+	if data.Type.ValueString() == "bucket" {
+		quotaSpec = account.BucketQuota
+	}
+
+	// Update state from response
+	if quotaSpec.Enabled != nil {
+		data.Enabled = types.BoolValue(*quotaSpec.Enabled)
+	}
+
+	if quotaSpec.MaxSize != nil {
+		data.MaxSize = types.Int64Value(*quotaSpec.MaxSize)
+	} else {
+		data.MaxSize = types.Int64Value(-1)
+	}
+
+	if quotaSpec.MaxObjects != nil {
+		data.MaxObjects = types.Int64Value(*quotaSpec.MaxObjects)
+	} else {
+		data.MaxObjects = types.Int64Value(-1)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccountQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setQuota(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Account Quota",
+			fmt.Sprintf("Could not update %s quota for account %s: %s", data.Type.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccountQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Disable quota on delete (quotas cannot be removed, only disabled)
+	// This resets the account's quota to unlimited and disabled state
+	data.Enabled = types.BoolValue(false)
+	data.MaxSize = types.Int64Value(-1)
+	data.MaxObjects = types.Int64Value(-1)
+
+	err := r.setQuota(ctx, &data)
+	if err != nil && !isAccountNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Account Quota",
+			fmt.Sprintf("Could not disable %s quota for account %s: %s", data.Type.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *AccountQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "account_id:type" where type is "account" or "bucket"
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'account_id:type'. Example: 'RGW12345678901234567:account' or 'RGW12345678901234567:bucket'",
+		)
+		return
+	}
+
+	accountID := parts[0]
+	quotaType := parts[1]
+
+	if quotaType != "account" && quotaType != "bucket" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Quota type must be 'account' or 'bucket', got: %s", quotaType),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), quotaType)...)
+}
+
+// setQuota applies the quota described by the model to the account.
+func (r *AccountQuotaResource) setQuota(ctx context.Context, data *AccountQuotaResourceModel) error {
+	enabled := data.Enabled.ValueBool()
+	maxSize := data.MaxSize.ValueInt64()
+	maxObjects := data.MaxObjects.ValueInt64()
+
+	quota := admin.QuotaSpec{
+		Enabled:    &enabled,
+		MaxSize:    &maxSize,
+		MaxObjects: &maxObjects,
+	}
+
+	return r.adminClient.SetAccountQuota(ctx, data.AccountID.ValueString(), data.Type.ValueString(), quota)
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRadosgwIAMAccountQuota_basic(t *testing.T) {
+	t.Parallel()
+
+	accountID := randomAccountID()
+	name := randomName("tf-acc-account")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccountQuotaConfig_full(accountID, name, "account", true, 1073741824, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccountQuotaExists("radosgw_iam_account_quota.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "account_id", accountID),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "type", "account"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "max_size", "1073741824"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "max_objects", "1000"),
+				),
+			},
+			// Import test - format: account_id:type
+			{
+				ResourceName:                         "radosgw_iam_account_quota.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        accountID + ":account",
+				ImportStateVerifyIdentifierAttribute: "account_id",
+			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_account_quota.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   accountID + ":account",
+			},
+			// Update the quota
+			{
+				Config: testAccRadosgwIAMAccountQuotaConfig_full(accountID, name, "account", false, 2147483648, -1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "max_size", "2147483648"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "max_objects", "-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMAccountQuota_bucketType(t *testing.T) {
+	t.Parallel()
+
+	accountID := randomAccountID()
+	name := randomName("tf-acc-account")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccountQuotaConfig_full(accountID, name, "bucket", true, 536870912, 500),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccountQuotaExists("radosgw_iam_account_quota.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "type", "bucket"),
+					resource.TestCheckResourceAttr("radosgw_iam_account_quota.test", "max_size", "536870912"),
+				),
+			},
+		},
+	})
+}
+
+// Helper functions
+
+func testAccCheckRadosgwIAMAccountQuotaExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		accountID := rs.Primary.Attributes["account_id"]
+		if accountID == "" {
+			return fmt.Errorf("account_id not set")
+		}
+
+		account, err := NewAdminClient(testAccAdminClient).GetAccount(testCtx, accountID)
+		if err != nil {
+			return fmt.Errorf("error fetching account %s: %s", accountID, err)
+		}
+
+		quota := account.Quota
+		if rs.Primary.Attributes["type"] == "bucket" {
+			quota = account.BucketQuota
+		}
+		if quota.Enabled == nil || !*quota.Enabled {
+			return fmt.Errorf("%s quota not enabled for account %s", rs.Primary.Attributes["type"], accountID)
+		}
+
+		return nil
+	}
+}
+
+// Test configurations
+
+func testAccRadosgwIAMAccountQuotaConfig_full(accountID, name, quotaType string, enabled bool, maxSize, maxObjects int64) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_account" "test" {
+  account_id = %q
+  name       = %q
+}
+
+resource "radosgw_iam_account_quota" "test" {
+  account_id  = radosgw_iam_account.test.id
+  type        = %q
+  enabled     = %t
+  max_size    = %d
+  max_objects = %d
+}
+`, accountID, name, quotaType, enabled, maxSize, maxObjects)
+}
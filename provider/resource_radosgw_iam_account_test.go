@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRadosgwIAMAccount_basic(t *testing.T) {
+	t.Parallel()
+
+	name := randomName("tf-acc-account")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccountConfig_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccountExists("radosgw_iam_account.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "name", name),
+					resource.TestMatchResourceAttr("radosgw_iam_account.test", "account_id", accountIDRegexp),
+					resource.TestCheckResourceAttrPair("radosgw_iam_account.test", "id", "radosgw_iam_account.test", "account_id"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_account.test", "max_users"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_account.test", "quota.enabled"),
+				),
+			},
+			// Import test
+			{
+				ResourceName:      "radosgw_iam_account.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_account.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMAccount_update(t *testing.T) {
+	t.Parallel()
+
+	accountID := randomAccountID()
+	name := randomName("tf-acc-account")
+	email := randomEmail()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMAccountConfig_limits(accountID, name, email, 10, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMAccountExists("radosgw_iam_account.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "account_id", accountID),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "email", email),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "max_users", "10"),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "max_buckets", "5"),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMAccountConfig_limits(accountID, name+"-renamed", email, 20, -1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "account_id", accountID),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "max_users", "20"),
+					resource.TestCheckResourceAttr("radosgw_iam_account.test", "max_buckets", "-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMAccount_withUser(t *testing.T) {
+	t.Parallel()
+
+	name := randomName("tf-acc-account")
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckRadosgwIAMUserDestroy,
+			testAccCheckRadosgwIAMAccountDestroy,
+		),
+		Steps: []resource.TestStep{
+			// Create the user outside of any account
+			{
+				Config: testAccRadosgwIAMAccountConfig_withUser(name, userID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "account_id", ""),
+				),
+			},
+			// Move the user into the account as its root user
+			{
+				Config: testAccRadosgwIAMAccountConfig_withUser(name, userID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("radosgw_iam_user.test", "account_id", "radosgw_iam_account.test", "id"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "account_root", "true"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "type", "root"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRadosgwIAMAccountExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		accountID := rs.Primary.Attributes["id"]
+		if accountID == "" {
+			return fmt.Errorf("account ID not set")
+		}
+
+		_, err := NewAdminClient(testAccAdminClient).GetAccount(testCtx, accountID)
+		if err != nil {
+			return fmt.Errorf("account %s not found: %w", accountID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRadosgwIAMAccountDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "radosgw_iam_account" {
+			continue
+		}
+
+		accountID := rs.Primary.Attributes["id"]
+		_, err := NewAdminClient(testAccAdminClient).GetAccount(testCtx, accountID)
+		if err == nil {
+			return fmt.Errorf("account %s still exists", accountID)
+		}
+	}
+
+	return nil
+}
+
+// Test configurations
+
+func testAccRadosgwIAMAccountConfig_basic(name string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_account" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccRadosgwIAMAccountConfig_limits(accountID, name, email string, maxUsers, maxBuckets int) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_account" "test" {
+  account_id  = %q
+  name        = %q
+  email       = %q
+  max_users   = %d
+  max_buckets = %d
+}
+`, accountID, name, email, maxUsers, maxBuckets)
+}
+
+func testAccRadosgwIAMAccountConfig_withUser(name, userID string, inAccount bool) string {
+	accountConfig := ""
+	if inAccount {
+		accountConfig = `
+  account_id   = radosgw_iam_account.test.id
+  account_root = true`
+	}
+
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_account" "test" {
+  name = %q
+}
+
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Account User"%s
+}
+`, name, userID, accountConfig)
+}
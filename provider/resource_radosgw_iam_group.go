@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupResource{}
+var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
+
+func NewIAMGroupResource() resource.Resource {
+	return &GroupResource{}
+}
+
+// GroupResource defines the resource implementation.
+type GroupResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// GroupResourceModel describes the resource data model.
+type GroupResourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	Path       types.String `tfsdk:"path"`
+	ARN        types.String `tfsdk:"arn"`
+	UniqueID   types.String `tfsdk:"unique_id"`
+	CreateDate types.String `tfsdk:"create_date"`
+}
+
+// XML response structures for RadosGW Group API
+type createGroupResponseXML struct {
+	XMLName xml.Name `xml:"CreateGroupResponse"`
+	Result  struct {
+		Group groupXML `xml:"Group"`
+	} `xml:"CreateGroupResult"`
+}
+
+type getGroupResponseXML struct {
+	XMLName xml.Name       `xml:"GetGroupResponse"`
+	Result  getGroupResult `xml:"GetGroupResult"`
+}
+
+type getGroupResult struct {
+	Group       groupXML       `xml:"Group"`
+	Users       []groupUserXML `xml:"Users>member"`
+	IsTruncated bool           `xml:"IsTruncated"`
+	Marker      string         `xml:"Marker"`
+}
+
+type groupXML struct {
+	GroupName  string `xml:"GroupName"`
+	GroupId    string `xml:"GroupId"`
+	Path       string `xml:"Path"`
+	Arn        string `xml:"Arn"`
+	CreateDate string `xml:"CreateDate"`
+}
+
+type groupUserXML struct {
+	UserName string `xml:"UserName"`
+	UserId   string `xml:"UserId"`
+	Arn      string `xml:"Arn"`
+}
+
+func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_group"
+}
+
+func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an IAM group in a RadosGW account. Groups organize the users of an account, " +
+			"which inherit the policies of the groups they are members of. Use `radosgw_iam_group_membership` to add " +
+			"users and `radosgw_iam_group_policy` to grant permissions.\n\n" +
+			"~> **Note:** Groups belong to a RadosGW account (see `radosgw_iam_account`) and require Ceph Squid (19.x) " +
+			"or higher. The provider credentials must belong to a user of the account, typically the account root user.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group. Must be unique within the account and can contain up to 128 characters. " +
+					"Valid characters: alphanumeric characters, plus (+), equals (=), comma (,), period (.), at (@), underscore (_), and hyphen (-).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[\w+=,.@-]+$`),
+						"must contain only alphanumeric characters, plus (+), equals (=), comma (,), period (.), at (@), underscore (_), and hyphen (-)",
+					),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path to the group. Default is `/`. Paths must begin and end with `/`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("/"),
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 512),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^/.*/$|^/$`),
+						"must begin and end with /",
+					),
+				},
+			},
+			"arn": schema.StringAttribute{
+				MarkdownDescription: "Amazon Resource Name (ARN) of the group. Changes when the path changes.",
+				Computed:            true,
+			},
+			"unique_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				MarkdownDescription: "Date and time when the group was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_Accounts) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing IAM groups", CephFeature_Accounts)
+	}
+}
+
+func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "CreateGroup")
+	params.Set("GroupName", plan.Name.ValueString())
+	params.Set("Path", plan.Path.ValueString())
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Group",
+			fmt.Sprintf("Could not create group %s: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var response createGroupResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse CreateGroup response: %s", err.Error()),
+		)
+		return
+	}
+
+	group := response.Result.Group
+
+	plan.ARN = types.StringValue(group.Arn)
+	plan.UniqueID = types.StringValue(group.GroupId)
+	plan.CreateDate = types.StringValue(group.CreateDate)
+	plan.Path = types.StringValue(group.Path)
+
+	tflog.Trace(ctx, "Created group", map[string]any{
+		"name": plan.Name.ValueString(),
+		"arn":  group.Arn,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := getGroup(ctx, r.iamClient, state.Name.ValueString(), "")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group not found, removing from state", map[string]any{
+				"name": state.Name.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Group",
+			fmt.Sprintf("Could not read group %s: %s", state.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	group := result.Group
+
+	state.ARN = types.StringValue(group.Arn)
+	state.UniqueID = types.StringValue(group.GroupId)
+	state.CreateDate = types.StringValue(group.CreateDate)
+	state.Path = types.StringValue(group.Path)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The name forces replacement, so only the path can change here
+	if !plan.Path.Equal(state.Path) {
+		params := url.Values{}
+		params.Set("Action", "UpdateGroup")
+		params.Set("GroupName", plan.Name.ValueString())
+		params.Set("NewPath", plan.Path.ValueString())
+
+		_, err := r.iamClient.DoRequest(ctx, params, "iam")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Group",
+				fmt.Sprintf("Could not update group %s: %s", plan.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Updated group", map[string]any{
+			"name": plan.Name.ValueString(),
+			"path": plan.Path.ValueString(),
+		})
+	}
+
+	// The ARN includes the path, so read it back
+	result, err := getGroup(ctx, r.iamClient, plan.Name.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group",
+			fmt.Sprintf("Group %s was updated but could not be read back: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ARN = types.StringValue(result.Group.Arn)
+	plan.UniqueID = state.UniqueID
+	plan.CreateDate = state.CreateDate
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DeleteGroup")
+	params.Set("GroupName", state.Name.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group already deleted", map[string]any{
+				"name": state.Name.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Group",
+			fmt.Sprintf("Could not delete group %s: %s. Note: Groups cannot be deleted while they have members or policies.", state.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted group", map[string]any{
+		"name": state.Name.ValueString(),
+	})
+}
+
+func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// getGroup calls GetGroup for one page of members, starting at marker.
+func getGroup(ctx context.Context, iamClient *IAMClient, groupName, marker string) (*getGroupResult, error) {
+	params := url.Values{}
+	params.Set("Action", "GetGroup")
+	params.Set("GroupName", groupName)
+	if marker != "" {
+		params.Set("Marker", marker)
+	}
+
+	body, err := iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		return nil, err
+	}
+
+	var response getGroupResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("could not parse GetGroup response: %w", err)
+	}
+	return &response.Result, nil
+}
+
+// listGroupUsers returns the names of the members of a group.
+func listGroupUsers(ctx context.Context, iamClient *IAMClient, groupName string) ([]string, error) {
+	var users []string
+	marker := ""
+	for {
+		result, err := getGroup(ctx, iamClient, groupName, marker)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range result.Users {
+			users = append(users, user.UserName)
+		}
+		if !result.IsTruncated {
+			return users, nil
+		}
+		marker = result.Marker
+	}
+}
@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupMembershipResource{}
+var _ resource.ResourceWithImportState = &GroupMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &GroupMembershipResource{}
+
+func NewIAMGroupMembershipResource() resource.Resource {
+	return &GroupMembershipResource{}
+}
+
+// GroupMembershipResource defines the resource implementation.
+type GroupMembershipResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// GroupMembershipResourceModel describes the resource data model.
+type GroupMembershipResourceModel struct {
+	Group types.String `tfsdk:"group"`
+	Users types.Set    `tfsdk:"users"`
+	ID    types.String `tfsdk:"id"`
+}
+
+func (r *GroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_group_membership"
+}
+
+func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the members of an IAM group in a RadosGW account.\n\n" +
+			"~> **Note:** This resource is authoritative: users that are members of the group but not listed in " +
+			"`users` are removed from it. Declare a single `radosgw_iam_group_membership` per group.\n\n" +
+			"~> **Note:** Groups require Ceph Squid (19.x) or higher, and the group and its members must belong to " +
+			"the same account as the provider credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"users": schema.SetAttribute{
+				MarkdownDescription: "The IAM user names of the members of the group. The IAM user name of an account " +
+					"user is its `display_name`.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *GroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *GroupMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_Accounts) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing IAM group membership", CephFeature_Accounts)
+	}
+}
+
+func (r *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := plan.Group.ValueString()
+
+	var users []string
+	resp.Diagnostics.Append(plan.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove members added out-of-band, so that the group matches the configuration
+	current, err := listGroupUsers(ctx, r.iamClient, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Membership",
+			fmt.Sprintf("Could not list the members of group %s: %s", group, err.Error()),
+		)
+		return
+	}
+
+	if err := r.reconcileMembers(ctx, group, current, users); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Group Membership",
+			fmt.Sprintf("Could not set the members of group %s: %s", group, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(group)
+
+	tflog.Trace(ctx, "Created group membership", map[string]any{
+		"group": group,
+		"users": len(users),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := state.Group.ValueString()
+
+	users, err := listGroupUsers(ctx, r.iamClient, group)
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group not found, removing membership from state", map[string]any{
+				"group": group,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Group Membership",
+			fmt.Sprintf("Could not list the members of group %s: %s", group, err.Error()),
+		)
+		return
+	}
+
+	usersSet, diags := types.SetValueFrom(ctx, types.StringType, users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Users = usersSet
+	state.ID = types.StringValue(group)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := plan.Group.ValueString()
+
+	var current, users []string
+	resp.Diagnostics.Append(state.Users.ElementsAs(ctx, &current, false)...)
+	resp.Diagnostics.Append(plan.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcileMembers(ctx, group, current, users); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Group Membership",
+			fmt.Sprintf("Could not update the members of group %s: %s", group, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(group)
+
+	tflog.Debug(ctx, "Updated group membership", map[string]any{
+		"group": group,
+		"users": len(users),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := state.Group.ValueString()
+
+	var users []string
+	resp.Diagnostics.Append(state.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcileMembers(ctx, group, users, nil); err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group already deleted", map[string]any{
+				"group": group,
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Membership",
+			fmt.Sprintf("Could not remove the members of group %s: %s", group, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted group membership", map[string]any{
+		"group": group,
+	})
+}
+
+func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by group name
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+}
+
+// reconcileMembers removes the users of current that are not in desired and
+// adds the users of desired that are not in current.
+func (r *GroupMembershipResource) reconcileMembers(ctx context.Context, group string, current, desired []string) error {
+	keep := make(map[string]bool, len(desired))
+	for _, user := range desired {
+		keep[user] = true
+	}
+	existing := make(map[string]bool, len(current))
+	for _, user := range current {
+		existing[user] = true
+	}
+
+	for _, user := range current {
+		if keep[user] {
+			continue
+		}
+		if err := r.groupMemberRequest(ctx, "RemoveUserFromGroup", group, user); err != nil {
+			// The user may have been deleted or removed out-of-band; only
+			// a missing group is an error
+			if errors.Is(err, ErrNoSuchEntity) {
+				if _, getErr := getGroup(ctx, r.iamClient, group, ""); getErr == nil {
+					continue
+				}
+			}
+			return fmt.Errorf("removing user %s: %w", user, err)
+		}
+	}
+
+	for _, user := range desired {
+		if existing[user] {
+			continue
+		}
+		if err := r.groupMemberRequest(ctx, "AddUserToGroup", group, user); err != nil {
+			return fmt.Errorf("adding user %s: %w", user, err)
+		}
+	}
+
+	return nil
+}
+
+// groupMemberRequest calls AddUserToGroup or RemoveUserFromGroup.
+func (r *GroupMembershipResource) groupMemberRequest(ctx context.Context, action, group, user string) error {
+	params := url.Values{}
+	params.Set("Action", action)
+	params.Set("GroupName", group)
+	params.Set("UserName", user)
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	return err
+}
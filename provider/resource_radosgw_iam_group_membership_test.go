@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMGroupMembership_basic(t *testing.T) {
+	t.Parallel()
+
+	accountName := randomName("tf-acc-account")
+	rootUserID := randomName("tf-acc-root")
+	groupName := randomName("tf-acc-group")
+	userName1 := randomName("tf-acc-user")
+	userName2 := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID),
+			},
+			{
+				Config: testAccRadosgwIAMGroupMembershipConfig(accountName, rootUserID, groupName, userName1, userName2, `[radosgw_iam_user.one.display_name]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group_membership.test", "group", groupName),
+					resource.TestCheckResourceAttr("radosgw_iam_group_membership.test", "id", groupName),
+					resource.TestCheckResourceAttr("radosgw_iam_group_membership.test", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("radosgw_iam_group_membership.test", "users.*", userName1),
+				),
+			},
+			// Add a member and remove the other
+			{
+				Config: testAccRadosgwIAMGroupMembershipConfig(accountName, rootUserID, groupName, userName1, userName2, `[radosgw_iam_user.two.display_name]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group_membership.test", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("radosgw_iam_group_membership.test", "users.*", userName2),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMGroupMembershipConfig(accountName, rootUserID, groupName, userName1, userName2, `[radosgw_iam_user.one.display_name, radosgw_iam_user.two.display_name]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group_membership.test", "users.#", "2"),
+				),
+			},
+			// Import test - format: group_name
+			{
+				ResourceName:                         "radosgw_iam_group_membership.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        groupName,
+				ImportStateVerifyIdentifierAttribute: "group",
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMGroupMembershipConfig(accountName, rootUserID, groupName, userName1, userName2, users string) string {
+	return testAccRadosgwIAMPolicyConfig_accountProvider(accountName, rootUserID) + fmt.Sprintf(`
+resource "radosgw_iam_group" "test" {
+  provider = radosgw.account
+  name     = %[1]q
+}
+
+resource "radosgw_iam_user" "one" {
+  user_id      = %[2]q
+  display_name = %[2]q
+  account_id   = radosgw_iam_account.test.id
+}
+
+resource "radosgw_iam_user" "two" {
+  user_id      = %[3]q
+  display_name = %[3]q
+  account_id   = radosgw_iam_account.test.id
+}
+
+resource "radosgw_iam_group_membership" "test" {
+  provider = radosgw.account
+  group    = radosgw_iam_group.test.name
+  users    = %[4]s
+}
+`, groupName, userName1, userName2, users)
+}
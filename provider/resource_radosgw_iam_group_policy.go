@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupPolicyResource{}
+var _ resource.ResourceWithImportState = &GroupPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &GroupPolicyResource{}
+
+func NewIAMGroupPolicyResource() resource.Resource {
+	return &GroupPolicyResource{}
+}
+
+// GroupPolicyResource defines the resource implementation.
+type GroupPolicyResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// GroupPolicyResourceModel describes the resource data model.
+type GroupPolicyResourceModel struct {
+	Group  types.String `tfsdk:"group"`
+	Name   types.String `tfsdk:"name"`
+	Policy types.String `tfsdk:"policy"`
+	ID     types.String `tfsdk:"id"`
+}
+
+// XML response structures for RadosGW Group Policy API
+type getGroupPolicyResponseXML struct {
+	XMLName xml.Name             `xml:"GetGroupPolicyResponse"`
+	Result  getGroupPolicyResult `xml:"GetGroupPolicyResult"`
+}
+
+type getGroupPolicyResult struct {
+	GroupName      string `xml:"GroupName"`
+	PolicyName     string `xml:"PolicyName"`
+	PolicyDocument string `xml:"PolicyDocument"`
+}
+
+func (r *GroupPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_group_policy"
+}
+
+func (r *GroupPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an inline IAM policy for an IAM group in a RadosGW account. The policy " +
+			"applies to every member of the group.\n\n" +
+			"~> **Note:** Groups require Ceph Squid (19.x) or higher, and the group must belong to the same account " +
+			"as the provider credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the group to associate the policy with.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the policy. Must be unique within the group.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[\w+=,.@-]+$`),
+						"must contain only alphanumeric characters, plus (+), equals (=), comma (,), period (.), at (@), underscore (_), and hyphen (-)",
+					),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The policy document (in JSON format). Use `jsonencode()` or the " +
+					"`radosgw_iam_policy_document` data source to generate this.",
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for this policy. Format: `group:policy_name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *GroupPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *GroupPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_Accounts) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing IAM group policies", CephFeature_Accounts)
+	}
+}
+
+func (r *GroupPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "PutGroupPolicy")
+	params.Set("GroupName", plan.Group.ValueString())
+	params.Set("PolicyName", plan.Name.ValueString())
+	params.Set("PolicyDocument", normalizedPolicy)
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Group Policy",
+			fmt.Sprintf("Could not create policy %s for group %s: %s", plan.Name.ValueString(), plan.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Set computed fields
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.Group.ValueString(), plan.Name.ValueString()))
+	plan.Policy = types.StringValue(normalizedPolicy)
+
+	tflog.Trace(ctx, "Created group policy", map[string]any{
+		"group":  plan.Group.ValueString(),
+		"policy": plan.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "GetGroupPolicy")
+	params.Set("GroupName", state.Group.ValueString())
+	params.Set("PolicyName", state.Name.ValueString())
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group policy not found, removing from state", map[string]any{
+				"group":  state.Group.ValueString(),
+				"policy": state.Name.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Group Policy",
+			fmt.Sprintf("Could not read policy %s for group %s: %s", state.Name.ValueString(), state.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var response getGroupPolicyResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse GetGroupPolicy response: %s", err.Error()),
+		)
+		return
+	}
+
+	// URL decode the policy if it's URL-encoded
+	policyDoc := response.Result.PolicyDocument
+	decodedPolicy, err := url.QueryUnescape(policyDoc)
+	if err != nil {
+		decodedPolicy = policyDoc
+	}
+
+	// Normalize the policy for comparison
+	normalizedPolicy, err := normalizeJSONPolicy(decodedPolicy)
+	if err == nil {
+		state.Policy = types.StringValue(normalizedPolicy)
+	} else {
+		state.Policy = types.StringValue(decodedPolicy)
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s:%s", state.Group.ValueString(), state.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GroupPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GroupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	// PutGroupPolicy is idempotent - it creates or updates
+	params := url.Values{}
+	params.Set("Action", "PutGroupPolicy")
+	params.Set("GroupName", plan.Group.ValueString())
+	params.Set("PolicyName", plan.Name.ValueString())
+	params.Set("PolicyDocument", normalizedPolicy)
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Group Policy",
+			fmt.Sprintf("Could not update policy %s for group %s: %s", plan.Name.ValueString(), plan.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.Policy = types.StringValue(normalizedPolicy)
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.Group.ValueString(), plan.Name.ValueString()))
+
+	tflog.Debug(ctx, "Updated group policy", map[string]any{
+		"group":  plan.Group.ValueString(),
+		"policy": plan.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GroupPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DeleteGroupPolicy")
+	params.Set("GroupName", state.Group.ValueString())
+	params.Set("PolicyName", state.Name.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Group policy already deleted", map[string]any{
+				"group":  state.Group.ValueString(),
+				"policy": state.Name.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Policy",
+			fmt.Sprintf("Could not delete policy %s for group %s: %s", state.Name.ValueString(), state.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted group policy", map[string]any{
+		"group":  state.Group.ValueString(),
+		"policy": state.Name.ValueString(),
+	})
+}
+
+func (r *GroupPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "group_name:policy_name"
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'group_name:policy_name'. Example: 'my-group:my-policy'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMGroupPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	accountName := randomName("tf-acc-account")
+	rootUserID := randomName("tf-acc-root")
+	groupName := randomName("tf-acc-group")
+	policyName := randomName("tf-acc-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID),
+			},
+			{
+				Config: testAccRadosgwIAMGroupPolicyConfig_basic(accountName, rootUserID, groupName, policyName, `["s3:GetObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group_policy.test", "group", groupName),
+					resource.TestCheckResourceAttr("radosgw_iam_group_policy.test", "name", policyName),
+					resource.TestCheckResourceAttr("radosgw_iam_group_policy.test", "id", groupName+":"+policyName),
+				),
+			},
+			// Import test - format: group_name:policy_name
+			{
+				ResourceName:                         "radosgw_iam_group_policy.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        groupName + ":" + policyName,
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+			{
+				Config: testAccRadosgwIAMGroupPolicyConfig_basic(accountName, rootUserID, groupName, policyName, `["s3:GetObject", "s3:PutObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group_policy.test", "name", policyName),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMGroupPolicyConfig_basic(accountName, rootUserID, groupName, policyName, actions string) string {
+	return testAccRadosgwIAMPolicyConfig_accountProvider(accountName, rootUserID) + fmt.Sprintf(`
+resource "radosgw_iam_group" "test" {
+  provider = radosgw.account
+  name     = %q
+}
+
+resource "radosgw_iam_group_policy" "test" {
+  provider = radosgw.account
+  group    = radosgw_iam_group.test.name
+  name     = %q
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = %s
+        Resource = ["arn:aws:s3:::*"]
+      }
+    ]
+  })
+}
+`, groupName, policyName, actions)
+}
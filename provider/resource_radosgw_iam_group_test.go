@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMGroup_basic(t *testing.T) {
+	t.Parallel()
+
+	accountName := randomName("tf-acc-account")
+	rootUserID := randomName("tf-acc-root")
+	groupName := randomName("tf-acc-group")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			// The account root user and its keys must exist before the
+			// account provider can be configured
+			{
+				Config: testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID),
+			},
+			{
+				Config: testAccRadosgwIAMGroupConfig_basic(accountName, rootUserID, groupName, "/"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group.test", "name", groupName),
+					resource.TestCheckResourceAttr("radosgw_iam_group.test", "path", "/"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_group.test", "arn"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_group.test", "unique_id"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_group.test", "create_date"),
+				),
+			},
+			// Import test - format: group_name
+			{
+				ResourceName:                         "radosgw_iam_group.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        groupName,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// The path is updated in place
+			{
+				Config: testAccRadosgwIAMGroupConfig_basic(accountName, rootUserID, groupName, "/engineering/"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_group.test", "path", "/engineering/"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_group.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMGroupConfig_basic(accountName, rootUserID, groupName, groupPath string) string {
+	return testAccRadosgwIAMPolicyConfig_accountProvider(accountName, rootUserID) + fmt.Sprintf(`
+resource "radosgw_iam_group" "test" {
+  provider = radosgw.account
+  name     = %q
+  path     = %q
+}
+`, groupName, groupPath)
+}
@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// otpTypeTOTP is the type of time-based devices in the otp metadata section.
+const otpTypeTOTP = 2
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MFADeviceResource{}
+var _ resource.ResourceWithImportState = &MFADeviceResource{}
+
+func NewIAMMFADeviceResource() resource.Resource {
+	return &MFADeviceResource{}
+}
+
+// MFADeviceResource defines the resource implementation.
+type MFADeviceResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// MFADeviceResourceModel describes the resource data model.
+type MFADeviceResourceModel struct {
+	UserID      types.String `tfsdk:"user_id"`
+	Serial      types.String `tfsdk:"serial"`
+	Seed        types.String `tfsdk:"seed"`
+	SeedType    types.String `tfsdk:"seed_type"`
+	TOTPSeconds types.Int64  `tfsdk:"totp_seconds"`
+	TOTPWindow  types.Int64  `tfsdk:"totp_window"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// metadataEntry is an entry of the Admin API metadata endpoints, as
+// returned by GET and expected by PUT /admin/metadata/{section}.
+type metadataEntry struct {
+	Key   string          `json:"key"`
+	Ver   metadataVersion `json:"ver"`
+	Mtime string          `json:"mtime"`
+	Data  json.RawMessage `json:"data"`
+	Attrs json.RawMessage `json:"attrs,omitempty"`
+}
+
+type metadataVersion struct {
+	Tag string `json:"tag"`
+	Ver uint64 `json:"ver"`
+}
+
+// otpDevices is the data of an entry of the otp metadata section.
+type otpDevices struct {
+	Devices []otpDevice `json:"devices"`
+}
+
+type otpDevice struct {
+	Type     int    `json:"type"`
+	ID       string `json:"id"`
+	Seed     string `json:"seed"`
+	SeedType string `json:"seed_type"`
+	TimeOfs  int64  `json:"time_ofs"`
+	StepSize int64  `json:"step_size"`
+	Window   int64  `json:"window"`
+}
+
+func (r *MFADeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_mfa_device"
+}
+
+func (r *MFADeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a TOTP MFA device of a RadosGW user, the equivalent of `radosgw-admin mfa create`. " +
+			"MFA devices are required to delete object versions or change the versioning of buckets with MFA delete " +
+			"enabled, for example in object lock governance bypass setups.\n\n" +
+			"The Admin Ops API has no MFA endpoint, so the device is written through the `otp` and `user` sections " +
+			"of the metadata API, which requires the provider credentials to have the `metadata=read,write` capability.\n\n" +
+			"~> **Note:** The seed is stored in the Terraform state. Treat the state as sensitive.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The user the device belongs to. Use the `tenant$user` format for users of a tenant.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"serial": schema.StringAttribute{
+				MarkdownDescription: "The serial of the device, passed with the code in the `x-amz-mfa` header.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^:]+$`), "must not be empty or contain colons"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"seed": schema.StringAttribute{
+				MarkdownDescription: "The TOTP seed shared with the authenticator, encoded as set in `seed_type`.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"seed_type": schema.StringAttribute{
+				MarkdownDescription: "The encoding of `seed`. Valid values: `hex`, `base32`. Default: `hex`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("hex"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("hex", "base32"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"totp_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The number of seconds each code is valid for. Default: `30`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"totp_window": schema.Int64Attribute{
+				MarkdownDescription: "The number of codes before and after the current one that are still accepted. Default: `2`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(2),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The device identifier in the format `{user_id}:{serial}`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MFADeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *MFADeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MFADeviceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	serial := data.Serial.ValueString()
+
+	tflog.Debug(ctx, "Creating MFA device", map[string]any{
+		"user_id": userID,
+		"serial":  serial,
+	})
+
+	// The devices of a user are stored in a single metadata entry, so
+	// concurrent read-modify-write cycles would lose devices
+	defer userLocks.lock(userID)()
+
+	entry, devices, err := r.getDevices(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading MFA Devices",
+			fmt.Sprintf("Could not read MFA devices of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	if slices.ContainsFunc(devices, func(device otpDevice) bool { return device.ID == serial }) {
+		resp.Diagnostics.AddError(
+			"MFA Device Already Exists",
+			fmt.Sprintf("User %s already has an MFA device with serial %s. Import it with the ID %s:%s.", userID, serial, userID, serial),
+		)
+		return
+	}
+
+	devices = append(devices, otpDevice{
+		Type:     otpTypeTOTP,
+		ID:       serial,
+		Seed:     data.Seed.ValueString(),
+		SeedType: data.SeedType.ValueString(),
+		StepSize: data.TOTPSeconds.ValueInt64(),
+		Window:   data.TOTPWindow.ValueInt64(),
+	})
+
+	if err := r.putDevices(ctx, userID, entry, devices); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating MFA Device",
+			fmt.Sprintf("Could not store MFA device %s of user %s: %s", serial, userID, err.Error()),
+		)
+		return
+	}
+
+	// S3 only accepts the serials listed in the user info
+	if err := r.updateUserMFAIDs(ctx, userID, serial, true); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating MFA Device",
+			fmt.Sprintf("Could not add MFA device %s to user %s: %s", serial, userID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(userID + ":" + serial)
+
+	tflog.Trace(ctx, "Created MFA device", map[string]any{
+		"user_id": userID,
+		"serial":  serial,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MFADeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MFADeviceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	serial := data.Serial.ValueString()
+
+	tflog.Debug(ctx, "Reading MFA device", map[string]any{
+		"user_id": userID,
+		"serial":  serial,
+	})
+
+	_, devices, err := r.getDevices(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading MFA Devices",
+			fmt.Sprintf("Could not read MFA devices of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	index := slices.IndexFunc(devices, func(device otpDevice) bool { return device.ID == serial })
+	if index < 0 {
+		tflog.Info(ctx, "MFA device not found, removing from state", map[string]any{
+			"user_id": userID,
+			"serial":  serial,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	device := devices[index]
+	data.Seed = types.StringValue(device.Seed)
+	data.SeedType = types.StringValue(device.SeedType)
+	data.TOTPSeconds = types.Int64Value(device.StepSize)
+	data.TOTPWindow = types.Int64Value(device.Window)
+	data.ID = types.StringValue(userID + ":" + serial)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MFADeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement, so Update is never called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"MFA devices cannot be updated in place. This is a provider bug.",
+	)
+}
+
+func (r *MFADeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MFADeviceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	serial := data.Serial.ValueString()
+
+	tflog.Debug(ctx, "Deleting MFA device", map[string]any{
+		"user_id": userID,
+		"serial":  serial,
+	})
+
+	// The devices of a user are stored in a single metadata entry, so
+	// concurrent read-modify-write cycles would lose devices
+	defer userLocks.lock(userID)()
+
+	entry, devices, err := r.getDevices(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading MFA Devices",
+			fmt.Sprintf("Could not read MFA devices of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(devices), func(device otpDevice) bool { return device.ID == serial })
+	if len(remaining) != len(devices) {
+		if err := r.putDevices(ctx, userID, entry, remaining); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting MFA Device",
+				fmt.Sprintf("Could not remove MFA device %s of user %s: %s", serial, userID, err.Error()),
+			)
+			return
+		}
+	}
+
+	err = r.updateUserMFAIDs(ctx, userID, serial, false)
+	if isAdminNotFoundError(err) {
+		// The user was deleted first, which removed its MFA devices
+		addUserDeletedFirstWarning(&resp.Diagnostics, "MFA devices", userID)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting MFA Device",
+			fmt.Sprintf("Could not remove MFA device %s from user %s: %s", serial, userID, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted MFA device", map[string]any{
+		"user_id": userID,
+		"serial":  serial,
+	})
+}
+
+func (r *MFADeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "user_id:serial"
+	userID, serial, ok := strings.Cut(req.ID, ":")
+	if !ok || userID == "" || serial == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'user_id:serial'. Example: 'myuser:totp-1'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serial"), serial)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// getDevices returns the otp metadata entry of a user and its devices. The
+// entry is nil when the user has no devices.
+func (r *MFADeviceResource) getDevices(ctx context.Context, userID string) (*metadataEntry, []otpDevice, error) {
+	entry, err := r.adminClient.GetMetadataEntry(ctx, "otp", userID)
+	if isAdminNotFoundError(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var data otpDevices
+	if err := json.Unmarshal(entry.Data, &data); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse otp metadata: %w", err)
+	}
+	return entry, data.Devices, nil
+}
+
+// putDevices replaces the devices of a user, removing the otp metadata entry
+// when no device is left.
+func (r *MFADeviceResource) putDevices(ctx context.Context, userID string, entry *metadataEntry, devices []otpDevice) error {
+	if len(devices) == 0 {
+		_, err := r.adminClient.DoRequest(ctx, http.MethodDelete, "/metadata/otp", url.Values{"key": {userID}})
+		if isAdminNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if entry == nil {
+		entry = &metadataEntry{Key: userID}
+	}
+
+	data, err := json.Marshal(otpDevices{Devices: devices})
+	if err != nil {
+		return err
+	}
+	entry.Data = data
+
+	return r.adminClient.PutMetadataEntry(ctx, "otp", userID, entry)
+}
+
+// updateUserMFAIDs adds or removes a serial from the mfa_ids of the user
+// info. The Admin Ops user endpoints do not expose them, so the whole user
+// metadata entry is rewritten with only that field changed.
+func (r *MFADeviceResource) updateUserMFAIDs(ctx context.Context, userID, serial string, add bool) error {
+	entry, err := r.adminClient.GetMetadataEntry(ctx, "user", userID)
+	if err != nil {
+		return err
+	}
+
+	var info map[string]json.RawMessage
+	if err := json.Unmarshal(entry.Data, &info); err != nil {
+		return fmt.Errorf("failed to parse user metadata: %w", err)
+	}
+
+	var mfaIDs []string
+	if raw, ok := info["mfa_ids"]; ok {
+		if err := json.Unmarshal(raw, &mfaIDs); err != nil {
+			return fmt.Errorf("failed to parse mfa_ids of user metadata: %w", err)
+		}
+	}
+
+	present := slices.Contains(mfaIDs, serial)
+	switch {
+	case add && !present:
+		mfaIDs = append(mfaIDs, serial)
+	case !add && present:
+		mfaIDs = slices.DeleteFunc(mfaIDs, func(id string) bool { return id == serial })
+	default:
+		return nil
+	}
+	slices.Sort(mfaIDs)
+
+	if info["mfa_ids"], err = json.Marshal(mfaIDs); err != nil {
+		return err
+	}
+	if entry.Data, err = json.Marshal(info); err != nil {
+		return err
+	}
+
+	return r.adminClient.PutMetadataEntry(ctx, "user", userID, entry)
+}
+
+// GetMetadataEntry reads an entry of a metadata section.
+func (c *AdminClient) GetMetadataEntry(ctx context.Context, section, key string) (*metadataEntry, error) {
+	body, err := c.DoRequest(ctx, http.MethodGet, "/metadata/"+section, url.Values{"key": {key}})
+	if err != nil {
+		return nil, err
+	}
+
+	var entry metadataEntry
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s metadata: %w", section, err)
+	}
+	return &entry, nil
+}
+
+// PutMetadataEntry writes an entry of a metadata section with a new version
+// and modification time, so that other zones apply the change.
+func (c *AdminClient) PutMetadataEntry(ctx context.Context, section, key string, entry *metadataEntry) error {
+	entry.Ver.Ver++
+	entry.Mtime = time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DoRequestWithBody(ctx, http.MethodPut, "/metadata/"+section, url.Values{"key": {key}}, body)
+	return err
+}
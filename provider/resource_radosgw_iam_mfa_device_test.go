@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMMFADevice_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMMFADeviceConfig(userID, `
+resource "radosgw_iam_mfa_device" "test" {
+  user_id = radosgw_iam_user.test.user_id
+  serial  = "totp-1"
+  seed    = "0123456789abcdef0123456789abcdef"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.test", "id", userID+":totp-1"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.test", "seed_type", "hex"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.test", "totp_seconds", "30"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.test", "totp_window", "2"),
+				),
+			},
+			// Import test - format: user_id:serial
+			{
+				ResourceName:      "radosgw_iam_mfa_device.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     userID + ":totp-1",
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMMFADevice_multiple(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	devices := `
+resource "radosgw_iam_mfa_device" "first" {
+  user_id   = radosgw_iam_user.test.user_id
+  serial    = "totp-1"
+  seed      = "JBSWY3DPEHPK3PXP"
+  seed_type = "base32"
+}
+
+resource "radosgw_iam_mfa_device" "second" {
+  user_id      = radosgw_iam_user.test.user_id
+  serial       = "totp-2"
+  seed         = "KRSXG5CTMVRXEZLU"
+  seed_type    = "base32"
+  totp_seconds = 60
+  totp_window  = 1
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			// Devices of the same user are created concurrently
+			{
+				Config: testAccRadosgwIAMMFADeviceConfig(userID, devices),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.first", "serial", "totp-1"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.second", "serial", "totp-2"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.second", "totp_seconds", "60"),
+					resource.TestCheckResourceAttr("radosgw_iam_mfa_device.second", "totp_window", "1"),
+				),
+			},
+			// Both devices are still there on refresh
+			{
+				Config:   testAccRadosgwIAMMFADeviceConfig(userID, devices),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMMFADeviceConfig(userID, devices string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for MFA"
+}
+`, userID) + devices
+}
@@ -24,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OIDCProviderResource{}
 var _ resource.ResourceWithImportState = &OIDCProviderResource{}
+var _ resource.ResourceWithModifyPlan = &OIDCProviderResource{}
 
 func NewIAMOIDCProviderResource() resource.Resource {
 	return &OIDCProviderResource{}
@@ -42,6 +43,15 @@ type OIDCProviderResourceModel struct {
 	ClientIDList   types.Set    `tfsdk:"client_id_list"`
 	ThumbprintList types.List   `tfsdk:"thumbprint_list"`
 	AllowUpdates   types.Bool   `tfsdk:"allow_updates"`
+	Issuer         types.String `tfsdk:"issuer"`
+}
+
+// oidcIssuer returns the provider URL without its protocol. RadosGW uses it as
+// the suffix of the provider ARN and as the prefix of the claim condition keys
+// (for example `<issuer>:sub`) in role trust policies.
+func oidcIssuer(providerURL string) string {
+	issuer := strings.TrimPrefix(providerURL, "https://")
+	return strings.TrimPrefix(issuer, "http://")
 }
 
 // Custom plan modifiers for conditional replacement based on allow_updates
@@ -139,7 +149,11 @@ func (r *OIDCProviderResource) Schema(ctx context.Context, req resource.SchemaRe
 			"~> **Note:** RadosGW normalizes OIDC provider URLs by stripping the protocol (`http://`, `https://`) " +
 			"when constructing ARNs. This means `http://example.com` and `https://example.com` would result in the " +
 			"**same** ARN `arn:aws:iam:::oidc-provider/example.com`. You cannot create separate providers for the " +
-			"same domain with different protocols. Changing the protocol in the URL triggers resource replacement.",
+			"same domain with different protocols. Changing the protocol in the URL triggers resource replacement.\n\n" +
+			"RadosGW does not accept client secrets or claim mapping parameters on the provider itself. Federated " +
+			"claims are mapped to roles in the role trust policy instead, through condition keys prefixed with " +
+			"`issuer` (such as `<issuer>:sub` and `<issuer>:aud`), and session tags are read from the " +
+			"`https://aws.amazon.com/tags` claim of the token when the trust policy allows `sts:TagSession`.",
 
 		Attributes: map[string]schema.Attribute{
 			"arn": schema.StringAttribute{
@@ -170,7 +184,8 @@ func (r *OIDCProviderResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"thumbprint_list": schema.ListAttribute{
 				MarkdownDescription: "List of certificate thumbprints for the OpenID Connect provider's IDP certificate(s). " +
-					"Each thumbprint is a hex-encoded SHA-1 hash (40 characters). A maximum of 5 thumbprints are allowed.",
+					"Each thumbprint is a hex-encoded SHA-1 hash (40 characters). A maximum of 5 thumbprints are allowed. " +
+					"Use the `radosgw_iam_openid_connect_thumbprint` data source to compute it from the provider URL.",
 				Required:    true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
@@ -185,11 +200,19 @@ func (r *OIDCProviderResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Whether to allow in-place updates for `client_id_list` and `thumbprint_list`. " +
 					"When `true` (default), changes will be applied in-place. " +
 					"When `false`, changes to these attributes will destroy and recreate the provider. " +
-					"~> **Note:** In-place updates require Ceph Tentacle (20.x). On older versions, set to `false`.",
+					"~> **Note:** In-place updates require Ceph Tentacle (20.x). On older versions, the provider is replaced instead when the Ceph version is detected; otherwise set to `false`.",
 				Optional: true,
 				Computed: true,
 				Default:  booldefault.StaticBool(true),
 			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "The provider URL without the protocol. Use it to build the claim condition keys " +
+					"of role trust policies, for example `\"${radosgw_iam_openid_connect_provider.example.issuer}:sub\"`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -210,12 +233,49 @@ func (r *OIDCProviderResource) Configure(ctx context.Context, req resource.Confi
 
 	r.client = client
 	// Create IAM client using the same credentials and endpoint
-	r.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	r.iamClient = client.IAM
+}
+
+func (r *OIDCProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if r.client.supportsFeature(CephFeature_OIDCProviderUpdate) {
+		return
+	}
+
+	var plan, state OIDCProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without allow_updates the plan modifiers already force replacement
+	if !plan.AllowUpdates.ValueBool() {
+		return
+	}
+
+	// Fall back to replacement instead of failing mid-apply on clusters
+	// without the update APIs
+	replace := false
+	if !plan.ClientIDList.Equal(state.ClientIDList) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("client_id_list"))
+		replace = true
+	}
+	if !plan.ThumbprintList.Equal(state.ThumbprintList) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("thumbprint_list"))
+		replace = true
+	}
+
+	if replace {
+		resp.Diagnostics.AddWarning(
+			"OIDC Provider Will Be Replaced",
+			fmt.Sprintf("In-place updates of client_id_list and thumbprint_list require Ceph %s or later, but the cluster runs Ceph %s. "+
+				"The provider %s will be replaced instead.", CephFeature_OIDCProviderUpdate.MinVersion(), r.client.CephVersion, state.URL.ValueString()),
+		)
+	}
 }
 
 func (r *OIDCProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -277,6 +337,7 @@ func (r *OIDCProviderResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	plan.ARN = types.StringValue(response.Result.OpenIDConnectProviderArn)
+	plan.Issuer = types.StringValue(oidcIssuer(plan.URL.ValueString()))
 
 	tflog.Trace(ctx, "Created OIDC provider", map[string]interface{}{
 		"arn": response.Result.OpenIDConnectProviderArn,
@@ -326,6 +387,7 @@ func (r *OIDCProviderResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	state.URL = types.StringValue(response.Result.URL)
+	state.Issuer = types.StringValue(oidcIssuer(response.Result.URL))
 
 	clientIDSet, diags := types.SetValueFrom(ctx, types.StringType, response.Result.ClientIDList.Members)
 	resp.Diagnostics.Append(diags...)
@@ -341,6 +403,11 @@ func (r *OIDCProviderResource) Read(ctx context.Context, req resource.ReadReques
 	}
 	state.ThumbprintList = thumbprintList
 
+	// allow_updates is not stored by RadosGW, imported providers get the default
+	if state.AllowUpdates.IsNull() {
+		state.AllowUpdates = types.BoolValue(true)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -477,6 +544,7 @@ func (r *OIDCProviderResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	plan.ARN = state.ARN
+	plan.Issuer = state.Issuer
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -29,6 +29,7 @@ func TestAccRadosgwIAMOIDCProvider_basic(t *testing.T) {
 					testAccCheckRadosgwIAMOIDCProviderExists("radosgw_iam_openid_connect_provider.test"),
 					resource.TestCheckResourceAttr("radosgw_iam_openid_connect_provider.test", "url", providerURL),
 					resource.TestCheckResourceAttrSet("radosgw_iam_openid_connect_provider.test", "arn"),
+					resource.TestCheckResourceAttr("radosgw_iam_openid_connect_provider.test", "issuer", suffix+"-test.example.com"),
 				),
 			},
 			// Import test - by ARN
@@ -36,10 +37,16 @@ func TestAccRadosgwIAMOIDCProvider_basic(t *testing.T) {
 				ResourceName:                         "radosgw_iam_openid_connect_provider.test",
 				ImportState:                          true,
 				ImportStateVerify:                    true,
-				ImportStateVerifyIgnore:              []string{"allow_updates"},
 				ImportStateId:                        providerARN,
 				ImportStateVerifyIdentifierAttribute: "arn",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_openid_connect_provider.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   providerARN,
+			},
 		},
 	})
 }
@@ -99,7 +106,7 @@ func TestAccRadosgwIAMOIDCProvider_inPlaceUpdate(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		// Skip on Ceph versions older than Tentacle (20.x) which supports in-place updates
-		PreCheck:                 func() { testAccPreCheckSkipForVersion(t, CephVersion_Tentacle) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_OIDCProviderUpdate) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwIAMOIDCProviderDestroy,
 		Steps: []resource.TestStep{
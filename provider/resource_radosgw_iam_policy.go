@@ -0,0 +1,597 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PolicyResource{}
+var _ resource.ResourceWithImportState = &PolicyResource{}
+
+func NewIAMPolicyResource() resource.Resource {
+	return &PolicyResource{}
+}
+
+// PolicyResource defines the resource implementation.
+type PolicyResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// PolicyResourceModel describes the resource data model.
+type PolicyResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Path        types.String `tfsdk:"path"`
+	Description types.String `tfsdk:"description"`
+	Policy      types.String `tfsdk:"policy"`
+	ARN         types.String `tfsdk:"arn"`
+	PolicyID    types.String `tfsdk:"policy_id"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// XML response structures for RadosGW managed policy API
+type createPolicyResponseXML struct {
+	XMLName xml.Name           `xml:"CreatePolicyResponse"`
+	Result  createPolicyResult `xml:"CreatePolicyResult"`
+}
+
+type createPolicyResult struct {
+	Policy policyXML `xml:"Policy"`
+}
+
+type getPolicyResponseXML struct {
+	XMLName xml.Name        `xml:"GetPolicyResponse"`
+	Result  getPolicyResult `xml:"GetPolicyResult"`
+}
+
+type getPolicyResult struct {
+	Policy policyXML `xml:"Policy"`
+}
+
+type policyXML struct {
+	PolicyName       string `xml:"PolicyName"`
+	PolicyId         string `xml:"PolicyId"`
+	Arn              string `xml:"Arn"`
+	Path             string `xml:"Path"`
+	Description      string `xml:"Description"`
+	DefaultVersionId string `xml:"DefaultVersionId"`
+}
+
+type getPolicyVersionResponseXML struct {
+	XMLName xml.Name               `xml:"GetPolicyVersionResponse"`
+	Result  getPolicyVersionResult `xml:"GetPolicyVersionResult"`
+}
+
+type getPolicyVersionResult struct {
+	PolicyVersion policyVersionXML `xml:"PolicyVersion"`
+}
+
+type listPolicyVersionsResponseXML struct {
+	XMLName xml.Name                 `xml:"ListPolicyVersionsResponse"`
+	Result  listPolicyVersionsResult `xml:"ListPolicyVersionsResult"`
+}
+
+type listPolicyVersionsResult struct {
+	Versions []policyVersionXML `xml:"Versions>member"`
+}
+
+type policyVersionXML struct {
+	Document         string `xml:"Document"`
+	VersionId        string `xml:"VersionId"`
+	IsDefaultVersion bool   `xml:"IsDefaultVersion"`
+	CreateDate       string `xml:"CreateDate"`
+}
+
+func (r *PolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy"
+}
+
+func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a customer managed IAM policy in RadosGW. Managed policies are standalone " +
+			"policies that can be attached to multiple users and roles with the `radosgw_iam_user_policy_attachment` " +
+			"and `radosgw_iam_role_policy_attachment` resources.\n\n" +
+			"~> **Note:** Managed policies belong to a RadosGW account (see `radosgw_iam_account`) and are only " +
+			"available on Ceph releases that support IAM managed policies. The provider credentials must belong to a " +
+			"user of the account, typically the account root user.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the policy. Must be unique within the account.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[\w+=,.@-]+$`),
+						"must contain only alphanumeric characters, plus (+), equals (=), comma (,), period (.), at (@), underscore (_), and hyphen (-)",
+					),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path to the policy. Default is `/`. Paths must begin and end with `/`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("/"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 512),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^/.*/$|^/$`),
+						"must begin and end with /",
+					),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the policy. Maximum 1000 characters. Changing this value will force resource replacement.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(1000),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The policy document (in JSON format). Use `jsonencode()` or the " +
+					"`radosgw_iam_policy_document` data source to generate this. Changes create a new default policy version.",
+				Required: true,
+			},
+			"arn": schema.StringAttribute{
+				MarkdownDescription: "Amazon Resource Name (ARN) of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "CreatePolicy")
+	params.Set("PolicyName", plan.Name.ValueString())
+	params.Set("Path", plan.Path.ValueString())
+	params.Set("PolicyDocument", normalizedPolicy)
+	if !plan.Description.IsNull() && plan.Description.ValueString() != "" {
+		params.Set("Description", plan.Description.ValueString())
+	}
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Policy",
+			fmt.Sprintf("Could not create policy %s: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var response createPolicyResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse CreatePolicy response: %s", err.Error()),
+		)
+		return
+	}
+
+	policy := response.Result.Policy
+
+	plan.ARN = types.StringValue(policy.Arn)
+	plan.PolicyID = types.StringValue(policy.PolicyId)
+	plan.ID = types.StringValue(policy.Arn)
+	plan.Policy = types.StringValue(normalizedPolicy)
+
+	tflog.Trace(ctx, "Created policy", map[string]any{
+		"name": plan.Name.ValueString(),
+		"arn":  policy.Arn,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyArn := state.ID.ValueString()
+
+	params := url.Values{}
+	params.Set("Action", "GetPolicy")
+	params.Set("PolicyArn", policyArn)
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Policy not found, removing from state", map[string]any{
+				"arn": policyArn,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Policy",
+			fmt.Sprintf("Could not read policy %s: %s", policyArn, err.Error()),
+		)
+		return
+	}
+
+	var response getPolicyResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse GetPolicy response: %s", err.Error()),
+		)
+		return
+	}
+
+	policy := response.Result.Policy
+
+	state.Name = types.StringValue(policy.PolicyName)
+	state.Path = types.StringValue(policy.Path)
+	state.ARN = types.StringValue(policy.Arn)
+	state.PolicyID = types.StringValue(policy.PolicyId)
+	if policy.Description != "" {
+		state.Description = types.StringValue(policy.Description)
+	}
+
+	// The document is returned by GetPolicyVersion for the default version
+	document, err := r.getPolicyVersionDocument(ctx, policyArn, policy.DefaultVersionId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Policy",
+			fmt.Sprintf("Could not read default version %s of policy %s: %s", policy.DefaultVersionId, policyArn, err.Error()),
+		)
+		return
+	}
+
+	normalizedPolicy, err := normalizeJSONPolicy(document)
+	if err == nil {
+		state.Policy = types.StringValue(normalizedPolicy)
+	} else {
+		state.Policy = types.StringValue(document)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PolicyResourceModel
+	var state PolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	policyArn := state.ID.ValueString()
+
+	// A policy can have at most five versions, remove the oldest non-default
+	// version before creating a new one
+	if err := r.pruneOldestPolicyVersion(ctx, policyArn); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Policy",
+			fmt.Sprintf("Could not remove old versions of policy %s: %s", policyArn, err.Error()),
+		)
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "CreatePolicyVersion")
+	params.Set("PolicyArn", policyArn)
+	params.Set("PolicyDocument", normalizedPolicy)
+	params.Set("SetAsDefault", "true")
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Policy",
+			fmt.Sprintf("Could not create new version of policy %s: %s", policyArn, err.Error()),
+		)
+		return
+	}
+
+	plan.Policy = types.StringValue(normalizedPolicy)
+	plan.ARN = state.ARN
+	plan.PolicyID = state.PolicyID
+	plan.ID = state.ID
+
+	tflog.Debug(ctx, "Updated policy", map[string]any{
+		"arn": policyArn,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyArn := state.ID.ValueString()
+
+	// Non-default versions must be deleted before the policy itself
+	versions, err := r.listPolicyVersions(ctx, policyArn)
+	if err != nil && !errors.Is(err, ErrNoSuchEntity) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Policy",
+			fmt.Sprintf("Could not list versions of policy %s: %s", policyArn, err.Error()),
+		)
+		return
+	}
+	for _, version := range versions {
+		if version.IsDefaultVersion {
+			continue
+		}
+		if err := r.deletePolicyVersion(ctx, policyArn, version.VersionId); err != nil && !errors.Is(err, ErrNoSuchEntity) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Policy",
+				fmt.Sprintf("Could not delete version %s of policy %s: %s", version.VersionId, policyArn, err.Error()),
+			)
+			return
+		}
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DeletePolicy")
+	params.Set("PolicyArn", policyArn)
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Policy already deleted", map[string]any{
+				"arn": policyArn,
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Policy",
+			fmt.Sprintf("Could not delete policy %s: %s. Ensure the policy is detached from all users and roles.", policyArn, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted policy", map[string]any{
+		"arn": policyArn,
+	})
+}
+
+func (r *PolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "policy_arn"
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// getPolicyVersionDocument returns the URL-decoded document of a policy version.
+func (r *PolicyResource) getPolicyVersionDocument(ctx context.Context, policyArn, versionID string) (string, error) {
+	params := url.Values{}
+	params.Set("Action", "GetPolicyVersion")
+	params.Set("PolicyArn", policyArn)
+	params.Set("VersionId", versionID)
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		return "", err
+	}
+
+	var response getPolicyVersionResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("could not parse GetPolicyVersion response: %w", err)
+	}
+
+	document := response.Result.PolicyVersion.Document
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return document, nil
+	}
+	return decoded, nil
+}
+
+// listPolicyVersions returns all versions of a policy.
+func (r *PolicyResource) listPolicyVersions(ctx context.Context, policyArn string) ([]policyVersionXML, error) {
+	params := url.Values{}
+	params.Set("Action", "ListPolicyVersions")
+	params.Set("PolicyArn", policyArn)
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		return nil, err
+	}
+
+	var response listPolicyVersionsResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("could not parse ListPolicyVersions response: %w", err)
+	}
+
+	return response.Result.Versions, nil
+}
+
+// deletePolicyVersion deletes a non-default version of a policy.
+func (r *PolicyResource) deletePolicyVersion(ctx context.Context, policyArn, versionID string) error {
+	params := url.Values{}
+	params.Set("Action", "DeletePolicyVersion")
+	params.Set("PolicyArn", policyArn)
+	params.Set("VersionId", versionID)
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	return err
+}
+
+// maxPolicyVersions is the maximum number of versions a managed policy can have.
+const maxPolicyVersions = 5
+
+// pruneOldestPolicyVersion deletes the oldest non-default version when the
+// policy already has the maximum number of versions.
+func (r *PolicyResource) pruneOldestPolicyVersion(ctx context.Context, policyArn string) error {
+	versions, err := r.listPolicyVersions(ctx, policyArn)
+	if err != nil {
+		return err
+	}
+	if len(versions) < maxPolicyVersions {
+		return nil
+	}
+
+	var oldest *policyVersionXML
+	for i := range versions {
+		if versions[i].IsDefaultVersion {
+			continue
+		}
+		if oldest == nil || versions[i].CreateDate < oldest.CreateDate {
+			oldest = &versions[i]
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Deleting oldest policy version", map[string]any{
+		"arn":        policyArn,
+		"version_id": oldest.VersionId,
+	})
+
+	return r.deletePolicyVersion(ctx, policyArn, oldest.VersionId)
+}
+
+type listAttachedPoliciesResponseXML struct {
+	UserResult listAttachedPoliciesResult `xml:"ListAttachedUserPoliciesResult"`
+	RoleResult listAttachedPoliciesResult `xml:"ListAttachedRolePoliciesResult"`
+}
+
+type listAttachedPoliciesResult struct {
+	AttachedPolicies []attachedPolicyXML `xml:"AttachedPolicies>member"`
+	IsTruncated      bool                `xml:"IsTruncated"`
+	Marker           string              `xml:"Marker"`
+}
+
+type attachedPolicyXML struct {
+	PolicyName string `xml:"PolicyName"`
+	PolicyArn  string `xml:"PolicyArn"`
+}
+
+// isPolicyAttached reports whether the managed policy is attached to the given
+// user or role. The list action is either ListAttachedUserPolicies (with the
+// UserName parameter) or ListAttachedRolePolicies (with RoleName).
+func isPolicyAttached(ctx context.Context, iamClient *IAMClient, action, entityParam, entityName, policyArn string) (bool, error) {
+	marker := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", action)
+		params.Set(entityParam, entityName)
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		body, err := iamClient.DoRequest(ctx, params, "iam")
+		if err != nil {
+			return false, err
+		}
+
+		var response listAttachedPoliciesResponseXML
+		if err := xml.Unmarshal(body, &response); err != nil {
+			return false, fmt.Errorf("could not parse %s response: %w", action, err)
+		}
+
+		result := response.UserResult
+		if action == "ListAttachedRolePolicies" {
+			result = response.RoleResult
+		}
+
+		for _, attached := range result.AttachedPolicies {
+			if attached.PolicyArn == policyArn {
+				return true, nil
+			}
+		}
+
+		if !result.IsTruncated || result.Marker == "" {
+			return false, nil
+		}
+		marker = result.Marker
+	}
+}
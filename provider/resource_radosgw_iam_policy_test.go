@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	accountName := randomName("tf-acc-account")
+	rootUserID := randomName("tf-acc-root")
+	policyName := randomName("tf-acc-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			// The account root user and its keys must exist before the
+			// account provider can be configured
+			{
+				Config: testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID),
+			},
+			{
+				Config: testAccRadosgwIAMPolicyConfig_basic(accountName, rootUserID, policyName, `["s3:GetObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_policy.test", "name", policyName),
+					resource.TestCheckResourceAttr("radosgw_iam_policy.test", "path", "/"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_policy.test", "arn"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_policy.test", "policy_id"),
+					resource.TestCheckResourceAttrPair("radosgw_iam_policy.test", "id", "radosgw_iam_policy.test", "arn"),
+				),
+			},
+			// Import test - format: policy_arn
+			{
+				ResourceName:      "radosgw_iam_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Updating the document creates a new default version
+			{
+				Config: testAccRadosgwIAMPolicyConfig_basic(accountName, rootUserID, policyName, `["s3:GetObject", "s3:ListBucket"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_policy.test", "name", policyName),
+					resource.TestCheckResourceAttrPair("radosgw_iam_policy.test", "id", "radosgw_iam_policy.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMPolicyAttachment_basic(t *testing.T) {
+	t.Parallel()
+
+	accountName := randomName("tf-acc-account")
+	rootUserID := randomName("tf-acc-root")
+	policyName := randomName("tf-acc-policy")
+	roleName := randomName("tf-acc-role")
+	userName := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_Accounts) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID),
+			},
+			{
+				Config: testAccRadosgwIAMPolicyConfig_attachments(accountName, rootUserID, policyName, roleName, userName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_role_policy_attachment.test", "role", roleName),
+					resource.TestCheckResourceAttrPair("radosgw_iam_role_policy_attachment.test", "policy_arn", "radosgw_iam_policy.test", "arn"),
+					resource.TestCheckResourceAttr("radosgw_iam_user_policy_attachment.test", "user", userName),
+					resource.TestCheckResourceAttrPair("radosgw_iam_user_policy_attachment.test", "policy_arn", "radosgw_iam_policy.test", "arn"),
+				),
+			},
+			// Import test - format: role_name:policy_arn
+			{
+				ResourceName:      "radosgw_iam_role_policy_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import test - format: user_name:policy_arn
+			{
+				ResourceName:      "radosgw_iam_user_policy_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+// testAccRadosgwIAMPolicyConfig_account creates an account with a root user
+// whose keys are used by the aliased "account" provider. Managed policies can
+// only be created by users of an account.
+func testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_account" "test" {
+  name = %q
+}
+
+resource "radosgw_iam_user" "root" {
+  user_id      = %q
+  display_name = "Account Root User"
+  account_id   = radosgw_iam_account.test.id
+  account_root = true
+}
+
+resource "radosgw_iam_access_key" "root" {
+  user_id = radosgw_iam_user.root.user_id
+}
+`, accountName, rootUserID)
+}
+
+func testAccRadosgwIAMPolicyConfig_accountProvider(accountName, rootUserID string) string {
+	return testAccRadosgwIAMPolicyConfig_account(accountName, rootUserID) + `
+provider "radosgw" {
+  alias      = "account"
+  access_key = radosgw_iam_access_key.root.access_key
+  secret_key = radosgw_iam_access_key.root.secret_key
+}
+`
+}
+
+func testAccRadosgwIAMPolicyConfig_basic(accountName, rootUserID, policyName, actions string) string {
+	return testAccRadosgwIAMPolicyConfig_accountProvider(accountName, rootUserID) + fmt.Sprintf(`
+resource "radosgw_iam_policy" "test" {
+  provider    = radosgw.account
+  name        = %q
+  description = "Test managed policy"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = %s
+        Resource = ["arn:aws:s3:::*"]
+      }
+    ]
+  })
+}
+`, policyName, actions)
+}
+
+func testAccRadosgwIAMPolicyConfig_attachments(accountName, rootUserID, policyName, roleName, userName string) string {
+	return testAccRadosgwIAMPolicyConfig_basic(accountName, rootUserID, policyName, `["s3:GetObject"]`) + fmt.Sprintf(`
+resource "radosgw_iam_role" "test" {
+  provider = radosgw.account
+  name     = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = { AWS = ["*"] }
+        Action    = ["sts:AssumeRole"]
+      }
+    ]
+  })
+}
+
+resource "radosgw_iam_role_policy_attachment" "test" {
+  provider   = radosgw.account
+  role       = radosgw_iam_role.test.name
+  policy_arn = radosgw_iam_policy.test.arn
+}
+
+resource "radosgw_iam_user" "test" {
+  user_id      = %[2]q
+  display_name = %[2]q
+  account_id   = radosgw_iam_account.test.id
+}
+
+# The IAM user name of an account user is its display name
+resource "radosgw_iam_user_policy_attachment" "test" {
+  provider   = radosgw.account
+  user       = radosgw_iam_user.test.display_name
+  policy_arn = radosgw_iam_policy.test.arn
+}
+`, roleName, userName)
+}
@@ -8,20 +8,24 @@ import (
 
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &QuotaResource{}
 var _ resource.ResourceWithImportState = &QuotaResource{}
+var _ resource.ResourceWithModifyPlan = &QuotaResource{}
 
 func NewIAMQuotaResource() resource.Resource {
 	return &QuotaResource{}
@@ -43,7 +47,7 @@ type QuotaResourceModel struct {
 	UserID     types.String `tfsdk:"user_id"`
 	Type       types.String `tfsdk:"type"`
 	Enabled    types.Bool   `tfsdk:"enabled"`
-	MaxSize    types.Int64  `tfsdk:"max_size"`
+	MaxSize    types.String `tfsdk:"max_size"`
 	MaxObjects types.Int64  `tfsdk:"max_objects"`
 }
 
@@ -63,7 +67,9 @@ func (r *QuotaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 
 Upon deletion, the quota is disabled (not removed, as quotas are properties of users).
 
-~> **Note:** Account-level quotas are not yet supported by the go-ceph library. Only user-level quotas are currently available.`,
+When a ` + "`user`" + ` quota would be set below the storage or object count the user currently consumes, the plan shows a warning, because applying such a quota immediately blocks all writes of the user.
+
+~> **Note:** To manage quotas of RadosGW accounts, use the ` + "`radosgw_iam_account_quota`" + ` resource.`,
 
 		Attributes: map[string]schema.Attribute{
 			"user_id": schema.StringAttribute{
@@ -91,11 +97,14 @@ Upon deletion, the quota is disabled (not removed, as quotas are properties of u
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 			},
-			"max_size": schema.Int64Attribute{
-				MarkdownDescription: "Maximum size in bytes. Use `-1` for unlimited. Default: `-1`.",
+			"max_size": schema.StringAttribute{
+				MarkdownDescription: "Maximum size. " + quotaSizeDescription + " Use `-1` for unlimited. Default: `-1`.",
 				Optional:            true,
 				Computed:            true,
-				Default:             int64default.StaticInt64(-1),
+				Default:             stringdefault.StaticString("-1"),
+				Validators: []validator.String{
+					quotaSizeValidator{},
+				},
 			},
 			"max_objects": schema.Int64Attribute{
 				MarkdownDescription: "Maximum number of objects. Use `-1` for unlimited. Default: `-1`.",
@@ -126,6 +135,55 @@ func (r *QuotaResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+func (r *QuotaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan QuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Per-bucket quotas apply to each bucket separately, so only the total
+	// quota of the user can be compared with the user statistics
+	if plan.Type.ValueString() != "user" || !plan.Enabled.ValueBool() || plan.UserID.IsUnknown() ||
+		plan.MaxSize.IsUnknown() || plan.MaxObjects.IsUnknown() {
+		return
+	}
+
+	// Only query the statistics when the quota changes
+	if !req.State.Raw.IsNull() {
+		var state QuotaResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Enabled.Equal(plan.Enabled) && state.MaxSize.Equal(plan.MaxSize) && state.MaxObjects.Equal(plan.MaxObjects) {
+			return
+		}
+	}
+
+	maxSize, err := parseQuotaSize(plan.MaxSize.ValueString())
+	if err != nil {
+		return
+	}
+
+	generateStat := true
+	user, err := r.client.Admin.GetUser(ctx, admin.User{ID: plan.UserID.ValueString(), GenerateStat: &generateStat})
+	if err != nil {
+		// The user may not exist yet; the quota is checked on apply
+		tflog.Debug(ctx, "Could not read user statistics to check the quota", map[string]any{
+			"user_id": plan.UserID.ValueString(),
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	addQuotaUsageWarnings(&resp.Diagnostics, plan.UserID.ValueString(), maxSize, plan.MaxObjects.ValueInt64(), user.Stat)
+}
+
 func (r *QuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data QuotaResourceModel
 
@@ -144,14 +202,12 @@ func (r *QuotaResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Set max_size (default -1 for unlimited)
-	if !data.MaxSize.IsNull() {
-		maxSize := data.MaxSize.ValueInt64()
-		quota.MaxSize = &maxSize
-	} else {
-		maxSize := int64(-1)
-		quota.MaxSize = &maxSize
-		data.MaxSize = types.Int64Value(-1)
+	maxSize, err := parseQuotaSize(data.MaxSize.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "Invalid Quota Size", err.Error())
+		return
 	}
+	quota.MaxSize = &maxSize
 
 	// Set max_objects
 	if !data.MaxObjects.IsNull() {
@@ -163,20 +219,15 @@ func (r *QuotaResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.MaxObjects = types.Int64Value(-1)
 	}
 
-	// Set user-level quota based on type with retry logic for ConcurrentModification
+	// Set user-level quota based on type
 	// "user" type: Sets total quota for the user across all their buckets
 	// "bucket" type: Sets per-bucket quota for all buckets owned by this user
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("SetQuota %s/%s", data.Type.ValueString(), data.UserID.ValueString()), func() error {
-		if data.Type.ValueString() == "user" {
-			return r.client.Admin.SetUserQuota(ctx, quota)
-		}
-		return r.client.Admin.SetBucketQuota(ctx, quota)
-	})
+	err = r.setQuota(ctx, data.Type.ValueString(), quota)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating User Quota",
-			fmt.Sprintf("Could not create %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -214,7 +265,7 @@ func (r *QuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading User Quota",
-			fmt.Sprintf("Could not read %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -226,9 +277,9 @@ func (r *QuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Set max_size from response
 	if quotaSpec.MaxSize != nil {
-		data.MaxSize = types.Int64Value(*quotaSpec.MaxSize)
+		data.MaxSize = quotaSizeValue(data.MaxSize, *quotaSpec.MaxSize)
 	} else {
-		data.MaxSize = types.Int64Value(-1)
+		data.MaxSize = quotaSizeValue(data.MaxSize, -1)
 	}
 
 	if quotaSpec.MaxObjects != nil {
@@ -258,13 +309,12 @@ func (r *QuotaResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Set max_size (default -1 for unlimited)
-	if !data.MaxSize.IsNull() {
-		maxSize := data.MaxSize.ValueInt64()
-		quota.MaxSize = &maxSize
-	} else {
-		maxSize := int64(-1)
-		quota.MaxSize = &maxSize
+	maxSize, err := parseQuotaSize(data.MaxSize.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "Invalid Quota Size", err.Error())
+		return
 	}
+	quota.MaxSize = &maxSize
 
 	// Set max_objects
 	if !data.MaxObjects.IsNull() {
@@ -275,18 +325,13 @@ func (r *QuotaResource) Update(ctx context.Context, req resource.UpdateRequest,
 		quota.MaxObjects = &maxObjects
 	}
 
-	// Update user-level quota based on type with retry logic for ConcurrentModification
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("UpdateQuota %s/%s", data.Type.ValueString(), data.UserID.ValueString()), func() error {
-		if data.Type.ValueString() == "user" {
-			return r.client.Admin.SetUserQuota(ctx, quota)
-		}
-		return r.client.Admin.SetBucketQuota(ctx, quota)
-	})
+	// Update user-level quota based on type
+	err = r.setQuota(ctx, data.Type.ValueString(), quota)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating User Quota",
-			fmt.Sprintf("Could not update %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -317,18 +362,18 @@ func (r *QuotaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		MaxObjects: &maxObjects,
 	}
 
-	// Disable user-level quota based on type with retry logic for ConcurrentModification
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("DeleteQuota %s/%s", data.Type.ValueString(), data.UserID.ValueString()), func() error {
-		if data.Type.ValueString() == "user" {
-			return r.client.Admin.SetUserQuota(ctx, quota)
-		}
-		return r.client.Admin.SetBucketQuota(ctx, quota)
-	})
+	// Disable user-level quota based on type
+	err := r.setQuota(ctx, data.Type.ValueString(), quota)
 
-	if err != nil && !errors.Is(err, admin.ErrNoSuchUser) {
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		// The user was deleted first, which removed its quotas
+		addUserDeletedFirstWarning(&resp.Diagnostics, "quotas", data.UserID.ValueString())
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting User Quota",
-			fmt.Sprintf("Could not disable %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not disable %s quota for user %s: %s", data.Type.ValueString(), data.UserID.ValueString(), adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -361,3 +406,42 @@ func (r *QuotaResource) ImportState(ctx context.Context, req resource.ImportStat
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), quotaType)...)
 }
+
+// setQuota sets the user or bucket quota of a user depending on quotaType.
+func (r *QuotaResource) setQuota(ctx context.Context, quotaType string, quota admin.QuotaSpec) error {
+	defer userLocks.lock(quota.UID)()
+
+	if quotaType == "user" {
+		return r.client.Admin.SetUserQuota(ctx, quota)
+	}
+	return r.client.Admin.SetBucketQuota(ctx, quota)
+}
+
+// addQuotaUsageWarnings warns when a user quota is below the current usage of
+// the user. Negative limits are unlimited.
+func addQuotaUsageWarnings(diags *diag.Diagnostics, userID string, maxSize, maxObjects int64, stat admin.UserStat) {
+	// Quotas are enforced against the size rounded up to 4 KiB per object
+	size := stat.SizeRounded
+	if size == nil {
+		size = stat.Size
+	}
+
+	if maxSize >= 0 && size != nil && *size > uint64(maxSize) {
+		diags.AddAttributeWarning(
+			path.Root("max_size"),
+			"Quota Below Current Usage",
+			fmt.Sprintf("User %q currently stores %d bytes (%s), more than the planned max_size of %d bytes (%s). "+
+				"Applying this quota immediately blocks all writes of the user until the usage drops below the quota.",
+				userID, *size, formatQuotaSize(int64(*size)), maxSize, formatQuotaSize(maxSize)),
+		)
+	}
+	if maxObjects >= 0 && stat.NumObjects != nil && *stat.NumObjects > uint64(maxObjects) {
+		diags.AddAttributeWarning(
+			path.Root("max_objects"),
+			"Quota Below Current Usage",
+			fmt.Sprintf("User %q currently stores %d objects, more than the planned max_objects of %d. "+
+				"Applying this quota immediately blocks all writes of the user until objects are deleted.",
+				userID, *stat.NumObjects, maxObjects),
+		)
+	}
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -38,6 +41,13 @@ func TestAccRadosgwIAMQuota_basic(t *testing.T) {
 				ImportStateId:                        userID + ":user",
 				ImportStateVerifyIdentifierAttribute: "user_id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_quota.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   userID + ":user",
+			},
 		},
 	})
 }
@@ -94,6 +104,38 @@ func TestAccRadosgwIAMQuota_update(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMQuota_humanSize(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMQuotaConfig_humanSize(userID, "10GiB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMQuotaExists("radosgw_iam_quota.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_quota.test", "max_size", "10GiB"),
+				),
+			},
+			// The same size in another unit is kept as configured
+			{
+				Config: testAccRadosgwIAMQuotaConfig_humanSize(userID, "10240M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_quota.test", "max_size", "10240M"),
+				),
+			},
+			{
+				Config:      testAccRadosgwIAMQuotaConfig_humanSize(userID, "10 gigabytes"),
+				ExpectError: regexp.MustCompile("Invalid Quota Size"),
+			},
+		},
+	})
+}
+
 func TestAccRadosgwIAMQuota_disable(t *testing.T) {
 	t.Parallel()
 
@@ -120,6 +162,51 @@ func TestAccRadosgwIAMQuota_disable(t *testing.T) {
 	})
 }
 
+func TestAddQuotaUsageWarnings(t *testing.T) {
+	t.Parallel()
+
+	size := uint64(2048)
+	sizeRounded := uint64(8192)
+	numObjects := uint64(2)
+
+	testCases := map[string]struct {
+		maxSize        int64
+		maxObjects     int64
+		stat           admin.UserStat
+		expectWarnings []string
+	}{
+		"unlimited":     {maxSize: -1, maxObjects: -1, stat: admin.UserStat{SizeRounded: &sizeRounded, NumObjects: &numObjects}},
+		"within quota":  {maxSize: 8192, maxObjects: 2, stat: admin.UserStat{SizeRounded: &sizeRounded, NumObjects: &numObjects}},
+		"size rounded":  {maxSize: 4096, maxObjects: -1, stat: admin.UserStat{Size: &size, SizeRounded: &sizeRounded}, expectWarnings: []string{"max_size"}},
+		"size fallback": {maxSize: 1024, maxObjects: -1, stat: admin.UserStat{Size: &size}, expectWarnings: []string{"max_size"}},
+		"objects":       {maxSize: -1, maxObjects: 1, stat: admin.UserStat{NumObjects: &numObjects}, expectWarnings: []string{"max_objects"}},
+		"both":          {maxSize: 0, maxObjects: 0, stat: admin.UserStat{SizeRounded: &sizeRounded, NumObjects: &numObjects}, expectWarnings: []string{"max_size", "max_objects"}},
+		"no statistics": {maxSize: 0, maxObjects: 0},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			addQuotaUsageWarnings(&diags, "alice", testCase.maxSize, testCase.maxObjects, testCase.stat)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if len(diags) != len(testCase.expectWarnings) {
+				t.Fatalf("expected %d warnings, got %v", len(testCase.expectWarnings), diags)
+			}
+			for i, attribute := range testCase.expectWarnings {
+				withPath, ok := diags[i].(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(path.Root(attribute)) {
+					t.Errorf("expected warning %d on %s, got %v", i, attribute, diags[i])
+				}
+			}
+		})
+	}
+}
+
 // Helper functions
 
 func testAccCheckRadosgwIAMQuotaExists(resourceName string) resource.TestCheckFunc {
@@ -199,3 +286,18 @@ resource "radosgw_iam_quota" "test" {
 }
 `, userID, quotaType, enabled, maxSize, maxObjects)
 }
+
+func testAccRadosgwIAMQuotaConfig_humanSize(userID, maxSize string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Quota"
+}
+
+resource "radosgw_iam_quota" "test" {
+  user_id  = radosgw_iam_user.test.user_id
+  type     = "user"
+  max_size = %q
+}
+`, userID, maxSize)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -26,6 +27,62 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithModifyPlan = &RoleResource{}
+
+// oidcProviderARNPattern matches the ARN of an OIDC provider, with an
+// optional tenant, for example arn:aws:iam:::oidc-provider/idp.example.com.
+var oidcProviderARNPattern = regexp.MustCompile(`^arn:aws:iam::[^:]*:oidc-provider/[^:/][^:]*$`)
+
+// assumeRolePolicyValidator validates that a trust policy is valid JSON and
+// that its Federated principals are OIDC provider ARNs.
+type assumeRolePolicyValidator struct{}
+
+func (v assumeRolePolicyValidator) Description(ctx context.Context) string {
+	return "validates that the trust policy is valid JSON with Federated principals that are OIDC provider ARNs"
+}
+
+func (v assumeRolePolicyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v assumeRolePolicyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, statements, err := parsePolicyDocument(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Assume Role Policy",
+			fmt.Sprintf("The assume_role_policy is not a valid policy document: %s", err.Error()),
+		)
+		return
+	}
+
+	for i, stmt := range statements {
+		for _, arn := range federatedPrincipals(stmt.Principal) {
+			if !oidcProviderARNPattern.MatchString(arn) {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Invalid Federated Principal",
+					fmt.Sprintf("Statement %d has the Federated principal %q, which is not an OIDC provider ARN. "+
+						"Use the arn attribute of a radosgw_iam_openid_connect_provider, for example "+
+						"arn:aws:iam:::oidc-provider/idp.example.com, without the https:// scheme.", i, arn),
+				)
+			}
+		}
+	}
+}
+
+// federatedPrincipals returns the Federated identifiers of a Principal element.
+func federatedPrincipals(element json.RawMessage) []string {
+	var byType map[string]json.RawMessage
+	if len(element) == 0 || json.Unmarshal(element, &byType) != nil {
+		return nil
+	}
+	return policyStringList(byType["Federated"])
+}
 
 func NewIAMRoleResource() resource.Resource {
 	return &RoleResource{}
@@ -44,6 +101,7 @@ type RoleResourceModel struct {
 	Description        types.String `tfsdk:"description"`
 	AssumeRolePolicy   types.String `tfsdk:"assume_role_policy"`
 	MaxSessionDuration types.Int64  `tfsdk:"max_session_duration"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 	ARN                types.String `tfsdk:"arn"`
 	CreateDate         types.String `tfsdk:"create_date"`
 	UniqueID           types.String `tfsdk:"unique_id"`
@@ -131,8 +189,13 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"assume_role_policy": schema.StringAttribute{
 				MarkdownDescription: "The trust relationship policy document (in JSON format) that grants an entity " +
-					"permission to assume the role. Use `jsonencode()` or the `radosgw_iam_policy_document` data source to generate this.",
+					"permission to assume the role. Use `jsonencode()` or the `radosgw_iam_policy_document` data source to generate this. " +
+					"`Federated` principals must be OIDC provider ARNs. When they are known at plan time, the plan warns about " +
+					"ARNs of OIDC providers that do not exist.",
 				Required: true,
+				Validators: []validator.String{
+					assumeRolePolicyValidator{},
+				},
 			},
 			"max_session_duration": schema.Int64Attribute{
 				MarkdownDescription: "Maximum session duration (in seconds) for the role. Default is 3600 (1 hour). " +
@@ -144,6 +207,13 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					int64validator.Between(3600, 43200),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the role, including replacing it, fails. Set it to false and apply " +
+					"before the role can be deleted. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"arn": schema.StringAttribute{
 				MarkdownDescription: "Amazon Resource Name (ARN) of the role.",
 				Computed:            true,
@@ -184,12 +254,52 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = client
-	r.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	r.iamClient = client.IAM
+}
+
+func (r *RoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.iamClient == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var policy types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("assume_role_policy"), &policy)...)
+	if resp.Diagnostics.HasError() || policy.IsNull() || policy.IsUnknown() {
+		return
+	}
+
+	_, statements, err := parsePolicyDocument(policy.ValueString())
+	if err != nil {
+		return
+	}
+
+	var arns []string
+	for _, stmt := range statements {
+		arns = append(arns, federatedPrincipals(stmt.Principal)...)
+	}
+	if len(arns) == 0 {
+		return
+	}
+
+	existing, err := listOIDCProviderARNs(ctx, r.iamClient)
+	if err != nil {
+		tflog.Warn(ctx, "Could not list OIDC providers to check the trust policy", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	for _, arn := range arns {
+		if oidcProviderARNPattern.MatchString(arn) && !existing[arn] {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("assume_role_policy"),
+				"Unknown OIDC Provider",
+				fmt.Sprintf("The trust policy references the OIDC provider %s, which does not exist. "+
+					"Check the ARN for typos, or reference the arn attribute of the radosgw_iam_openid_connect_provider "+
+					"resource if it is created in the same configuration.", arn),
+			)
+		}
+	}
 }
 
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -427,6 +537,10 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if !checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, fmt.Sprintf("role %q", state.Name.ValueString())) {
+		return
+	}
+
 	// Note: Role can only be deleted when it has no permission policies attached
 	// Users should delete role policies first
 
@@ -456,6 +570,7 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
 }
 
 // normalizeJSONPolicy parses and re-encodes JSON to normalize whitespace and key ordering.
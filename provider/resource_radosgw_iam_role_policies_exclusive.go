@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RolePoliciesExclusiveResource{}
+var _ resource.ResourceWithImportState = &RolePoliciesExclusiveResource{}
+
+func NewIAMRolePoliciesExclusiveResource() resource.Resource {
+	return &RolePoliciesExclusiveResource{}
+}
+
+// RolePoliciesExclusiveResource defines the resource implementation.
+type RolePoliciesExclusiveResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// RolePoliciesExclusiveResourceModel describes the resource data model.
+type RolePoliciesExclusiveResourceModel struct {
+	RoleName    types.String `tfsdk:"role_name"`
+	PolicyNames types.Set    `tfsdk:"policy_names"`
+}
+
+// XML response structures for RadosGW ListRolePolicies API
+type listRolePoliciesResponseXML struct {
+	XMLName xml.Name               `xml:"ListRolePoliciesResponse"`
+	Result  listRolePoliciesResult `xml:"ListRolePoliciesResult"`
+}
+
+type listRolePoliciesResult struct {
+	PolicyNames []string `xml:"PolicyNames>member"`
+	IsTruncated bool     `xml:"IsTruncated"`
+	Marker      string   `xml:"Marker"`
+}
+
+func (r *RolePoliciesExclusiveResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_role_policies_exclusive"
+}
+
+func (r *RolePoliciesExclusiveResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an exclusive set of inline policies for a RadosGW role. Any inline policy " +
+			"attached to the role that is not listed in `policy_names` is removed, so policies added out of band " +
+			"show up as drift and are deleted on the next apply.\n\n" +
+			"The policies themselves are still managed with `radosgw_iam_role_policy`; this resource only " +
+			"enforces which of them may exist.\n\n" +
+			"~> **Note:** Destroying this resource does not delete any policies, it only stops enforcing the set. " +
+			"To remove all inline policies from a role, set `policy_names` to an empty set.",
+
+		Attributes: map[string]schema.Attribute{
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the role.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"policy_names": schema.SetAttribute{
+				MarkdownDescription: "The names of the inline policies allowed on the role.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *RolePoliciesExclusiveResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *RolePoliciesExclusiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RolePoliciesExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.syncPolicies(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RolePoliciesExclusiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RolePoliciesExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyNames, err := listRolePolicies(ctx, r.iamClient, state.RoleName.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Role not found, removing exclusive policies from state", map[string]any{
+				"role": state.RoleName.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Role Policies",
+			fmt.Sprintf("Could not list policies for role %s: %s", state.RoleName.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	policyNameSet, diags := types.SetValueFrom(ctx, types.StringType, policyNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.PolicyNames = policyNameSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RolePoliciesExclusiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RolePoliciesExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.syncPolicies(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RolePoliciesExclusiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Removing the resource only stops enforcing the policy set, the policies
+	// are left in place
+	tflog.Trace(ctx, "Removed exclusive role policies from state")
+}
+
+func (r *RolePoliciesExclusiveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "role_name"
+	resource.ImportStatePassthroughID(ctx, path.Root("role_name"), req, resp)
+}
+
+// syncPolicies deletes every inline policy of the role that is not listed in
+// the plan.
+func (r *RolePoliciesExclusiveResource) syncPolicies(ctx context.Context, plan *RolePoliciesExclusiveResourceModel, diags *diag.Diagnostics) {
+	roleName := plan.RoleName.ValueString()
+
+	var wanted []string
+	diags.Append(plan.PolicyNames.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+	allowed := make(map[string]bool, len(wanted))
+	for _, name := range wanted {
+		allowed[name] = true
+	}
+
+	existing, err := listRolePolicies(ctx, r.iamClient, roleName)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Role Policies",
+			fmt.Sprintf("Could not list policies for role %s: %s", roleName, err.Error()),
+		)
+		return
+	}
+
+	for _, name := range existing {
+		if allowed[name] {
+			continue
+		}
+
+		params := url.Values{}
+		params.Set("Action", "DeleteRolePolicy")
+		params.Set("RoleName", roleName)
+		params.Set("PolicyName", name)
+
+		if _, err := r.iamClient.DoRequest(ctx, params, "iam"); err != nil && !errors.Is(err, ErrNoSuchEntity) {
+			diags.AddError(
+				"Error Deleting Role Policy",
+				fmt.Sprintf("Could not delete policy %s from role %s: %s", name, roleName, err.Error()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Deleted undeclared role policy", map[string]any{
+			"role":   roleName,
+			"policy": name,
+		})
+	}
+}
+
+// listRolePolicies returns the names of all inline policies of a role.
+func listRolePolicies(ctx context.Context, iamClient *IAMClient, roleName string) ([]string, error) {
+	policyNames := []string{}
+	marker := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "ListRolePolicies")
+		params.Set("RoleName", roleName)
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		var response listRolePoliciesResponseXML
+		if err := iamClient.DoRequestXML(ctx, params, "iam", &response); err != nil {
+			return nil, err
+		}
+
+		policyNames = append(policyNames, response.Result.PolicyNames...)
+
+		if !response.Result.IsTruncated || response.Result.Marker == "" {
+			return policyNames, nil
+		}
+		marker = response.Result.Marker
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRadosgwIAMRolePoliciesExclusive_basic(t *testing.T) {
+	t.Parallel()
+
+	roleName := randomName("tf-acc-role")
+	policyName := randomName("tf-acc-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMRolePoliciesExclusiveConfig_basic(roleName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_role_policies_exclusive.test", "role_name", roleName),
+					resource.TestCheckResourceAttr("radosgw_iam_role_policies_exclusive.test", "policy_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("radosgw_iam_role_policies_exclusive.test", "policy_names.*", policyName),
+				),
+			},
+			// Import test - format: role_name
+			{
+				ResourceName:                         "radosgw_iam_role_policies_exclusive.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        roleName,
+				ImportStateVerifyIdentifierAttribute: "role_name",
+			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_role_policies_exclusive.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   roleName,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMRolePoliciesExclusive_outOfBand(t *testing.T) {
+	t.Parallel()
+
+	roleName := randomName("tf-acc-role")
+	policyName := randomName("tf-acc-policy")
+	outOfBandPolicyName := randomName("tf-acc-oob-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMRolePoliciesExclusiveConfig_basic(roleName, policyName),
+			},
+			// A policy added outside of Terraform is detected as drift
+			{
+				PreConfig: func() {
+					if err := testAccPutRolePolicy(roleName, outOfBandPolicyName); err != nil {
+						t.Fatalf("error adding out-of-band role policy: %s", err)
+					}
+				},
+				Config:             testAccRadosgwIAMRolePoliciesExclusiveConfig_basic(roleName, policyName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying removes it again
+			{
+				Config: testAccRadosgwIAMRolePoliciesExclusiveConfig_basic(roleName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_role_policies_exclusive.test", "policy_names.#", "1"),
+					testAccCheckRadosgwIAMRolePolicyNotExists(roleName, outOfBandPolicyName),
+				),
+			},
+		},
+	})
+}
+
+// Helper functions
+
+func testAccPutRolePolicy(roleName, policyName string) error {
+	iamClient := NewIAMClient(
+		testAccAdminClient.Endpoint,
+		testAccAdminClient.AccessKey,
+		testAccAdminClient.SecretKey,
+		testAccAdminClient.HTTPClient,
+	)
+
+	params := url.Values{}
+	params.Set("Action", "PutRolePolicy")
+	params.Set("RoleName", roleName)
+	params.Set("PolicyName", policyName)
+	params.Set("PolicyDocument", `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::*"]}]}`)
+
+	_, err := iamClient.DoRequest(testCtx, params, "iam")
+	return err
+}
+
+func testAccCheckRadosgwIAMRolePolicyNotExists(roleName, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		iamClient := NewIAMClient(
+			testAccAdminClient.Endpoint,
+			testAccAdminClient.AccessKey,
+			testAccAdminClient.SecretKey,
+			testAccAdminClient.HTTPClient,
+		)
+
+		policyNames, err := listRolePolicies(testCtx, iamClient, roleName)
+		if err != nil {
+			return fmt.Errorf("error listing policies for role %s: %s", roleName, err)
+		}
+		for _, name := range policyNames {
+			if name == policyName {
+				return fmt.Errorf("role policy %s:%s still exists", roleName, policyName)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Test configurations
+
+func testAccRadosgwIAMRolePoliciesExclusiveConfig_basic(roleName, policyName string) string {
+	return testAccRadosgwIAMRolePolicyConfig_basic(roleName, policyName) + `
+resource "radosgw_iam_role_policies_exclusive" "test" {
+  role_name    = radosgw_iam_role.test.name
+  policy_names = [radosgw_iam_role_policy.test.name]
+}
+`
+}
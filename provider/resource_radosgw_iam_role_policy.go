@@ -120,12 +120,7 @@ func (r *RolePolicyResource) Configure(ctx context.Context, req resource.Configu
 	}
 
 	r.client = client
-	r.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	r.iamClient = client.IAM
 }
 
 func (r *RolePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RolePolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &RolePolicyAttachmentResource{}
+
+func NewIAMRolePolicyAttachmentResource() resource.Resource {
+	return &RolePolicyAttachmentResource{}
+}
+
+// RolePolicyAttachmentResource defines the resource implementation.
+type RolePolicyAttachmentResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// RolePolicyAttachmentResourceModel describes the resource data model.
+type RolePolicyAttachmentResourceModel struct {
+	Role      types.String `tfsdk:"role"`
+	PolicyArn types.String `tfsdk:"policy_arn"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func (r *RolePolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_role_policy_attachment"
+}
+
+func (r *RolePolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a managed IAM policy to a RadosGW role.\n\n" +
+			"~> **Note:** Managed policies are only available for roles that belong to a RadosGW account. " +
+			"Both the role and the policy must belong to the same account as the provider credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The name of the role the policy should be attached to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"policy_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the managed policy to attach. Can be an AWS managed policy " +
+					"(e.g. `arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess`) or a policy created with `radosgw_iam_policy`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The attachment identifier in the format `role:policy_arn`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RolePolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *RolePolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RolePolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "AttachRolePolicy")
+	params.Set("RoleName", plan.Role.ValueString())
+	params.Set("PolicyArn", plan.PolicyArn.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Attaching Role Policy",
+			fmt.Sprintf("Could not attach policy %s to role %s: %s", plan.PolicyArn.ValueString(), plan.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.Role.ValueString(), plan.PolicyArn.ValueString()))
+
+	tflog.Trace(ctx, "Attached role policy", map[string]any{
+		"role":       plan.Role.ValueString(),
+		"policy_arn": plan.PolicyArn.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RolePolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RolePolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attached, err := isPolicyAttached(ctx, r.iamClient, "ListAttachedRolePolicies", "RoleName", state.Role.ValueString(), state.PolicyArn.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Role not found, removing policy attachment from state", map[string]any{
+				"role": state.Role.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Role Policy Attachment",
+			fmt.Sprintf("Could not list attached policies for role %s: %s", state.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if !attached {
+		tflog.Info(ctx, "Role policy attachment not found, removing from state", map[string]any{
+			"role":       state.Role.ValueString(),
+			"policy_arn": state.PolicyArn.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s:%s", state.Role.ValueString(), state.PolicyArn.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RolePolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement, so Update is never called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Role policy attachments cannot be updated in place. This is a provider bug.",
+	)
+}
+
+func (r *RolePolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RolePolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DetachRolePolicy")
+	params.Set("RoleName", state.Role.ValueString())
+	params.Set("PolicyArn", state.PolicyArn.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "Role policy attachment already removed", map[string]any{
+				"role":       state.Role.ValueString(),
+				"policy_arn": state.PolicyArn.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Detaching Role Policy",
+			fmt.Sprintf("Could not detach policy %s from role %s: %s", state.PolicyArn.ValueString(), state.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Detached role policy", map[string]any{
+		"role":       state.Role.ValueString(),
+		"policy_arn": state.PolicyArn.ValueString(),
+	})
+}
+
+func (r *RolePolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "role_name:policy_arn"
+	// The policy ARN itself contains colons, so only split on the first one
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'role_name:policy_arn'. Example: 'my-role:arn:aws:iam::RGW11111111111111111:policy/my-policy'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_arn"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -35,6 +35,13 @@ func TestAccRadosgwIAMRolePolicy_basic(t *testing.T) {
 				ImportStateId:                        roleName + ":" + policyName,
 				ImportStateVerifyIdentifierAttribute: "id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_role_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   roleName + ":" + policyName,
+			},
 		},
 	})
 }
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -35,6 +39,13 @@ func TestAccRadosgwIAMRole_basic(t *testing.T) {
 				ImportStateId:                        roleName,
 				ImportStateVerifyIdentifierAttribute: "name",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_role.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   roleName,
+			},
 		},
 	})
 }
@@ -115,6 +126,40 @@ func TestAccRadosgwIAMRole_update(t *testing.T) {
 
 // Helper functions
 
+func TestAssumeRolePolicyValidator(t *testing.T) {
+	t.Parallel()
+
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":%s,"Action":"sts:AssumeRoleWithWebIdentity"}]}`
+	testCases := map[string]struct {
+		principal   string
+		expectError bool
+	}{
+		"aws user":          {principal: `{"AWS":["arn:aws:iam:::user/uid"]}`},
+		"oidc provider":     {principal: `{"Federated":["arn:aws:iam:::oidc-provider/idp.example.com/realms/test"]}`},
+		"tenant provider":   {principal: `{"Federated":"arn:aws:iam::tenant:oidc-provider/idp.example.com"}`},
+		"provider with url": {principal: `{"Federated":["arn:aws:iam:::oidc-provider/https://idp.example.com"]}`, expectError: true},
+		"bare url":          {principal: `{"Federated":"idp.example.com"}`, expectError: true},
+		"wrong resource":    {principal: `{"Federated":"arn:aws:iam:::oidc_provider/idp.example.com"}`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("assume_role_policy"),
+				ConfigValue: types.StringValue(fmt.Sprintf(policy, testCase.principal)),
+			}
+			resp := &validator.StringResponse{}
+			assumeRolePolicyValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
 func testAccCheckRadosgwIAMRoleExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
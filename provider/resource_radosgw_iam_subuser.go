@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -45,6 +46,7 @@ func accessFromAPI(access string) string {
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SubuserResource{}
 var _ resource.ResourceWithImportState = &SubuserResource{}
+var _ resource.ResourceWithModifyPlan = &SubuserResource{}
 
 func NewIAMSubuserResource() resource.Resource {
 	return &SubuserResource{}
@@ -57,11 +59,12 @@ type SubuserResource struct {
 
 // SubuserResourceModel describes the resource data model.
 type SubuserResourceModel struct {
-	UserID    types.String `tfsdk:"user_id"`
-	Subuser   types.String `tfsdk:"subuser"`
-	Access    types.String `tfsdk:"access"`
-	SecretKey types.String `tfsdk:"secret_key"`
-	FullID    types.String `tfsdk:"id"`
+	UserID      types.String `tfsdk:"user_id"`
+	Subuser     types.String `tfsdk:"subuser"`
+	Access      types.String `tfsdk:"access"`
+	SecretKey   types.String `tfsdk:"secret_key"`
+	StoreSecret types.Bool   `tfsdk:"store_secret"`
+	FullID      types.String `tfsdk:"id"`
 }
 
 func (r *SubuserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -74,7 +77,8 @@ func (r *SubuserResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"~> **Note:** Ceph automatically generates one Swift secret key when creating a subuser (only one Swift key is allowed per subuser). " +
 			"Keys can be managed separately or replaced later using the `radosgw_iam_access_key` resource with `key_type = \"swift\"`.\n\n" +
 			"~> **Note:** Creating multiple subusers for a single user requires Ceph Squid (19.x) or higher. " +
-			"Older versions (Reef 18.x) may have issues with multiple subuser creation.",
+			"Older versions (Reef 18.x) may have issues with multiple subuser creation; use `radosgw_iam_subusers` " +
+			"to manage all subusers of a user in one resource instead.",
 
 		Attributes: map[string]schema.Attribute{
 			"user_id": schema.StringAttribute{
@@ -103,13 +107,22 @@ func (r *SubuserResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"secret_key": schema.StringAttribute{
 				MarkdownDescription: "The auto-generated Swift secret key. This is the initial key created by Ceph when the subuser is created. " +
 					"~> **Note:** For production use, consider managing keys explicitly with the `radosgw_iam_access_key` resource for rotation and lifecycle management. " +
-					"This field is computed (read-only) and will not detect or track external key changes.",
+					"This field is computed (read-only) and will not detect or track external key changes. " +
+					"Always null when `store_secret` is `false`.",
 				Computed:  true,
 				Sensitive: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"store_secret": schema.BoolAttribute{
+				MarkdownDescription: "Whether to store the auto-generated Swift secret key in the `secret_key` attribute. " +
+					"Set to `false` to keep the credential out of Terraform state entirely, for example when Swift keys are " +
+					"managed exclusively with the `radosgw_iam_access_key` resource. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The full subuser ID in the format `{user_id}:{subuser}`.",
 				Computed:            true,
@@ -170,18 +183,29 @@ func (r *SubuserResource) Create(ctx context.Context, req resource.CreateRequest
 		"subuser_spec": fmt.Sprintf("%+v", subuser),
 	})
 
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("CreateSubuser %s", fullSubuserID), func() error {
-		return r.client.Admin.CreateSubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
-	})
+	unlock := userLocks.lock(data.UserID.ValueString())
+	err := r.client.Admin.CreateSubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
+	unlock()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Subuser",
-			fmt.Sprintf("Could not create subuser %s: %s", fullSubuserID, err.Error()),
+			fmt.Sprintf("Could not create subuser %s: %s", fullSubuserID, adminError(err, "users=write").Error()),
 		)
 		return
 	}
 
+	// Set computed fields
+	data.FullID = types.StringValue(fullSubuserID)
+	data.SecretKey = types.StringNull()
+
+	// Skip fetching the auto-generated key when it must not be stored in state
+	if !data.StoreSecret.ValueBool() {
+		tflog.Trace(ctx, "Created subuser")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Fetch the user to get the auto-generated Swift secret key
 	// Architecture note: We expose the auto-generated key as a computed attribute for simple use cases.
 	// For production deployments with key rotation requirements, users should manage keys explicitly
@@ -191,7 +215,7 @@ func (r *SubuserResource) Create(ctx context.Context, req resource.CreateRequest
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading User After Subuser Creation",
-			fmt.Sprintf("Could not read user %s to retrieve secret key: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read user %s to retrieve secret key: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -208,9 +232,6 @@ func (r *SubuserResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 	}
 
-	// Set computed fields
-	data.FullID = types.StringValue(fullSubuserID)
-
 	tflog.Trace(ctx, "Created subuser")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -242,7 +263,7 @@ func (r *SubuserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading User",
-			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -269,13 +290,21 @@ func (r *SubuserResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Fetch the Swift secret key from the user's keys
 	// Note: We only read the key that exists in state. If the key was externally rotated
 	// (e.g., via radosgw_iam_access_key resource or manual admin commands), this will detect the change.
-	for _, key := range user.SwiftKeys {
-		if key.User == fullSubuserID {
-			data.SecretKey = types.StringValue(key.SecretKey)
-			break
+	data.SecretKey = types.StringNull()
+	if data.StoreSecret.IsNull() || data.StoreSecret.ValueBool() {
+		for _, key := range user.SwiftKeys {
+			if key.User == fullSubuserID {
+				data.SecretKey = types.StringValue(key.SecretKey)
+				break
+			}
 		}
 	}
 
+	// Imported resources have no store_secret value yet
+	if data.StoreSecret.IsNull() {
+		data.StoreSecret = types.BoolValue(true)
+	}
+
 	// Ensure computed fields are set
 	data.FullID = types.StringValue(fullSubuserID)
 
@@ -312,14 +341,14 @@ func (r *SubuserResource) Update(ctx context.Context, req resource.UpdateRequest
 		Access: admin.SubuserAccess(accessToAPI(data.Access.ValueString())),
 	}
 
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("ModifySubuser %s", fullSubuserID), func() error {
-		return r.client.Admin.ModifySubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
-	})
+	unlock := userLocks.lock(data.UserID.ValueString())
+	err := r.client.Admin.ModifySubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
+	unlock()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Subuser",
-			fmt.Sprintf("Could not update subuser %s: %s", fullSubuserID, err.Error()),
+			fmt.Sprintf("Could not update subuser %s: %s", fullSubuserID, adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -329,6 +358,26 @@ func (r *SubuserResource) Update(ctx context.Context, req resource.UpdateRequest
 	data.FullID = state.FullID
 	data.SecretKey = state.SecretKey
 
+	if !data.StoreSecret.ValueBool() {
+		data.SecretKey = types.StringNull()
+	} else if state.SecretKey.IsNull() {
+		// store_secret was switched back on, fetch the current Swift key
+		user, err := r.client.Admin.GetUser(ctx, admin.User{ID: data.UserID.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading User After Subuser Update",
+				fmt.Sprintf("Could not read user %s to retrieve secret key: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
+			)
+			return
+		}
+		for _, key := range user.SwiftKeys {
+			if key.User == fullSubuserID {
+				data.SecretKey = types.StringValue(key.SecretKey)
+				break
+			}
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -354,22 +403,62 @@ func (r *SubuserResource) Delete(ctx context.Context, req resource.DeleteRequest
 		PurgeKeys: &purgeKeys, // Purge associated keys
 	}
 
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("RemoveSubuser %s", fullSubuserID), func() error {
-		return r.client.Admin.RemoveSubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
-	})
+	unlock := userLocks.lock(data.UserID.ValueString())
+	err := r.client.Admin.RemoveSubuser(ctx, admin.User{ID: data.UserID.ValueString()}, subuser)
+	unlock()
 
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		// The user was deleted first, which removed its subusers
+		addUserDeletedFirstWarning(&resp.Diagnostics, "subusers", data.UserID.ValueString())
+		return
+	}
 	if err != nil {
-		// Ignore error if user or subuser doesn't exist
-		if !errors.Is(err, admin.ErrNoSuchUser) && !errors.Is(err, admin.ErrNoSuchSubUser) {
+		// Ignore error if subuser doesn't exist
+		if !errors.Is(err, admin.ErrNoSuchSubUser) {
 			resp.Diagnostics.AddError(
 				"Error Deleting Subuser",
-				fmt.Sprintf("Could not delete subuser %s: %s", fullSubuserID, err.Error()),
+				fmt.Sprintf("Could not delete subuser %s: %s", fullSubuserID, adminError(err, "users=write").Error()),
 			)
 			return
 		}
 	}
 }
 
+func (r *SubuserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SubuserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.StoreSecret.IsUnknown() {
+		return
+	}
+
+	// Keep the planned secret_key consistent with store_secret
+	if !plan.StoreSecret.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_key"), types.StringNull())...)
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state SubuserResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Only switching store_secret back on fetches the secret. A subuser
+		// without a Swift key keeps a null secret_key otherwise
+		if !state.StoreSecret.ValueBool() && state.SecretKey.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_key"), types.StringUnknown())...)
+		}
+	}
+}
+
 func (r *SubuserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import format: "user_id:subuser" (the full subuser ID)
 	parts := strings.SplitN(req.ID, ":", 2)
@@ -38,6 +38,13 @@ func TestAccRadosgwIAMSubuser_basic(t *testing.T) {
 				ImportStateId:                        userID + ":" + subuser,
 				ImportStateVerifyIdentifierAttribute: "id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_subuser.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   userID + ":" + subuser,
+			},
 		},
 	})
 }
@@ -91,6 +98,81 @@ func TestAccRadosgwIAMSubuser_update(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMSubuser_storeSecret(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	subuser := "swift"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMSubuserExists("radosgw_iam_subuser.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_subuser.test", "store_secret", "false"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_subuser.test", "secret_key"),
+				),
+			},
+			// Switching store_secret back on populates the secret
+			{
+				Config: testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subuser.test", "store_secret", "true"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_subuser.test", "secret_key"),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subuser.test", "store_secret", "false"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_subuser.test", "secret_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMSubuser_withoutSwiftKey(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	subuser := "swift"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, true),
+			},
+			// A subuser whose Swift key was removed outside of Terraform has
+			// no secret and no planned changes
+			{
+				PreConfig: func() {
+					err := testAccAdminClient.RemoveKey(testCtx, admin.UserKeySpec{UID: userID, SubUser: subuser, KeyType: "swift"})
+					if err != nil {
+						t.Fatalf("error removing Swift key: %s", err)
+					}
+				},
+				Config:   testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, true),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subuser.test", "store_secret", "true"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_subuser.test", "secret_key"),
+				),
+			},
+		},
+	})
+}
+
 // Helper functions
 
 func testAccCheckRadosgwIAMSubuserExists(resourceName string) resource.TestCheckFunc {
@@ -153,3 +235,19 @@ resource "radosgw_iam_subuser" "test" {
 }
 `, userID, subuser, access)
 }
+
+func testAccRadosgwIAMSubuserConfig_storeSecret(userID, subuser string, storeSecret bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Subuser"
+}
+
+resource "radosgw_iam_subuser" "test" {
+  user_id      = radosgw_iam_user.test.user_id
+  subuser      = %q
+  access       = "full-control"
+  store_secret = %t
+}
+`, userID, subuser, storeSecret)
+}
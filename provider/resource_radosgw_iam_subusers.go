@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SubusersResource{}
+var _ resource.ResourceWithImportState = &SubusersResource{}
+
+func NewIAMSubusersResource() resource.Resource {
+	return &SubusersResource{}
+}
+
+// SubusersResource manages the complete set of subusers of a user.
+type SubusersResource struct {
+	client *RadosgwClient
+}
+
+// SubusersResourceModel describes the resource data model.
+type SubusersResourceModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	Subusers types.Map    `tfsdk:"subusers"`
+	ID       types.String `tfsdk:"id"`
+}
+
+func (r *SubusersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_subusers"
+}
+
+func (r *SubusersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete set of subusers of a RadosGW user. Subusers that are not listed in " +
+			"`subusers` are removed together with their keys, so subusers added out of band show up as drift and are " +
+			"deleted on the next apply.\n\n" +
+			"Subusers are created, modified and removed one at a time, which avoids the issues of Reef (18.x) with " +
+			"creating several subusers of a user in parallel from separate `radosgw_iam_subuser` resources.\n\n" +
+			"Ceph generates a Swift key for every new subuser. The keys are not stored in state; manage them with " +
+			"`radosgw_iam_access_key` and `key_type = \"swift\"`.\n\n" +
+			"~> **Note:** Do not manage the subusers of a user with both this resource and `radosgw_iam_subuser`. " +
+			"Destroying this resource removes all listed subusers.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The parent user ID.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subusers": schema.MapAttribute{
+				MarkdownDescription: "Map of subuser name (without the parent user prefix) to access level. " +
+					"Valid access levels: `read`, `write`, `read-write`, `full-control`.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^:]+$`), "must be a subuser name without the user_id prefix"),
+					),
+					mapvalidator.ValueStringsAre(
+						stringvalidator.OneOf("read", "write", "read-write", "full-control"),
+					),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The parent user ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SubusersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SubusersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SubusersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.syncSubusers(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.UserID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SubusersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SubusersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listSubusers(ctx, state.UserID.ValueString())
+	if err != nil {
+		if errors.Is(err, admin.ErrNoSuchUser) {
+			tflog.Info(ctx, "User not found, removing subusers from state", map[string]any{
+				"user_id": state.UserID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Subusers",
+			fmt.Sprintf("Could not read subusers of user %s: %s", state.UserID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	subusers, diags := types.MapValueFrom(ctx, types.StringType, existing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Subusers = subusers
+	state.ID = state.UserID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SubusersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SubusersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.syncSubusers(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.UserID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SubusersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SubusersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var subusers map[string]string
+	resp.Diagnostics.Append(state.Subusers.ElementsAs(ctx, &subusers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.UserID.ValueString()
+	defer userLocks.lock(userID)()
+
+	for _, name := range sortedKeys(subusers) {
+		err := r.removeSubuser(ctx, userID, name)
+		if errors.Is(err, admin.ErrNoSuchUser) {
+			// The user was deleted first, which removed its subusers
+			addUserDeletedFirstWarning(&resp.Diagnostics, "subusers", userID)
+			return
+		}
+		if err != nil && !errors.Is(err, admin.ErrNoSuchSubUser) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Subuser",
+				fmt.Sprintf("Could not delete subuser %s:%s: %s", userID, name, err.Error()),
+			)
+			return
+		}
+	}
+}
+
+func (r *SubusersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "user_id"
+	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+}
+
+// syncSubusers creates, modifies and removes subusers one at a time until the
+// subusers of the user match the plan.
+func (r *SubusersResource) syncSubusers(ctx context.Context, plan *SubusersResourceModel, diags *diag.Diagnostics) {
+	userID := plan.UserID.ValueString()
+	defer userLocks.lock(userID)()
+
+	var wanted map[string]string
+	diags.Append(plan.Subusers.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	existing, err := r.listSubusers(ctx, userID)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Subusers",
+			fmt.Sprintf("Could not read subusers of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	// Remove undeclared subusers first, so that their names can be reused
+	for _, name := range sortedKeys(existing) {
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		if err := r.removeSubuser(ctx, userID, name); err != nil && !errors.Is(err, admin.ErrNoSuchSubUser) {
+			diags.AddError(
+				"Error Deleting Subuser",
+				fmt.Sprintf("Could not delete subuser %s:%s: %s", userID, name, err.Error()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Deleted undeclared subuser", map[string]any{
+			"user_id": userID,
+			"subuser": name,
+		})
+	}
+
+	for _, name := range sortedKeys(wanted) {
+		access := wanted[name]
+		current, ok := existing[name]
+		if ok && current == access {
+			continue
+		}
+
+		spec := admin.SubuserSpec{
+			Name:   userID + ":" + name,
+			Access: admin.SubuserAccess(accessToAPI(access)),
+		}
+		if ok {
+			err = r.client.Admin.ModifySubuser(ctx, admin.User{ID: userID}, spec)
+		} else {
+			err = r.client.Admin.CreateSubuser(ctx, admin.User{ID: userID}, spec)
+		}
+		if err != nil {
+			diags.AddError(
+				"Error Managing Subuser",
+				fmt.Sprintf("Could not set subuser %s to %s access: %s", spec.Name, access, err.Error()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Set subuser access", map[string]any{
+			"user_id": userID,
+			"subuser": name,
+			"access":  access,
+		})
+	}
+}
+
+// listSubusers returns the access level of every subuser of a user, keyed by
+// subuser name without the user prefix.
+func (r *SubusersResource) listSubusers(ctx context.Context, userID string) (map[string]string, error) {
+	user, err := r.client.Admin.GetUser(ctx, admin.User{ID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	subusers := make(map[string]string, len(user.Subusers))
+	for _, subuser := range user.Subusers {
+		name := strings.TrimPrefix(subuser.Name, userID+":")
+		subusers[name] = accessFromAPI(string(subuser.Access))
+	}
+	return subusers, nil
+}
+
+// removeSubuser removes a subuser together with its keys.
+func (r *SubusersResource) removeSubuser(ctx context.Context, userID, name string) error {
+	purgeKeys := true
+	return r.client.Admin.RemoveSubuser(ctx, admin.User{ID: userID}, admin.SubuserSpec{
+		Name:      userID + ":" + name,
+		PurgeKeys: &purgeKeys,
+	})
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwIAMSubusers_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMSubusersConfig(userID, `
+    swift    = "full-control"
+    readonly = "read"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "id", userID),
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.swift", "full-control"),
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.readonly", "read"),
+				),
+			},
+			// Change an access level, remove a subuser and add another one
+			{
+				Config: testAccRadosgwIAMSubusersConfig(userID, `
+    swift  = "read-write"
+    writer = "write"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.swift", "read-write"),
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.writer", "write"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_subusers.test", "subusers.readonly"),
+				),
+			},
+			// Import test - format: user_id
+			{
+				ResourceName:                         "radosgw_iam_subusers.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        userID,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMSubusers_outOfBand(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	subusers := `
+    swift = "full-control"
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMSubusersConfig(userID, subusers),
+			},
+			// A subuser added outside of Terraform is detected as drift
+			{
+				PreConfig: func() {
+					err := testAccAdminClient.CreateSubuser(testCtx, admin.User{ID: userID}, admin.SubuserSpec{
+						Name:   userID + ":oob",
+						Access: admin.SubuserAccessRead,
+					})
+					if err != nil {
+						t.Fatalf("error adding out-of-band subuser: %s", err)
+					}
+				},
+				Config:             testAccRadosgwIAMSubusersConfig(userID, subusers),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying removes it again
+			{
+				Config: testAccRadosgwIAMSubusersConfig(userID, subusers),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_subusers.test", "subusers.%", "1"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_subusers.test", "subusers.oob"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwIAMSubusersConfig(userID, subusers string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Subusers"
+}
+
+resource "radosgw_iam_subusers" "test" {
+  user_id = radosgw_iam_user.test.user_id
+
+  subusers = {%s  }
+}
+`, userID, subusers)
+}
@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/ceph/go-ceph/rgw/admin"
@@ -22,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
 
 func NewIAMUserResource() resource.Resource {
 	return &UserResource{}
@@ -29,7 +34,8 @@ func NewIAMUserResource() resource.Resource {
 
 // UserResource defines the resource implementation.
 type UserResource struct {
-	client *RadosgwClient
+	client      *RadosgwClient
+	adminClient *AdminClient
 }
 
 // UserResourceModel describes the resource data model.
@@ -40,10 +46,19 @@ type UserResourceModel struct {
 	Tenant              types.String `tfsdk:"tenant"`
 	MaxBuckets          types.Int64  `tfsdk:"max_buckets"`
 	Suspended           types.Bool   `tfsdk:"suspended"`
+	PurgeKeysOnSuspend  types.Bool   `tfsdk:"purge_keys_on_suspend"`
 	OpMask              types.String `tfsdk:"op_mask"`
 	DefaultPlacement    types.String `tfsdk:"default_placement"`
 	DefaultStorageClass types.String `tfsdk:"default_storage_class"`
 	Type                types.String `tfsdk:"type"`
+	AccountID           types.String `tfsdk:"account_id"`
+	AccountRoot         types.Bool   `tfsdk:"account_root"`
+	System              types.Bool   `tfsdk:"system"`
+	Admin               types.Bool   `tfsdk:"admin"`
+	GenerateKey         types.Bool   `tfsdk:"generate_key"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+	AccessKey           types.String `tfsdk:"access_key"`
+	SecretKey           types.String `tfsdk:"secret_key"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,7 +67,13 @@ func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a RadosGW user.",
+		MarkdownDescription: `Manages a RadosGW user.
+
+By default, the user is created without keys; use ` + "`radosgw_iam_access_key`" + ` to manage them. For simple setups, set ` + "`generate_key`" + ` to let RadosGW generate an S3 key pair when the user is created, exposed as ` + "`access_key`" + ` and ` + "`secret_key`" + `.
+
+~> **Important:** The generated secret key is stored in the Terraform state. The key is not rotated by Terraform; to rotate it, manage the keys with ` + "`radosgw_iam_access_key`" + ` instead.
+
+~> **Note:** Users of type ` + "`ldap`" + ` or ` + "`keystone`" + ` cannot be created with this resource, import them instead. See below.`,
 
 		Attributes: map[string]schema.Attribute{
 			"user_id": schema.StringAttribute{
@@ -90,10 +111,22 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Default:             int64default.StaticInt64(1000),
 			},
 			"suspended": schema.BoolAttribute{
-				MarkdownDescription: "Whether the user is suspended. Default is false.",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the user is suspended. A suspended user, and its subusers, which " +
+					"authenticate as the user, are denied all requests. Resources that rely on its credentials, such as " +
+					"buckets accessed with its keys, fail until it is reinstated. The plan warns when a user is about to " +
+					"be suspended. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"purge_keys_on_suspend": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete all S3 and Swift keys of the user, including the keys of its " +
+					"subusers, when `suspended` changes to true. Reinstating the user does not restore the keys, so " +
+					"leaked credentials stay unusable. Keys managed by `radosgw_iam_access_key` resources are recreated " +
+					"on the next apply unless those resources are removed as well. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"op_mask": schema.StringAttribute{
 				MarkdownDescription: "The operation mask for the user. Default is 'read, write, delete'.",
@@ -117,8 +150,82 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "The user type (e.g., 'rgw', 'ldap').",
-				Computed:            true,
+				MarkdownDescription: "The user type: `rgw` for users created through the Admin API, `root` for account root users, " +
+					"`ldap` or `keystone` for users created by RadosGW on their first request through external authentication.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the account the user belongs to (see `radosgw_iam_account`). " +
+					"Setting this on an existing user moves the user into the account. RadosGW does not allow users " +
+					"to leave an account, so changing or removing an already set value will force resource replacement. " +
+					"Requires Ceph Squid (19.x) or higher.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.ValueString() != ""
+						},
+						"Users cannot be removed from or moved between accounts.",
+						"Users cannot be removed from or moved between accounts.",
+					),
+				},
+			},
+			"account_root": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is the root user of its account. The account root user has full " +
+					"access to all resources in the account. Only valid together with `account_id`. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"system": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is a system user. System users are used by multisite zones to sync " +
+					"metadata and data, and by the Ceph Dashboard. Only a system user can set this flag, so the provider " +
+					"credentials must belong to a system user. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is an admin user. Admin users can access the buckets and objects of " +
+					"all users. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"generate_key": schema.BoolAttribute{
+				MarkdownDescription: "Whether RadosGW generates an S3 key pair when the user is created. Only used on creation: " +
+					"changing it on an existing user neither generates nor removes keys. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the user, including replacing it, fails. Set it to false and apply " +
+					"before the user can be deleted. Deleting a user also deletes its keys, subusers and capabilities. " +
+					"Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"access_key": schema.StringAttribute{
+				MarkdownDescription: "The access key generated on creation when `generate_key` is true. " +
+					"Null if no key was generated, or if the key was removed from the user.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_key": schema.StringAttribute{
+				MarkdownDescription: "The secret key generated on creation when `generate_key` is true. " +
+					"Null if no key was generated, or if the key was removed from the user.",
+				Computed:  true,
+				Sensitive: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -144,6 +251,38 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan UserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.Suspended.ValueBool() {
+		return
+	}
+
+	// Only warn when the user goes from active to suspended
+	if !req.State.Raw.IsNull() {
+		var state UserResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.Suspended.ValueBool() {
+			return
+		}
+	}
+
+	detail := fmt.Sprintf("User %q will be suspended. All requests made with its keys and the keys of its subusers "+
+		"will be denied, including requests of other resources in this configuration that use its credentials.",
+		plan.UserID.ValueString())
+	if !req.State.Raw.IsNull() && plan.PurgeKeysOnSuspend.ValueBool() {
+		detail += " Its S3 and Swift keys will be deleted and are not restored when the user is reinstated."
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("access_key"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_key"), types.StringNull())...)
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("suspended"), "User Will Be Suspended", detail)
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -165,7 +304,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if data.Suspended.ValueBool() {
 		suspended = 1
 	}
-	generateKey := false
+	generateKey := data.GenerateKey.ValueBool()
 
 	userConfig := admin.User{
 		ID:               data.UserID.ValueString(),
@@ -179,17 +318,30 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		GenerateKey:      &generateKey,
 	}
 
-	// Create user with retry logic for ConcurrentModification
-	var user admin.User
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("CreateUser %s", data.UserID.ValueString()), func() error {
-		var createErr error
-		user, createErr = r.client.Admin.CreateUser(ctx, userConfig)
-		return createErr
-	})
+	// Account membership is only sent when requested, older releases reject the parameters
+	if data.AccountID.ValueString() != "" {
+		accountRoot := data.AccountRoot.ValueBool()
+		userConfig.AccountID = data.AccountID.ValueString()
+		userConfig.AccountRoot = &accountRoot
+	}
+
+	// Create user
+	user, err := r.client.Admin.CreateUser(ctx, userConfig)
+	if errors.Is(err, admin.ErrUserExists) {
+		fullUserID := buildFullUserID(data.UserID.ValueString(), data.Tenant.ValueString())
+		if existing, getErr := r.client.Admin.GetUser(ctx, admin.User{ID: fullUserID}); getErr == nil && isExternalUser(existing) {
+			resp.Diagnostics.AddError(
+				"User Managed by External Authentication",
+				fmt.Sprintf("User %s was created by RadosGW through %s authentication. Import it with `terraform import` to manage it.",
+					fullUserID, existing.Type),
+			)
+			return
+		}
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating RadosGW User",
-			"Could not create user, unexpected error: "+err.Error(),
+			"Could not create user, unexpected error: "+adminError(err, "users=write").Error(),
 		)
 		return
 	}
@@ -205,6 +357,34 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.DefaultPlacement = types.StringValue(user.DefaultPlacement)
 	data.DefaultStorageClass = types.StringValue(user.DefaultStorageClass)
 	data.Type = types.StringValue(user.Type)
+	data.AccountID = types.StringValue(user.AccountID)
+	data.AccountRoot = types.BoolValue(user.Type == "root")
+
+	// The generated key is the only key of the new user
+	data.AccessKey = types.StringNull()
+	data.SecretKey = types.StringNull()
+	if generateKey && len(user.Keys) > 0 {
+		data.AccessKey = types.StringValue(user.Keys[0].AccessKey)
+		data.SecretKey = types.StringValue(user.Keys[0].SecretKey)
+	}
+
+	// go-ceph cannot set the system and admin flags on creation
+	flags := userFlags{System: jsonBool(data.System.ValueBool()), Admin: jsonBool(data.Admin.ValueBool())}
+	if flags.System || flags.Admin {
+		fullUserID := buildFullUserID(user.ID, user.Tenant)
+		err := r.adminClient.SetUserFlags(ctx, fullUserID, flags)
+		if err != nil {
+			// Save the user so it is tainted and replaced rather than orphaned
+			data.System = types.BoolValue(false)
+			data.Admin = types.BoolValue(false)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError(
+				"Error Setting RadosGW User Flags",
+				fmt.Sprintf("User %s was created, but its system and admin flags could not be set: %s", fullUserID, err.Error()),
+			)
+			return
+		}
+	}
 
 	tflog.Trace(ctx, "Created RadosGW user")
 
@@ -240,7 +420,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading RadosGW User",
-			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -256,6 +436,39 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.DefaultPlacement = types.StringValue(user.DefaultPlacement)
 	data.DefaultStorageClass = types.StringValue(user.DefaultStorageClass)
 	data.Type = types.StringValue(user.Type)
+	data.AccountID = types.StringValue(user.AccountID)
+	data.AccountRoot = types.BoolValue(user.Type == "root")
+
+	flags, err := r.adminClient.GetUserFlags(ctx, fullUserID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RadosGW User",
+			fmt.Sprintf("Could not read the flags of user %s: %s", data.UserID.ValueString(), err.Error()),
+		)
+		return
+	}
+	data.System = types.BoolValue(bool(flags.System))
+	data.Admin = types.BoolValue(bool(flags.Admin))
+
+	// generate_key has no counterpart in RadosGW, default it on import
+	if data.GenerateKey.IsNull() {
+		data.GenerateKey = types.BoolValue(false)
+	}
+
+	// The credentials of external users are managed by the external service
+	if isExternalUser(user) {
+		data.AccessKey = types.StringNull()
+		data.SecretKey = types.StringNull()
+	}
+
+	// Forget the generated key once it has been removed from the user
+	if !data.AccessKey.IsNull() && !userHasAccessKey(user, data.AccessKey.ValueString()) {
+		tflog.Info(ctx, "Generated access key no longer exists, removing it from state", map[string]any{
+			"user_id": data.UserID.ValueString(),
+		})
+		data.AccessKey = types.StringNull()
+		data.SecretKey = types.StringNull()
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -305,21 +518,50 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		userConfig.DefaultPlacement = data.DefaultPlacement.ValueString()
 	}
 
-	// Modify user with retry logic for ConcurrentModification
-	var user admin.User
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("ModifyUser %s", data.UserID.ValueString()), func() error {
-		var modifyErr error
-		user, modifyErr = r.client.Admin.ModifyUser(ctx, userConfig)
-		return modifyErr
-	})
+	// Setting account_id on an existing user moves it into the account
+	if data.AccountID.ValueString() != "" {
+		accountRoot := data.AccountRoot.ValueBool()
+		userConfig.AccountID = data.AccountID.ValueString()
+		userConfig.AccountRoot = &accountRoot
+	}
+
+	// Modify user, and purge its keys if needed, without racing the
+	// resources managing its keys, subusers and caps
+	defer userLocks.lock(fullUserID)()
+
+	user, err := r.client.Admin.ModifyUser(ctx, userConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating RadosGW User",
-			"Could not update user, unexpected error: "+err.Error(),
+			"Could not update user, unexpected error: "+adminError(err, "users=write").Error(),
 		)
 		return
 	}
 
+	if data.Suspended.ValueBool() && !state.Suspended.ValueBool() && data.PurgeKeysOnSuspend.ValueBool() {
+		if err := r.purgeUserKeys(ctx, fullUserID, user); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Purging RadosGW User Keys",
+				fmt.Sprintf("User %s was suspended, but its keys could not be deleted: %s", fullUserID, adminError(err, "users=write").Error()),
+			)
+			return
+		}
+		data.AccessKey = types.StringNull()
+		data.SecretKey = types.StringNull()
+	}
+
+	if !data.System.Equal(state.System) || !data.Admin.Equal(state.Admin) {
+		flags := userFlags{System: jsonBool(data.System.ValueBool()), Admin: jsonBool(data.Admin.ValueBool())}
+		err := r.adminClient.SetUserFlags(ctx, fullUserID, flags)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating RadosGW User",
+				fmt.Sprintf("Could not update the system and admin flags of user %s: %s", fullUserID, err.Error()),
+			)
+			return
+		}
+	}
+
 	// Update state
 	data.UserID = types.StringValue(user.ID)
 	data.DisplayName = types.StringValue(user.DisplayName)
@@ -331,6 +573,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.DefaultPlacement = types.StringValue(user.DefaultPlacement)
 	data.DefaultStorageClass = types.StringValue(user.DefaultStorageClass)
 	data.Type = types.StringValue(user.Type)
+	data.AccountID = types.StringValue(user.AccountID)
+	data.AccountRoot = types.BoolValue(user.Type == "root")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -347,20 +591,22 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	// Build the full user ID for API calls
 	fullUserID := buildFullUserID(data.UserID.ValueString(), data.Tenant.ValueString())
 
+	if !checkDeletionProtection(&resp.Diagnostics, data.DeletionProtection, fmt.Sprintf("user %q", fullUserID)) {
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting RadosGW user", map[string]any{
 		"user_id":      data.UserID.ValueString(),
 		"tenant":       data.Tenant.ValueString(),
 		"full_user_id": fullUserID,
 	})
 
-	// Delete user with retry logic for ConcurrentModification
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("RemoveUser %s", fullUserID), func() error {
-		return r.client.Admin.RemoveUser(ctx, admin.User{ID: fullUserID})
-	})
+	// Delete user
+	err := r.client.Admin.RemoveUser(ctx, admin.User{ID: fullUserID})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting RadosGW User",
-			"Could not delete user, unexpected error: "+err.Error(),
+			"Could not delete user, unexpected error: "+adminError(err, "users=write").Error(),
 		)
 		return
 	}
@@ -370,16 +616,7 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 	// Import ID can be either "user_id" or "tenant$user_id"
 	importID := req.ID
 
-	var userID, tenant string
-	if idx := strings.Index(importID, "$"); idx != -1 {
-		// Format: tenant$user_id
-		tenant = importID[:idx]
-		userID = importID[idx+1:]
-	} else {
-		// Format: user_id (no tenant)
-		userID = importID
-		tenant = ""
-	}
+	tenant, userID := splitUserID(importID)
 
 	tflog.Debug(ctx, "Importing RadosGW user", map[string]any{
 		"import_id": importID,
@@ -389,6 +626,52 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("purge_keys_on_suspend"), false)...)
+}
+
+// purgeUserKeys deletes all S3 and Swift keys of a user and its subusers.
+func (r *UserResource) purgeUserKeys(ctx context.Context, fullUserID string, user admin.User) error {
+	for _, key := range user.Keys {
+		err := r.client.Admin.RemoveKey(ctx, admin.UserKeySpec{UID: fullUserID, AccessKey: key.AccessKey, KeyType: "s3"})
+		if err != nil && !errors.Is(err, admin.ErrNoSuchKey) {
+			return fmt.Errorf("deleting S3 key %s: %w", key.AccessKey, err)
+		}
+	}
+
+	// Swift keys belong to subusers and are named "user:subuser"
+	for _, key := range user.SwiftKeys {
+		_, subuser, _ := strings.Cut(key.User, ":")
+		err := r.client.Admin.RemoveKey(ctx, admin.UserKeySpec{UID: fullUserID, SubUser: subuser, KeyType: "swift"})
+		if err != nil && !errors.Is(err, admin.ErrNoSuchKey) {
+			return fmt.Errorf("deleting Swift key of subuser %s: %w", subuser, err)
+		}
+	}
+
+	tflog.Info(ctx, "Purged keys of suspended user", map[string]any{
+		"user_id":    fullUserID,
+		"s3_keys":    len(user.Keys),
+		"swift_keys": len(user.SwiftKeys),
+	})
+
+	return nil
+}
+
+// isExternalUser reports whether a user was created by RadosGW on its first
+// request through LDAP or Keystone authentication.
+func isExternalUser(user admin.User) bool {
+	return user.Type == "ldap" || user.Type == "keystone"
+}
+
+// userHasAccessKey reports whether the user has an S3 key with the given
+// access key.
+func userHasAccessKey(user admin.User, accessKey string) bool {
+	for _, key := range user.Keys {
+		if key.AccessKey == accessKey {
+			return true
+		}
+	}
+	return false
 }
 
 // buildFullUserID constructs the full user ID for API calls.
@@ -400,3 +683,37 @@ func buildFullUserID(userID, tenant string) string {
 	}
 	return userID
 }
+
+// userFlags are the system and admin flags of a user, which go-ceph does not
+// expose. RadosGW only includes them in user info when they are set.
+type userFlags struct {
+	System jsonBool `json:"system"`
+	Admin  jsonBool `json:"admin"`
+}
+
+// GetUserFlags returns the system and admin flags of a user.
+func (c *AdminClient) GetUserFlags(ctx context.Context, userID string) (userFlags, error) {
+	body, err := c.DoRequest(ctx, http.MethodGet, "/user", url.Values{"uid": {userID}})
+	if err != nil {
+		return userFlags{}, err
+	}
+
+	var flags userFlags
+	if err := json.Unmarshal(body, &flags); err != nil {
+		return userFlags{}, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	return flags, nil
+}
+
+// SetUserFlags sets the system and admin flags of an existing user.
+func (c *AdminClient) SetUserFlags(ctx context.Context, userID string, flags userFlags) error {
+	params := url.Values{
+		"uid":    {userID},
+		"system": {strconv.FormatBool(bool(flags.System))},
+		"admin":  {strconv.FormatBool(bool(flags.Admin))},
+	}
+
+	_, err := c.DoRequest(ctx, http.MethodPost, "/user", params)
+	return err
+}
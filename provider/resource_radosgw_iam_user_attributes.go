@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserAttributesResource{}
+var _ resource.ResourceWithImportState = &UserAttributesResource{}
+
+func NewIAMUserAttributesResource() resource.Resource {
+	return &UserAttributesResource{}
+}
+
+// UserAttributesResource defines the resource implementation.
+type UserAttributesResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// UserAttributesResourceModel describes the resource data model.
+type UserAttributesResourceModel struct {
+	UserID     types.String `tfsdk:"user_id"`
+	Attributes types.Map    `tfsdk:"attributes"`
+	ID         types.String `tfsdk:"id"`
+}
+
+// userMetaAttrPrefix is the prefix of the user attributes holding metadata,
+// which Swift also exposes as X-Account-Meta-* headers.
+const userMetaAttrPrefix = "user.rgw.x-amz-meta-"
+
+// userAttr is an extended attribute of the user object, as listed in the
+// attrs of the user metadata section. RadosGW encodes the value in base64.
+type userAttr struct {
+	Key string `json:"key"`
+	Val []byte `json:"val"`
+}
+
+func (r *UserAttributesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_user_attributes"
+}
+
+func (r *UserAttributesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the metadata attributes of a RadosGW user, arbitrary key/value pairs such as the " +
+			"owning team or cost center that are stored on the user and read back on refresh. RadosGW keeps them as " +
+			"`user.rgw.x-amz-meta-<key>` attributes of the user object, which Swift clients also see as the " +
+			"`X-Account-Meta-<key>` headers of the account.\n\n" +
+			"The Admin Ops API has no endpoint for user attributes, so they are written through the `user` section " +
+			"of the metadata API, which requires the provider credentials to have the `metadata=read,write` " +
+			"capability. The resource manages all metadata attributes of the user: attributes missing from " +
+			"`attributes` are removed.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The user the attributes belong to. Use the `tenant$user` format for users of a tenant.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Map of attribute key to value. Keys are lowercase, as Swift and S3 lowercase " +
+					"metadata header names.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`),
+							"must only contain lowercase letters, numbers, hyphens and underscores"),
+					),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The user ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserAttributesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *UserAttributesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserAttributesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := map[string]string{}
+	resp.Diagnostics.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+
+	tflog.Debug(ctx, "Creating user attributes", map[string]any{
+		"user_id":    userID,
+		"attributes": sortedKeys(attributes),
+	})
+
+	if err := r.putAttributes(ctx, userID, attributes); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating User Attributes",
+			fmt.Sprintf("Could not set attributes of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(userID)
+
+	tflog.Trace(ctx, "Created user attributes", map[string]any{
+		"user_id": userID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAttributesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserAttributesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+
+	tflog.Debug(ctx, "Reading user attributes", map[string]any{
+		"user_id": userID,
+	})
+
+	entry, err := r.adminClient.GetMetadataEntry(ctx, "user", userID)
+	if isAdminNotFoundError(err) {
+		tflog.Info(ctx, "User not found, removing attributes from state", map[string]any{
+			"user_id": userID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User Attributes",
+			fmt.Sprintf("Could not read user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	attributes, err := userMetaAttributes(entry.Data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User Attributes",
+			fmt.Sprintf("Could not read attributes of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	attributesValue, diags := types.MapValueFrom(ctx, types.StringType, attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Attributes = attributesValue
+	data.ID = types.StringValue(userID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAttributesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserAttributesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := map[string]string{}
+	resp.Diagnostics.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+
+	tflog.Debug(ctx, "Updating user attributes", map[string]any{
+		"user_id":    userID,
+		"attributes": sortedKeys(attributes),
+	})
+
+	if err := r.putAttributes(ctx, userID, attributes); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating User Attributes",
+			fmt.Sprintf("Could not set attributes of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(userID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAttributesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserAttributesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+
+	tflog.Debug(ctx, "Deleting user attributes", map[string]any{
+		"user_id": userID,
+	})
+
+	err := r.putAttributes(ctx, userID, nil)
+	if isAdminNotFoundError(err) {
+		addUserDeletedFirstWarning(&resp.Diagnostics, "attributes", userID)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting User Attributes",
+			fmt.Sprintf("Could not remove attributes of user %s: %s", userID, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted user attributes", map[string]any{
+		"user_id": userID,
+	})
+}
+
+func (r *UserAttributesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+}
+
+// putAttributes replaces the metadata attributes of a user. The attributes
+// are stored in the user metadata entry, which is rewritten with only them
+// changed.
+func (r *UserAttributesResource) putAttributes(ctx context.Context, userID string, attributes map[string]string) error {
+	defer userLocks.lock(userID)()
+
+	entry, err := r.adminClient.GetMetadataEntry(ctx, "user", userID)
+	if err != nil {
+		return err
+	}
+
+	if entry.Data, err = setUserMetaAttributes(entry.Data, attributes); err != nil {
+		return err
+	}
+
+	return r.adminClient.PutMetadataEntry(ctx, "user", userID, entry)
+}
+
+// userMetaAttributes returns the metadata attributes in the data of a user
+// metadata entry, keyed without their prefix.
+func userMetaAttributes(data json.RawMessage) (map[string]string, error) {
+	var info struct {
+		Attrs []userAttr `json:"attrs"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse user metadata: %w", err)
+	}
+
+	attributes := map[string]string{}
+	for _, attr := range info.Attrs {
+		if key, ok := strings.CutPrefix(attr.Key, userMetaAttrPrefix); ok {
+			// Swift stores the values NUL-terminated
+			attributes[key] = strings.TrimSuffix(string(attr.Val), "\x00")
+		}
+	}
+	return attributes, nil
+}
+
+// setUserMetaAttributes returns the data of a user metadata entry with its
+// metadata attributes replaced by attributes, keeping the other attributes
+// and fields unchanged.
+func setUserMetaAttributes(data json.RawMessage, attributes map[string]string) (json.RawMessage, error) {
+	var info map[string]json.RawMessage
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse user metadata: %w", err)
+	}
+
+	var attrs []userAttr
+	if raw, ok := info["attrs"]; ok {
+		if err := json.Unmarshal(raw, &attrs); err != nil {
+			return nil, fmt.Errorf("failed to parse attrs of user metadata: %w", err)
+		}
+	}
+
+	kept := make([]userAttr, 0, len(attrs)+len(attributes))
+	for _, attr := range attrs {
+		if !strings.HasPrefix(attr.Key, userMetaAttrPrefix) {
+			kept = append(kept, attr)
+		}
+	}
+	for _, key := range sortedKeys(attributes) {
+		kept = append(kept, userAttr{Key: userMetaAttrPrefix + key, Val: append([]byte(attributes[key]), 0)})
+	}
+
+	var err error
+	if info["attrs"], err = json.Marshal(kept); err != nil {
+		return nil, err
+	}
+	return json.Marshal(info)
+}
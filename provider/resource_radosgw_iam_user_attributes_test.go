@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSetUserMetaAttributes(t *testing.T) {
+	t.Parallel()
+
+	// "AQID" is the base64 encoding of the bytes 1, 2, 3 and "b3BzAA==" of
+	// "ops" followed by a NUL byte
+	data := json.RawMessage(`{"user_id":"alice","mfa_ids":["totp-1"],"attrs":[` +
+		`{"key":"user.rgw.idtag","val":"AQID"},{"key":"user.rgw.x-amz-meta-team","val":"b3BzAA=="}]}`)
+
+	attributes, err := userMetaAttributes(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := map[string]string{"team": "ops"}; !maps.Equal(attributes, expected) {
+		t.Errorf("expected attributes %v, got %v", expected, attributes)
+	}
+
+	updated, err := setUserMetaAttributes(data, map[string]string{"cost-center": "1234", "team": "storage"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	attributes, err = userMetaAttributes(updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := map[string]string{"cost-center": "1234", "team": "storage"}; !maps.Equal(attributes, expected) {
+		t.Errorf("expected attributes %v, got %v", expected, attributes)
+	}
+
+	var info struct {
+		MFAIDs []string   `json:"mfa_ids"`
+		Attrs  []userAttr `json:"attrs"`
+	}
+	if err := json.Unmarshal(updated, &info); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(info.MFAIDs) != 1 {
+		t.Errorf("expected the other fields to be kept, got %s", updated)
+	}
+	if len(info.Attrs) != 3 || info.Attrs[0].Key != "user.rgw.idtag" || string(info.Attrs[0].Val) != "\x01\x02\x03" {
+		t.Errorf("expected the other attributes to be kept, got %s", updated)
+	}
+	if string(info.Attrs[1].Val) != "1234\x00" {
+		t.Errorf("expected a NUL-terminated value, got %q", info.Attrs[1].Val)
+	}
+
+	cleared, err := setUserMetaAttributes(updated, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attributes, _ := userMetaAttributes(cleared); len(attributes) != 0 {
+		t.Errorf("expected no attributes, got %v", attributes)
+	}
+}
+
+func TestAccRadosgwIAMUserAttributes_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserAttributesConfig(userID, `{
+    team        = "storage"
+    cost-center = "1234"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user_attributes.test", "id", userID),
+					resource.TestCheckResourceAttr("radosgw_iam_user_attributes.test", "attributes.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_iam_user_attributes.test", "attributes.team", "storage"),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMUserAttributesConfig(userID, `{
+    team = "ops"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user_attributes.test", "attributes.%", "1"),
+					resource.TestCheckResourceAttr("radosgw_iam_user_attributes.test", "attributes.team", "ops"),
+				),
+			},
+			{
+				ResourceName:      "radosgw_iam_user_attributes.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     userID,
+			},
+		},
+	})
+}
+
+func testAccRadosgwIAMUserAttributesConfig(userID, attributes string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for attributes"
+}
+
+resource "radosgw_iam_user_attributes" "test" {
+  user_id    = radosgw_iam_user.test.user_id
+  attributes = %s
+}
+`, userID, attributes)
+}
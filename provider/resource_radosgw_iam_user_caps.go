@@ -64,6 +64,7 @@ func (v uniqueCapTypesValidator) ValidateSet(ctx context.Context, req validator.
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserCapsResource{}
 var _ resource.ResourceWithImportState = &UserCapsResource{}
+var _ resource.ResourceWithModifyPlan = &UserCapsResource{}
 
 func NewIAMUserCapsResource() resource.Resource {
 	return &UserCapsResource{}
@@ -90,7 +91,13 @@ type UserCapsResourceModel struct {
 var validCapTypes = []string{
 	"users", "buckets", "metadata", "usage", "zone", "info",
 	"accounts", "ratelimit", "roles", "user-policy", "amz-cache",
-	"oidc-provider", "bilog", "mdlog", "datalog",
+	"oidc-provider", "bilog", "mdlog", "datalog", "user-info-without-keys",
+}
+
+// capTypeFeatures holds the feature providing the capability types that older
+// supported releases reject.
+var capTypeFeatures = map[string]CephFeature{
+	"accounts": CephFeature_Accounts,
 }
 
 // Valid permissions
@@ -163,7 +170,7 @@ On deletion, all specified capabilities are removed from the user.`,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"type": schema.StringAttribute{
-							MarkdownDescription: "The capability type. Valid values: `users`, `buckets`, `metadata`, `usage`, `zone`, `info`, `accounts`, `ratelimit`, `roles`, `user-policy`, `amz-cache`, `oidc-provider`, `bilog`, `mdlog`, `datalog`.",
+							MarkdownDescription: "The capability type. Valid values: `users`, `buckets`, `metadata`, `usage`, `zone`, `info`, `accounts`, `ratelimit`, `roles`, `user-policy`, `amz-cache`, `oidc-provider`, `bilog`, `mdlog`, `datalog`, `user-info-without-keys`. `accounts` requires Ceph Squid (19.x) or higher.",
 							Required:            true,
 							Validators: []validator.String{
 								stringvalidator.OneOf(validCapTypes...),
@@ -202,6 +209,31 @@ func (r *UserCapsResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+func (r *UserCapsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var caps types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("caps"), &caps)...)
+	if resp.Diagnostics.HasError() || caps.IsNull() || caps.IsUnknown() {
+		return
+	}
+
+	var capModels []CapModel
+	resp.Diagnostics.Append(caps.ElementsAs(ctx, &capModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, cap := range capModels {
+		feature, ok := capTypeFeatures[cap.Type.ValueString()]
+		if ok && !r.client.supportsFeature(feature) {
+			r.client.addCephFeatureError(&resp.Diagnostics, fmt.Sprintf("The %q capability type", cap.Type.ValueString()), feature)
+		}
+	}
+}
+
 // capsToString converts the caps set to the string format expected by go-ceph
 // It also normalizes and sorts the capabilities
 func capsToString(ctx context.Context, caps types.Set) (string, error) {
@@ -375,15 +407,14 @@ func (r *UserCapsResource) Create(ctx context.Context, req resource.CreateReques
 		"caps":    capsStr,
 	})
 
-	// Add capabilities with retry logic for ConcurrentModification
-	err = retryOnConcurrentModification(ctx, fmt.Sprintf("AddUserCap %s", data.UserID.ValueString()), func() error {
-		_, addErr := r.client.Admin.AddUserCap(ctx, data.UserID.ValueString(), capsStr)
-		return addErr
-	})
+	// Add capabilities
+	unlock := userLocks.lock(data.UserID.ValueString())
+	_, err = r.client.Admin.AddUserCap(ctx, data.UserID.ValueString(), capsStr)
+	unlock()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Adding User Capabilities",
-			fmt.Sprintf("Could not add capabilities for user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not add capabilities for user %s: %s", data.UserID.ValueString(), adminError(err, "users=write").Error()),
 		)
 		return
 	}
@@ -427,7 +458,7 @@ func (r *UserCapsResource) Read(ctx context.Context, req resource.ReadRequest, r
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading User Capabilities",
-			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read user %s: %s", data.UserID.ValueString(), adminError(err, "users=read").Error()),
 		)
 		return
 	}
@@ -490,31 +521,27 @@ func (r *UserCapsResource) Update(ctx context.Context, req resource.UpdateReques
 		"new_caps": newCapsStr,
 	})
 
-	// Remove old capabilities with retry logic
+	defer userLocks.lock(data.UserID.ValueString())()
+
+	// Remove old capabilities
 	if oldCapsStr != "" {
-		err = retryOnConcurrentModification(ctx, fmt.Sprintf("RemoveUserCap %s", state.UserID.ValueString()), func() error {
-			_, removeErr := r.client.Admin.RemoveUserCap(ctx, state.UserID.ValueString(), oldCapsStr)
-			return removeErr
-		})
+		_, err = r.client.Admin.RemoveUserCap(ctx, state.UserID.ValueString(), oldCapsStr)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Removing Old User Capabilities",
-				fmt.Sprintf("Could not remove old capabilities for user %s: %s", state.UserID.ValueString(), err.Error()),
+				fmt.Sprintf("Could not remove old capabilities for user %s: %s", state.UserID.ValueString(), adminError(err, "users=write").Error()),
 			)
 			return
 		}
 	}
 
-	// Add new capabilities with retry logic
+	// Add new capabilities
 	if newCapsStr != "" {
-		err = retryOnConcurrentModification(ctx, fmt.Sprintf("AddUserCap %s", data.UserID.ValueString()), func() error {
-			_, addErr := r.client.Admin.AddUserCap(ctx, data.UserID.ValueString(), newCapsStr)
-			return addErr
-		})
+		_, err = r.client.Admin.AddUserCap(ctx, data.UserID.ValueString(), newCapsStr)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Adding New User Capabilities",
-				fmt.Sprintf("Could not add new capabilities for user %s: %s", data.UserID.ValueString(), err.Error()),
+				fmt.Sprintf("Could not add new capabilities for user %s: %s", data.UserID.ValueString(), adminError(err, "users=write").Error()),
 			)
 			return
 		}
@@ -558,20 +585,21 @@ func (r *UserCapsResource) Delete(ctx context.Context, req resource.DeleteReques
 		"caps":    capsStr,
 	})
 
-	// Remove capabilities with retry logic
-	err = retryOnConcurrentModification(ctx, fmt.Sprintf("RemoveUserCap %s", data.UserID.ValueString()), func() error {
-		_, removeErr := r.client.Admin.RemoveUserCap(ctx, data.UserID.ValueString(), capsStr)
-		return removeErr
-	})
+	// Remove capabilities
+	unlock := userLocks.lock(data.UserID.ValueString())
+	_, err = r.client.Admin.RemoveUserCap(ctx, data.UserID.ValueString(), capsStr)
+	unlock()
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		// The user was deleted first, which removed its capabilities
+		addUserDeletedFirstWarning(&resp.Diagnostics, "capabilities", data.UserID.ValueString())
+		return
+	}
 	if err != nil {
-		// Ignore error if user doesn't exist
-		if !errors.Is(err, admin.ErrNoSuchUser) {
-			resp.Diagnostics.AddError(
-				"Error Removing User Capabilities",
-				fmt.Sprintf("Could not remove capabilities for user %s: %s", data.UserID.ValueString(), err.Error()),
-			)
-			return
-		}
+		resp.Diagnostics.AddError(
+			"Error Removing User Capabilities",
+			fmt.Sprintf("Could not remove capabilities for user %s: %s", data.UserID.ValueString(), adminError(err, "users=write").Error()),
+		)
+		return
 	}
 }
 
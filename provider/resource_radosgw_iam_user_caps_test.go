@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/ceph/go-ceph/rgw/admin"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -36,6 +39,13 @@ func TestAccRadosgwIAMUserCaps_basic(t *testing.T) {
 				ImportStateId:                        userID,
 				ImportStateVerifyIdentifierAttribute: "user_id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_user_caps.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   userID,
+			},
 		},
 	})
 }
@@ -121,6 +131,48 @@ func TestUniqueCapTypesValidator(t *testing.T) {
 	})
 }
 
+func TestUserCapsModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cephVersion CephVersion
+		capType     string
+		expectError bool
+	}{
+		"accounts on reef":  {cephVersion: CephVersion_Reef, capType: "accounts", expectError: true},
+		"accounts on squid": {cephVersion: CephVersion_Squid, capType: "accounts"},
+		"accounts unknown":  {cephVersion: CephVersion_Unknown, capType: "accounts"},
+		"users on reef":     {cephVersion: CephVersion_Reef, capType: "users"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &UserCapsResource{client: &RadosgwClient{CephVersion: testCase.cephVersion}}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(testCtx, fwresource.SchemaRequest{}, &schemaResp)
+
+			caps, err := stringToCaps(testCtx, testCase.capType+"=read")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if d := plan.Set(testCtx, &UserCapsResourceModel{UserID: types.StringValue("user"), Caps: caps}); d.HasError() {
+				t.Fatalf("unexpected error: %v", d)
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(testCtx, fwresource.ModifyPlanRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
 // Helper functions
 
 func testAccCheckRadosgwIAMUserCapsExists(resourceName string) resource.TestCheckFunc {
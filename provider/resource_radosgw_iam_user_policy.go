@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserPolicyResource{}
+var _ resource.ResourceWithImportState = &UserPolicyResource{}
+
+func NewIAMUserPolicyResource() resource.Resource {
+	return &UserPolicyResource{}
+}
+
+// UserPolicyResource defines the resource implementation.
+type UserPolicyResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// UserPolicyResourceModel describes the resource data model.
+type UserPolicyResourceModel struct {
+	User   types.String `tfsdk:"user"`
+	Name   types.String `tfsdk:"name"`
+	Policy types.String `tfsdk:"policy"`
+	ID     types.String `tfsdk:"id"`
+}
+
+// XML response structures for RadosGW User Policy API
+type getUserPolicyResponseXML struct {
+	XMLName xml.Name            `xml:"GetUserPolicyResponse"`
+	Result  getUserPolicyResult `xml:"GetUserPolicyResult"`
+}
+
+type getUserPolicyResult struct {
+	UserName       string `xml:"UserName"`
+	PolicyName     string `xml:"PolicyName"`
+	PolicyDocument string `xml:"PolicyDocument"`
+}
+
+func (r *UserPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_user_policy"
+}
+
+func (r *UserPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an inline IAM policy for a RadosGW user. Inline policies are embedded " +
+			"directly in the user and grant S3 permissions without relying on bucket ACLs.\n\n" +
+			"~> **Note:** Managing user policies requires the `user-policy=*` capability.",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user to associate the policy with. Tenant users are given as `tenant$user_id`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the policy. Must be unique within the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[\w+=,.@-]+$`),
+						"must contain only alphanumeric characters, plus (+), equals (=), comma (,), period (.), at (@), underscore (_), and hyphen (-)",
+					),
+				},
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "The policy document (in JSON format). Use `jsonencode()` or the " +
+					"`radosgw_iam_policy_document` data source to generate this.",
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for this policy. Format: `user:policy_name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *UserPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan UserPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "PutUserPolicy")
+	params.Set("UserName", plan.User.ValueString())
+	params.Set("PolicyName", plan.Name.ValueString())
+	params.Set("PolicyDocument", normalizedPolicy)
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating User Policy",
+			fmt.Sprintf("Could not create policy %s for user %s: %s", plan.Name.ValueString(), plan.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Set computed fields
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.User.ValueString(), plan.Name.ValueString()))
+	plan.Policy = types.StringValue(normalizedPolicy)
+
+	tflog.Trace(ctx, "Created user policy", map[string]interface{}{
+		"user":   plan.User.ValueString(),
+		"policy": plan.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state UserPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "GetUserPolicy")
+	params.Set("UserName", state.User.ValueString())
+	params.Set("PolicyName", state.Name.ValueString())
+
+	body, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "User policy not found, removing from state", map[string]interface{}{
+				"user":   state.User.ValueString(),
+				"policy": state.Name.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User Policy",
+			fmt.Sprintf("Could not read policy %s for user %s: %s", state.Name.ValueString(), state.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var response getUserPolicyResponseXML
+	if err := xml.Unmarshal(body, &response); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Response",
+			fmt.Sprintf("Could not parse GetUserPolicy response: %s", err.Error()),
+		)
+		return
+	}
+
+	// URL decode the policy if it's URL-encoded
+	policyDoc := response.Result.PolicyDocument
+	decodedPolicy, err := url.QueryUnescape(policyDoc)
+	if err != nil {
+		decodedPolicy = policyDoc
+	}
+
+	// Normalize the policy for comparison
+	normalizedPolicy, err := normalizeJSONPolicy(decodedPolicy)
+	if err == nil {
+		state.Policy = types.StringValue(normalizedPolicy)
+	} else {
+		state.Policy = types.StringValue(decodedPolicy)
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s:%s", state.User.ValueString(), state.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *UserPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan UserPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the policy JSON
+	normalizedPolicy, err := normalizeJSONPolicy(plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	// PutUserPolicy is idempotent - it creates or updates
+	params := url.Values{}
+	params.Set("Action", "PutUserPolicy")
+	params.Set("UserName", plan.User.ValueString())
+	params.Set("PolicyName", plan.Name.ValueString())
+	params.Set("PolicyDocument", normalizedPolicy)
+
+	_, err = r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating User Policy",
+			fmt.Sprintf("Could not update policy %s for user %s: %s", plan.Name.ValueString(), plan.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.Policy = types.StringValue(normalizedPolicy)
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.User.ValueString(), plan.Name.ValueString()))
+
+	tflog.Debug(ctx, "Updated user policy", map[string]interface{}{
+		"user":   plan.User.ValueString(),
+		"policy": plan.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state UserPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DeleteUserPolicy")
+	params.Set("UserName", state.User.ValueString())
+	params.Set("PolicyName", state.Name.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "User policy already deleted", map[string]interface{}{
+				"user":   state.User.ValueString(),
+				"policy": state.Name.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting User Policy",
+			fmt.Sprintf("Could not delete policy %s for user %s: %s", state.Name.ValueString(), state.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted user policy", map[string]interface{}{
+		"user":   state.User.ValueString(),
+		"policy": state.Name.ValueString(),
+	})
+}
+
+func (r *UserPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "user_id:policy_name"
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'user_id:policy_name'. Example: 'my-user:my-policy'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserPolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &UserPolicyAttachmentResource{}
+
+func NewIAMUserPolicyAttachmentResource() resource.Resource {
+	return &UserPolicyAttachmentResource{}
+}
+
+// UserPolicyAttachmentResource defines the resource implementation.
+type UserPolicyAttachmentResource struct {
+	client    *RadosgwClient
+	iamClient *IAMClient
+}
+
+// UserPolicyAttachmentResourceModel describes the resource data model.
+type UserPolicyAttachmentResourceModel struct {
+	User      types.String `tfsdk:"user"`
+	PolicyArn types.String `tfsdk:"policy_arn"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func (r *UserPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_user_policy_attachment"
+}
+
+func (r *UserPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a managed IAM policy to a RadosGW user.\n\n" +
+			"~> **Note:** Managed policies are only available for users that belong to a RadosGW account. " +
+			"Both the user and the policy must belong to the same account as the provider credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The name of the user the policy should be attached to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"policy_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the managed policy to attach. Can be an AWS managed policy " +
+					"(e.g. `arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess`) or a policy created with `radosgw_iam_policy`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The attachment identifier in the format `user:policy_arn`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	r.iamClient = client.IAM
+}
+
+func (r *UserPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan UserPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "AttachUserPolicy")
+	params.Set("UserName", plan.User.ValueString())
+	params.Set("PolicyArn", plan.PolicyArn.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Attaching User Policy",
+			fmt.Sprintf("Could not attach policy %s to user %s: %s", plan.PolicyArn.ValueString(), plan.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.User.ValueString(), plan.PolicyArn.ValueString()))
+
+	tflog.Trace(ctx, "Attached user policy", map[string]any{
+		"user":       plan.User.ValueString(),
+		"policy_arn": plan.PolicyArn.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state UserPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attached, err := isPolicyAttached(ctx, r.iamClient, "ListAttachedUserPolicies", "UserName", state.User.ValueString(), state.PolicyArn.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "User not found, removing policy attachment from state", map[string]any{
+				"user": state.User.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User Policy Attachment",
+			fmt.Sprintf("Could not list attached policies for user %s: %s", state.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if !attached {
+		tflog.Info(ctx, "User policy attachment not found, removing from state", map[string]any{
+			"user":       state.User.ValueString(),
+			"policy_arn": state.PolicyArn.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s:%s", state.User.ValueString(), state.PolicyArn.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *UserPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement, so Update is never called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"User policy attachments cannot be updated in place. This is a provider bug.",
+	)
+}
+
+func (r *UserPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state UserPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("Action", "DetachUserPolicy")
+	params.Set("UserName", state.User.ValueString())
+	params.Set("PolicyArn", state.PolicyArn.ValueString())
+
+	_, err := r.iamClient.DoRequest(ctx, params, "iam")
+	if err != nil {
+		if errors.Is(err, ErrNoSuchEntity) {
+			tflog.Info(ctx, "User policy attachment already removed", map[string]any{
+				"user":       state.User.ValueString(),
+				"policy_arn": state.PolicyArn.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Detaching User Policy",
+			fmt.Sprintf("Could not detach policy %s from user %s: %s", state.PolicyArn.ValueString(), state.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Detached user policy", map[string]any{
+		"user":       state.User.ValueString(),
+		"policy_arn": state.PolicyArn.ValueString(),
+	})
+}
+
+func (r *UserPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "user_name:policy_arn"
+	// The policy ARN itself contains colons, so only split on the first one
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'user_name:policy_arn'. Example: 'my-user:arn:aws:iam::RGW11111111111111111:policy/my-policy'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_arn"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRadosgwIAMUserPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	policyName := randomName("tf-acc-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserPolicyConfig_basic(userID, policyName, `["s3:GetObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserPolicyExists("radosgw_iam_user_policy.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user_policy.test", "user", userID),
+					resource.TestCheckResourceAttr("radosgw_iam_user_policy.test", "name", policyName),
+					resource.TestCheckResourceAttr("radosgw_iam_user_policy.test", "id", userID+":"+policyName),
+				),
+			},
+			// Import test - format: user_id:policy_name
+			{
+				ResourceName:                         "radosgw_iam_user_policy.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        userID + ":" + policyName,
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_user_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   userID + ":" + policyName,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUserPolicy_update(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	policyName := randomName("tf-acc-policy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserPolicyConfig_basic(userID, policyName, `["s3:GetObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserPolicyExists("radosgw_iam_user_policy.test"),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMUserPolicyConfig_basic(userID, policyName, `["s3:GetObject", "s3:PutObject"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserPolicyExists("radosgw_iam_user_policy.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user_policy.test", "name", policyName),
+				),
+			},
+		},
+	})
+}
+
+// Helper functions
+
+func testAccGetUserPolicy(userID, policyName string) error {
+	iamClient := NewIAMClient(
+		testAccAdminClient.Endpoint,
+		testAccAdminClient.AccessKey,
+		testAccAdminClient.SecretKey,
+		testAccAdminClient.HTTPClient,
+	)
+
+	params := url.Values{}
+	params.Set("Action", "GetUserPolicy")
+	params.Set("UserName", userID)
+	params.Set("PolicyName", policyName)
+
+	_, err := iamClient.DoRequest(testCtx, params, "iam")
+	return err
+}
+
+func testAccCheckRadosgwIAMUserPolicyExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		userID := rs.Primary.Attributes["user"]
+		policyName := rs.Primary.Attributes["name"]
+		if userID == "" || policyName == "" {
+			return fmt.Errorf("user or name not set")
+		}
+
+		if err := testAccGetUserPolicy(userID, policyName); err != nil {
+			return fmt.Errorf("error verifying user policy %s:%s exists: %s", userID, policyName, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRadosgwIAMUserPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "radosgw_iam_user_policy" {
+			continue
+		}
+
+		userID := rs.Primary.Attributes["user"]
+		policyName := rs.Primary.Attributes["name"]
+
+		if err := testAccGetUserPolicy(userID, policyName); err == nil {
+			return fmt.Errorf("user policy %s:%s still exists", userID, policyName)
+		}
+	}
+	return nil
+}
+
+// Test configurations
+
+func testAccRadosgwIAMUserPolicyConfig_basic(userID, policyName, actions string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for User Policy"
+}
+
+resource "radosgw_iam_user_policy" "test" {
+  user = radosgw_iam_user.test.user_id
+  name = %q
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = %s
+        Resource = ["arn:aws:s3:::*"]
+      }
+    ]
+  })
+}
+`, userID, policyName, actions)
+}
@@ -2,9 +2,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -38,6 +44,13 @@ func TestAccRadosgwIAMUser_basic(t *testing.T) {
 				ImportStateId:                        userID,
 				ImportStateVerifyIdentifierAttribute: "user_id",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_iam_user.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   userID,
+			},
 		},
 	})
 }
@@ -188,6 +201,219 @@ func TestAccRadosgwIAMUser_maxBuckets(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwIAMUser_generateKey(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	displayName := "Generated Key User"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserConfig_generateKey(userID, displayName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserExists("radosgw_iam_user.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "generate_key", "true"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_user.test", "access_key"),
+					resource.TestCheckResourceAttrSet("radosgw_iam_user.test", "secret_key"),
+				),
+			},
+			// The generated key cannot be imported
+			{
+				ResourceName:                         "radosgw_iam_user.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        userID,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+				ImportStateVerifyIgnore:              []string{"generate_key", "access_key", "secret_key"},
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUser_systemAdmin(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	displayName := "System User"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckSystemUser(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserConfig_systemAdmin(userID, displayName, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserExists("radosgw_iam_user.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "system", "true"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "admin", "true"),
+				),
+			},
+			{
+				ResourceName:                         "radosgw_iam_user.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        userID,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+			{
+				Config: testAccRadosgwIAMUserConfig_systemAdmin(userID, displayName, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "system", "false"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "admin", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUser_deletionProtection(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserConfig_deletionProtection(userID, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserExists("radosgw_iam_user.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccRadosgwIAMUserConfig_deletionProtection(userID, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			// Disabling the protection allows the destroy at the end of the test
+			{
+				Config: testAccRadosgwIAMUserConfig_deletionProtection(userID, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwIAMUserExists("radosgw_iam_user.test"),
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwIAMUser_purgeKeysOnSuspend(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwIAMUserConfig_purgeKeysOnSuspend(userID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("radosgw_iam_user.test", "access_key"),
+				),
+			},
+			{
+				Config: testAccRadosgwIAMUserConfig_purgeKeysOnSuspend(userID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "suspended", "true"),
+					resource.TestCheckNoResourceAttr("radosgw_iam_user.test", "access_key"),
+					testAccCheckRadosgwIAMUserKeyCount(userID, 0),
+				),
+			},
+			// Reinstating the user does not restore its keys
+			{
+				Config: testAccRadosgwIAMUserConfig_purgeKeysOnSuspend(userID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_iam_user.test", "suspended", "false"),
+					testAccCheckRadosgwIAMUserKeyCount(userID, 0),
+				),
+			},
+		},
+	})
+}
+
+func TestUserModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		update         bool
+		stateSuspended bool
+		planSuspended  bool
+		purgeKeys      bool
+		expectWarning  bool
+		expectNullKeys bool
+	}{
+		"create active":         {planSuspended: false},
+		"create suspended":      {planSuspended: true, purgeKeys: true, expectWarning: true},
+		"suspend":               {update: true, planSuspended: true, expectWarning: true},
+		"suspend and purge":     {update: true, planSuspended: true, purgeKeys: true, expectWarning: true, expectNullKeys: true},
+		"stay suspended":        {update: true, stateSuspended: true, planSuspended: true, purgeKeys: true},
+		"reinstate":             {update: true, stateSuspended: true, planSuspended: false},
+		"active with purge set": {update: true, planSuspended: false, purgeKeys: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &UserResource{}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(testCtx, fwresource.SchemaRequest{}, &schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			d := plan.Set(testCtx, &UserResourceModel{
+				UserID:             types.StringValue("user"),
+				Suspended:          types.BoolValue(testCase.planSuspended),
+				PurgeKeysOnSuspend: types.BoolValue(testCase.purgeKeys),
+				AccessKey:          types.StringValue("AKEY"),
+				SecretKey:          types.StringValue("SKEY"),
+			})
+			if d.HasError() {
+				t.Fatalf("unexpected error: %v", d)
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(testCtx), nil)}
+			if testCase.update {
+				d := state.Set(testCtx, &UserResourceModel{
+					UserID:    types.StringValue("user"),
+					Suspended: types.BoolValue(testCase.stateSuspended),
+					AccessKey: types.StringValue("AKEY"),
+					SecretKey: types.StringValue("SKEY"),
+				})
+				if d.HasError() {
+					t.Fatalf("unexpected error: %v", d)
+				}
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(testCtx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != testCase.expectWarning {
+				t.Errorf("expected warning %t, got diagnostics %v", testCase.expectWarning, resp.Diagnostics)
+			}
+
+			var accessKey types.String
+			resp.Plan.GetAttribute(testCtx, path.Root("access_key"), &accessKey)
+			if accessKey.IsNull() != testCase.expectNullKeys {
+				t.Errorf("expected null access_key %t, got %s", testCase.expectNullKeys, accessKey)
+			}
+		})
+	}
+}
+
 // Helper functions
 
 func testAccCheckRadosgwIAMUserExists(resourceName string) resource.TestCheckFunc {
@@ -234,6 +460,21 @@ func testAccCheckRadosgwIAMUserExistsWithTenant(resourceName, tenant string) res
 	}
 }
 
+func testAccCheckRadosgwIAMUserKeyCount(userID string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		user, err := testAccAdminClient.GetUser(testCtx, admin.User{ID: userID})
+		if err != nil {
+			return fmt.Errorf("error fetching user %s: %s", userID, err)
+		}
+
+		if len(user.Keys) != count {
+			return fmt.Errorf("expected user %s to have %d keys, got %d", userID, count, len(user.Keys))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckRadosgwIAMUserDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "radosgw_iam_user" {
@@ -312,3 +553,46 @@ resource "radosgw_iam_user" "test" {
 }
 `, userID, displayName, maxBuckets)
 }
+
+func testAccRadosgwIAMUserConfig_generateKey(userID, displayName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = %q
+  generate_key = true
+}
+`, userID, displayName)
+}
+
+func testAccRadosgwIAMUserConfig_systemAdmin(userID, displayName string, system, admin bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = %q
+  system       = %t
+  admin        = %t
+}
+`, userID, displayName, system, admin)
+}
+
+func testAccRadosgwIAMUserConfig_deletionProtection(userID string, deletionProtection bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id             = %q
+  display_name        = "Protected User"
+  deletion_protection = %t
+}
+`, userID, deletionProtection)
+}
+
+func testAccRadosgwIAMUserConfig_purgeKeysOnSuspend(userID string, suspended bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id               = %q
+  display_name          = "Purged User"
+  generate_key          = true
+  suspended             = %t
+  purge_keys_on_suspend = true
+}
+`, userID, suspended)
+}
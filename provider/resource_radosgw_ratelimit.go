@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RatelimitResource{}
+var _ resource.ResourceWithImportState = &RatelimitResource{}
+
+func NewRatelimitResource() resource.Resource {
+	return &RatelimitResource{}
+}
+
+// RatelimitResource manages the rate limit of a user or a bucket in RadosGW.
+// go-ceph does not expose the rate limit operations, so the Admin Ops API is
+// called directly.
+type RatelimitResource struct {
+	client      *RadosgwClient
+	adminClient *AdminClient
+}
+
+// RatelimitResourceModel describes the resource data model.
+type RatelimitResourceModel struct {
+	Scope         types.String `tfsdk:"scope"`
+	TargetID      types.String `tfsdk:"target_id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	MaxReadOps    types.Int64  `tfsdk:"max_read_ops"`
+	MaxWriteOps   types.Int64  `tfsdk:"max_write_ops"`
+	MaxReadBytes  types.Int64  `tfsdk:"max_read_bytes"`
+	MaxWriteBytes types.Int64  `tfsdk:"max_write_bytes"`
+}
+
+// ratelimitJSON is a rate limit as returned by GET /admin/ratelimit.
+type ratelimitJSON struct {
+	MaxReadOps    int64 `json:"max_read_ops"`
+	MaxWriteOps   int64 `json:"max_write_ops"`
+	MaxReadBytes  int64 `json:"max_read_bytes"`
+	MaxWriteBytes int64 `json:"max_write_bytes"`
+	Enabled       bool  `json:"enabled"`
+}
+
+func (r *RatelimitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ratelimit"
+}
+
+func (r *RatelimitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the rate limit of a user or a bucket in RadosGW.
+
+Rate limits cap the number of read and write operations and bytes per minute that each RadosGW instance serves for a user or a bucket. Requests over the limit are rejected with ` + "`503 SlowDown`" + `. A value of ` + "`0`" + ` means unlimited.
+
+Upon deletion, the rate limit is disabled and reset to unlimited (not removed, as rate limits are properties of users and buckets).
+
+~> **Note:** The user configured in the provider needs the ` + "`ratelimit=*`" + ` capability.`,
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The scope of the rate limit: `user` or `bucket`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("user", "bucket"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_id": schema.StringAttribute{
+				MarkdownDescription: "The user ID for the `user` scope, or the bucket name for the `bucket` scope. " +
+					"Use the format `tenant$user_id` for users and `tenant/bucket` for buckets in a tenant.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the rate limit is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"max_read_ops": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of read operations per minute. Use `0` for unlimited. Default: `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_write_ops": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of write operations per minute. Use `0` for unlimited. Default: `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_read_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of bytes read per minute. Use `0` for unlimited. Default: `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_write_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of bytes written per minute. Use `0` for unlimited. Default: `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *RatelimitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	r.adminClient = NewAdminClient(client.Admin)
+}
+
+func (r *RatelimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RatelimitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setRatelimit(ctx, data.Scope.ValueString(), data.TargetID.ValueString(), ratelimitJSON{
+		MaxReadOps:    data.MaxReadOps.ValueInt64(),
+		MaxWriteOps:   data.MaxWriteOps.ValueInt64(),
+		MaxReadBytes:  data.MaxReadBytes.ValueInt64(),
+		MaxWriteBytes: data.MaxWriteBytes.ValueInt64(),
+		Enabled:       data.Enabled.ValueBool(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Rate Limit",
+			fmt.Sprintf("Could not create rate limit for %s %s: %s", data.Scope.ValueString(), data.TargetID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RatelimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RatelimitResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ratelimit, err := r.getRatelimit(ctx, data.Scope.ValueString(), data.TargetID.ValueString())
+	if err != nil {
+		if isAdminNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Rate Limit",
+			fmt.Sprintf("Could not read rate limit for %s %s: %s", data.Scope.ValueString(), data.TargetID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.Enabled = types.BoolValue(ratelimit.Enabled)
+	data.MaxReadOps = types.Int64Value(ratelimit.MaxReadOps)
+	data.MaxWriteOps = types.Int64Value(ratelimit.MaxWriteOps)
+	data.MaxReadBytes = types.Int64Value(ratelimit.MaxReadBytes)
+	data.MaxWriteBytes = types.Int64Value(ratelimit.MaxWriteBytes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RatelimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RatelimitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setRatelimit(ctx, data.Scope.ValueString(), data.TargetID.ValueString(), ratelimitJSON{
+		MaxReadOps:    data.MaxReadOps.ValueInt64(),
+		MaxWriteOps:   data.MaxWriteOps.ValueInt64(),
+		MaxReadBytes:  data.MaxReadBytes.ValueInt64(),
+		MaxWriteBytes: data.MaxWriteBytes.ValueInt64(),
+		Enabled:       data.Enabled.ValueBool(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Rate Limit",
+			fmt.Sprintf("Could not update rate limit for %s %s: %s", data.Scope.ValueString(), data.TargetID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RatelimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RatelimitResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Disable the rate limit on delete, it cannot be removed
+	err := r.setRatelimit(ctx, data.Scope.ValueString(), data.TargetID.ValueString(), ratelimitJSON{})
+
+	if isAdminNotFoundError(err) {
+		// The user or bucket was deleted first, which removed its rate limit
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Rate Limit",
+			fmt.Sprintf("Could not disable rate limit for %s %s: %s", data.Scope.ValueString(), data.TargetID.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *RatelimitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "scope:target_id" where scope is "user" or "bucket"
+	// Example: "user:myuser" or "bucket:mytenant/mybucket"
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'scope:target_id'. Example: 'user:myuser' or 'bucket:mybucket'",
+		)
+		return
+	}
+
+	scope := parts[0]
+	if scope != "user" && scope != "bucket" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Rate limit scope must be 'user' or 'bucket', got: %s", scope),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target_id"), parts[1])...)
+}
+
+// ratelimitParams returns the parameters identifying the rate limit of a user
+// or a bucket. Buckets in a tenant are given as "tenant/bucket".
+func ratelimitParams(scope, targetID string) url.Values {
+	params := url.Values{}
+	params.Set("ratelimit-scope", scope)
+	if scope == "user" {
+		params.Set("uid", targetID)
+		return params
+	}
+
+	if tenant, bucket, ok := strings.Cut(targetID, "/"); ok {
+		params.Set("tenant", tenant)
+		params.Set("bucket", bucket)
+	} else {
+		params.Set("bucket", targetID)
+	}
+	return params
+}
+
+// getRatelimit reads the rate limit of a user or a bucket.
+func (r *RatelimitResource) getRatelimit(ctx context.Context, scope, targetID string) (*ratelimitJSON, error) {
+	body, err := r.adminClient.DoRequest(ctx, http.MethodGet, "/ratelimit", ratelimitParams(scope, targetID))
+	if err != nil {
+		return nil, err
+	}
+
+	// The rate limit is nested under "user_ratelimit" or "bucket_ratelimit"
+	var response map[string]ratelimitJSON
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit: %w", err)
+	}
+
+	ratelimit, ok := response[scope+"_ratelimit"]
+	if !ok {
+		return nil, fmt.Errorf("response does not contain %s_ratelimit", scope)
+	}
+	return &ratelimit, nil
+}
+
+// setRatelimit replaces the rate limit of a user or a bucket.
+func (r *RatelimitResource) setRatelimit(ctx context.Context, scope, targetID string, ratelimit ratelimitJSON) error {
+	params := ratelimitParams(scope, targetID)
+	params.Set("max-read-ops", strconv.FormatInt(ratelimit.MaxReadOps, 10))
+	params.Set("max-write-ops", strconv.FormatInt(ratelimit.MaxWriteOps, 10))
+	params.Set("max-read-bytes", strconv.FormatInt(ratelimit.MaxReadBytes, 10))
+	params.Set("max-write-bytes", strconv.FormatInt(ratelimit.MaxWriteBytes, 10))
+	params.Set("enabled", strconv.FormatBool(ratelimit.Enabled))
+
+	_, err := r.adminClient.DoRequest(ctx, http.MethodPost, "/ratelimit", params)
+	return err
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwRatelimit_user(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwIAMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwRatelimitConfig_user(userID, true, 100, 1048576),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "scope", "user"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "target_id", userID),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_read_ops", "100"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_write_ops", "0"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_read_bytes", "0"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_write_bytes", "1048576"),
+				),
+			},
+			{
+				Config: testAccRadosgwRatelimitConfig_user(userID, false, 200, 2097152),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_read_ops", "200"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_write_bytes", "2097152"),
+				),
+			},
+			// Import test - format: scope:target_id
+			{
+				ResourceName:                         "radosgw_ratelimit.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "user:" + userID,
+				ImportStateVerifyIdentifierAttribute: "target_id",
+			},
+		},
+	})
+}
+
+func TestAccRadosgwRatelimit_bucket(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwRatelimitConfig_bucket(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "scope", "bucket"),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "target_id", bucketName),
+					resource.TestCheckResourceAttr("radosgw_ratelimit.test", "max_write_ops", "50"),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwRatelimitConfig_user(userID string, enabled bool, maxReadOps, maxWriteBytes int64) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Rate Limit"
+}
+
+resource "radosgw_ratelimit" "test" {
+  scope           = "user"
+  target_id       = radosgw_iam_user.test.user_id
+  enabled         = %t
+  max_read_ops    = %d
+  max_write_bytes = %d
+}
+`, userID, enabled, maxReadOps, maxWriteBytes)
+}
+
+func testAccRadosgwRatelimitConfig_bucket(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_ratelimit" "test" {
+  scope         = "bucket"
+  target_id     = radosgw_s3_bucket.test.bucket
+  max_write_ops = 50
+}
+`, bucketName)
+}
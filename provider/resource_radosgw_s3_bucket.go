@@ -2,13 +2,21 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -29,6 +37,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketResource{}
 var _ resource.ResourceWithImportState = &BucketResource{}
+var _ resource.ResourceWithModifyPlan = &BucketResource{}
 
 func NewS3BucketResource() resource.Resource {
 	return &BucketResource{}
@@ -42,24 +51,58 @@ type BucketResource struct {
 // BucketResourceModel describes the resource data model.
 type BucketResourceModel struct {
 	// User-configurable attributes
-	Bucket            types.String `tfsdk:"bucket"`
-	ForceDestroy      types.Bool   `tfsdk:"force_destroy"`
-	ObjectLockEnabled types.Bool   `tfsdk:"object_lock_enabled"`
-	Owner             types.String `tfsdk:"owner"`
-	Tenant            types.String `tfsdk:"tenant"`
-	Versioning        types.String `tfsdk:"versioning"`
-	Acl               types.String `tfsdk:"acl"`
-	BucketQuota       types.Object `tfsdk:"bucket_quota"`
+	Bucket              types.String `tfsdk:"bucket"`
+	BucketPrefix        types.String `tfsdk:"bucket_prefix"`
+	AdoptExisting       types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+	ForceDestroy        types.Bool   `tfsdk:"force_destroy"`
+	ForceDestroyMode    types.String `tfsdk:"force_destroy_mode"`
+	MaxObjectsOnDestroy types.Int64  `tfsdk:"max_objects_on_destroy"`
+	ObjectLockEnabled   types.Bool   `tfsdk:"object_lock_enabled"`
+	Owner               types.String `tfsdk:"owner"`
+	Tenant              types.String `tfsdk:"tenant"`
+	Versioning          types.String `tfsdk:"versioning"`
+	Acl                 types.String `tfsdk:"acl"`
+	BucketQuota         types.Object `tfsdk:"bucket_quota"`
+	PlacementRule       types.String `tfsdk:"placement_rule"`
 
 	// Computed attributes from Admin API
 	ID                types.String `tfsdk:"id"`
 	CreationTime      types.String `tfsdk:"creation_time"`
-	PlacementRule     types.String `tfsdk:"placement_rule"`
 	Zonegroup         types.String `tfsdk:"zonegroup"`
 	NumShards         types.Int64  `tfsdk:"num_shards"`
 	Marker            types.String `tfsdk:"marker"`
 	IndexType         types.String `tfsdk:"index_type"`
 	ExplicitPlacement types.Object `tfsdk:"explicit_placement"`
+
+	// Computed attributes from the zone serving the endpoint
+	IsReadOnly   types.Bool `tfsdk:"is_read_only"`
+	ZoneIsMaster types.Bool `tfsdk:"zone_is_master"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
+}
+
+// Valid values of force_destroy_mode.
+const (
+	forceDestroyModeAdmin = "admin"
+	forceDestroyModeS3    = "s3"
+)
+
+// bucketPurgeBatchSize is the maximum number of objects of a DeleteObjects
+// request, and bucketPurgeWorkers the number of requests in flight.
+const (
+	bucketPurgeBatchSize = 1000
+	bucketPurgeWorkers   = 8
+)
+
+// bucketTimeouts are the default timeouts of radosgw_s3_bucket. Deleting a
+// bucket with force_destroy purges all of its objects, which can take hours
+// for large buckets.
+var bucketTimeouts = operationTimeouts{
+	timeoutCreate: 20 * time.Minute,
+	timeoutRead:   20 * time.Minute,
+	timeoutUpdate: 20 * time.Minute,
+	timeoutDelete: 60 * time.Minute,
 }
 
 // explicitPlacementAttrTypes returns the attribute types for explicit_placement.
@@ -71,7 +114,8 @@ func explicitPlacementAttrTypes() map[string]attr.Type {
 	}
 }
 
-// bucketQuotaAttrTypes returns the attribute types for bucket_quota.
+// bucketQuotaAttrTypes returns the attribute types for the read-only
+// bucket_quota of the bucket data source and accounts.
 func bucketQuotaAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"enabled":     types.BoolType,
@@ -80,6 +124,16 @@ func bucketQuotaAttrTypes() map[string]attr.Type {
 	}
 }
 
+// bucketQuotaConfigAttrTypes returns the attribute types for the configurable
+// bucket_quota of the bucket resource, whose max_size is a quota size string.
+func bucketQuotaConfigAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"enabled":     types.BoolType,
+		"max_size":    types.StringType,
+		"max_objects": types.Int64Type,
+	}
+}
+
 func (r *BucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_s3_bucket"
 }
@@ -91,18 +145,81 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			// User-configurable attributes
 			"bucket": schema.StringAttribute{
-				MarkdownDescription: "The name of the bucket. Must be unique within the RadosGW cluster. Bucket names must be between 3 and 63 characters, start with a lowercase letter or number, and contain only lowercase letters, numbers, and hyphens.",
-				Required:            true,
+				MarkdownDescription: "The name of the bucket. Must be unique within the RadosGW cluster. Bucket names must be between 3 and 63 characters, start and end with a lowercase letter or number, and contain only lowercase letters, numbers, hyphens, and periods, unless the `strict_bucket_names` provider attribute is `false`. Conflicts with `bucket_prefix`; exactly one of them must be set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					// Must run before RequiresReplace, which treats an
+					// unknown name as a change
+					generatedBucketNamePlanModifier{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("bucket_prefix")),
+					bucketNameValidator{},
+				},
+			},
+			"bucket_prefix": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Creates a unique bucket name beginning with the specified prefix, followed by a "+
+					"timestamp and random suffix. Must be at most %d characters and follow the same naming rules as `bucket`. "+
+					"Conflicts with `bucket`.", maxBucketPrefixLength),
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, maxBucketPrefixLength),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing bucket owned by the provider credentials instead of failing " +
+					"the creation. The bucket and its objects are kept; `versioning` and `bucket_quota` are applied to it like to a " +
+					"new bucket. Creation still fails when the bucket is owned by another user. Settings that can only be chosen at " +
+					"creation time (`object_lock_enabled`, `placement_rule`) are read from the bucket, so a mismatch with the " +
+					"configuration shows as a replacement on the next plan. Default is false.",
+				Optional: true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the bucket, including replacing it, fails. Set it to false and apply " +
+					"before the bucket can be deleted. Unlike the `prevent_destroy` lifecycle argument, the protection is stored " +
+					"in the state and also applies when the resource is removed from the configuration. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"force_destroy": schema.BoolAttribute{
-				MarkdownDescription: "Whether to delete all objects in the bucket when destroying the resource. Uses the Admin API with purge-objects option. Default is false.",
+				MarkdownDescription: "Whether to delete all objects in the bucket when destroying the resource. See `force_destroy_mode` for how the objects are deleted. Default is false.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"force_destroy_mode": schema.StringAttribute{
+				MarkdownDescription: "How `force_destroy` deletes the objects. Valid values: `admin` (default), `s3`. " +
+					"`admin` sends a single Admin API request with the purge-objects option; RadosGW reports no progress, so " +
+					"very large buckets can exceed the `request_timeout` of the provider. `s3` lists all object versions and " +
+					"delete markers, deletes them in batches of 1000 with parallel `DeleteObjects` requests, aborts incomplete " +
+					"multipart uploads and logs the progress after every batch. A purge that is interrupted, for example by the " +
+					"delete timeout, continues with the remaining objects on the next destroy. Governance-mode retention is " +
+					"bypassed, which requires the `s3:BypassGovernanceRetention` permission. The objects are deleted with the " +
+					"S3 credentials of the provider; when they are denied access, for example because the bucket was linked " +
+					"to another user with `radosgw_s3_bucket_link`, the purge falls back to `admin`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(forceDestroyModeAdmin),
+				Validators: []validator.String{
+					stringvalidator.OneOf(forceDestroyModeAdmin, forceDestroyModeS3),
+				},
+			},
+			"max_objects_on_destroy": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of objects `force_destroy` may delete. Before deleting the bucket, its " +
+					"object count is read from the Admin API, and the destroy fails if the bucket holds more objects. Set to `0` to " +
+					"only allow destroying empty buckets. Guards against accidentally deleting the data of a bucket that was " +
+					"created as a scratch bucket but has been put to use since.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 			"object_lock_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether S3 Object Lock is enabled for the bucket. Can only be set at creation time and cannot be modified afterwards.",
 				Optional:            true,
@@ -157,10 +274,13 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Optional:            true,
 						Computed:            true,
 					},
-					"max_size": schema.Int64Attribute{
-						MarkdownDescription: "Maximum size in bytes. -1 means unlimited.",
+					"max_size": schema.StringAttribute{
+						MarkdownDescription: "Maximum size. " + quotaSizeDescription + " -1 means unlimited.",
 						Optional:            true,
 						Computed:            true,
+						Validators: []validator.String{
+							quotaSizeValidator{},
+						},
 					},
 					"max_objects": schema.Int64Attribute{
 						MarkdownDescription: "Maximum number of objects. -1 means unlimited.",
@@ -169,17 +289,25 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
-
-			// Computed attributes from Admin API
-			"id": schema.StringAttribute{
-				MarkdownDescription: "The unique identifier of the bucket assigned by RadosGW.",
-				Computed:            true,
+			"placement_rule": schema.StringAttribute{
+				MarkdownDescription: "The placement rule for the bucket, determining which pools store the bucket's data, e.g. " +
+					"`ssd-placement`. The placement target must exist in the zonegroup. Defaults to the default placement " +
+					"of the owner or the zonegroup. Can only be set at creation time; changing it forces a new bucket.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^:]+$`), "must not contain a colon"),
 				},
 			},
-			"placement_rule": schema.StringAttribute{
-				MarkdownDescription: "The placement rule for the bucket, determining which pools store the bucket's data.",
+
+			// Computed attributes from Admin API
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the bucket assigned by RadosGW.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -200,8 +328,12 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"num_shards": schema.Int64Attribute{
-				MarkdownDescription: "The number of shards for the bucket index.",
-				Computed:            true,
+				MarkdownDescription: "The number of shards for the bucket index. RadosGW offers no API to choose it when creating a bucket: " +
+					"the initial count comes from the `bucket_index_max_shards` setting of the zonegroup or from " +
+					"`rgw_override_bucket_index_max_shards`, and dynamic resharding adjusts it afterwards. " +
+					"The Admin Ops API has no resharding endpoint either, so a bucket can only be resharded manually " +
+					"with `radosgw-admin bucket reshard`; the new count is picked up on the next refresh.",
+				Computed: true,
 			},
 			"marker": schema.StringAttribute{
 				MarkdownDescription: "The internal bucket marker used by RadosGW.",
@@ -238,6 +370,28 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"is_read_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone serving the provider endpoint is read-only, in which case the bucket " +
+					"and its configuration cannot be changed through this endpoint. Requires the `zone=read` capability; " +
+					"null when the zone status cannot be read.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_is_master": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone serving the provider endpoint is the metadata master zone of the realm. " +
+					"Secondary zones forward bucket metadata changes to the master zone. Requires the `zone=read` capability; " +
+					"null when the zone status cannot be read.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": bucketTimeouts.block(),
 		},
 	}
 }
@@ -259,6 +413,20 @@ func (r *BucketResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+func (r *BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var bucket types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("bucket"), &bucket)...)
+	if resp.Diagnostics.HasError() || bucket.IsUnknown() {
+		return
+	}
+
+	r.client.validateStrictBucketName(&resp.Diagnostics, path.Root("bucket"), bucket.ValueString())
+}
+
 func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data BucketResourceModel
 
@@ -267,14 +435,30 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := bucketTimeouts.withTimeout(ctx, data.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate the bucket name when only a prefix is configured
+	if data.Bucket.IsNull() || data.Bucket.IsUnknown() {
+		generated, err := generateBucketName(data.BucketPrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Generating Bucket Name",
+				fmt.Sprintf("Could not generate a bucket name with prefix %s: %s", data.BucketPrefix.ValueString(), err.Error()),
+			)
+			return
+		}
+		data.Bucket = types.StringValue(generated)
+	}
+
 	bucketName := data.Bucket.ValueString()
 	tenant := data.Tenant.ValueString()
 
 	// Build full bucket name with tenant if specified
-	fullBucketName := bucketName
-	if tenant != "" {
-		fullBucketName = tenant + ":" + bucketName
-	}
+	fullBucketName := s3BucketName(tenant, bucketName)
 
 	tflog.Debug(ctx, "Creating bucket", map[string]any{
 		"bucket": fullBucketName,
@@ -287,8 +471,33 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		ObjectLockEnabledForBucket: data.ObjectLockEnabled.ValueBoolPointer(),
 	}
 
+	// RadosGW reads the placement rule from the location constraint, an empty
+	// zonegroup name before the colon selects the current zonegroup
+	if !data.PlacementRule.IsNull() && !data.PlacementRule.IsUnknown() {
+		createInput.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
+			LocationConstraint: s3types.BucketLocationConstraint(":" + data.PlacementRule.ValueString()),
+		}
+	}
+
 	_, err := r.client.S3.CreateBucket(ctx, createInput)
+	if err != nil && data.AdoptExisting.ValueBool() && isBucketExistsError(err) {
+		var owned bool
+		owned, err = r.ownsBucket(ctx, bucketName)
+		if err == nil && !owned {
+			err = fmt.Errorf("bucket already exists and is not owned by the provider credentials")
+		}
+		if err == nil {
+			tflog.Info(ctx, "Adopted existing bucket", map[string]any{
+				"bucket": fullBucketName,
+			})
+		}
+	} else if err == nil {
+		tflog.Trace(ctx, "Created bucket", map[string]any{
+			"bucket": fullBucketName,
+		})
+	}
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Creating Bucket",
 			fmt.Sprintf("Could not create bucket %s: %s", fullBucketName, err.Error()),
@@ -296,10 +505,6 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	tflog.Trace(ctx, "Created bucket", map[string]any{
-		"bucket": fullBucketName,
-	})
-
 	// Set versioning if specified (only for enabled or suspended, not for off)
 	versioning := data.Versioning.ValueString()
 	if versioning == "enabled" || versioning == "suspended" {
@@ -315,7 +520,7 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Set bucket quota if specified
 	if !data.BucketQuota.IsNull() && !data.BucketQuota.IsUnknown() {
-		err = r.setBucketQuota(ctx, bucketName, data.BucketQuota)
+		err = r.setBucketQuota(ctx, tenant, bucketName, data.BucketQuota)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Setting Bucket Quota",
@@ -326,7 +531,7 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Read bucket info from Admin API to populate computed fields
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(tenant, bucketName)})
 	if err != nil {
 		tflog.Warn(ctx, "Could not get bucket info after creation", map[string]any{
 			"bucket": bucketName,
@@ -337,11 +542,12 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.Acl = types.StringNull()
 		data.Owner = types.StringNull()
 		if data.BucketQuota.IsNull() || data.BucketQuota.IsUnknown() {
-			data.BucketQuota = types.ObjectNull(bucketQuotaAttrTypes())
+			data.BucketQuota = types.ObjectNull(bucketQuotaConfigAttrTypes())
 		}
 	} else {
 		r.populateModelFromBucketInfo(ctx, &data, &bucketInfo)
 	}
+	r.populateZoneStatus(ctx, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -354,14 +560,21 @@ func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, cancel := bucketTimeouts.withTimeout(ctx, data.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bucketName := data.Bucket.ValueString()
 
 	tflog.Debug(ctx, "Reading bucket", map[string]any{
 		"bucket": bucketName,
+		"tenant": data.Tenant.ValueString(),
 	})
 
 	// Get bucket info from Admin API
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(data.Tenant.ValueString(), bucketName)})
 	if err != nil {
 		if isBucketNotFoundError(err) {
 			tflog.Debug(ctx, "Bucket not found, removing from state", map[string]any{
@@ -384,6 +597,11 @@ func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Restore force_destroy from state (not returned by Admin API)
 	data.ForceDestroy = forceDestroy
+	if data.ForceDestroyMode.IsNull() {
+		data.ForceDestroyMode = types.StringValue(forceDestroyModeAdmin)
+	}
+
+	r.populateZoneStatus(ctx, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -398,13 +616,16 @@ func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel := bucketTimeouts.withTimeout(ctx, data.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bucketName := data.Bucket.ValueString()
 	tenant := data.Tenant.ValueString()
 
-	fullBucketName := bucketName
-	if tenant != "" {
-		fullBucketName = tenant + ":" + bucketName
-	}
+	fullBucketName := s3BucketName(tenant, bucketName)
 
 	tflog.Debug(ctx, "Updating bucket", map[string]any{
 		"bucket": bucketName,
@@ -427,7 +648,7 @@ func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Handle quota change
 	if !data.BucketQuota.Equal(state.BucketQuota) && !data.BucketQuota.IsNull() {
-		err := r.setBucketQuota(ctx, bucketName, data.BucketQuota)
+		err := r.setBucketQuota(ctx, tenant, bucketName, data.BucketQuota)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Setting Bucket Quota",
@@ -438,7 +659,7 @@ func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Re-read bucket info to get fresh computed values
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(tenant, bucketName)})
 	if err != nil {
 		tflog.Warn(ctx, "Could not refresh bucket info during update", map[string]any{
 			"bucket": bucketName,
@@ -455,6 +676,7 @@ func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 	} else {
 		r.populateModelFromBucketInfo(ctx, &data, &bucketInfo)
 	}
+	r.populateZoneStatus(ctx, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -467,7 +689,19 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if !checkDeletionProtection(&resp.Diagnostics, data.DeletionProtection,
+		fmt.Sprintf("bucket %q", s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString()))) {
+		return
+	}
+
+	ctx, cancel := bucketTimeouts.withTimeout(ctx, data.Timeouts, timeoutDelete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bucketName := data.Bucket.ValueString()
+	tenant := data.Tenant.ValueString()
 	forceDestroy := data.ForceDestroy.ValueBool()
 
 	tflog.Debug(ctx, "Deleting bucket", map[string]any{
@@ -475,11 +709,65 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		"force_destroy": forceDestroy,
 	})
 
+	if forceDestroy && !data.MaxObjectsOnDestroy.IsNull() {
+		bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(tenant, bucketName)})
+		if err != nil {
+			if isBucketNotFoundError(err) {
+				tflog.Debug(ctx, "Bucket already deleted", map[string]any{
+					"bucket": bucketName,
+				})
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Bucket",
+				fmt.Sprintf("Could not read the object count of bucket %s before deleting it: %s", bucketName, err.Error()),
+			)
+			return
+		}
+
+		var numObjects uint64
+		if bucketInfo.Usage.RgwMain.NumObjects != nil {
+			numObjects = *bucketInfo.Usage.RgwMain.NumObjects
+		}
+		if maxObjects := data.MaxObjectsOnDestroy.ValueInt64(); numObjects > uint64(maxObjects) {
+			resp.Diagnostics.AddError(
+				"Bucket Destroy Prevented",
+				fmt.Sprintf("Bucket %s holds %d objects, more than max_objects_on_destroy (%d). "+
+					"Raise max_objects_on_destroy or remove it to delete the bucket and all its objects.", bucketName, numObjects, maxObjects),
+			)
+			return
+		}
+	}
+
+	if forceDestroy && data.ForceDestroyMode.ValueString() == forceDestroyModeS3 {
+		// Empty the bucket through the S3 API, then delete it below like an
+		// empty bucket
+		err := r.purgeBucketObjects(ctx, s3BucketName(tenant, bucketName))
+		switch {
+		case err == nil:
+			forceDestroy = false
+		case isS3ErrorCode(err, "AccessDenied"):
+			// The provider credentials do not own the bucket, for example
+			// after it was linked to another user, purge it as an admin
+			tflog.Warn(ctx, "Access denied purging bucket through the S3 API, falling back to the Admin API", map[string]any{
+				"bucket": bucketName,
+				"error":  err.Error(),
+			})
+		default:
+			resp.Diagnostics.AddError(
+				"Error Deleting Bucket",
+				fmt.Sprintf("Could not delete the objects of bucket %s with force_destroy: %s. "+
+					"The objects deleted so far stay deleted, destroy the bucket again to delete the rest.", bucketName, err.Error()),
+			)
+			return
+		}
+	}
+
 	if forceDestroy {
 		// Use Admin API to remove bucket with purge-objects option
 		purge := true
 		err := r.client.Admin.RemoveBucket(ctx, admin.Bucket{
-			Bucket:      bucketName,
+			Bucket:      adminBucketName(tenant, bucketName),
 			PurgeObject: &purge,
 		})
 		if err != nil {
@@ -497,10 +785,12 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		}
 	} else {
 		// Use S3 API for standard deletion (bucket must be empty)
+		fullBucketName := s3BucketName(tenant, bucketName)
 		_, err := r.client.S3.DeleteBucket(ctx, &s3.DeleteBucketInput{
-			Bucket: &bucketName,
+			Bucket: &fullBucketName,
 		})
 		if err != nil {
+			err = zoneWriteError(ctx, r.client.Admin, err)
 			var ae smithy.APIError
 			if errors.As(err, &ae) {
 				if ae.ErrorCode() == "NoSuchBucket" || ae.ErrorCode() == "404" {
@@ -531,36 +821,209 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	bucketName := req.ID
+	// Import format: "bucket", "tenant:bucket" or "tenant/bucket"
+	tenant, bucketName := splitBucketID(req.ID)
 
 	tflog.Debug(ctx, "Importing bucket", map[string]any{
 		"bucket": bucketName,
+		"tenant": tenant,
 	})
 
 	// Verify bucket exists using Admin API
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(tenant, bucketName)})
 	if err != nil {
 		if isBucketNotFoundError(err) {
 			resp.Diagnostics.AddError(
 				"Bucket Not Found",
-				fmt.Sprintf("Bucket %s does not exist.", bucketName),
+				fmt.Sprintf("Bucket %s does not exist.", req.ID),
 			)
 			return
 		}
 		resp.Diagnostics.AddError(
 			"Error Importing Bucket",
-			fmt.Sprintf("Could not import bucket %s: %s", bucketName, err.Error()),
+			fmt.Sprintf("Could not import bucket %s: %s", req.ID, err.Error()),
 		)
 		return
 	}
 
 	// Set attributes for import
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucketName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy_mode"), forceDestroyModeAdmin)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_lock_enabled"), bucketInfo.ObjectLockEnabled)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), bucketInfo.Tenant)...)
 }
 
+// isBucketExistsError reports whether CreateBucket failed because the bucket
+// already exists. RadosGW releases differ in which of the two codes they
+// return to the owner of the bucket, so ownership is checked separately.
+func isBucketExistsError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "BucketAlreadyExists", "BucketAlreadyOwnedByYou":
+			return true
+		}
+	}
+	return false
+}
+
+// ownsBucket reports whether the provider credentials own the bucket, by
+// looking for it in the buckets listed for them.
+func (r *BucketResource) ownsBucket(ctx context.Context, bucketName string) (bool, error) {
+	paginator := s3.NewListBucketsPaginator(r.client.S3, &s3.ListBucketsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("listing the buckets of the provider credentials: %w", err)
+		}
+		for _, bucket := range page.Buckets {
+			if aws.ToString(bucket.Name) == bucketName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// purgeBucketObjects deletes all object versions, delete markers and
+// incomplete multipart uploads of a bucket through the S3 API. The versions
+// are listed page by page and deleted in batches by parallel workers, so a
+// purge that is cancelled can be resumed by calling it again.
+func (r *BucketResource) purgeBucketObjects(ctx context.Context, bucket string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan []s3types.ObjectIdentifier)
+	var deleted atomic.Int64
+	var firstErr error
+	var once sync.Once
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var wg sync.WaitGroup
+	for range bucketPurgeWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := r.deleteObjectBatch(ctx, bucket, batch); err != nil {
+					fail(err)
+					continue
+				}
+				tflog.Info(ctx, "Purging bucket", map[string]any{
+					"bucket":  bucket,
+					"deleted": deleted.Add(int64(len(batch))),
+				})
+			}
+		}()
+	}
+
+	paginator := s3.NewListObjectVersionsPaginator(r.client.S3, &s3.ListObjectVersionsInput{Bucket: &bucket})
+	batch := make([]s3types.ObjectIdentifier, 0, bucketPurgeBatchSize)
+	send := func() bool {
+		select {
+		case batches <- batch:
+			batch = make([]s3types.ObjectIdentifier, 0, bucketPurgeBatchSize)
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+list:
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			fail(fmt.Errorf("failed to list object versions: %w", err))
+			break
+		}
+
+		objects := make([]s3types.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, version := range page.Versions {
+			objects = append(objects, s3types.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range page.DeleteMarkers {
+			objects = append(objects, s3types.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+		}
+
+		for _, object := range objects {
+			batch = append(batch, object)
+			if len(batch) == bucketPurgeBatchSize && !send() {
+				break list
+			}
+		}
+	}
+	if len(batch) > 0 && ctx.Err() == nil {
+		send()
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, "Purged bucket objects", map[string]any{
+		"bucket":  bucket,
+		"deleted": deleted.Load(),
+	})
+
+	return r.abortMultipartUploads(ctx, bucket)
+}
+
+// deleteObjectBatch deletes up to 1000 object versions with a single
+// DeleteObjects request.
+func (r *BucketResource) deleteObjectBatch(ctx context.Context, bucket string, objects []s3types.ObjectIdentifier) error {
+	output, err := r.client.S3.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket:                    &bucket,
+		Delete:                    &s3types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		BypassGovernanceRetention: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete objects: %w", err)
+	}
+
+	if len(output.Errors) > 0 {
+		first := output.Errors[0]
+		return fmt.Errorf("failed to delete %d objects, first %s (version %s): %s: %s",
+			len(output.Errors), aws.ToString(first.Key), aws.ToString(first.VersionId), aws.ToString(first.Code), aws.ToString(first.Message))
+	}
+	return nil
+}
+
+// abortMultipartUploads aborts all incomplete multipart uploads of a bucket,
+// whose parts would otherwise keep it from being deleted.
+func (r *BucketResource) abortMultipartUploads(ctx context.Context, bucket string) error {
+	paginator := s3.NewListMultipartUploadsPaginator(r.client.S3, &s3.ListMultipartUploadsInput{Bucket: &bucket})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list multipart uploads: %w", err)
+		}
+
+		for _, upload := range page.Uploads {
+			_, err := r.client.S3.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   &bucket,
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			if err != nil && !isS3ErrorCode(err, "NoSuchUpload") {
+				return fmt.Errorf("failed to abort multipart upload of %s: %w", aws.ToString(upload.Key), err)
+			}
+		}
+	}
+	return nil
+}
+
 // setBucketVersioning sets the versioning state on a bucket.
 func (r *BucketResource) setBucketVersioning(ctx context.Context, bucketName, versioning string) error {
 	var status s3types.BucketVersioningStatus
@@ -579,11 +1042,14 @@ func (r *BucketResource) setBucketVersioning(ctx context.Context, bucketName, ve
 			Status: status,
 		},
 	})
-	return err
+	if err != nil {
+		return zoneWriteError(ctx, r.client.Admin, err)
+	}
+	return nil
 }
 
 // setBucketQuota sets the quota on a bucket via Admin API.
-func (r *BucketResource) setBucketQuota(ctx context.Context, bucketName string, quotaObj types.Object) error {
+func (r *BucketResource) setBucketQuota(ctx context.Context, tenant, bucketName string, quotaObj types.Object) error {
 	if quotaObj.IsNull() || quotaObj.IsUnknown() {
 		return nil
 	}
@@ -594,23 +1060,16 @@ func (r *BucketResource) setBucketQuota(ctx context.Context, bucketName string,
 		return fmt.Errorf("could not parse bucket quota")
 	}
 
-	// Get bucket info to find owner
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
-	if err != nil {
-		return fmt.Errorf("could not get bucket info: %w", err)
-	}
-
-	quotaSpec := admin.QuotaSpec{
-		UID:    bucketInfo.Owner,
-		Bucket: bucketName,
-	}
-
+	var quotaSpec admin.QuotaSpec
 	if !quota.Enabled.IsNull() && !quota.Enabled.IsUnknown() {
 		enabled := quota.Enabled.ValueBool()
 		quotaSpec.Enabled = &enabled
 	}
 	if !quota.MaxSize.IsNull() && !quota.MaxSize.IsUnknown() {
-		maxSize := quota.MaxSize.ValueInt64()
+		maxSize, err := parseQuotaSize(quota.MaxSize.ValueString())
+		if err != nil {
+			return err
+		}
 		quotaSpec.MaxSize = &maxSize
 	}
 	if !quota.MaxObjects.IsNull() && !quota.MaxObjects.IsUnknown() {
@@ -618,14 +1077,31 @@ func (r *BucketResource) setBucketQuota(ctx context.Context, bucketName string,
 		quotaSpec.MaxObjects = &maxObjects
 	}
 
-	return r.client.Admin.SetIndividualBucketQuota(ctx, quotaSpec)
+	return setIndividualBucketQuota(ctx, r.client.Admin, tenant, bucketName, quotaSpec)
 }
 
 // BucketQuotaModel represents bucket quota settings.
 type BucketQuotaModel struct {
-	Enabled    types.Bool  `tfsdk:"enabled"`
-	MaxSize    types.Int64 `tfsdk:"max_size"`
-	MaxObjects types.Int64 `tfsdk:"max_objects"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	MaxSize    types.String `tfsdk:"max_size"`
+	MaxObjects types.Int64  `tfsdk:"max_objects"`
+}
+
+// populateZoneStatus sets the zone attributes from the zone serving the
+// provider endpoint, or null when the zone status cannot be read.
+func (r *BucketResource) populateZoneStatus(ctx context.Context, data *BucketResourceModel) {
+	status, err := NewAdminClient(r.client.Admin).GetZoneStatus(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read zone status", map[string]any{
+			"error": err.Error(),
+		})
+		data.IsReadOnly = types.BoolNull()
+		data.ZoneIsMaster = types.BoolNull()
+		return
+	}
+
+	data.IsReadOnly = types.BoolValue(status.ReadOnly)
+	data.ZoneIsMaster = types.BoolValue(status.IsMaster)
 }
 
 // populateModelFromBucketInfo updates the model with data from Admin API bucket info.
@@ -682,31 +1158,85 @@ func (r *BucketResource) populateModelFromBucketInfo(ctx context.Context, data *
 		data.ExplicitPlacement = placementObj
 	}
 
-	// Build bucket_quota object
+	// Build bucket_quota object, keeping the configured max_size format
+	priorMaxSize := types.StringNull()
+	if !data.BucketQuota.IsNull() && !data.BucketQuota.IsUnknown() {
+		if maxSize, ok := data.BucketQuota.Attributes()["max_size"].(types.String); ok {
+			priorMaxSize = maxSize
+		}
+	}
 	quotaValues := map[string]attr.Value{
 		"enabled":     types.BoolNull(),
-		"max_size":    types.Int64Null(),
+		"max_size":    types.StringNull(),
 		"max_objects": types.Int64Null(),
 	}
 	if info.BucketQuota.Enabled != nil {
 		quotaValues["enabled"] = types.BoolValue(*info.BucketQuota.Enabled)
 	}
 	if info.BucketQuota.MaxSize != nil {
-		quotaValues["max_size"] = types.Int64Value(*info.BucketQuota.MaxSize)
+		quotaValues["max_size"] = quotaSizeValue(priorMaxSize, *info.BucketQuota.MaxSize)
 	}
 	if info.BucketQuota.MaxObjects != nil {
 		quotaValues["max_objects"] = types.Int64Value(*info.BucketQuota.MaxObjects)
 	}
 
-	quotaObj, diags := types.ObjectValue(bucketQuotaAttrTypes(), quotaValues)
+	quotaObj, diags := types.ObjectValue(bucketQuotaConfigAttrTypes(), quotaValues)
 	if diags.HasError() {
 		tflog.Warn(ctx, "Could not build bucket_quota object")
-		data.BucketQuota = types.ObjectNull(bucketQuotaAttrTypes())
+		data.BucketQuota = types.ObjectNull(bucketQuotaConfigAttrTypes())
 	} else {
 		data.BucketQuota = quotaObj
 	}
 }
 
+// maxBucketPrefixLength is the longest bucket_prefix that still leaves room
+// for the generated suffix within the 63 character bucket name limit.
+const maxBucketPrefixLength = 63 - generatedBucketSuffixLength
+
+// generatedBucketSuffixLength is the length of the suffix appended to
+// bucket_prefix: a 14 digit UTC timestamp followed by 8 random hex characters.
+const generatedBucketSuffixLength = 22
+
+// generateBucketName returns a unique bucket name starting with prefix.
+func generateBucketName(prefix string) (string, error) {
+	random := make([]byte, 4)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return prefix + time.Now().UTC().Format("20060102150405") + hex.EncodeToString(random), nil
+}
+
+// generatedBucketNamePlanModifier keeps a generated bucket name in the plan as
+// long as bucket_prefix is unchanged. When the prefix changes the bucket is
+// replaced and the name is left unknown so a new one is generated.
+type generatedBucketNamePlanModifier struct{}
+
+func (m generatedBucketNamePlanModifier) Description(ctx context.Context) string {
+	return "Keeps the generated bucket name while bucket_prefix is unchanged."
+}
+
+func (m generatedBucketNamePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m generatedBucketNamePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to keep on create, and a configured name is used as is
+	if req.StateValue.IsNull() || !req.ConfigValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var planPrefix, statePrefix types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("bucket_prefix"), &planPrefix)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("bucket_prefix"), &statePrefix)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planPrefix.Equal(statePrefix) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
 // isBucketNotFoundError checks if an error indicates the bucket doesn't exist.
 func isBucketNotFoundError(err error) bool {
 	return errors.Is(err, admin.ErrNoSuchBucket)
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -35,7 +36,12 @@ type BucketAclResource struct {
 type BucketAclResourceModel struct {
 	ID     types.String `tfsdk:"id"`
 	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
 	Acl    types.String `tfsdk:"acl"`
+
+	S3AccessKey       types.String `tfsdk:"s3_access_key"`
+	S3SecretKey       types.String `tfsdk:"s3_secret_key"`
+	S3AddressingStyle types.String `tfsdk:"s3_addressing_style"`
 }
 
 func (r *BucketAclResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,9 +52,11 @@ func (r *BucketAclResource) Schema(ctx context.Context, req resource.SchemaReque
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Manages the ACL (Access Control List) for an S3 bucket in Ceph RadosGW. This resource allows you to set canned ACLs on buckets and tracks drift when the ACL is changed outside of Terraform.
 
-~> **Important:** This resource can only manage ACLs for buckets owned by the user configured in the provider. The S3 API restricts ACL operations to the bucket owner only - even admin credentials cannot manage ACLs on buckets owned by other users. If you need to manage ACLs on buckets with different owners, you must use separate provider configurations (aliases) with each owner's credentials.
+~> **Important:** This resource can only manage ACLs for buckets owned by the user configured in the provider. The S3 API restricts ACL operations to the bucket owner only - even admin credentials cannot manage ACLs on buckets owned by other users. To manage ACLs on buckets with different owners, set ` + "`s3_access_key`" + ` and ` + "`s3_secret_key`" + ` to the keys of each owner, or use separate provider configurations (aliases).
+
+~> **Note:** When destroying this resource, the bucket ACL is reset to ` + "`private`" + `.
 
-~> **Note:** When destroying this resource, the bucket ACL is reset to ` + "`private`" + `.`,
+The bucket ACL does not apply to objects. To make the bucket owner own objects uploaded by other users, use ` + "`radosgw_s3_bucket_ownership_controls`" + `.`,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,6 +73,13 @@ func (r *BucketAclResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"acl": schema.StringAttribute{
 				MarkdownDescription: "The canned ACL to apply to the bucket. Valid values: `private`, `public-read`, `public-read-write`, `authenticated-read`.",
 				Required:            true,
@@ -74,6 +89,7 @@ func (r *BucketAclResource) Schema(ctx context.Context, req resource.SchemaReque
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, s3ClientAttributes())
 }
 
 func (r *BucketAclResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -101,7 +117,7 @@ func (r *BucketAclResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	bucketName := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 	acl := data.Acl.ValueString()
 
 	tflog.Debug(ctx, "Setting bucket ACL", map[string]any{
@@ -109,7 +125,7 @@ func (r *BucketAclResource) Create(ctx context.Context, req resource.CreateReque
 		"acl":    acl,
 	})
 
-	err := r.putBucketAcl(ctx, bucketName, acl)
+	err := r.putBucketAcl(ctx, r.client.s3ClientFor(data.S3AccessKey, data.S3SecretKey, data.S3AddressingStyle), bucketName, acl)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Setting Bucket ACL",
@@ -137,14 +153,14 @@ func (r *BucketAclResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	bucketName := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 
 	tflog.Debug(ctx, "Reading bucket ACL", map[string]any{
 		"bucket": bucketName,
 	})
 
 	// Get current ACL from S3 API
-	currentAcl, err := r.getBucketAcl(ctx, bucketName)
+	currentAcl, err := r.getBucketAcl(ctx, r.client.s3ClientFor(data.S3AccessKey, data.S3SecretKey, data.S3AddressingStyle), bucketName)
 	if err != nil {
 		// Check if bucket doesn't exist
 		if isBucketNotFoundS3Error(err) {
@@ -181,7 +197,7 @@ func (r *BucketAclResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	bucketName := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 	acl := data.Acl.ValueString()
 
 	tflog.Debug(ctx, "Updating bucket ACL", map[string]any{
@@ -189,7 +205,7 @@ func (r *BucketAclResource) Update(ctx context.Context, req resource.UpdateReque
 		"acl":    acl,
 	})
 
-	err := r.putBucketAcl(ctx, bucketName, acl)
+	err := r.putBucketAcl(ctx, r.client.s3ClientFor(data.S3AccessKey, data.S3SecretKey, data.S3AddressingStyle), bucketName, acl)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Bucket ACL",
@@ -212,14 +228,14 @@ func (r *BucketAclResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	bucketName := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 
 	tflog.Debug(ctx, "Resetting bucket ACL to private", map[string]any{
 		"bucket": bucketName,
 	})
 
 	// Reset ACL to private on delete
-	err := r.putBucketAcl(ctx, bucketName, "private")
+	err := r.putBucketAcl(ctx, r.client.s3ClientFor(data.S3AccessKey, data.S3SecretKey, data.S3AddressingStyle), bucketName, "private")
 	if err != nil {
 		// Ignore errors if bucket doesn't exist
 		if !isBucketNotFoundS3Error(err) {
@@ -237,22 +253,21 @@ func (r *BucketAclResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *BucketAclResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: bucket_name
-	bucketName := req.ID
-	if bucketName == "" {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be the bucket name.",
-		)
+	// Import format: "bucket" or "tenant:bucket"
+	tenant, bucket := importBucketState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	bucketName := s3BucketName(tenant, bucket)
+
 	tflog.Debug(ctx, "Importing bucket ACL", map[string]any{
 		"bucket": bucketName,
 	})
 
-	// Read current ACL
-	currentAcl, err := r.getBucketAcl(ctx, bucketName)
+	// Read current ACL, with the credentials of the provider since the
+	// configuration is not available during import
+	currentAcl, err := r.getBucketAcl(ctx, r.client.S3, bucketName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Bucket ACL",
@@ -261,13 +276,12 @@ func (r *BucketAclResource) ImportState(ctx context.Context, req resource.Import
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucketName)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("acl"), currentAcl)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), bucketName)...)
 }
 
 // putBucketAcl sets a canned ACL on a bucket.
-func (r *BucketAclResource) putBucketAcl(ctx context.Context, bucketName, acl string) error {
+func (r *BucketAclResource) putBucketAcl(ctx context.Context, s3Client *s3.Client, bucketName, acl string) error {
 	var cannedAcl s3types.BucketCannedACL
 	switch acl {
 	case "private":
@@ -282,16 +296,19 @@ func (r *BucketAclResource) putBucketAcl(ctx context.Context, bucketName, acl st
 		return fmt.Errorf("unsupported ACL: %s", acl)
 	}
 
-	_, err := r.client.S3.PutBucketAcl(ctx, &s3.PutBucketAclInput{
+	_, err := s3Client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 		Bucket: &bucketName,
 		ACL:    cannedAcl,
 	})
-	return err
+	if err != nil {
+		return zoneWriteError(ctx, r.client.Admin, err)
+	}
+	return nil
 }
 
 // getBucketAcl retrieves the current ACL of a bucket and maps it to a canned ACL string.
-func (r *BucketAclResource) getBucketAcl(ctx context.Context, bucketName string) (string, error) {
-	output, err := r.client.S3.GetBucketAcl(ctx, &s3.GetBucketAclInput{
+func (r *BucketAclResource) getBucketAcl(ctx context.Context, s3Client *s3.Client, bucketName string) (string, error) {
+	output, err := s3Client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 		Bucket: &bucketName,
 	})
 	if err != nil {
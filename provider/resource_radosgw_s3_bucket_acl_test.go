@@ -32,6 +32,13 @@ func TestAccRadosgwS3BucketAcl_basic(t *testing.T) {
 				ImportStateId:                        bucketName,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket_acl.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   bucketName,
+			},
 		},
 	})
 }
@@ -74,6 +81,33 @@ func TestAccRadosgwS3BucketAcl_update(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3BucketAcl_ownerCredentials(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-user")
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketAclConfig_ownerCredentials(userID, bucketName, "public-read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_acl.test", "acl", "public-read"),
+				),
+			},
+			{
+				Config: testAccRadosgwS3BucketAclConfig_ownerCredentials(userID, bucketName, "authenticated-read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_acl.test", "acl", "authenticated-read"),
+				),
+			},
+		},
+	})
+}
+
 // Test configurations
 
 func testAccRadosgwS3BucketAclConfig_basic(bucketName, acl string) string {
@@ -88,3 +122,33 @@ resource "radosgw_s3_bucket_acl" "test" {
 }
 `, bucketName, acl)
 }
+
+func testAccRadosgwS3BucketAclConfig_ownerCredentials(userID, bucketName, acl string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "owner" {
+  user_id      = %q
+  display_name = "Bucket Owner"
+}
+
+resource "radosgw_iam_access_key" "owner" {
+  user_id = radosgw_iam_user.owner.user_id
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_link" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  uid           = radosgw_iam_user.owner.user_id
+  unlink_to_uid = "admin"
+}
+
+resource "radosgw_s3_bucket_acl" "test" {
+  bucket        = radosgw_s3_bucket_link.test.bucket
+  acl           = %q
+  s3_access_key = radosgw_iam_access_key.owner.access_key
+  s3_secret_key = radosgw_iam_access_key.owner.secret_key
+}
+`, userID, bucketName, acl)
+}
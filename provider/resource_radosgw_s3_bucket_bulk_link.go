@@ -0,0 +1,438 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketBulkLinkResource{}
+
+func NewS3BucketBulkLinkResource() resource.Resource {
+	return &BucketBulkLinkResource{}
+}
+
+// BucketBulkLinkResource links many buckets to their owners at once.
+type BucketBulkLinkResource struct {
+	client *RadosgwClient
+}
+
+// BucketBulkLinkResourceModel describes the resource data model.
+type BucketBulkLinkResourceModel struct {
+	Links          types.Map    `tfsdk:"links"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	UnlinkToUID    types.String `tfsdk:"unlink_to_uid"`
+	Timeouts       types.Object `tfsdk:"timeouts"`
+}
+
+// bulkLinkTimeouts are the default timeouts of radosgw_s3_bucket_bulk_link.
+var bulkLinkTimeouts = operationTimeouts{
+	timeoutCreate: 30 * time.Minute,
+	timeoutRead:   20 * time.Minute,
+	timeoutUpdate: 30 * time.Minute,
+	timeoutDelete: 30 * time.Minute,
+}
+
+// defaultBulkLinkConcurrency is the number of buckets linked in parallel
+// when max_concurrency is not set.
+const defaultBulkLinkConcurrency = 10
+
+func (r *BucketBulkLinkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_bulk_link"
+}
+
+func (r *BucketBulkLinkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Links many existing buckets to their owners in a single resource, for example to move hundreds of buckets of an offboarded tenant to an archive user.
+
+The buckets are linked concurrently, each with the same retry logic as ` + "`radosgw_s3_bucket_link`" + `. Failures do not stop the other links: the buckets that could be linked are saved in state and every failure is reported, so a following apply only retries the failed buckets. When the first apply fails partially, Terraform marks the resource as tainted: run ` + "`terraform untaint`" + ` before retrying to keep the linked buckets instead of unlinking and linking them again.
+
+Changing the owner of a bucket relinks it in place. Buckets removed from ` + "`links`" + ` are handled like on destroy: they are linked to ` + "`unlink_to_uid`" + ` when set, or unlinked from their owner otherwise.
+
+~> **Note:** The buckets must already exist. Do not manage the same bucket with both this resource and ` + "`radosgw_s3_bucket_link`" + `.`,
+
+		Attributes: map[string]schema.Attribute{
+			"links": schema.MapAttribute{
+				MarkdownDescription: "A map of bucket names to the user ID that should own them. Buckets of a tenant use the format `tenant/bucket`, " +
+					"as returned in the `names` of the `radosgw_s3_buckets` data source.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of buckets linked in parallel. Default is %d.", defaultBulkLinkConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"unlink_to_uid": schema.StringAttribute{
+				MarkdownDescription: "The user ID to link the buckets to when they are removed from `links` or this resource is destroyed. " +
+					"If not set, the buckets will be unlinked from their owner but remain in the system.",
+				Optional: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": bulkLinkTimeouts.block(),
+		},
+	}
+}
+
+func (r *BucketBulkLinkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketBulkLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketBulkLinkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := bulkLinkTimeouts.withTimeout(ctx, data.Timeouts, timeoutCreate, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	links := map[string]string{}
+	resp.Diagnostics.Append(data.Links.ElementsAs(ctx, &links, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Linking buckets", map[string]any{
+		"buckets": len(links),
+	})
+
+	linked, errs := r.linkBuckets(ctx, links, data.MaxConcurrency)
+
+	resp.Diagnostics.Append(r.setLinks(ctx, &data, linked)...)
+	addBulkLinkErrors(&resp.Diagnostics, "Error Linking Buckets", errs)
+
+	tflog.Trace(ctx, "Linked buckets", map[string]any{
+		"linked": len(linked),
+		"failed": len(errs),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketBulkLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketBulkLinkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := bulkLinkTimeouts.withTimeout(ctx, data.Timeouts, timeoutRead, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	links := map[string]string{}
+	resp.Diagnostics.Append(data.Links.ElementsAs(ctx, &links, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading bucket bulk link", map[string]any{
+		"buckets": len(links),
+	})
+
+	// Report the current owner of every bucket so that relinked buckets show
+	// up as drift, and forget the buckets that no longer exist
+	var mu sync.Mutex
+	owners := make(map[string]string, len(links))
+	errs := forEachBucket(sortedKeys(links), bulkLinkConcurrency(data.MaxConcurrency), func(bucket string) error {
+		bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucket})
+		if err != nil {
+			if errors.Is(err, admin.ErrNoSuchBucket) {
+				tflog.Info(ctx, "Bucket no longer exists, removing from state", map[string]any{
+					"bucket": bucket,
+				})
+				return nil
+			}
+			return err
+		}
+
+		mu.Lock()
+		owners[bucket] = bucketInfo.Owner
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		addBulkLinkErrors(&resp.Diagnostics, "Error Reading Bucket Bulk Link", errs)
+		return
+	}
+
+	if len(owners) == 0 {
+		tflog.Info(ctx, "No linked bucket exists anymore, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLinks(ctx, &data, owners)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketBulkLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BucketBulkLinkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := bulkLinkTimeouts.withTimeout(ctx, plan.Timeouts, timeoutUpdate, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planLinks := map[string]string{}
+	stateLinks := map[string]string{}
+	resp.Diagnostics.Append(plan.Links.ElementsAs(ctx, &planLinks, false)...)
+	resp.Diagnostics.Append(state.Links.ElementsAs(ctx, &stateLinks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := map[string]string{}
+	for bucket, uid := range planLinks {
+		if stateLinks[bucket] != uid {
+			changed[bucket] = uid
+		}
+	}
+	removed := map[string]string{}
+	for bucket, uid := range stateLinks {
+		if _, ok := planLinks[bucket]; !ok {
+			removed[bucket] = uid
+		}
+	}
+
+	tflog.Debug(ctx, "Updating bucket bulk link", map[string]any{
+		"changed": len(changed),
+		"removed": len(removed),
+	})
+
+	linked, errs := r.linkBuckets(ctx, changed, plan.MaxConcurrency)
+	unlinkErrs := r.unlinkBuckets(ctx, removed, plan.MaxConcurrency, plan.UnlinkToUID)
+
+	// Keep the previous owner of the buckets that could not be changed, so
+	// that the next plan retries them
+	result := map[string]string{}
+	for bucket, uid := range planLinks {
+		if _, ok := changed[bucket]; !ok {
+			result[bucket] = uid
+		}
+	}
+	for bucket, uid := range linked {
+		result[bucket] = uid
+	}
+	for bucket := range errs {
+		if uid, ok := stateLinks[bucket]; ok {
+			result[bucket] = uid
+		}
+	}
+	for bucket := range unlinkErrs {
+		result[bucket] = removed[bucket]
+	}
+
+	resp.Diagnostics.Append(r.setLinks(ctx, &plan, result)...)
+	addBulkLinkErrors(&resp.Diagnostics, "Error Linking Buckets", errs)
+	addBulkLinkErrors(&resp.Diagnostics, "Error Unlinking Buckets", unlinkErrs)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketBulkLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketBulkLinkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := bulkLinkTimeouts.withTimeout(ctx, data.Timeouts, timeoutDelete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	links := map[string]string{}
+	resp.Diagnostics.Append(data.Links.ElementsAs(ctx, &links, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting bucket bulk link", map[string]any{
+		"buckets":       len(links),
+		"unlink_to_uid": data.UnlinkToUID.ValueString(),
+	})
+
+	errs := r.unlinkBuckets(ctx, links, data.MaxConcurrency, data.UnlinkToUID)
+	addBulkLinkErrors(&resp.Diagnostics, "Error Deleting Bucket Bulk Link", errs)
+}
+
+// linkBuckets links every bucket to its user and returns the links that
+// succeeded along with the error of every bucket that failed.
+func (r *BucketBulkLinkResource) linkBuckets(ctx context.Context, links map[string]string, maxConcurrency types.Int64) (map[string]string, map[string]error) {
+	errs := forEachBucket(sortedKeys(links), bulkLinkConcurrency(maxConcurrency), func(bucket string) error {
+		uid := links[bucket]
+		return r.client.Admin.LinkBucket(ctx, admin.BucketLinkInput{
+			Bucket: bucket,
+			UID:    uid,
+		})
+	})
+
+	linked := make(map[string]string, len(links))
+	for bucket, uid := range links {
+		if _, failed := errs[bucket]; !failed {
+			linked[bucket] = uid
+		}
+	}
+	return linked, errs
+}
+
+// unlinkBuckets links every bucket to unlinkToUID when set, or unlinks it
+// from its user otherwise. Buckets or users that no longer exist are ignored.
+func (r *BucketBulkLinkResource) unlinkBuckets(ctx context.Context, links map[string]string, maxConcurrency types.Int64, unlinkToUID types.String) map[string]error {
+	return forEachBucket(sortedKeys(links), bulkLinkConcurrency(maxConcurrency), func(bucket string) error {
+		var err error
+		if !unlinkToUID.IsNull() && unlinkToUID.ValueString() != "" {
+			err = r.client.Admin.LinkBucket(ctx, admin.BucketLinkInput{
+				Bucket: bucket,
+				UID:    unlinkToUID.ValueString(),
+			})
+		} else {
+			err = r.client.Admin.UnlinkBucket(ctx, admin.BucketLinkInput{
+				Bucket: bucket,
+				UID:    links[bucket],
+			})
+		}
+		if errors.Is(err, admin.ErrNoSuchBucket) || errors.Is(err, admin.ErrNoSuchUser) {
+			return nil
+		}
+		return err
+	})
+}
+
+// setLinks stores links in the links attribute of data.
+func (r *BucketBulkLinkResource) setLinks(ctx context.Context, data *BucketBulkLinkResourceModel, links map[string]string) diag.Diagnostics {
+	linksValue, diags := types.MapValueFrom(ctx, types.StringType, links)
+	if !diags.HasError() {
+		data.Links = linksValue
+	}
+	return diags
+}
+
+// bulkLinkConcurrency returns the configured concurrency or the default.
+func bulkLinkConcurrency(maxConcurrency types.Int64) int {
+	if maxConcurrency.IsNull() || maxConcurrency.IsUnknown() {
+		return defaultBulkLinkConcurrency
+	}
+	return int(maxConcurrency.ValueInt64())
+}
+
+// forEachBucket calls fn for every bucket with at most concurrency calls in
+// flight and returns the errors keyed by bucket.
+func forEachBucket(buckets []string, concurrency int, fn func(bucket string) error) map[string]error {
+	var mu sync.Mutex
+	errs := map[string]error{}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for _, bucket := range buckets {
+		wg.Add(1)
+		go func(bucket string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := fn(bucket); err != nil {
+				mu.Lock()
+				errs[bucket] = err
+				mu.Unlock()
+			}
+		}(bucket)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// addBulkLinkErrors reports the failed buckets as a single error diagnostic.
+func addBulkLinkErrors(diags *diag.Diagnostics, summary string, errs map[string]error) {
+	if len(errs) == 0 {
+		return
+	}
+
+	buckets := make([]string, 0, len(errs))
+	for bucket := range errs {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+
+	lines := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		lines = append(lines, fmt.Sprintf("- %s: %s", bucket, errs[bucket].Error()))
+	}
+
+	diags.AddError(
+		summary,
+		fmt.Sprintf("%d bucket(s) failed:\n%s", len(buckets), strings.Join(lines, "\n")),
+	)
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
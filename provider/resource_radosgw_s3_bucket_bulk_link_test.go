@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Note: Bucket bulk link tests use unlink_to_uid="admin" for the same reason
+// as the bucket link tests, so the buckets can be cleaned up on destroy.
+
+func TestAccRadosgwS3BucketBulkLink_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName1 := randomName("tf-acc-bucket")
+	bucketName2 := randomName("tf-acc-bucket")
+	userID1 := randomName("tf-acc-user")
+	userID2 := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketBulkLinkConfig_basic(bucketName1, bucketName2, userID1, userID2, "first", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_bulk_link.test", "links.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_bulk_link.test", "links."+bucketName1, userID1),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_bulk_link.test", "links."+bucketName2, userID1),
+				),
+			},
+			{
+				Config: testAccRadosgwS3BucketBulkLinkConfig_basic(bucketName1, bucketName2, userID1, userID2, "first", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_bulk_link.test", "links."+bucketName1, userID1),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_bulk_link.test", "links."+bucketName2, userID2),
+				),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketBulkLinkConfig_basic(bucketName1, bucketName2, userID1, userID2, owner1, owner2 string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "first" {
+  user_id      = %[3]q
+  display_name = "First Owner"
+}
+
+resource "radosgw_iam_user" "second" {
+  user_id      = %[4]q
+  display_name = "Second Owner"
+}
+
+resource "radosgw_s3_bucket" "first" {
+  bucket = %[1]q
+}
+
+resource "radosgw_s3_bucket" "second" {
+  bucket = %[2]q
+}
+
+resource "radosgw_s3_bucket_bulk_link" "test" {
+  links = {
+    (radosgw_s3_bucket.first.bucket)  = radosgw_iam_user.%[5]s.user_id
+    (radosgw_s3_bucket.second.bucket) = radosgw_iam_user.%[6]s.user_id
+  }
+  max_concurrency = 2
+  unlink_to_uid   = "admin"
+}
+`, bucketName1, bucketName2, userID1, userID2, owner1, owner2)
+}
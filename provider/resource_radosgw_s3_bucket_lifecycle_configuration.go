@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -18,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -28,6 +31,104 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketLifecycleResource{}
 var _ resource.ResourceWithImportState = &BucketLifecycleResource{}
+var _ resource.ResourceWithModifyPlan = &BucketLifecycleResource{}
+
+// lifecycleDateValidator validates that a lifecycle date is an RFC3339
+// timestamp at midnight UTC, the only dates S3 lifecycle rules accept.
+type lifecycleDateValidator struct{}
+
+func (v lifecycleDateValidator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp at midnight UTC, for example 2030-01-01T00:00:00Z"
+}
+
+func (v lifecycleDateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v lifecycleDateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil || formatLifecycleDate(date) != value || !date.Equal(date.Truncate(24*time.Hour)) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Lifecycle Date",
+			fmt.Sprintf("The date %q must be an RFC3339 timestamp at midnight UTC, for example 2030-01-01T00:00:00Z.", value),
+		)
+	}
+}
+
+// lifecycleRulesValidator validates that every lifecycle rule has at least one
+// action, so that RadosGW does not reject the configuration at apply time.
+type lifecycleRulesValidator struct{}
+
+func (v lifecycleRulesValidator) Description(ctx context.Context) string {
+	return "validates that every lifecycle rule has at least one action"
+}
+
+func (v lifecycleRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v lifecycleRulesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var rules []LifecycleRuleModel
+	diags := req.ConfigValue.ElementsAs(ctx, &rules, false)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	for i, rule := range rules {
+		rulePath := req.Path.AtListIndex(i)
+		actions := []types.List{
+			rule.Expiration,
+			rule.Transition,
+			rule.NoncurrentVersionExpiration,
+			rule.NoncurrentVersionTransition,
+			rule.AbortIncompleteMultipartUpload,
+		}
+		hasAction := false
+		for _, action := range actions {
+			if action.IsUnknown() || len(action.Elements()) > 0 {
+				hasAction = true
+			}
+		}
+		if !hasAction {
+			resp.Diagnostics.AddAttributeError(
+				rulePath,
+				"Lifecycle Rule Without Action",
+				fmt.Sprintf("Rule %q must have at least one of expiration, transition, noncurrent_version_expiration, "+
+					"noncurrent_version_transition or abort_incomplete_multipart_upload.", rule.ID.ValueString()),
+			)
+		}
+
+		var expirations []LifecycleExpirationModel
+		if !rule.Expiration.IsUnknown() {
+			resp.Diagnostics.Append(rule.Expiration.ElementsAs(ctx, &expirations, false)...)
+		}
+		for _, exp := range expirations {
+			if exp.Days.IsNull() && exp.Date.IsNull() && exp.ExpiredObjectDeleteMarker.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					rulePath.AtName("expiration"),
+					"Empty Lifecycle Expiration",
+					fmt.Sprintf("The expiration of rule %q must set days, date or expired_object_delete_marker.", rule.ID.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// formatLifecycleDate formats a lifecycle date the way it is stored in the state.
+func formatLifecycleDate(date time.Time) string {
+	return date.UTC().Format(time.RFC3339)
+}
 
 func NewS3BucketLifecycleResource() resource.Resource {
 	return &BucketLifecycleResource{}
@@ -41,8 +142,15 @@ type BucketLifecycleResource struct {
 // BucketLifecycleResourceModel describes the resource data model.
 type BucketLifecycleResourceModel struct {
 	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
 	Rule   types.List   `tfsdk:"rule"`
 	ID     types.String `tfsdk:"id"`
+
+	LegacyPrefixCompat types.Bool `tfsdk:"legacy_prefix_compat"`
+
+	S3AccessKey       types.String `tfsdk:"s3_access_key"`
+	S3SecretKey       types.String `tfsdk:"s3_secret_key"`
+	S3AddressingStyle types.String `tfsdk:"s3_addressing_style"`
 }
 
 // LifecycleRuleModel describes a lifecycle rule.
@@ -59,15 +167,19 @@ type LifecycleRuleModel struct {
 
 // LifecycleFilterModel describes a lifecycle rule filter.
 type LifecycleFilterModel struct {
-	Prefix types.String `tfsdk:"prefix"`
-	Tag    types.List   `tfsdk:"tag"`
-	And    types.List   `tfsdk:"and"`
+	Prefix                types.String `tfsdk:"prefix"`
+	ObjectSizeGreaterThan types.Int64  `tfsdk:"object_size_greater_than"`
+	ObjectSizeLessThan    types.Int64  `tfsdk:"object_size_less_than"`
+	Tag                   types.List   `tfsdk:"tag"`
+	And                   types.List   `tfsdk:"and"`
 }
 
 // LifecycleFilterAndModel describes the AND condition in a filter.
 type LifecycleFilterAndModel struct {
-	Prefix types.String `tfsdk:"prefix"`
-	Tags   types.Map    `tfsdk:"tags"`
+	Prefix                types.String `tfsdk:"prefix"`
+	ObjectSizeGreaterThan types.Int64  `tfsdk:"object_size_greater_than"`
+	ObjectSizeLessThan    types.Int64  `tfsdk:"object_size_less_than"`
+	Tags                  types.Map    `tfsdk:"tags"`
 }
 
 // LifecycleTagModel describes a tag filter.
@@ -78,13 +190,15 @@ type LifecycleTagModel struct {
 
 // LifecycleExpirationModel describes expiration settings.
 type LifecycleExpirationModel struct {
-	Days                      types.Int64 `tfsdk:"days"`
-	ExpiredObjectDeleteMarker types.Bool  `tfsdk:"expired_object_delete_marker"`
+	Days                      types.Int64  `tfsdk:"days"`
+	Date                      types.String `tfsdk:"date"`
+	ExpiredObjectDeleteMarker types.Bool   `tfsdk:"expired_object_delete_marker"`
 }
 
 // LifecycleTransitionModel describes transition settings.
 type LifecycleTransitionModel struct {
 	Days         types.Int64  `tfsdk:"days"`
+	Date         types.String `tfsdk:"date"`
 	StorageClass types.String `tfsdk:"storage_class"`
 }
 
@@ -120,9 +234,11 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 - Cleaning up incomplete multipart uploads
 - Managing noncurrent versions in versioned buckets
 
-~> **Note:** RadosGW supports a subset of Amazon S3 lifecycle features. Some advanced filtering options (like object size filtering) may not be available. See the [Ceph documentation](https://docs.ceph.com/en/latest/radosgw/s3/) for details.
+~> **Note:** RadosGW supports a subset of Amazon S3 lifecycle features. Filtering by object size requires Ceph Squid (19.x) or later. See the [Ceph documentation](https://docs.ceph.com/en/latest/radosgw/s3/) for details.
+
+~> **Important:** Only one lifecycle configuration can exist per bucket. This resource will replace any existing lifecycle configuration.
 
-~> **Important:** Only one lifecycle configuration can exist per bucket. This resource will replace any existing lifecycle configuration.`,
+Rules written with the deprecated rule-level ` + "`Prefix`" + ` element instead of a ` + "`Filter`" + `, as done by older tools such as s3cmd, are read into ` + "`filter.prefix`" + `. Set ` + "`legacy_prefix_compat`" + ` to write rules in that shape for old clients that only parse it.`,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -139,12 +255,27 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"legacy_prefix_compat": schema.BoolAttribute{
+				MarkdownDescription: "Write the prefix of each rule as the deprecated rule-level `Prefix` element instead of a " +
+					"`Filter`, for old clients that only parse that shape. Rules may then only filter by `prefix`. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"rule": schema.ListNestedBlock{
-				MarkdownDescription: "A lifecycle rule for the bucket. At least one rule is required.",
+				MarkdownDescription: "A lifecycle rule for the bucket. At least one rule is required, and each rule must have at least one action.",
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
+					lifecycleRulesValidator{},
 				},
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
@@ -175,6 +306,27 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 										MarkdownDescription: "Object key prefix that identifies one or more objects to which the rule applies.",
 										Optional:            true,
 									},
+									"object_size_greater_than": schema.Int64Attribute{
+										MarkdownDescription: "Minimum object size in bytes to which the rule applies. Use an `and` block to combine it with other conditions. Requires Ceph Squid (19.x) or later.",
+										Optional:            true,
+										Validators: []validator.Int64{
+											int64validator.AtLeast(0),
+											int64validator.ConflictsWith(
+												path.MatchRelative().AtParent().AtName("prefix"),
+												path.MatchRelative().AtParent().AtName("object_size_less_than"),
+											),
+										},
+									},
+									"object_size_less_than": schema.Int64Attribute{
+										MarkdownDescription: "Maximum object size in bytes to which the rule applies. Use an `and` block to combine it with other conditions. Requires Ceph Squid (19.x) or later.",
+										Optional:            true,
+										Validators: []validator.Int64{
+											int64validator.AtLeast(1),
+											int64validator.ConflictsWith(
+												path.MatchRelative().AtParent().AtName("prefix"),
+											),
+										},
+									},
 								},
 								Blocks: map[string]schema.Block{
 									"tag": schema.ListNestedBlock{
@@ -206,6 +358,20 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 													MarkdownDescription: "Object key prefix.",
 													Optional:            true,
 												},
+												"object_size_greater_than": schema.Int64Attribute{
+													MarkdownDescription: "Minimum object size in bytes. Requires Ceph Squid (19.x) or later.",
+													Optional:            true,
+													Validators: []validator.Int64{
+														int64validator.AtLeast(0),
+													},
+												},
+												"object_size_less_than": schema.Int64Attribute{
+													MarkdownDescription: "Maximum object size in bytes. Requires Ceph Squid (19.x) or later.",
+													Optional:            true,
+													Validators: []validator.Int64{
+														int64validator.AtLeast(1),
+													},
+												},
 												"tags": schema.MapAttribute{
 													MarkdownDescription: "Map of tags that objects must have to match.",
 													Optional:            true,
@@ -225,12 +391,20 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 							NestedObject: schema.NestedBlockObject{
 								Attributes: map[string]schema.Attribute{
 									"days": schema.Int64Attribute{
-										MarkdownDescription: "Number of days after object creation when the object expires.",
+										MarkdownDescription: "Number of days after object creation when the object expires. Conflicts with `date`.",
 										Optional:            true,
 										Validators: []validator.Int64{
 											int64validator.AtLeast(1),
 										},
 									},
+									"date": schema.StringAttribute{
+										MarkdownDescription: "Date when objects expire, in RFC3339 format at midnight UTC, for example `2030-01-01T00:00:00Z`. Conflicts with `days`.",
+										Optional:            true,
+										Validators: []validator.String{
+											lifecycleDateValidator{},
+											stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("days")),
+										},
+									},
 									"expired_object_delete_marker": schema.BoolAttribute{
 										MarkdownDescription: "Whether to remove expired object delete markers. Only valid for versioned buckets.",
 										Optional:            true,
@@ -243,12 +417,20 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 							NestedObject: schema.NestedBlockObject{
 								Attributes: map[string]schema.Attribute{
 									"days": schema.Int64Attribute{
-										MarkdownDescription: "Number of days after object creation when the transition occurs.",
-										Required:            true,
+										MarkdownDescription: "Number of days after object creation when the transition occurs. Exactly one of `days` or `date` must be set.",
+										Optional:            true,
 										Validators: []validator.Int64{
 											int64validator.AtLeast(0),
 										},
 									},
+									"date": schema.StringAttribute{
+										MarkdownDescription: "Date when the transition occurs, in RFC3339 format at midnight UTC, for example `2030-01-01T00:00:00Z`. Exactly one of `days` or `date` must be set.",
+										Optional:            true,
+										Validators: []validator.String{
+											lifecycleDateValidator{},
+											stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("days")),
+										},
+									},
 									"storage_class": schema.StringAttribute{
 										MarkdownDescription: "The storage class to transition objects to. The available storage classes depend on your RadosGW configuration.",
 										Required:            true,
@@ -327,6 +509,7 @@ Lifecycle rules allow you to define actions that RadosGW applies to objects duri
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, s3ClientAttributes())
 }
 
 func (r *BucketLifecycleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -346,6 +529,71 @@ func (r *BucketLifecycleResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+func (r *BucketLifecycleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BucketLifecycleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	legacyPrefix := plan.LegacyPrefixCompat.ValueBool()
+	if !legacyPrefix && r.client.supportsFeature(CephFeature_LifecycleObjectSize) {
+		return
+	}
+
+	config, diags := r.buildLifecycleConfiguration(ctx, plan.Rule, legacyPrefix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config == nil || r.client.supportsFeature(CephFeature_LifecycleObjectSize) {
+		return
+	}
+
+	for _, rule := range config.Rules {
+		if lifecycleFilterUsesObjectSize(rule.Filter) {
+			r.client.addCephFeatureError(&resp.Diagnostics, fmt.Sprintf("Filtering rule %q by object size", aws.ToString(rule.ID)), CephFeature_LifecycleObjectSize)
+		}
+	}
+}
+
+// useLegacyLifecyclePrefix replaces the filter of a lifecycle rule with the
+// deprecated rule-level prefix. Only prefix filters can be expressed that way.
+func useLegacyLifecyclePrefix(rule *s3types.LifecycleRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	filter := rule.Filter
+	if filter != nil && (filter.And != nil || filter.Tag != nil || filter.ObjectSizeGreaterThan != nil || filter.ObjectSizeLessThan != nil) {
+		diags.AddAttributeError(
+			path.Root("legacy_prefix_compat"),
+			"Unsupported Legacy Lifecycle Rule",
+			fmt.Sprintf("Rule %q filters by tags or object size, which cannot be written with the legacy rule-level prefix. "+
+				"Filter the rule by prefix only or set legacy_prefix_compat = false.", aws.ToString(rule.ID)),
+		)
+		return diags
+	}
+
+	rule.Prefix = aws.String("")
+	if filter != nil && filter.Prefix != nil {
+		rule.Prefix = filter.Prefix
+	}
+	rule.Filter = nil
+	return diags
+}
+
+// lifecycleFilterUsesObjectSize reports whether a lifecycle filter has an
+// object size condition.
+func lifecycleFilterUsesObjectSize(filter *s3types.LifecycleRuleFilter) bool {
+	if filter == nil {
+		return false
+	}
+	if filter.ObjectSizeGreaterThan != nil || filter.ObjectSizeLessThan != nil {
+		return true
+	}
+	return filter.And != nil && (filter.And.ObjectSizeGreaterThan != nil || filter.And.ObjectSizeLessThan != nil)
+}
+
 func (r *BucketLifecycleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan BucketLifecycleResourceModel
 
@@ -354,21 +602,24 @@ func (r *BucketLifecycleResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	s3Client := r.client.s3ClientFor(plan.S3AccessKey, plan.S3SecretKey, plan.S3AddressingStyle)
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	// Build lifecycle configuration
-	lifecycleConfig, diags := r.buildLifecycleConfiguration(ctx, plan.Rule)
+	lifecycleConfig, diags := r.buildLifecycleConfiguration(ctx, plan.Rule, plan.LegacyPrefixCompat.ValueBool())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Put lifecycle configuration
-	_, err := r.client.S3.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+	_, err := s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
 		Bucket:                 aws.String(bucket),
 		LifecycleConfiguration: lifecycleConfig,
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Creating Bucket Lifecycle Configuration",
 			fmt.Sprintf("Could not create lifecycle configuration for bucket %s: %s", bucket, err.Error()),
@@ -383,7 +634,7 @@ func (r *BucketLifecycleResource) Create(ctx context.Context, req resource.Creat
 	})
 
 	// Read back the configuration and preserve rule order from plan
-	output, err := r.client.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+	output, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
@@ -396,7 +647,7 @@ func (r *BucketLifecycleResource) Create(ctx context.Context, req resource.Creat
 
 	// Extract rule IDs from plan to preserve order
 	expectedOrder := r.extractRuleIDsFromList(ctx, plan.Rule)
-	rules, ruleDiags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder)
+	rules, ruleDiags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder, r.extractRuleFiltersFromList(ctx, plan.Rule))
 	resp.Diagnostics.Append(ruleDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -414,10 +665,12 @@ func (r *BucketLifecycleResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	s3Client := r.client.s3ClientFor(state.S3AccessKey, state.S3SecretKey, state.S3AddressingStyle)
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	// Get lifecycle configuration
-	output, err := r.client.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+	output, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
@@ -445,11 +698,13 @@ func (r *BucketLifecycleResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	// Extract rule IDs from current state to preserve order
+	// Extract rule IDs and filters from current state to preserve order and
+	// the shape of empty filters
 	expectedOrder := r.extractRuleIDsFromList(ctx, state.Rule)
+	priorFilters := r.extractRuleFiltersFromList(ctx, state.Rule)
 
 	// Convert rules to Terraform state
-	rules, diags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder)
+	rules, diags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder, priorFilters)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -469,21 +724,24 @@ func (r *BucketLifecycleResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	s3Client := r.client.s3ClientFor(plan.S3AccessKey, plan.S3SecretKey, plan.S3AddressingStyle)
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	// Build lifecycle configuration
-	lifecycleConfig, diags := r.buildLifecycleConfiguration(ctx, plan.Rule)
+	lifecycleConfig, diags := r.buildLifecycleConfiguration(ctx, plan.Rule, plan.LegacyPrefixCompat.ValueBool())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Put lifecycle configuration (replaces existing)
-	_, err := r.client.S3.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+	_, err := s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
 		Bucket:                 aws.String(bucket),
 		LifecycleConfiguration: lifecycleConfig,
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Updating Bucket Lifecycle Configuration",
 			fmt.Sprintf("Could not update lifecycle configuration for bucket %s: %s", bucket, err.Error()),
@@ -498,7 +756,7 @@ func (r *BucketLifecycleResource) Update(ctx context.Context, req resource.Updat
 	})
 
 	// Read back the configuration and preserve rule order from plan
-	output, err := r.client.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+	output, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
@@ -511,7 +769,7 @@ func (r *BucketLifecycleResource) Update(ctx context.Context, req resource.Updat
 
 	// Extract rule IDs from plan to preserve order
 	expectedOrder := r.extractRuleIDsFromList(ctx, plan.Rule)
-	rules, ruleDiags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder)
+	rules, ruleDiags := r.flattenLifecycleRules(ctx, output.Rules, expectedOrder, r.extractRuleFiltersFromList(ctx, plan.Rule))
 	resp.Diagnostics.Append(ruleDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -529,13 +787,16 @@ func (r *BucketLifecycleResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	s3Client := r.client.s3ClientFor(state.S3AccessKey, state.S3SecretKey, state.S3AddressingStyle)
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	// Delete lifecycle configuration
-	_, err := r.client.S3.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
+	_, err := s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		var apiErr smithy.APIError
 		if ok := errors.As(err, &apiErr); ok {
 			if apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" || apiErr.ErrorCode() == "NoSuchBucket" {
@@ -558,11 +819,15 @@ func (r *BucketLifecycleResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *BucketLifecycleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("legacy_prefix_compat"), false)...)
 }
 
-// buildLifecycleConfiguration converts Terraform state to AWS SDK lifecycle configuration.
-func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Context, rulesList types.List) (*s3types.BucketLifecycleConfiguration, diag.Diagnostics) {
+// buildLifecycleConfiguration converts Terraform state to AWS SDK lifecycle
+// configuration. With legacyPrefix, rules are written with the deprecated
+// rule-level prefix instead of a filter.
+func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Context, rulesList types.List, legacyPrefix bool) (*s3types.BucketLifecycleConfiguration, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if rulesList.IsNull() || rulesList.IsUnknown() {
@@ -586,6 +851,9 @@ func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Contex
 		filter, filterDiags := r.buildLifecycleFilter(ctx, rule.Filter)
 		diags.Append(filterDiags...)
 		s3Rule.Filter = filter
+		if legacyPrefix {
+			diags.Append(useLegacyLifecyclePrefix(&s3Rule)...)
+		}
 
 		// Build expiration
 		if !rule.Expiration.IsNull() && !rule.Expiration.IsUnknown() {
@@ -597,6 +865,9 @@ func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Contex
 				if !exp.Days.IsNull() {
 					s3Exp.Days = aws.Int32(int32(exp.Days.ValueInt64()))
 				}
+				if !exp.Date.IsNull() && !exp.Date.IsUnknown() {
+					s3Exp.Date = parseLifecycleDate(exp.Date, &diags)
+				}
 				if !exp.ExpiredObjectDeleteMarker.IsNull() {
 					s3Exp.ExpiredObjectDeleteMarker = aws.Bool(exp.ExpiredObjectDeleteMarker.ValueBool())
 				}
@@ -609,10 +880,16 @@ func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Contex
 			var transitions []LifecycleTransitionModel
 			diags.Append(rule.Transition.ElementsAs(ctx, &transitions, false)...)
 			for _, t := range transitions {
-				s3Rule.Transitions = append(s3Rule.Transitions, s3types.Transition{
-					Days:         aws.Int32(int32(t.Days.ValueInt64())),
+				s3Transition := s3types.Transition{
 					StorageClass: s3types.TransitionStorageClass(t.StorageClass.ValueString()),
-				})
+				}
+				if !t.Days.IsNull() {
+					s3Transition.Days = aws.Int32(int32(t.Days.ValueInt64()))
+				}
+				if !t.Date.IsNull() && !t.Date.IsUnknown() {
+					s3Transition.Date = parseLifecycleDate(t.Date, &diags)
+				}
+				s3Rule.Transitions = append(s3Rule.Transitions, s3Transition)
 			}
 		}
 
@@ -668,6 +945,19 @@ func (r *BucketLifecycleResource) buildLifecycleConfiguration(ctx context.Contex
 	}, diags
 }
 
+// parseLifecycleDate parses a validated lifecycle date.
+func parseLifecycleDate(value types.String, diags *diag.Diagnostics) *time.Time {
+	date, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Invalid Lifecycle Date",
+			fmt.Sprintf("Could not parse the lifecycle date %q: %s", value.ValueString(), err.Error()),
+		)
+		return nil
+	}
+	return &date
+}
+
 // buildLifecycleFilter converts Terraform filter to AWS SDK filter.
 func (r *BucketLifecycleResource) buildLifecycleFilter(ctx context.Context, filterList types.List) (*s3types.LifecycleRuleFilter, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -698,6 +988,12 @@ func (r *BucketLifecycleResource) buildLifecycleFilter(ctx context.Context, filt
 			if !and.Prefix.IsNull() {
 				s3And.Prefix = aws.String(and.Prefix.ValueString())
 			}
+			if !and.ObjectSizeGreaterThan.IsNull() {
+				s3And.ObjectSizeGreaterThan = aws.Int64(and.ObjectSizeGreaterThan.ValueInt64())
+			}
+			if !and.ObjectSizeLessThan.IsNull() {
+				s3And.ObjectSizeLessThan = aws.Int64(and.ObjectSizeLessThan.ValueInt64())
+			}
 			if !and.Tags.IsNull() && !and.Tags.IsUnknown() {
 				var tags map[string]string
 				diags.Append(and.Tags.ElementsAs(ctx, &tags, false)...)
@@ -713,8 +1009,18 @@ func (r *BucketLifecycleResource) buildLifecycleFilter(ctx context.Context, filt
 		}
 	}
 
+	hasSize := !filter.ObjectSizeGreaterThan.IsNull() || !filter.ObjectSizeLessThan.IsNull()
+
 	// Check for tag
 	if !filter.Tag.IsNull() && !filter.Tag.IsUnknown() && len(filter.Tag.Elements()) > 0 {
+		if hasSize {
+			diags.AddError(
+				"Invalid Lifecycle Filter",
+				"A filter cannot have both a tag and an object size condition. Use an and block to combine them.",
+			)
+			return nil, diags
+		}
+
 		var tags []LifecycleTagModel
 		diags.Append(filter.Tag.ElementsAs(ctx, &tags, false)...)
 		if len(tags) > 0 {
@@ -727,6 +1033,17 @@ func (r *BucketLifecycleResource) buildLifecycleFilter(ctx context.Context, filt
 		}
 	}
 
+	// Check for object size
+	if hasSize {
+		if !filter.ObjectSizeGreaterThan.IsNull() {
+			s3Filter.ObjectSizeGreaterThan = aws.Int64(filter.ObjectSizeGreaterThan.ValueInt64())
+		}
+		if !filter.ObjectSizeLessThan.IsNull() {
+			s3Filter.ObjectSizeLessThan = aws.Int64(filter.ObjectSizeLessThan.ValueInt64())
+		}
+		return s3Filter, diags
+	}
+
 	// Default to prefix filter
 	if !filter.Prefix.IsNull() {
 		s3Filter.Prefix = aws.String(filter.Prefix.ValueString())
@@ -757,9 +1074,33 @@ func (r *BucketLifecycleResource) extractRuleIDsFromList(ctx context.Context, ru
 	return ids
 }
 
+// extractRuleFiltersFromList extracts the filter of each lifecycle rule of a
+// types.List, keyed by rule ID.
+func (r *BucketLifecycleResource) extractRuleFiltersFromList(ctx context.Context, rulesList types.List) map[string]types.List {
+	if rulesList.IsNull() || rulesList.IsUnknown() {
+		return nil
+	}
+
+	var rules []LifecycleRuleModel
+	if diags := rulesList.ElementsAs(ctx, &rules, false); diags.HasError() {
+		return nil
+	}
+
+	filters := make(map[string]types.List, len(rules))
+	for _, rule := range rules {
+		if !rule.ID.IsNull() && !rule.ID.IsUnknown() {
+			filters[rule.ID.ValueString()] = rule.Filter
+		}
+	}
+	return filters
+}
+
 // flattenLifecycleRules converts AWS SDK rules to Terraform state.
 // expectedOrder contains rule IDs in the expected order (from config/plan) to preserve order consistency.
-func (r *BucketLifecycleResource) flattenLifecycleRules(ctx context.Context, s3Rules []s3types.LifecycleRule, expectedOrder []string) (types.List, diag.Diagnostics) {
+// priorFilters contains the filters from config/plan by rule ID. An empty
+// filter keeps its prior shape, because RadosGW does not distinguish between a
+// missing filter, an empty filter and an empty prefix.
+func (r *BucketLifecycleResource) flattenLifecycleRules(ctx context.Context, s3Rules []s3types.LifecycleRule, expectedOrder []string, priorFilters map[string]types.List) (types.List, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if len(s3Rules) == 0 {
@@ -806,18 +1147,33 @@ func (r *BucketLifecycleResource) flattenLifecycleRules(ctx context.Context, s3R
 			"status": types.StringValue(string(s3Rule.Status)),
 		}
 
-		// Flatten filter
-		rule["filter"] = r.flattenLifecycleFilter(ctx, s3Rule.Filter)
+		// Flatten filter. Tools such as s3cmd write the deprecated rule-level
+		// prefix instead of a filter.
+		filter := s3Rule.Filter
+		if lifecycleFilterIsEmpty(filter) && aws.ToString(s3Rule.Prefix) != "" {
+			filter = &s3types.LifecycleRuleFilter{Prefix: s3Rule.Prefix}
+		}
+		if !lifecycleFilterIsEmpty(filter) {
+			rule["filter"] = r.flattenLifecycleFilter(ctx, filter)
+		} else if prior, ok := priorFilters[aws.ToString(s3Rule.ID)]; ok && r.lifecycleFilterListIsEmpty(ctx, prior) {
+			rule["filter"] = prior
+		} else {
+			rule["filter"] = types.ListNull(types.ObjectType{AttrTypes: lifecycleFilterAttrTypes()})
+		}
 
 		// Flatten expiration
 		if s3Rule.Expiration != nil {
 			expValues := map[string]attr.Value{
 				"days":                         types.Int64Null(),
+				"date":                         types.StringNull(),
 				"expired_object_delete_marker": types.BoolNull(),
 			}
 			if s3Rule.Expiration.Days != nil && *s3Rule.Expiration.Days > 0 {
 				expValues["days"] = types.Int64Value(int64(*s3Rule.Expiration.Days))
 			}
+			if s3Rule.Expiration.Date != nil {
+				expValues["date"] = types.StringValue(formatLifecycleDate(*s3Rule.Expiration.Date))
+			}
 			if s3Rule.Expiration.ExpiredObjectDeleteMarker != nil {
 				expValues["expired_object_delete_marker"] = types.BoolValue(*s3Rule.Expiration.ExpiredObjectDeleteMarker)
 			}
@@ -832,9 +1188,15 @@ func (r *BucketLifecycleResource) flattenLifecycleRules(ctx context.Context, s3R
 			var transitions []attr.Value
 			for _, t := range s3Rule.Transitions {
 				tValues := map[string]attr.Value{
-					"days":          types.Int64Value(int64(aws.ToInt32(t.Days))),
+					"days":          types.Int64Null(),
+					"date":          types.StringNull(),
 					"storage_class": types.StringValue(string(t.StorageClass)),
 				}
+				if t.Date != nil {
+					tValues["date"] = types.StringValue(formatLifecycleDate(*t.Date))
+				} else {
+					tValues["days"] = types.Int64Value(int64(aws.ToInt32(t.Days)))
+				}
 				tObj, _ := types.ObjectValue(lifecycleTransitionAttrTypes(), tValues)
 				transitions = append(transitions, tObj)
 			}
@@ -898,6 +1260,35 @@ func (r *BucketLifecycleResource) flattenLifecycleRules(ctx context.Context, s3R
 	return result, diags
 }
 
+// lifecycleFilterIsEmpty reports whether a lifecycle filter matches all
+// objects of the bucket.
+func lifecycleFilterIsEmpty(filter *s3types.LifecycleRuleFilter) bool {
+	return filter == nil || (filter.And == nil && filter.Tag == nil &&
+		filter.ObjectSizeGreaterThan == nil && filter.ObjectSizeLessThan == nil &&
+		aws.ToString(filter.Prefix) == "")
+}
+
+// lifecycleFilterListIsEmpty reports whether a filter block from config/plan
+// matches all objects of the bucket.
+func (r *BucketLifecycleResource) lifecycleFilterListIsEmpty(ctx context.Context, filterList types.List) bool {
+	if filterList.IsUnknown() {
+		return false
+	}
+	if filterList.IsNull() || len(filterList.Elements()) == 0 {
+		return true
+	}
+
+	var filters []LifecycleFilterModel
+	if diags := filterList.ElementsAs(ctx, &filters, false); diags.HasError() {
+		return false
+	}
+
+	filter := filters[0]
+	return (filter.Prefix.IsNull() || filter.Prefix.ValueString() == "") &&
+		filter.ObjectSizeGreaterThan.IsNull() && filter.ObjectSizeLessThan.IsNull() &&
+		len(filter.Tag.Elements()) == 0 && len(filter.And.Elements()) == 0
+}
+
 // flattenLifecycleFilter converts AWS SDK filter to Terraform state.
 func (r *BucketLifecycleResource) flattenLifecycleFilter(ctx context.Context, filter *s3types.LifecycleRuleFilter) types.List {
 	if filter == nil {
@@ -905,20 +1296,30 @@ func (r *BucketLifecycleResource) flattenLifecycleFilter(ctx context.Context, fi
 	}
 
 	filterValues := map[string]attr.Value{
-		"prefix": types.StringNull(),
-		"tag":    types.ListNull(types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}),
-		"and":    types.ListNull(types.ObjectType{AttrTypes: lifecycleFilterAndAttrTypes()}),
+		"prefix":                   types.StringNull(),
+		"object_size_greater_than": types.Int64Null(),
+		"object_size_less_than":    types.Int64Null(),
+		"tag":                      types.ListNull(types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}),
+		"and":                      types.ListNull(types.ObjectType{AttrTypes: lifecycleFilterAndAttrTypes()}),
 	}
 
 	// Check for AND condition
 	if filter.And != nil {
 		andValues := map[string]attr.Value{
-			"prefix": types.StringNull(),
-			"tags":   types.MapNull(types.StringType),
+			"prefix":                   types.StringNull(),
+			"object_size_greater_than": types.Int64Null(),
+			"object_size_less_than":    types.Int64Null(),
+			"tags":                     types.MapNull(types.StringType),
 		}
 		if filter.And.Prefix != nil {
 			andValues["prefix"] = types.StringValue(*filter.And.Prefix)
 		}
+		if filter.And.ObjectSizeGreaterThan != nil {
+			andValues["object_size_greater_than"] = types.Int64Value(*filter.And.ObjectSizeGreaterThan)
+		}
+		if filter.And.ObjectSizeLessThan != nil {
+			andValues["object_size_less_than"] = types.Int64Value(*filter.And.ObjectSizeLessThan)
+		}
 		if len(filter.And.Tags) > 0 {
 			tags := make(map[string]attr.Value)
 			for _, tag := range filter.And.Tags {
@@ -936,6 +1337,14 @@ func (r *BucketLifecycleResource) flattenLifecycleFilter(ctx context.Context, fi
 		}
 		tagObj, _ := types.ObjectValue(lifecycleTagAttrTypes(), tagValues)
 		filterValues["tag"], _ = types.ListValue(types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}, []attr.Value{tagObj})
+	} else if filter.ObjectSizeGreaterThan != nil || filter.ObjectSizeLessThan != nil {
+		// Check for object size
+		if filter.ObjectSizeGreaterThan != nil {
+			filterValues["object_size_greater_than"] = types.Int64Value(*filter.ObjectSizeGreaterThan)
+		}
+		if filter.ObjectSizeLessThan != nil {
+			filterValues["object_size_less_than"] = types.Int64Value(*filter.ObjectSizeLessThan)
+		}
 	} else if filter.Prefix != nil && *filter.Prefix != "" {
 		// Check for prefix
 		filterValues["prefix"] = types.StringValue(*filter.Prefix)
@@ -966,16 +1375,20 @@ func lifecycleRuleAttrTypes() map[string]attr.Type {
 
 func lifecycleFilterAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"prefix": types.StringType,
-		"tag":    types.ListType{ElemType: types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}},
-		"and":    types.ListType{ElemType: types.ObjectType{AttrTypes: lifecycleFilterAndAttrTypes()}},
+		"prefix":                   types.StringType,
+		"object_size_greater_than": types.Int64Type,
+		"object_size_less_than":    types.Int64Type,
+		"tag":                      types.ListType{ElemType: types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}},
+		"and":                      types.ListType{ElemType: types.ObjectType{AttrTypes: lifecycleFilterAndAttrTypes()}},
 	}
 }
 
 func lifecycleFilterAndAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"prefix": types.StringType,
-		"tags":   types.MapType{ElemType: types.StringType},
+		"prefix":                   types.StringType,
+		"object_size_greater_than": types.Int64Type,
+		"object_size_less_than":    types.Int64Type,
+		"tags":                     types.MapType{ElemType: types.StringType},
 	}
 }
 
@@ -989,6 +1402,7 @@ func lifecycleTagAttrTypes() map[string]attr.Type {
 func lifecycleExpirationAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"days":                         types.Int64Type,
+		"date":                         types.StringType,
 		"expired_object_delete_marker": types.BoolType,
 	}
 }
@@ -996,6 +1410,7 @@ func lifecycleExpirationAttrTypes() map[string]attr.Type {
 func lifecycleTransitionAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"days":          types.Int64Type,
+		"date":          types.StringType,
 		"storage_class": types.StringType,
 	}
 }
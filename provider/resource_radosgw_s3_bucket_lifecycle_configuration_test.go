@@ -1,9 +1,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -34,6 +40,13 @@ func TestAccRadosgwS3BucketLifecycleConfiguration_basic(t *testing.T) {
 				ImportStateId:                        bucketName,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket_lifecycle_configuration.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   bucketName,
+			},
 		},
 	})
 }
@@ -59,6 +72,27 @@ func TestAccRadosgwS3BucketLifecycleConfiguration_withPrefix(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3BucketLifecycleConfiguration_legacyPrefix(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketLifecycleConfigurationConfig_legacyPrefix(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "legacy_prefix_compat", "true"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.0.filter.0.prefix", "logs/"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRadosgwS3BucketLifecycleConfiguration_multipleRules(t *testing.T) {
 	t.Parallel()
 
@@ -127,6 +161,217 @@ func TestAccRadosgwS3BucketLifecycleConfiguration_update(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3BucketLifecycleConfiguration_expirationDate(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketLifecycleConfigurationConfig_expirationDate(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.0.expiration.0.date", "2030-01-01T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.0.expiration.0.days"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketLifecycleConfiguration_objectSize(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_LifecycleObjectSize) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketLifecycleConfigurationConfig_objectSize(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.0.filter.0.object_size_greater_than", "1048576"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.1.filter.0.and.0.prefix", "logs/"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_lifecycle_configuration.test", "rule.1.filter.0.and.0.object_size_less_than", "1024"),
+				),
+			},
+		},
+	})
+}
+
+func TestLifecycleDateValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		date        string
+		expectError bool
+	}{
+		"midnight utc":  {date: "2030-01-01T00:00:00Z"},
+		"not midnight":  {date: "2030-01-01T12:00:00Z", expectError: true},
+		"utc offset":    {date: "2030-01-01T00:00:00+00:00", expectError: true},
+		"other offset":  {date: "2030-01-01T00:00:00+02:00", expectError: true},
+		"date only":     {date: "2030-01-01", expectError: true},
+		"invalid value": {date: "tomorrow", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("date"),
+				ConfigValue: types.StringValue(testCase.date),
+			}
+			resp := &validator.StringResponse{}
+			lifecycleDateValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestLifecycleRulesValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	expiration := func(days types.Int64) types.List {
+		value, _ := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: lifecycleExpirationAttrTypes()}, []LifecycleExpirationModel{{
+			Days:                      days,
+			Date:                      types.StringNull(),
+			ExpiredObjectDeleteMarker: types.BoolNull(),
+		}})
+		return value
+	}
+
+	testCases := map[string]struct {
+		expiration  types.List
+		expectError bool
+	}{
+		"expiration":       {expiration: expiration(types.Int64Value(30))},
+		"no action":        {expiration: types.ListNull(types.ObjectType{AttrTypes: lifecycleExpirationAttrTypes()}), expectError: true},
+		"empty expiration": {expiration: expiration(types.Int64Null()), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rules, diags := types.ListValueFrom(ctx, lifecycleRuleObjectType(), []LifecycleRuleModel{testLifecycleRule("rule", testCase.expiration)})
+			if diags.HasError() {
+				t.Fatalf("failed to build rules: %v", diags)
+			}
+
+			req := validator.ListRequest{
+				Path:        path.Root("rule"),
+				ConfigValue: rules,
+			}
+			resp := &validator.ListResponse{}
+			lifecycleRulesValidator{}.ValidateList(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestFlattenLifecycleRulesFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &BucketLifecycleResource{}
+	filterType := types.ObjectType{AttrTypes: lifecycleFilterAttrTypes()}
+	emptyPrefixFilter, _ := types.ListValueFrom(ctx, filterType, []LifecycleFilterModel{{
+		Prefix:                types.StringValue(""),
+		ObjectSizeGreaterThan: types.Int64Null(),
+		ObjectSizeLessThan:    types.Int64Null(),
+		Tag:                   types.ListNull(types.ObjectType{AttrTypes: lifecycleTagAttrTypes()}),
+		And:                   types.ListNull(types.ObjectType{AttrTypes: lifecycleFilterAndAttrTypes()}),
+	}})
+
+	s3Rules := []s3types.LifecycleRule{
+		{ID: aws.String("empty"), Status: s3types.ExpirationStatusEnabled, Filter: &s3types.LifecycleRuleFilter{Prefix: aws.String("")}},
+		{ID: aws.String("legacy"), Status: s3types.ExpirationStatusEnabled, Prefix: aws.String("logs/")},
+		{ID: aws.String("missing"), Status: s3types.ExpirationStatusEnabled},
+	}
+	priorFilters := map[string]types.List{
+		"empty":   emptyPrefixFilter,
+		"missing": types.ListNull(filterType),
+	}
+
+	rules, diags := r.flattenLifecycleRules(ctx, s3Rules, []string{"empty", "legacy", "missing"}, priorFilters)
+	if diags.HasError() {
+		t.Fatalf("flattenLifecycleRules returned unexpected errors: %v", diags)
+	}
+
+	var models []LifecycleRuleModel
+	if diags := rules.ElementsAs(ctx, &models, false); diags.HasError() {
+		t.Fatalf("failed to read rules: %v", diags)
+	}
+
+	if !models[0].Filter.Equal(emptyPrefixFilter) {
+		t.Errorf("expected the empty filter to keep its prior shape, got %s", models[0].Filter)
+	}
+
+	var filters []LifecycleFilterModel
+	if diags := models[1].Filter.ElementsAs(ctx, &filters, false); diags.HasError() || len(filters) != 1 {
+		t.Fatalf("expected one filter for the legacy rule, got %s", models[1].Filter)
+	}
+	if filters[0].Prefix.ValueString() != "logs/" {
+		t.Errorf("expected the legacy prefix in the filter, got %s", filters[0].Prefix)
+	}
+
+	if !models[2].Filter.IsNull() {
+		t.Errorf("expected no filter for the rule without one, got %s", models[2].Filter)
+	}
+}
+
+func TestUseLegacyLifecyclePrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		filter       *s3types.LifecycleRuleFilter
+		expectPrefix string
+		expectError  bool
+	}{
+		"no filter":    {filter: nil, expectPrefix: ""},
+		"empty prefix": {filter: &s3types.LifecycleRuleFilter{Prefix: aws.String("")}, expectPrefix: ""},
+		"prefix":       {filter: &s3types.LifecycleRuleFilter{Prefix: aws.String("logs/")}, expectPrefix: "logs/"},
+		"tag":          {filter: &s3types.LifecycleRuleFilter{Tag: &s3types.Tag{Key: aws.String("k"), Value: aws.String("v")}}, expectError: true},
+		"and":          {filter: &s3types.LifecycleRuleFilter{And: &s3types.LifecycleRuleAndOperator{Prefix: aws.String("logs/")}}, expectError: true},
+		"object size":  {filter: &s3types.LifecycleRuleFilter{ObjectSizeGreaterThan: aws.Int64(1024)}, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := s3types.LifecycleRule{ID: aws.String("rule"), Filter: testCase.filter}
+			diags := useLegacyLifecyclePrefix(&rule)
+
+			if diags.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got diagnostics %v", testCase.expectError, diags)
+			}
+			if testCase.expectError {
+				return
+			}
+			if rule.Filter != nil {
+				t.Errorf("expected the filter to be removed, got %+v", rule.Filter)
+			}
+			if rule.Prefix == nil || *rule.Prefix != testCase.expectPrefix {
+				t.Errorf("expected rule prefix %q, got %v", testCase.expectPrefix, rule.Prefix)
+			}
+		})
+	}
+}
+
 // Test configurations
 
 func testAccRadosgwS3BucketLifecycleConfigurationConfig_basic(bucketName string) string {
@@ -196,6 +441,32 @@ resource "radosgw_s3_bucket_lifecycle_configuration" "test" {
 `, bucketName)
 }
 
+func testAccRadosgwS3BucketLifecycleConfigurationConfig_legacyPrefix(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_lifecycle_configuration" "test" {
+  bucket               = radosgw_s3_bucket.test.bucket
+  legacy_prefix_compat = true
+
+  rule {
+    id     = "expire-logs"
+    status = "Enabled"
+
+    filter {
+      prefix = "logs/"
+    }
+
+    expiration {
+      days = 7
+    }
+  }
+}
+`, bucketName)
+}
+
 func testAccRadosgwS3BucketLifecycleConfigurationConfig_multipleRules(bucketName string) string {
 	return providerConfig() + fmt.Sprintf(`
 resource "radosgw_s3_bucket" "test" {
@@ -254,3 +525,82 @@ resource "radosgw_s3_bucket_lifecycle_configuration" "test" {
 }
 `, bucketName)
 }
+
+func testAccRadosgwS3BucketLifecycleConfigurationConfig_expirationDate(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_lifecycle_configuration" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+
+  rule {
+    id     = "expire-on-date"
+    status = "Enabled"
+
+    expiration {
+      date = "2030-01-01T00:00:00Z"
+    }
+  }
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3BucketLifecycleConfigurationConfig_objectSize(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_lifecycle_configuration" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+
+  rule {
+    id     = "expire-large-objects"
+    status = "Enabled"
+
+    filter {
+      object_size_greater_than = 1048576
+    }
+
+    expiration {
+      days = 30
+    }
+  }
+
+  rule {
+    id     = "expire-small-logs"
+    status = "Enabled"
+
+    filter {
+      and {
+        prefix                = "logs/"
+        object_size_less_than = 1024
+      }
+    }
+
+    expiration {
+      days = 7
+    }
+  }
+}
+`, bucketName)
+}
+
+// Helper functions
+
+// testLifecycleRule returns a lifecycle rule model with the given expiration
+// and no other actions.
+func testLifecycleRule(id string, expiration types.List) LifecycleRuleModel {
+	return LifecycleRuleModel{
+		ID:                             types.StringValue(id),
+		Status:                         types.StringValue("Enabled"),
+		Filter:                         types.ListNull(types.ObjectType{AttrTypes: lifecycleFilterAttrTypes()}),
+		Expiration:                     expiration,
+		Transition:                     types.ListNull(types.ObjectType{AttrTypes: lifecycleTransitionAttrTypes()}),
+		NoncurrentVersionExpiration:    types.ListNull(types.ObjectType{AttrTypes: lifecycleNoncurrentVersionExpirationAttrTypes()}),
+		NoncurrentVersionTransition:    types.ListNull(types.ObjectType{AttrTypes: lifecycleNoncurrentVersionTransitionAttrTypes()}),
+		AbortIncompleteMultipartUpload: types.ListNull(types.ObjectType{AttrTypes: lifecycleAbortIncompleteMultipartUploadAttrTypes()}),
+	}
+}
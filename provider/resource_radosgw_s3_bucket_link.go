@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -19,6 +20,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketLinkResource{}
 var _ resource.ResourceWithImportState = &BucketLinkResource{}
+var _ resource.ResourceWithModifyPlan = &BucketLinkResource{}
 
 func NewS3BucketLinkResource() resource.Resource {
 	return &BucketLinkResource{}
@@ -32,6 +34,7 @@ type BucketLinkResource struct {
 // BucketLinkResourceModel describes the resource data model.
 type BucketLinkResourceModel struct {
 	Bucket        types.String `tfsdk:"bucket"`
+	Tenant        types.String `tfsdk:"tenant"`
 	UID           types.String `tfsdk:"uid"`
 	BucketID      types.String `tfsdk:"bucket_id"`
 	NewBucketName types.String `tfsdk:"new_bucket_name"`
@@ -49,7 +52,9 @@ func (r *BucketLinkResource) Schema(ctx context.Context, req resource.SchemaRequ
 This resource links an existing bucket to a user, unlinking it from any previous owner. It is primarily useful for:
 - Transferring bucket ownership between users
 - Moving buckets from one tenant to another
-- Renaming buckets during the link operation
+- Renaming buckets, during the link operation or later by changing ` + "`new_bucket_name`" + `
+
+Like ` + "`radosgw-admin bucket link`" + `, linking a bucket to a user of another tenant moves the bucket into that tenant: ` + "`tenant`" + ` is the tenant the bucket belongs to before the link, and the tenant of ` + "`uid`" + ` (in the format ` + "`tenant$user`" + `) is the tenant it belongs to afterwards.
 
 On destruction, the bucket can optionally be linked to a different user (via ` + "`unlink_to_uid`" + `), or simply unlinked from the current user.
 
@@ -65,25 +70,38 @@ On destruction, the bucket can optionally be linked to a different user (via ` +
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to before the link. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"uid": schema.StringAttribute{
-				MarkdownDescription: "The user ID to link the bucket to. This user will become the bucket owner.",
+				MarkdownDescription: "The user ID to link the bucket to, in the format `tenant$user` for users of a tenant. This user will become the bucket owner.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"bucket_id": schema.StringAttribute{
-				MarkdownDescription: "The unique bucket ID assigned by RadosGW.",
-				Computed:            true,
+				MarkdownDescription: "The ID of the bucket instance to link. Set it to pin the link to a specific instance when stale instances " +
+					"of the bucket exist, e.g. after a reshard or an interrupted deletion; RadosGW rejects the link when no such instance exists. " +
+					"When unset, the current instance is linked and its ID is exported.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"new_bucket_name": schema.StringAttribute{
-				MarkdownDescription: "Optional new name for the bucket. Use this to rename the bucket during the link operation.",
-				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				MarkdownDescription: "Optional new name for the bucket. Use this to rename the bucket during the link operation. " +
+					"Changing it renames the linked bucket in place, and removing it renames the bucket back to `bucket`. " +
+					"Follows the same naming rules as the `bucket` attribute of `radosgw_s3_bucket`.",
+				Optional: true,
+				Validators: []validator.String{
+					bucketNameValidator{},
 				},
 			},
 			"unlink_to_uid": schema.StringAttribute{
@@ -113,6 +131,29 @@ func (r *BucketLinkResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+func (r *BucketLinkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var newBucketName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("new_bucket_name"), &newBucketName)...)
+	if resp.Diagnostics.HasError() || newBucketName.IsUnknown() {
+		return
+	}
+
+	// Names already in use were accepted when they were applied
+	if !req.State.Raw.IsNull() {
+		var priorBucketName types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("new_bucket_name"), &priorBucketName)...)
+		if resp.Diagnostics.HasError() || priorBucketName.Equal(newBucketName) {
+			return
+		}
+	}
+
+	r.client.validateStrictBucketName(&resp.Diagnostics, path.Root("new_bucket_name"), newBucketName.ValueString())
+}
+
 func (r *BucketLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data BucketLinkResourceModel
 
@@ -122,38 +163,40 @@ func (r *BucketLinkResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	sourceBucket := adminBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
 	bucketLink := admin.BucketLinkInput{
-		Bucket: data.Bucket.ValueString(),
+		Bucket: sourceBucket,
 		UID:    data.UID.ValueString(),
 	}
 
+	if !data.BucketID.IsUnknown() && data.BucketID.ValueString() != "" {
+		bucketLink.BucketID = data.BucketID.ValueString()
+	}
+
 	if !data.NewBucketName.IsNull() && data.NewBucketName.ValueString() != "" {
 		bucketLink.NewBucketName = data.NewBucketName.ValueString()
 	}
 
 	tflog.Debug(ctx, "Linking bucket to user", map[string]any{
-		"bucket":          data.Bucket.ValueString(),
+		"bucket":          sourceBucket,
+		"bucket_id":       bucketLink.BucketID,
 		"uid":             data.UID.ValueString(),
 		"new_bucket_name": data.NewBucketName.ValueString(),
 	})
 
-	// Link bucket with retry logic for ConcurrentModification
-	err := retryOnConcurrentModification(ctx, fmt.Sprintf("LinkBucket %s to %s", data.Bucket.ValueString(), data.UID.ValueString()), func() error {
-		return r.client.Admin.LinkBucket(ctx, bucketLink)
-	})
+	// Link bucket
+	err := r.client.Admin.LinkBucket(ctx, bucketLink)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Linking Bucket",
-			fmt.Sprintf("Could not link bucket %s to user %s: %s", data.Bucket.ValueString(), data.UID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not link bucket %s to user %s: %s", sourceBucket, data.UID.ValueString(), adminError(err, "buckets=write").Error()),
 		)
 		return
 	}
 
 	// Get bucket info to retrieve the bucket ID
-	effectiveBucketName := data.Bucket.ValueString()
-	if !data.NewBucketName.IsNull() && data.NewBucketName.ValueString() != "" {
-		effectiveBucketName = data.NewBucketName.ValueString()
-	}
+	effectiveBucketName := linkedBucketName(data)
 
 	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: effectiveBucketName})
 	if err != nil {
@@ -161,7 +204,7 @@ func (r *BucketLinkResource) Create(ctx context.Context, req resource.CreateRequ
 			"bucket": effectiveBucketName,
 			"error":  err.Error(),
 		})
-		data.BucketID = types.StringValue("")
+		data.BucketID = types.StringValue(bucketLink.BucketID)
 	} else {
 		data.BucketID = types.StringValue(bucketInfo.ID)
 	}
@@ -185,75 +228,90 @@ func (r *BucketLinkResource) Read(ctx context.Context, req resource.ReadRequest,
 		"uid":    data.UID.ValueString(),
 	})
 
-	// Get the effective bucket name (might have been renamed)
-	effectiveBucketName := data.Bucket.ValueString()
-	if !data.NewBucketName.IsNull() && data.NewBucketName.ValueString() != "" {
-		effectiveBucketName = data.NewBucketName.ValueString()
-	}
+	// Get the effective bucket name (might have been renamed or moved to
+	// the tenant of the user)
+	effectiveBucketName := linkedBucketName(data)
 
-	// Get user's buckets to verify the link still exists
-	buckets, err := r.client.Admin.ListUsersBuckets(ctx, data.UID.ValueString())
+	// Verify the link by comparing the bucket owner instead of listing all of the
+	// user's buckets, which is slow and unpaginated for users with many buckets
+	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: effectiveBucketName})
 	if err != nil {
-		if errors.Is(err, admin.ErrNoSuchUser) {
-			tflog.Info(ctx, "User no longer exists, removing bucket link from state")
+		if errors.Is(err, admin.ErrNoSuchBucket) {
+			tflog.Info(ctx, "Bucket no longer exists, removing from state")
 			resp.State.RemoveResource(ctx)
 			return
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Bucket Link",
-			fmt.Sprintf("Could not list buckets for user %s: %s", data.UID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not get bucket info for %s: %s", effectiveBucketName, adminError(err, "buckets=read").Error()),
 		)
 		return
 	}
 
-	// Check if bucket is in user's bucket list
-	found := false
-	for _, bucket := range buckets {
-		if bucket == effectiveBucketName {
-			found = true
-			break
-		}
-	}
-
-	if !found {
+	if bucketInfo.Owner != data.UID.ValueString() {
 		tflog.Info(ctx, "Bucket is no longer linked to user, removing from state", map[string]any{
 			"bucket": effectiveBucketName,
 			"uid":    data.UID.ValueString(),
+			"owner":  bucketInfo.Owner,
 		})
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Get bucket info for bucket_id
-	bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: effectiveBucketName})
-	if err != nil {
-		if errors.Is(err, admin.ErrNoSuchBucket) {
-			tflog.Info(ctx, "Bucket no longer exists, removing from state")
-			resp.State.RemoveResource(ctx)
-			return
-		}
-		tflog.Warn(ctx, "Could not retrieve bucket info", map[string]any{
-			"bucket": effectiveBucketName,
-			"error":  err.Error(),
-		})
-	} else {
-		data.BucketID = types.StringValue(bucketInfo.ID)
-	}
+	data.BucketID = types.StringValue(bucketInfo.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *BucketLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data BucketLinkResourceModel
+	var data, state BucketLinkResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Only unlink_to_uid can be updated in place (bucket, uid, new_bucket_name require replace)
-	// Just save the updated state
+	// Only new_bucket_name and unlink_to_uid can be updated in place (bucket,
+	// tenant, uid and a configured bucket_id require replace). A new name is
+	// applied by linking the bucket again to the same user with the rename.
+	currentBucketName := linkedBucketName(state)
+	newBucketName := linkedBucketName(data)
+	if newBucketName != currentBucketName {
+		_, renameTo := splitBucketID(newBucketName)
+
+		tflog.Debug(ctx, "Renaming linked bucket", map[string]any{
+			"bucket":          currentBucketName,
+			"uid":             data.UID.ValueString(),
+			"new_bucket_name": renameTo,
+		})
+
+		err := r.client.Admin.LinkBucket(ctx, admin.BucketLinkInput{
+			Bucket:        currentBucketName,
+			BucketID:      state.BucketID.ValueString(),
+			UID:           data.UID.ValueString(),
+			NewBucketName: renameTo,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Renaming Bucket",
+				fmt.Sprintf("Could not rename bucket %s to %s: %s", currentBucketName, renameTo, adminError(err, "buckets=write").Error()),
+			)
+			return
+		}
+
+		bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: newBucketName})
+		if err != nil {
+			tflog.Warn(ctx, "Could not retrieve bucket info after rename", map[string]any{
+				"bucket": newBucketName,
+				"error":  err.Error(),
+			})
+		} else {
+			data.BucketID = types.StringValue(bucketInfo.ID)
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -267,10 +325,7 @@ func (r *BucketLinkResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	// Get the effective bucket name
-	effectiveBucketName := data.Bucket.ValueString()
-	if !data.NewBucketName.IsNull() && data.NewBucketName.ValueString() != "" {
-		effectiveBucketName = data.NewBucketName.ValueString()
-	}
+	effectiveBucketName := linkedBucketName(data)
 
 	tflog.Debug(ctx, "Deleting bucket link", map[string]any{
 		"bucket":        effectiveBucketName,
@@ -281,19 +336,16 @@ func (r *BucketLinkResource) Delete(ctx context.Context, req resource.DeleteRequ
 	var err error
 	if !data.UnlinkToUID.IsNull() && data.UnlinkToUID.ValueString() != "" {
 		// Link bucket to a different user
-		err = retryOnConcurrentModification(ctx, fmt.Sprintf("LinkBucket %s to %s (on destroy)", effectiveBucketName, data.UnlinkToUID.ValueString()), func() error {
-			return r.client.Admin.LinkBucket(ctx, admin.BucketLinkInput{
-				Bucket: effectiveBucketName,
-				UID:    data.UnlinkToUID.ValueString(),
-			})
+		err = r.client.Admin.LinkBucket(ctx, admin.BucketLinkInput{
+			Bucket:   effectiveBucketName,
+			BucketID: data.BucketID.ValueString(),
+			UID:      data.UnlinkToUID.ValueString(),
 		})
 	} else {
 		// Unlink bucket from current user
-		err = retryOnConcurrentModification(ctx, fmt.Sprintf("UnlinkBucket %s from %s", effectiveBucketName, data.UID.ValueString()), func() error {
-			return r.client.Admin.UnlinkBucket(ctx, admin.BucketLinkInput{
-				Bucket: effectiveBucketName,
-				UID:    data.UID.ValueString(),
-			})
+		err = r.client.Admin.UnlinkBucket(ctx, admin.BucketLinkInput{
+			Bucket: effectiveBucketName,
+			UID:    data.UID.ValueString(),
 		})
 	}
 
@@ -302,7 +354,7 @@ func (r *BucketLinkResource) Delete(ctx context.Context, req resource.DeleteRequ
 		if !errors.Is(err, admin.ErrNoSuchBucket) && !errors.Is(err, admin.ErrNoSuchUser) {
 			resp.Diagnostics.AddError(
 				"Error Deleting Bucket Link",
-				fmt.Sprintf("Could not unlink/relink bucket %s: %s", effectiveBucketName, err.Error()),
+				fmt.Sprintf("Could not unlink/relink bucket %s: %s", effectiveBucketName, adminError(err, "buckets=write").Error()),
 			)
 			return
 		}
@@ -310,21 +362,22 @@ func (r *BucketLinkResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *BucketLinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: "bucket:uid" or just "bucket" (uid will be read from bucket info)
+	// Import format: "bucket:uid" or just "bucket" (uid will be read from bucket info).
+	// Buckets of a tenant use the format "tenant/bucket".
 	parts := strings.SplitN(req.ID, ":", 2)
 
-	bucket := parts[0]
+	tenant, bucket := splitBucketID(parts[0])
 	var uid string
 
 	if len(parts) == 2 {
 		uid = parts[1]
 	} else {
 		// Get bucket info to find the owner
-		bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: bucket})
+		bucketInfo, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: parts[0]})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Importing Bucket Link",
-				fmt.Sprintf("Could not get bucket info for %s: %s. Try importing with format 'bucket:uid'.", bucket, err.Error()),
+				fmt.Sprintf("Could not get bucket info for %s: %s. Try importing with format 'bucket:uid'.", parts[0], adminError(err, "buckets=read").Error()),
 			)
 			return
 		}
@@ -332,10 +385,26 @@ func (r *BucketLinkResource) ImportState(ctx context.Context, req resource.Impor
 	}
 
 	tflog.Debug(ctx, "Importing bucket link", map[string]any{
-		"bucket": bucket,
+		"bucket": parts[0],
 		"uid":    uid,
 	})
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	if tenant != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uid"), uid)...)
 }
+
+// linkedBucketName returns the Admin API name of the bucket once linked. The
+// link moves the bucket into the tenant of the user and applies the rename.
+func linkedBucketName(data BucketLinkResourceModel) string {
+	tenant, _ := splitUserID(data.UID.ValueString())
+
+	bucket := data.Bucket.ValueString()
+	if !data.NewBucketName.IsNull() && data.NewBucketName.ValueString() != "" {
+		bucket = data.NewBucketName.ValueString()
+	}
+
+	return adminBucketName(tenant, bucket)
+}
@@ -2,11 +2,82 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
+func TestLinkedBucketName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		bucket        string
+		tenant        types.String
+		uid           string
+		newBucketName types.String
+		expected      string
+	}{
+		"without tenants": {
+			bucket:        "data",
+			tenant:        types.StringNull(),
+			uid:           "alice",
+			newBucketName: types.StringNull(),
+			expected:      "data",
+		},
+		"renamed": {
+			bucket:        "data",
+			tenant:        types.StringNull(),
+			uid:           "alice",
+			newBucketName: types.StringValue("archive"),
+			expected:      "archive",
+		},
+		"into tenant": {
+			bucket:        "data",
+			tenant:        types.StringNull(),
+			uid:           "acme$alice",
+			newBucketName: types.StringNull(),
+			expected:      "acme/data",
+		},
+		"across tenants": {
+			bucket:        "data",
+			tenant:        types.StringValue("acme"),
+			uid:           "globex$bob",
+			newBucketName: types.StringValue("archive"),
+			expected:      "globex/archive",
+		},
+		"out of tenant": {
+			bucket:        "data",
+			tenant:        types.StringValue("acme"),
+			uid:           "admin",
+			newBucketName: types.StringNull(),
+			expected:      "data",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := linkedBucketName(BucketLinkResourceModel{
+				Bucket:        types.StringValue(testCase.bucket),
+				Tenant:        testCase.tenant,
+				UID:           types.StringValue(testCase.uid),
+				NewBucketName: testCase.newBucketName,
+			})
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
 // Note: Bucket link tests use unlink_to_uid="admin" to transfer ownership back
 // to the admin user on destroy, so the bucket can be properly cleaned up.
 // Without this, the admin user loses access to the bucket after linking it
@@ -62,6 +133,164 @@ func TestAccRadosgwS3BucketLink_import(t *testing.T) {
 				ImportStateId:                        bucketName + ":" + userID,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - unlink_to_uid is not stored by RadosGW, so a plan is expected
+			{
+				ResourceName:       "radosgw_s3_bucket_link.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ImportStateId:      bucketName + ":" + userID,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketLink_ownerChanged(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketLinkConfig_basic(bucketName, userID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_link.test", "uid", userID),
+				),
+			},
+			// Link the bucket back to admin outside of Terraform; the link must be recreated
+			{
+				PreConfig: func() {
+					err := testAccAdminClient.LinkBucket(testCtx, admin.BucketLinkInput{
+						Bucket: bucketName,
+						UID:    "admin",
+					})
+					if err != nil {
+						t.Fatalf("failed to relink bucket %s: %s", bucketName, err)
+					}
+				},
+				Config:             testAccRadosgwS3BucketLinkConfig_basic(bucketName, userID),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketLink_tenant(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	userID := randomName("tf-acc-user")
+	tenant := "tfacc" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			// Linking to a user of a tenant moves the bucket into the tenant
+			{
+				Config: testAccRadosgwS3BucketLinkConfig_tenant(bucketName, userID, tenant),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_link.test", "uid", tenant+"$"+userID),
+					resource.TestCheckResourceAttrSet("radosgw_s3_bucket_link.test", "bucket_id"),
+				),
+			},
+			// Import test - format: tenant/bucket:uid
+			{
+				ResourceName:                         "radosgw_s3_bucket_link.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"unlink_to_uid", "tenant"},
+				ImportStateId:                        tenant + "/" + bucketName + ":" + tenant + "$" + userID,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketLink_bucketIDMismatch(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	userID := randomName("tf-acc-user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRadosgwS3BucketLinkConfig_bucketID(bucketName, userID, "no-such-instance"),
+				ExpectError: regexp.MustCompile(`Error Linking Bucket`),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketLink_rename(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	userID := randomName("tf-acc-user")
+
+	// The bucket is created outside of Terraform, as renaming it would
+	// remove it from under a radosgw_s3_bucket resource
+	t.Cleanup(func() {
+		_, _ = testAccS3Client().DeleteBucket(testCtx, &s3.DeleteBucketInput{
+			Bucket: aws.String(bucketName),
+		})
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					_, err := testAccS3Client().CreateBucket(testCtx, &s3.CreateBucketInput{
+						Bucket: aws.String(bucketName),
+					})
+					if err != nil {
+						t.Fatalf("error creating bucket: %s", err)
+					}
+				},
+				Config: testAccRadosgwS3BucketLinkConfig_rename(bucketName, userID, bucketName+"-a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_link.test", "new_bucket_name", bucketName+"-a"),
+					resource.TestCheckResourceAttrSet("radosgw_s3_bucket_link.test", "bucket_id"),
+				),
+			},
+			// A new name renames the linked bucket in place
+			{
+				Config: testAccRadosgwS3BucketLinkConfig_rename(bucketName, userID, bucketName+"-b"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("radosgw_s3_bucket_link.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_link.test", "new_bucket_name", bucketName+"-b"),
+				),
+			},
+			// Removing the new name renames the bucket back, so it can be
+			// cleaned up under its original name
+			{
+				Config: testAccRadosgwS3BucketLinkConfig_rename(bucketName, userID, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("radosgw_s3_bucket_link.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("radosgw_s3_bucket_link.test", "new_bucket_name"),
+				),
+			},
 		},
 	})
 }
@@ -87,3 +316,64 @@ resource "radosgw_s3_bucket_link" "test" {
 }
 `, userID, bucketName)
 }
+
+func testAccRadosgwS3BucketLinkConfig_tenant(bucketName, userID, tenant string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  tenant       = %q
+  display_name = "Test Tenant User for Bucket Link"
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_link" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  uid           = format("%%s$%%s", radosgw_iam_user.test.tenant, radosgw_iam_user.test.user_id)
+  unlink_to_uid = "admin"
+}
+`, userID, tenant, bucketName)
+}
+
+func testAccRadosgwS3BucketLinkConfig_bucketID(bucketName, userID, bucketID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Bucket Link"
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_link" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  bucket_id     = %q
+  uid           = radosgw_iam_user.test.user_id
+  unlink_to_uid = "admin"
+}
+`, userID, bucketName, bucketID)
+}
+
+func testAccRadosgwS3BucketLinkConfig_rename(bucketName, userID, newBucketName string) string {
+	newBucketNameAttr := ""
+	if newBucketName != "" {
+		newBucketNameAttr = fmt.Sprintf("new_bucket_name = %q", newBucketName)
+	}
+
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Test User for Bucket Link"
+}
+
+resource "radosgw_s3_bucket_link" "test" {
+  bucket        = %q
+  uid           = radosgw_iam_user.test.user_id
+  unlink_to_uid = "admin"
+  %s
+}
+`, userID, bucketName, newBucketNameAttr)
+}
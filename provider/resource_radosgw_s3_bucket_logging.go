@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketLoggingResource{}
+var _ resource.ResourceWithImportState = &BucketLoggingResource{}
+var _ resource.ResourceWithModifyPlan = &BucketLoggingResource{}
+
+func NewS3BucketLoggingResource() resource.Resource {
+	return &BucketLoggingResource{}
+}
+
+// BucketLoggingResource defines the resource implementation.
+type BucketLoggingResource struct {
+	client *RadosgwClient
+}
+
+// BucketLoggingResourceModel describes the resource data model.
+type BucketLoggingResourceModel struct {
+	Bucket       types.String `tfsdk:"bucket"`
+	Tenant       types.String `tfsdk:"tenant"`
+	TargetBucket types.String `tfsdk:"target_bucket"`
+	TargetPrefix types.String `tfsdk:"target_prefix"`
+	ID           types.String `tfsdk:"id"`
+}
+
+func (r *BucketLoggingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_logging"
+}
+
+func (r *BucketLoggingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the access logging configuration of an S3 bucket in RadosGW.
+
+When logging is enabled, RadosGW writes a log object for the requests made to the source bucket into the target bucket,
+under the configured prefix.
+
+~> **Note:** Bucket logging requires Ceph Tentacle (20.x) or higher.
+
+~> **Important:** The target bucket must exist, belong to the same tenant as the source bucket and must not have
+logging enabled itself. Destroying this resource disables logging on the source bucket; log objects already written
+to the target bucket are kept.`,
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the source bucket.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket the log objects are written to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"target_prefix": schema.StringAttribute{
+				MarkdownDescription: "The prefix of the log object keys in the target bucket. Defaults to an empty prefix.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name (used as the resource ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketLoggingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketLoggingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.client.checkS3Operations(&resp.Diagnostics, "Managing bucket logging", "GetBucketLogging", "PutBucketLogging")
+}
+
+func (r *BucketLoggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putBucketLogging(ctx, bucket, plan.TargetBucket.ValueString(), plan.TargetPrefix.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Bucket Logging",
+			fmt.Sprintf("Could not enable logging for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Created bucket logging", map[string]any{
+		"bucket":        bucket,
+		"target_bucket": plan.TargetBucket.ValueString(),
+		"target_prefix": plan.TargetPrefix.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketLoggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketLoggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	output, err := r.client.S3.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "NoSuchBucket" {
+			tflog.Info(ctx, "Bucket not found, removing bucket logging from state", map[string]any{
+				"bucket": bucket,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Logging",
+			fmt.Sprintf("Could not read logging configuration for bucket %s: %s", bucket, s3OperationError("GetBucketLogging", err).Error()),
+		)
+		return
+	}
+
+	if output.LoggingEnabled == nil {
+		tflog.Info(ctx, "Bucket logging is disabled, removing from state", map[string]any{
+			"bucket": bucket,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.TargetBucket = types.StringValue(aws.ToString(output.LoggingEnabled.TargetBucket))
+	state.TargetPrefix = types.StringValue(aws.ToString(output.LoggingEnabled.TargetPrefix))
+	state.ID = types.StringValue(bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketLoggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putBucketLogging(ctx, bucket, plan.TargetBucket.ValueString(), plan.TargetPrefix.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Bucket Logging",
+			fmt.Sprintf("Could not update logging for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Debug(ctx, "Updated bucket logging", map[string]any{
+		"bucket":        bucket,
+		"target_bucket": plan.TargetBucket.ValueString(),
+		"target_prefix": plan.TargetPrefix.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketLoggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BucketLoggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	// An empty logging status disables logging
+	_, err := r.client.S3.PutBucketLogging(ctx, &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucket),
+		BucketLoggingStatus: &s3types.BucketLoggingStatus{},
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "NoSuchBucket" {
+			tflog.Info(ctx, "Bucket already deleted", map[string]any{
+				"bucket": bucket,
+			})
+			return
+		}
+		err = zoneWriteError(ctx, r.client.Admin, s3OperationError("PutBucketLogging", err))
+		resp.Diagnostics.AddError(
+			"Error Deleting Bucket Logging",
+			fmt.Sprintf("Could not disable logging for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket logging", map[string]any{
+		"bucket": bucket,
+	})
+}
+
+func (r *BucketLoggingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
+}
+
+// putBucketLogging enables logging of a bucket into the target bucket.
+func (r *BucketLoggingResource) putBucketLogging(ctx context.Context, bucket, targetBucket, targetPrefix string) error {
+	_, err := r.client.S3.PutBucketLogging(ctx, &s3.PutBucketLoggingInput{
+		Bucket: aws.String(bucket),
+		BucketLoggingStatus: &s3types.BucketLoggingStatus{
+			LoggingEnabled: &s3types.LoggingEnabled{
+				TargetBucket: aws.String(targetBucket),
+				TargetPrefix: aws.String(targetPrefix),
+			},
+		},
+	})
+	if err != nil {
+		return zoneWriteError(ctx, r.client.Admin, s3OperationError("PutBucketLogging", err))
+	}
+	return nil
+}
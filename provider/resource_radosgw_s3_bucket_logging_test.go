@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketLogging_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+	logBucketName := randomName("tf-acc-logs")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_BucketLogging) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketLoggingConfig_basic(bucketName, logBucketName, "logs/"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_logging.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_logging.test", "target_bucket", logBucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_logging.test", "target_prefix", "logs/"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_logging.test", "id", bucketName),
+				),
+			},
+			{
+				Config: testAccRadosgwS3BucketLoggingConfig_basic(bucketName, logBucketName, "access/"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_logging.test", "target_prefix", "access/"),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      "radosgw_s3_bucket_logging.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketLoggingConfig_basic(bucketName, logBucketName, targetPrefix string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket" "logs" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_logging" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  target_bucket = radosgw_s3_bucket.logs.bucket
+  target_prefix = %q
+}
+`, bucketName, logBucketName, targetPrefix)
+}
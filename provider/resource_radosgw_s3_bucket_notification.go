@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -38,6 +37,7 @@ type S3BucketNotificationResource struct {
 // S3BucketNotificationResourceModel describes the resource data model.
 type S3BucketNotificationResourceModel struct {
 	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
 	Topic  types.List   `tfsdk:"topic"`
 }
 
@@ -78,6 +78,13 @@ func (r *S3BucketNotificationResource) Schema(ctx context.Context, req resource.
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -171,7 +178,7 @@ func (r *S3BucketNotificationResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	// Build the notification configuration from the plan
 	notifConfig, diags := r.buildNotificationConfiguration(ctx, plan)
@@ -186,6 +193,7 @@ func (r *S3BucketNotificationResource) Create(ctx context.Context, req resource.
 		NotificationConfiguration: notifConfig,
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Creating Bucket Notification",
 			fmt.Sprintf("Could not set notification configuration on bucket %s: %s", bucket, err.Error()),
@@ -206,7 +214,6 @@ func (r *S3BucketNotificationResource) Create(ctx context.Context, req resource.
 	}
 
 	// Flatten API response back to state
-	plan.Bucket = types.StringValue(bucket)
 	topicList, diags := flattenTopicConfigurations(ctx, output.TopicConfigurations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -229,7 +236,7 @@ func (r *S3BucketNotificationResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	output, err := r.client.S3.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{
 		Bucket: aws.String(bucket),
@@ -261,7 +268,6 @@ func (r *S3BucketNotificationResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	state.Bucket = types.StringValue(bucket)
 	topicList, diags := flattenTopicConfigurations(ctx, output.TopicConfigurations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -280,7 +286,7 @@ func (r *S3BucketNotificationResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	// Build the notification configuration from the plan
 	notifConfig, diags := r.buildNotificationConfiguration(ctx, plan)
@@ -296,6 +302,7 @@ func (r *S3BucketNotificationResource) Update(ctx context.Context, req resource.
 		NotificationConfiguration: &s3types.NotificationConfiguration{},
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Updating Bucket Notification",
 			fmt.Sprintf("Could not clear existing notification configuration on bucket %s: %s", bucket, err.Error()),
@@ -309,6 +316,7 @@ func (r *S3BucketNotificationResource) Update(ctx context.Context, req resource.
 		NotificationConfiguration: notifConfig,
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Updating Bucket Notification",
 			fmt.Sprintf("Could not update notification configuration on bucket %s: %s", bucket, err.Error()),
@@ -328,7 +336,6 @@ func (r *S3BucketNotificationResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	plan.Bucket = types.StringValue(bucket)
 	topicList, diags := flattenTopicConfigurations(ctx, output.TopicConfigurations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -351,7 +358,7 @@ func (r *S3BucketNotificationResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	// Delete by putting an empty notification configuration
 	_, err := r.client.S3.PutBucketNotificationConfiguration(ctx, &s3.PutBucketNotificationConfigurationInput{
@@ -359,6 +366,7 @@ func (r *S3BucketNotificationResource) Delete(ctx context.Context, req resource.
 		NotificationConfiguration: &s3types.NotificationConfiguration{},
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		var apiErr smithy.APIError
 		if ok := errors.As(err, &apiErr); ok {
 			if apiErr.ErrorCode() == "NoSuchBucket" {
@@ -381,7 +389,8 @@ func (r *S3BucketNotificationResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *S3BucketNotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
 }
 
 // =============================================================================
@@ -35,6 +35,13 @@ func TestAccRadosgwS3BucketNotification_basic(t *testing.T) {
 				ImportStateId:                        bucketName,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket_notification.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   bucketName,
+			},
 		},
 	})
 }
@@ -72,7 +79,7 @@ func TestAccRadosgwS3BucketNotification_multipleTopics(t *testing.T) {
 	topicName2 := randomName("tf-acc-topic")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_MultipleNotificationTopics) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
 		Steps: []resource.TestStep{
@@ -95,7 +102,7 @@ func TestAccRadosgwS3BucketNotification_update(t *testing.T) {
 	topicName2 := randomName("tf-acc-topic")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_MultipleNotificationTopics) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
 		Steps: []resource.TestStep{
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketOwnershipControlsResource{}
+var _ resource.ResourceWithImportState = &BucketOwnershipControlsResource{}
+var _ resource.ResourceWithModifyPlan = &BucketOwnershipControlsResource{}
+
+func NewS3BucketOwnershipControlsResource() resource.Resource {
+	return &BucketOwnershipControlsResource{}
+}
+
+// BucketOwnershipControlsResource defines the resource implementation.
+type BucketOwnershipControlsResource struct {
+	client *RadosgwClient
+}
+
+// BucketOwnershipControlsResourceModel describes the resource data model.
+type BucketOwnershipControlsResourceModel struct {
+	Bucket          types.String `tfsdk:"bucket"`
+	Tenant          types.String `tfsdk:"tenant"`
+	ObjectOwnership types.String `tfsdk:"object_ownership"`
+	ID              types.String `tfsdk:"id"`
+}
+
+func (r *BucketOwnershipControlsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_ownership_controls"
+}
+
+func (r *BucketOwnershipControlsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the object ownership controls of an S3 bucket in RadosGW.
+
+By default, an object belongs to the user who uploaded it, so objects uploaded by other users through a bucket policy
+are unreadable by the bucket owner. S3 has no default object ACL; object ownership controls solve this instead:
+- ` + "`BucketOwnerEnforced`" + `: ACLs are disabled and the bucket owner owns every object.
+- ` + "`BucketOwnerPreferred`" + `: the bucket owner owns objects uploaded with the ` + "`bucket-owner-full-control`" + ` canned ACL.
+- ` + "`ObjectWriter`" + `: the uploader owns the object (the default behavior).
+
+~> **Note:** Object ownership controls require Ceph Squid (19.x) or higher.
+
+~> **Important:** With ` + "`BucketOwnerEnforced`" + `, requests that set ACLs other than ` + "`bucket-owner-full-control`" + ` are rejected, so ` + "`radosgw_s3_bucket_acl`" + ` cannot be used on the bucket. Destroying this resource deletes the ownership controls and restores the default behavior.`,
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_ownership": schema.StringAttribute{
+				MarkdownDescription: "The object ownership setting. Valid values: `BucketOwnerEnforced`, `BucketOwnerPreferred`, `ObjectWriter`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(s3types.ObjectOwnershipBucketOwnerEnforced),
+						string(s3types.ObjectOwnershipBucketOwnerPreferred),
+						string(s3types.ObjectOwnershipObjectWriter),
+					),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name (used as the resource ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketOwnershipControlsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketOwnershipControlsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.client.checkS3Operations(&resp.Diagnostics, "Managing bucket ownership controls", "GetBucketOwnershipControls", "PutBucketOwnershipControls")
+}
+
+func (r *BucketOwnershipControlsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketOwnershipControlsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putOwnershipControls(ctx, bucket, plan.ObjectOwnership.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Bucket Ownership Controls",
+			fmt.Sprintf("Could not set ownership controls for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Created bucket ownership controls", map[string]any{
+		"bucket":           bucket,
+		"object_ownership": plan.ObjectOwnership.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketOwnershipControlsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketOwnershipControlsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	output, err := r.client.S3.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok {
+			if apiErr.ErrorCode() == "OwnershipControlsNotFoundError" || apiErr.ErrorCode() == "NoSuchBucket" {
+				tflog.Info(ctx, "Bucket ownership controls not found, removing from state", map[string]any{
+					"bucket": bucket,
+				})
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Ownership Controls",
+			fmt.Sprintf("Could not read ownership controls for bucket %s: %s", bucket, s3OperationError("GetBucketOwnershipControls", err).Error()),
+		)
+		return
+	}
+
+	if output.OwnershipControls == nil || len(output.OwnershipControls.Rules) == 0 {
+		tflog.Info(ctx, "Bucket ownership controls are empty, removing from state", map[string]any{
+			"bucket": bucket,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ObjectOwnership = types.StringValue(string(output.OwnershipControls.Rules[0].ObjectOwnership))
+	state.ID = types.StringValue(bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketOwnershipControlsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketOwnershipControlsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putOwnershipControls(ctx, bucket, plan.ObjectOwnership.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Bucket Ownership Controls",
+			fmt.Sprintf("Could not update ownership controls for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Debug(ctx, "Updated bucket ownership controls", map[string]any{
+		"bucket":           bucket,
+		"object_ownership": plan.ObjectOwnership.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketOwnershipControlsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BucketOwnershipControlsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	_, err := r.client.S3.DeleteBucketOwnershipControls(ctx, &s3.DeleteBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		// Ignore errors if bucket or ownership controls don't exist
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok {
+			if apiErr.ErrorCode() == "OwnershipControlsNotFoundError" || apiErr.ErrorCode() == "NoSuchBucket" {
+				tflog.Info(ctx, "Bucket or ownership controls already deleted", map[string]any{
+					"bucket": bucket,
+				})
+				return
+			}
+		}
+		err = zoneWriteError(ctx, r.client.Admin, s3OperationError("DeleteBucketOwnershipControls", err))
+		resp.Diagnostics.AddError(
+			"Error Deleting Bucket Ownership Controls",
+			fmt.Sprintf("Could not delete ownership controls for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket ownership controls", map[string]any{
+		"bucket": bucket,
+	})
+}
+
+func (r *BucketOwnershipControlsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
+}
+
+// putOwnershipControls sets the object ownership rule of a bucket.
+func (r *BucketOwnershipControlsResource) putOwnershipControls(ctx context.Context, bucket, objectOwnership string) error {
+	_, err := r.client.S3.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+		OwnershipControls: &s3types.OwnershipControls{
+			Rules: []s3types.OwnershipControlsRule{
+				{ObjectOwnership: s3types.ObjectOwnership(objectOwnership)},
+			},
+		},
+	})
+	if err != nil {
+		return zoneWriteError(ctx, r.client.Admin, s3OperationError("PutBucketOwnershipControls", err))
+	}
+	return nil
+}
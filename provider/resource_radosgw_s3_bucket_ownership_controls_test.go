@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRadosgwS3BucketOwnershipControls_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_BucketOwnershipControls) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketOwnershipControlsConfig_basic(bucketName, "BucketOwnerPreferred"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_ownership_controls.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_ownership_controls.test", "object_ownership", "BucketOwnerPreferred"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_ownership_controls.test", "id", bucketName),
+				),
+			},
+			{
+				Config: testAccRadosgwS3BucketOwnershipControlsConfig_basic(bucketName, "BucketOwnerEnforced"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_ownership_controls.test", "object_ownership", "BucketOwnerEnforced"),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      "radosgw_s3_bucket_ownership_controls.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketOwnershipControlsConfig_basic(bucketName, objectOwnership string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_ownership_controls" "test" {
+  bucket           = radosgw_s3_bucket.test.bucket
+  object_ownership = %q
+}
+`, bucketName, objectOwnership)
+}
@@ -5,19 +5,108 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// maxBucketPolicySize is the maximum size of a bucket policy in bytes, as
+// enforced by Amazon S3 on the policy without whitespace.
+const maxBucketPolicySize = 20 * 1024
+
+// bucketPolicyAWSPrincipalPattern matches the AWS principals RadosGW accepts:
+// users, roles and the root of a tenant or account, e.g.
+// arn:aws:iam:::user/uid or arn:aws:iam::tenant:role/name.
+var bucketPolicyAWSPrincipalPattern = regexp.MustCompile(`^arn:aws:iam::[^:]*:(root|user/.+|role/.+)$`)
+
+// bucketPolicyValidator validates the size and the principals of a bucket policy.
+type bucketPolicyValidator struct{}
+
+func (v bucketPolicyValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("validates that the policy is valid JSON of at most %d bytes with principals RadosGW accepts", maxBucketPolicySize)
+}
+
+func (v bucketPolicyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v bucketPolicyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	normalized, err := normalizeJSONString(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Policy JSON",
+			fmt.Sprintf("The policy is not valid JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(normalized) > maxBucketPolicySize {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Bucket Policy Too Large",
+			fmt.Sprintf("The policy is %d bytes without whitespace, the maximum is %d bytes.", len(normalized), maxBucketPolicySize),
+		)
+	}
+
+	_, statements, err := parsePolicyDocument(normalized)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Bucket Policy",
+			fmt.Sprintf("The policy statements could not be decoded: %s", err.Error()),
+		)
+		return
+	}
+
+	for i, stmt := range statements {
+		for _, element := range []json.RawMessage{stmt.Principal, stmt.NotPrincipal} {
+			for _, principal := range invalidAWSPrincipals(element) {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Invalid Bucket Policy Principal",
+					fmt.Sprintf("Statement %d has the AWS principal %q, which RadosGW does not accept. Use \"*\" or an ARN "+
+						"such as arn:aws:iam:::user/uid, arn:aws:iam::tenant:user/uid, arn:aws:iam::tenant:role/name or "+
+						"arn:aws:iam::tenant:root.", i, principal),
+				)
+			}
+		}
+	}
+}
+
+// invalidAWSPrincipals returns the AWS principals of a Principal or
+// NotPrincipal element that RadosGW does not accept.
+func invalidAWSPrincipals(element json.RawMessage) []string {
+	var byType map[string]json.RawMessage
+	if len(element) == 0 || json.Unmarshal(element, &byType) != nil {
+		return nil
+	}
+
+	var invalid []string
+	for _, principal := range policyStringList(byType["AWS"]) {
+		if principal != "*" && !bucketPolicyAWSPrincipalPattern.MatchString(principal) {
+			invalid = append(invalid, principal)
+		}
+	}
+	return invalid
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketPolicyResource{}
 var _ resource.ResourceWithImportState = &BucketPolicyResource{}
@@ -33,9 +122,15 @@ type BucketPolicyResource struct {
 
 // BucketPolicyResourceModel describes the resource data model.
 type BucketPolicyResourceModel struct {
-	Bucket types.String `tfsdk:"bucket"`
-	Policy types.String `tfsdk:"policy"`
-	ID     types.String `tfsdk:"id"`
+	Bucket  types.String `tfsdk:"bucket"`
+	Tenant  types.String `tfsdk:"tenant"`
+	Policy  types.String `tfsdk:"policy"`
+	OnDrift types.String `tfsdk:"on_drift"`
+	ID      types.String `tfsdk:"id"`
+
+	S3AccessKey       types.String `tfsdk:"s3_access_key"`
+	S3SecretKey       types.String `tfsdk:"s3_secret_key"`
+	S3AddressingStyle types.String `tfsdk:"s3_addressing_style"`
 }
 
 func (r *BucketPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,9 +157,33 @@ but are attached directly to buckets rather than users or roles.
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"policy": schema.StringAttribute{
-				MarkdownDescription: "The policy document in JSON format. Use `jsonencode()` or the `radosgw_iam_policy_document` data source to generate this.",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The policy document in JSON format. Use `jsonencode()` or the `radosgw_iam_policy_document` data source to generate this. "+
+					"Must be at most %d bytes without whitespace, and AWS principals must be `*` or user, role or root ARNs "+
+					"such as `arn:aws:iam:::user/uid`.", maxBucketPolicySize),
+				Required: true,
+				Validators: []validator.String{
+					bucketPolicyValidator{},
+				},
+			},
+			"on_drift": schema.StringAttribute{
+				MarkdownDescription: "What to do when the policy was changed outside of Terraform. " +
+					"With `overwrite`, the change shows up in the plan and the next apply restores the configured policy. " +
+					"With `error`, refreshing fails until the change is reverted, or until an apply with `-refresh=false` restores the configured policy. " +
+					"Default is `overwrite`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("overwrite"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("overwrite", "error"),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The bucket name (used as the resource ID).",
@@ -75,6 +194,7 @@ but are attached directly to buckets rather than users or roles.
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, s3ClientAttributes())
 }
 
 func (r *BucketPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -102,7 +222,7 @@ func (r *BucketPolicyResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 	policy := plan.Policy.ValueString()
 
 	// Normalize the policy JSON
@@ -116,11 +236,12 @@ func (r *BucketPolicyResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Put the bucket policy
-	_, err = r.client.S3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+	_, err = r.client.s3ClientFor(plan.S3AccessKey, plan.S3SecretKey, plan.S3AddressingStyle).PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 		Bucket: aws.String(bucket),
 		Policy: aws.String(normalizedPolicy),
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Creating Bucket Policy",
 			fmt.Sprintf("Could not create bucket policy for bucket %s: %s", bucket, err.Error()),
@@ -146,10 +267,10 @@ func (r *BucketPolicyResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	// Get the bucket policy
-	output, err := r.client.S3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+	output, err := r.client.s3ClientFor(state.S3AccessKey, state.S3SecretKey, state.S3AddressingStyle).GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
@@ -193,6 +314,30 @@ func (r *BucketPolicyResource) Read(ctx context.Context, req resource.ReadReques
 		normalizedPolicy = *output.Policy
 	}
 
+	// Policies changed outside of Terraform are restored by the next apply,
+	// unless the configuration asks to fail instead
+	if !state.Policy.IsNull() && state.Policy.ValueString() != normalizedPolicy {
+		if state.OnDrift.ValueString() == "error" {
+			resp.Diagnostics.AddError(
+				"Bucket Policy Changed Outside Terraform",
+				fmt.Sprintf("The policy of bucket %s differs from the policy in state and on_drift is \"error\".\n\n"+
+					"Expected: %s\nFound: %s\n\n"+
+					"Revert the change, run an apply with -refresh=false to restore the configured policy, "+
+					"or set on_drift to \"overwrite\".",
+					bucket, state.Policy.ValueString(), normalizedPolicy),
+			)
+			return
+		}
+		tflog.Info(ctx, "Bucket policy changed outside of Terraform", map[string]any{
+			"bucket": bucket,
+		})
+	}
+
+	// Imported policies have no on_drift yet
+	if state.OnDrift.IsNull() {
+		state.OnDrift = types.StringValue("overwrite")
+	}
+
 	state.Policy = types.StringValue(normalizedPolicy)
 	state.ID = types.StringValue(bucket)
 
@@ -207,7 +352,7 @@ func (r *BucketPolicyResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 	policy := plan.Policy.ValueString()
 
 	// Normalize the policy JSON
@@ -221,11 +366,12 @@ func (r *BucketPolicyResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Put the bucket policy (same as create - PutBucketPolicy is idempotent)
-	_, err = r.client.S3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+	_, err = r.client.s3ClientFor(plan.S3AccessKey, plan.S3SecretKey, plan.S3AddressingStyle).PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 		Bucket: aws.String(bucket),
 		Policy: aws.String(normalizedPolicy),
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Updating Bucket Policy",
 			fmt.Sprintf("Could not update bucket policy for bucket %s: %s", bucket, err.Error()),
@@ -251,13 +397,14 @@ func (r *BucketPolicyResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	// Delete the bucket policy
-	_, err := r.client.S3.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+	_, err := r.client.s3ClientFor(state.S3AccessKey, state.S3SecretKey, state.S3AddressingStyle).DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		// Ignore errors if bucket or policy doesn't exist
 		var apiErr smithy.APIError
 		if ok := errors.As(err, &apiErr); ok {
@@ -281,8 +428,8 @@ func (r *BucketPolicyResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *BucketPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by bucket name
-	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
 }
 
 // normalizeJSONString parses and re-encodes JSON to normalize whitespace and key ordering.
@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -32,6 +41,12 @@ func TestAccRadosgwS3BucketPolicy_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
 		},
 	})
 }
@@ -62,8 +77,161 @@ func TestAccRadosgwS3BucketPolicy_update(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3BucketPolicy_tenant(t *testing.T) {
+	t.Parallel()
+
+	tenant := "tfacctenant"
+	userID := randomName("tf-acc-user")
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			// The tenant user and its keys must exist before the tenant
+			// provider can be configured
+			{
+				Config: testAccRadosgwS3BucketPolicyConfig_tenantUser(tenant, userID),
+			},
+			{
+				Config: testAccRadosgwS3BucketPolicyConfig_tenant(tenant, userID, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "tenant", tenant),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_policy.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_policy.test", "tenant", tenant),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_policy.test", "id", tenant+":"+bucketName),
+					resource.TestCheckResourceAttrSet("radosgw_s3_bucket_policy.test", "policy"),
+				),
+			},
+			// Tenant-qualified import IDs populate the tenant attribute
+			{
+				Config:                               testAccRadosgwS3BucketPolicyConfig_tenant(tenant, userID, bucketName),
+				ResourceName:                         "radosgw_s3_bucket.test",
+				ImportState:                          true,
+				ImportStateId:                        tenant + ":" + bucketName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+				ImportStateVerifyIgnore:              []string{"force_destroy"},
+			},
+			{
+				Config:            testAccRadosgwS3BucketPolicyConfig_tenant(tenant, userID, bucketName),
+				ResourceName:      "radosgw_s3_bucket_policy.test",
+				ImportState:       true,
+				ImportStateId:     tenant + "/" + bucketName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketPolicy_onDriftError(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketPolicyConfig_onDrift(bucketName, "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_policy.test", "on_drift", "error"),
+				),
+			},
+			// A policy changed outside of Terraform fails the refresh
+			{
+				PreConfig: func() {
+					if err := testAccPutBucketPolicy(bucketName); err != nil {
+						t.Fatalf("error changing the bucket policy out of band: %s", err)
+					}
+				},
+				Config:      testAccRadosgwS3BucketPolicyConfig_onDrift(bucketName, "error"),
+				ExpectError: regexp.MustCompile(`Bucket Policy Changed Outside Terraform`),
+			},
+			// With overwrite, the next apply restores the configured policy
+			{
+				Config: testAccRadosgwS3BucketPolicyConfig_onDrift(bucketName, "overwrite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_policy.test", "on_drift", "overwrite"),
+					resource.TestCheckResourceAttrWith("radosgw_s3_bucket_policy.test", "policy", func(value string) error {
+						if !strings.Contains(value, "s3:GetObject") {
+							return fmt.Errorf("expected the configured policy to be restored, got %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestBucketPolicyValidator(t *testing.T) {
+	t.Parallel()
+
+	statement := `{"Effect":"Allow","Principal":%s,"Action":"s3:GetObject","Resource":"arn:aws:s3:::bucket/*"}`
+	testCases := map[string]struct {
+		policy      string
+		expectError bool
+	}{
+		"wildcard":         {policy: fmt.Sprintf(statement, `"*"`)},
+		"aws wildcard":     {policy: fmt.Sprintf(statement, `{"AWS":"*"}`)},
+		"user":             {policy: fmt.Sprintf(statement, `{"AWS":["arn:aws:iam:::user/uid"]}`)},
+		"tenant user":      {policy: fmt.Sprintf(statement, `{"AWS":"arn:aws:iam::tenant:user/uid"}`)},
+		"role":             {policy: fmt.Sprintf(statement, `{"AWS":"arn:aws:iam::RGW11111111111111111:role/app"}`)},
+		"root":             {policy: fmt.Sprintf(statement, `{"AWS":"arn:aws:iam::tenant:root"}`)},
+		"federated":        {policy: fmt.Sprintf(statement, `{"Federated":"arn:aws:iam:::oidc-provider/idp.example.com"}`)},
+		"bare user id":     {policy: fmt.Sprintf(statement, `{"AWS":"uid"}`), expectError: true},
+		"bare account id":  {policy: fmt.Sprintf(statement, `{"AWS":["123456789012"]}`), expectError: true},
+		"invalid json":     {policy: `{"Statement":`, expectError: true},
+		"too large":        {policy: fmt.Sprintf(`{"Statement":[%s],"Id":"%s"}`, fmt.Sprintf(statement, `"*"`), strings.Repeat("x", maxBucketPolicySize)), expectError: true},
+		"single statement": {policy: fmt.Sprintf(`{"Statement":%s}`, fmt.Sprintf(statement, `{"AWS":"uid"}`)), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := testCase.policy
+			if !strings.Contains(policy, `"Statement"`) {
+				policy = fmt.Sprintf(`{"Version":"2012-10-17","Statement":[%s]}`, policy)
+			}
+
+			req := validator.StringRequest{
+				Path:        path.Root("policy"),
+				ConfigValue: types.StringValue(policy),
+			}
+			resp := &validator.StringResponse{}
+			bucketPolicyValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
 // Helper functions
 
+// testAccPutBucketPolicy replaces the policy of a bucket without Terraform.
+func testAccPutBucketPolicy(bucketName string) error {
+	s3Client := s3.NewFromConfig(aws.Config{
+		Region:      "default",
+		Credentials: credentials.NewStaticCredentialsProvider(testAccAdminClient.AccessKey, testAccAdminClient.SecretKey, ""),
+	}, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(testAccAdminClient.Endpoint)
+		o.UsePathStyle = true
+	})
+
+	_, err := s3Client.PutBucketPolicy(testCtx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::%s"]}]}`, bucketName)),
+	})
+	return err
+}
+
 func testAccCheckRadosgwS3BucketPolicyExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -83,6 +251,31 @@ func testAccCheckRadosgwS3BucketPolicyExists(resourceName string) resource.TestC
 
 // Test configurations
 
+func testAccRadosgwS3BucketPolicyConfig_onDrift(bucketName, onDrift string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_policy" "test" {
+  bucket   = radosgw_s3_bucket.test.bucket
+  on_drift = %q
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = ["s3:GetObject"]
+        Resource  = "arn:aws:s3:::${radosgw_s3_bucket.test.bucket}/*"
+      }
+    ]
+  })
+}
+`, bucketName, onDrift)
+}
+
 func testAccRadosgwS3BucketPolicyConfig_basic(bucketName string) string {
 	return providerConfig() + fmt.Sprintf(`
 resource "radosgw_s3_bucket" "test" {
@@ -133,3 +326,66 @@ resource "radosgw_s3_bucket_policy" "test" {
 }
 `, bucketName)
 }
+
+func testAccRadosgwS3BucketPolicyConfig_tenantUser(tenant, userID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "tenant" {
+  user_id      = %q
+  display_name = "Tenant User"
+  tenant       = %q
+}
+
+# The bucket resource reads bucket details through the Admin API
+resource "radosgw_iam_user_caps" "tenant" {
+  user_id = "${radosgw_iam_user.tenant.tenant}$${radosgw_iam_user.tenant.user_id}"
+
+  caps = [
+    {
+      type = "buckets"
+      perm = "*"
+    }
+  ]
+}
+
+resource "radosgw_iam_access_key" "tenant" {
+  user_id = "${radosgw_iam_user.tenant.tenant}$${radosgw_iam_user.tenant.user_id}"
+}
+`, userID, tenant)
+}
+
+func testAccRadosgwS3BucketPolicyConfig_tenant(tenant, userID, bucketName string) string {
+	return testAccRadosgwS3BucketPolicyConfig_tenantUser(tenant, userID) + fmt.Sprintf(`
+provider "radosgw" {
+  alias      = "tenant"
+  access_key = radosgw_iam_access_key.tenant.access_key
+  secret_key = radosgw_iam_access_key.tenant.secret_key
+}
+
+resource "radosgw_s3_bucket" "test" {
+  provider      = radosgw.tenant
+  bucket        = %q
+  tenant        = radosgw_iam_user.tenant.tenant
+  force_destroy = true
+
+  depends_on = [radosgw_iam_user_caps.tenant]
+}
+
+resource "radosgw_s3_bucket_policy" "test" {
+  provider = radosgw.tenant
+  bucket   = radosgw_s3_bucket.test.bucket
+  tenant   = radosgw_s3_bucket.test.tenant
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = ["s3:GetObject"]
+        Resource  = "arn:aws:s3::${radosgw_s3_bucket.test.tenant}:${radosgw_s3_bucket.test.bucket}/*"
+      }
+    ]
+  })
+}
+`, bucketName)
+}
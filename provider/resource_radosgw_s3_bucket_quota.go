@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketQuotaResource{}
+var _ resource.ResourceWithImportState = &BucketQuotaResource{}
+
+func NewS3BucketQuotaResource() resource.Resource {
+	return &BucketQuotaResource{}
+}
+
+// BucketQuotaResource manages the quota of an individual bucket, independently
+// of the radosgw_s3_bucket resource.
+type BucketQuotaResource struct {
+	client *RadosgwClient
+}
+
+// BucketQuotaResourceModel describes the resource data model.
+type BucketQuotaResourceModel struct {
+	Bucket     types.String `tfsdk:"bucket"`
+	Tenant     types.String `tfsdk:"tenant"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	MaxSize    types.String `tfsdk:"max_size"`
+	MaxObjects types.Int64  `tfsdk:"max_objects"`
+	ID         types.String `tfsdk:"id"`
+}
+
+func (r *BucketQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_quota"
+}
+
+func (r *BucketQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the quota of an individual bucket in RadosGW via the Admin API.
+
+Unlike the ` + "`bucket_quota`" + ` attribute of ` + "`radosgw_s3_bucket`" + `, this resource works for buckets created outside
+Terraform or owned by other users. The quota applies to this bucket only; per-bucket quotas applied to every bucket of a
+user are managed by the ` + "`radosgw_iam_quota`" + ` resource with ` + "`type = \"bucket\"`" + `.
+
+Upon deletion, the quota is disabled (not removed, as quotas are properties of buckets).
+
+~> **Note:** Do not set ` + "`bucket_quota`" + ` on a ` + "`radosgw_s3_bucket`" + ` resource managing the same bucket, or the two will overwrite each other.`,
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the quota is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"max_size": schema.StringAttribute{
+				MarkdownDescription: "Maximum size. " + quotaSizeDescription + " Use `-1` for unlimited. Default: `-1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("-1"),
+				Validators: []validator.String{
+					quotaSizeValidator{},
+				},
+			},
+			"max_objects": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of objects. Use `-1` for unlimited. Default: `-1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(-1),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name (used as the resource ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	quota, err := bucketQuotaSpec(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "Invalid Quota Size", err.Error())
+		return
+	}
+
+	err = setIndividualBucketQuota(ctx, r.client.Admin, data.Tenant.ValueString(), data.Bucket.ValueString(), quota)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Bucket Quota",
+			fmt.Sprintf("Could not set quota on bucket %s: %s", bucket, adminError(err, "buckets=write").Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Created bucket quota", map[string]any{
+		"bucket": bucket,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	info, err := r.client.Admin.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())})
+	if err != nil {
+		if errors.Is(err, admin.ErrNoSuchBucket) {
+			tflog.Info(ctx, "Bucket no longer exists, removing quota from state", map[string]any{
+				"bucket": bucket,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Quota",
+			fmt.Sprintf("Could not read quota of bucket %s: %s", bucket, adminError(err, "buckets=read").Error()),
+		)
+		return
+	}
+
+	quota := info.BucketQuota
+	if quota.Enabled != nil {
+		data.Enabled = types.BoolValue(*quota.Enabled)
+	}
+	if quota.MaxSize != nil {
+		data.MaxSize = quotaSizeValue(data.MaxSize, *quota.MaxSize)
+	} else {
+		data.MaxSize = quotaSizeValue(data.MaxSize, -1)
+	}
+	if quota.MaxObjects != nil {
+		data.MaxObjects = types.Int64Value(*quota.MaxObjects)
+	} else {
+		data.MaxObjects = types.Int64Value(-1)
+	}
+	data.ID = types.StringValue(bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	quota, err := bucketQuotaSpec(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "Invalid Quota Size", err.Error())
+		return
+	}
+
+	err = setIndividualBucketQuota(ctx, r.client.Admin, data.Tenant.ValueString(), data.Bucket.ValueString(), quota)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Bucket Quota",
+			fmt.Sprintf("Could not update quota on bucket %s: %s", bucket, adminError(err, "buckets=write").Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	// Disable quota on delete (quotas cannot be removed, only disabled)
+	enabled := false
+	maxSize := int64(-1)
+	maxObjects := int64(-1)
+
+	err := setIndividualBucketQuota(ctx, r.client.Admin, data.Tenant.ValueString(), data.Bucket.ValueString(), admin.QuotaSpec{
+		Enabled:    &enabled,
+		MaxSize:    &maxSize,
+		MaxObjects: &maxObjects,
+	})
+	if errors.Is(err, admin.ErrNoSuchBucket) {
+		tflog.Info(ctx, "Bucket already deleted, nothing to disable", map[string]any{
+			"bucket": bucket,
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Bucket Quota",
+			fmt.Sprintf("Could not disable quota on bucket %s: %s", bucket, adminError(err, "buckets=write").Error()),
+		)
+		return
+	}
+}
+
+func (r *BucketQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "bucket", "tenant:bucket" or "tenant/bucket"
+	importBucketState(ctx, req, resp)
+}
+
+// bucketQuotaSpec builds the quota spec of a bucket from the resource model.
+func bucketQuotaSpec(data BucketQuotaResourceModel) (admin.QuotaSpec, error) {
+	maxSize, err := parseQuotaSize(data.MaxSize.ValueString())
+	if err != nil {
+		return admin.QuotaSpec{}, err
+	}
+
+	enabled := data.Enabled.ValueBool()
+	maxObjects := int64(-1)
+	if !data.MaxObjects.IsNull() && !data.MaxObjects.IsUnknown() {
+		maxObjects = data.MaxObjects.ValueInt64()
+	}
+
+	return admin.QuotaSpec{
+		Enabled:    &enabled,
+		MaxSize:    &maxSize,
+		MaxObjects: &maxObjects,
+	}, nil
+}
+
+// setIndividualBucketQuota sets the quota of a single bucket. The Admin API
+// addresses the bucket through its owner, which is looked up first.
+func setIndividualBucketQuota(ctx context.Context, api *admin.API, tenant, bucket string, quota admin.QuotaSpec) error {
+	info, err := api.GetBucketInfo(ctx, admin.Bucket{Bucket: adminBucketName(tenant, bucket)})
+	if err != nil {
+		return fmt.Errorf("could not get bucket info: %w", err)
+	}
+
+	quota.UID = info.Owner
+	quota.Bucket = bucket
+	return api.SetIndividualBucketQuota(ctx, quota)
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSetIndividualBucketQuota(t *testing.T) {
+	t.Parallel()
+
+	var quotaQuery string
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"bucket":"data","tenant":"acme","owner":"acme$alice"}`
+		if req.Method == http.MethodPut {
+			quotaQuery = req.URL.RawQuery
+			body = ""
+		} else if got := req.URL.Query().Get("bucket"); got != "acme/data" {
+			t.Errorf("expected bucket info of acme/data, got %q", got)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})}
+	api, err := admin.New("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+	if err != nil {
+		t.Fatalf("could not create admin client: %s", err)
+	}
+
+	enabled := true
+	maxObjects := int64(100)
+	err = setIndividualBucketQuota(testCtx, api, "acme", "data", admin.QuotaSpec{Enabled: &enabled, MaxObjects: &maxObjects})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, param := range []string{"uid=acme%24alice", "bucket=data", "enabled=true", "max-objects=100"} {
+		if !strings.Contains(quotaQuery, param) {
+			t.Errorf("expected quota request to contain %q, got %q", param, quotaQuery)
+		}
+	}
+}
+
+func TestAccRadosgwS3BucketQuota_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketQuotaConfig_basic(bucketName, "1GiB", 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "max_size", "1GiB"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "max_objects", "1000"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "id", bucketName),
+				),
+			},
+			{
+				Config: testAccRadosgwS3BucketQuotaConfig_basic(bucketName, "-1", 500),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "max_size", "-1"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_quota.test", "max_objects", "500"),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      "radosgw_s3_bucket_quota.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketQuotaConfig_basic(bucketName, maxSize string, maxObjects int64) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_quota" "test" {
+  bucket      = radosgw_s3_bucket.test.bucket
+  max_size    = %q
+  max_objects = %d
+}
+`, bucketName, maxSize, maxObjects)
+}
@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketEncryptionResource{}
+var _ resource.ResourceWithImportState = &BucketEncryptionResource{}
+var _ resource.ResourceWithModifyPlan = &BucketEncryptionResource{}
+
+func NewS3BucketServerSideEncryptionConfigurationResource() resource.Resource {
+	return &BucketEncryptionResource{}
+}
+
+// BucketEncryptionResource defines the resource implementation.
+type BucketEncryptionResource struct {
+	client *RadosgwClient
+}
+
+// BucketEncryptionResourceModel describes the resource data model.
+type BucketEncryptionResourceModel struct {
+	Bucket         types.String `tfsdk:"bucket"`
+	Tenant         types.String `tfsdk:"tenant"`
+	SSEAlgorithm   types.String `tfsdk:"sse_algorithm"`
+	KMSMasterKeyID types.String `tfsdk:"kms_master_key_id"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (r *BucketEncryptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_server_side_encryption_configuration"
+}
+
+func (r *BucketEncryptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the default server-side encryption of an S3 bucket in RadosGW.
+
+Objects uploaded without encryption headers are encrypted with the bucket default:
+- ` + "`AES256`" + `: SSE-S3, with keys managed by the backend configured in ` + "`rgw_crypt_sse_s3_backend`" + `.
+- ` + "`aws:kms`" + `: SSE-KMS, with the key ` + "`kms_master_key_id`" + ` of the backend configured in ` + "`rgw_crypt_s3_kms_backend`" + ` (Vault, Barbican, KMIP or testing).
+
+~> **Note:** The Admin API does not report which encryption backends a gateway has configured, so the provider cannot check
+them at plan time. RadosGW rejects the configuration or the uploads when the backend or the key is unavailable.
+
+Destroying this resource deletes the default encryption configuration; objects already encrypted stay encrypted.`,
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sse_algorithm": schema.StringAttribute{
+				MarkdownDescription: "The server-side encryption algorithm. Valid values: `AES256`, `aws:kms`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(s3types.ServerSideEncryptionAes256),
+						string(s3types.ServerSideEncryptionAwsKms),
+					),
+				},
+			},
+			"kms_master_key_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the key in the KMS backend, e.g. the key name in Vault or the key UUID in " +
+					"Barbican. Only valid with `sse_algorithm = \"aws:kms\"`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The bucket name (used as the resource ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketEncryptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketEncryptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BucketEncryptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.KMSMasterKeyID.IsNull() && !plan.SSEAlgorithm.IsUnknown() &&
+		plan.SSEAlgorithm.ValueString() != string(s3types.ServerSideEncryptionAwsKms) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kms_master_key_id"),
+			"Invalid KMS Key",
+			fmt.Sprintf("kms_master_key_id can only be set with sse_algorithm = %q, got %q.",
+				s3types.ServerSideEncryptionAwsKms, plan.SSEAlgorithm.ValueString()),
+		)
+	}
+}
+
+func (r *BucketEncryptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putEncryption(ctx, bucket, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Bucket Encryption Configuration",
+			fmt.Sprintf("Could not set default encryption for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Trace(ctx, "Created bucket encryption configuration", map[string]any{
+		"bucket":        bucket,
+		"sse_algorithm": plan.SSEAlgorithm.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketEncryptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	output, err := r.client.S3.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isBucketEncryptionNotFoundError(err) {
+			tflog.Info(ctx, "Bucket encryption configuration not found, removing from state", map[string]any{
+				"bucket": bucket,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Bucket Encryption Configuration",
+			fmt.Sprintf("Could not read default encryption of bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	if output.ServerSideEncryptionConfiguration == nil || len(output.ServerSideEncryptionConfiguration.Rules) == 0 ||
+		output.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault == nil {
+		tflog.Info(ctx, "Bucket encryption configuration is empty, removing from state", map[string]any{
+			"bucket": bucket,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	encryption := output.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault
+	state.SSEAlgorithm = types.StringValue(string(encryption.SSEAlgorithm))
+	state.KMSMasterKeyID = types.StringNull()
+	if aws.ToString(encryption.KMSMasterKeyID) != "" {
+		state.KMSMasterKeyID = types.StringValue(aws.ToString(encryption.KMSMasterKeyID))
+	}
+	state.ID = types.StringValue(bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketEncryptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+
+	if err := r.putEncryption(ctx, bucket, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Bucket Encryption Configuration",
+			fmt.Sprintf("Could not update default encryption for bucket %s: %s", bucket, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(bucket)
+
+	tflog.Debug(ctx, "Updated bucket encryption configuration", map[string]any{
+		"bucket":        bucket,
+		"sse_algorithm": plan.SSEAlgorithm.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketEncryptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+
+	_, err := r.client.S3.DeleteBucketEncryption(ctx, &s3.DeleteBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isBucketEncryptionNotFoundError(err) {
+			tflog.Info(ctx, "Bucket or encryption configuration already deleted", map[string]any{
+				"bucket": bucket,
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Bucket Encryption Configuration",
+			fmt.Sprintf("Could not delete default encryption of bucket %s: %s", bucket, zoneWriteError(ctx, r.client.Admin, err).Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket encryption configuration", map[string]any{
+		"bucket": bucket,
+	})
+}
+
+func (r *BucketEncryptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
+}
+
+// putEncryption sets the default encryption of a bucket.
+func (r *BucketEncryptionResource) putEncryption(ctx context.Context, bucket string, plan BucketEncryptionResourceModel) error {
+	encryption := &s3types.ServerSideEncryptionByDefault{
+		SSEAlgorithm: s3types.ServerSideEncryption(plan.SSEAlgorithm.ValueString()),
+	}
+	if !plan.KMSMasterKeyID.IsNull() {
+		encryption.KMSMasterKeyID = aws.String(plan.KMSMasterKeyID.ValueString())
+	}
+
+	_, err := r.client.S3.PutBucketEncryption(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+			Rules: []s3types.ServerSideEncryptionRule{
+				{ApplyServerSideEncryptionByDefault: encryption},
+			},
+		},
+	})
+	if err != nil {
+		return zoneWriteError(ctx, r.client.Admin, bucketEncryptionError(plan.SSEAlgorithm.ValueString(), err))
+	}
+	return nil
+}
+
+// isBucketEncryptionNotFoundError reports whether err means the bucket or its
+// default encryption configuration does not exist.
+func isBucketEncryptionNotFoundError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ServerSideEncryptionConfigurationNotFoundError", "NoSuchBucket":
+			return true
+		}
+	}
+	return false
+}
+
+// bucketEncryptionError points at the gateway configuration when RadosGW
+// rejects a default encryption, usually because no backend is configured
+// for the algorithm.
+func bucketEncryptionError(algorithm string, err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.ErrorCode() {
+	case "InvalidArgument", "InvalidRequest", "NotImplemented":
+	default:
+		return err
+	}
+
+	option := "rgw_crypt_sse_s3_backend"
+	if algorithm == string(s3types.ServerSideEncryptionAwsKms) {
+		option = "rgw_crypt_s3_kms_backend"
+	}
+	return fmt.Errorf("%w (check that %s is configured on the gateway and the key exists)", err, option)
+}
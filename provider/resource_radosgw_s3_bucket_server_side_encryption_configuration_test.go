@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBucketEncryptionError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		algorithm    string
+		err          error
+		expectOption string
+	}{
+		"kms":        {algorithm: "aws:kms", err: &smithy.GenericAPIError{Code: "InvalidArgument"}, expectOption: "rgw_crypt_s3_kms_backend"},
+		"sse-s3":     {algorithm: "AES256", err: &smithy.GenericAPIError{Code: "NotImplemented"}, expectOption: "rgw_crypt_sse_s3_backend"},
+		"other code": {algorithm: "aws:kms", err: &smithy.GenericAPIError{Code: "AccessDenied"}},
+		"not api":    {algorithm: "aws:kms", err: errors.New("connection refused")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := bucketEncryptionError(testCase.algorithm, testCase.err)
+			if !errors.Is(err, testCase.err) {
+				t.Errorf("expected the error to wrap %v, got %v", testCase.err, err)
+			}
+			if testCase.expectOption == "" {
+				if err != testCase.err {
+					t.Errorf("expected the error unchanged, got %v", err)
+				}
+				return
+			}
+			if !strings.Contains(err.Error(), testCase.expectOption) {
+				t.Errorf("expected the error to mention %s, got %v", testCase.expectOption, err)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3BucketServerSideEncryptionConfiguration_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketServerSideEncryptionConfigurationConfig_basic(bucketName, "AES256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_server_side_encryption_configuration.test", "bucket", bucketName),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_server_side_encryption_configuration.test", "sse_algorithm", "AES256"),
+					resource.TestCheckNoResourceAttr("radosgw_s3_bucket_server_side_encryption_configuration.test", "kms_master_key_id"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket_server_side_encryption_configuration.test", "id", bucketName),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      "radosgw_s3_bucket_server_side_encryption_configuration.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3BucketServerSideEncryptionConfiguration_keyWithoutKMS(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "radosgw_s3_bucket_server_side_encryption_configuration" "test" {
+  bucket            = "some-bucket"
+  sse_algorithm     = "AES256"
+  kms_master_key_id = "my-key"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid KMS Key"),
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwS3BucketServerSideEncryptionConfigurationConfig_basic(bucketName, algorithm string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket = %q
+}
+
+resource "radosgw_s3_bucket_server_side_encryption_configuration" "test" {
+  bucket        = radosgw_s3_bucket.test.bucket
+  sse_algorithm = %q
+}
+`, bucketName, algorithm)
+}
@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -25,6 +35,9 @@ func TestAccRadosgwS3Bucket_basic(t *testing.T) {
 					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
 					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "bucket", bucketName),
 					resource.TestCheckResourceAttrSet("radosgw_s3_bucket.test", "owner"),
+					// The test gateway is a single writable zone
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "is_read_only", "false"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "zone_is_master", "true"),
 				),
 			},
 			// Import test - by bucket name
@@ -36,6 +49,13 @@ func TestAccRadosgwS3Bucket_basic(t *testing.T) {
 				ImportStateId:                        bucketName,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   bucketName,
+			},
 		},
 	})
 }
@@ -61,6 +81,110 @@ func TestAccRadosgwS3Bucket_forceDestroy(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3Bucket_forceDestroyS3(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketConfig_forceDestroyS3(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "force_destroy_mode", "s3"),
+				),
+			},
+			// More objects than fit in a single DeleteObjects request, with
+			// several versions and an incomplete multipart upload
+			{
+				PreConfig: func() {
+					if err := testAccPutBucketObjects(bucketName, 1100); err != nil {
+						t.Fatalf("error uploading objects: %s", err)
+					}
+				},
+				Config: testAccRadosgwS3BucketConfig_forceDestroyS3(bucketName),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3Bucket_maxObjectsOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketConfig_maxObjectsOnDestroy(bucketName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "max_objects_on_destroy", "2"),
+				),
+			},
+			// The guard stops force_destroy from deleting more objects
+			{
+				PreConfig: func() {
+					if err := testAccPutBucketObjects(bucketName, 5); err != nil {
+						t.Fatalf("error uploading objects: %s", err)
+					}
+				},
+				Config:      testAccRadosgwS3BucketConfig_maxObjectsOnDestroy(bucketName, 2),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Bucket Destroy Prevented`),
+			},
+			// Raising the limit allows the destroy at the end of the test
+			{
+				Config: testAccRadosgwS3BucketConfig_maxObjectsOnDestroy(bucketName, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "max_objects_on_destroy", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3Bucket_bucketPrefix(t *testing.T) {
+	t.Parallel()
+
+	bucketPrefix := randomName("tf-acc-prefix") + "-"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketConfig_bucketPrefix(bucketPrefix, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "bucket_prefix", bucketPrefix),
+					resource.TestMatchResourceAttr("radosgw_s3_bucket.test", "bucket", regexp.MustCompile("^"+regexp.QuoteMeta(bucketPrefix)+`\d{14}[0-9a-f]{8}$`)),
+				),
+			},
+			// Updating an unrelated attribute keeps the generated name
+			{
+				Config: testAccRadosgwS3BucketConfig_bucketPrefix(bucketPrefix, "enabled"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("radosgw_s3_bucket.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "versioning", "enabled"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRadosgwS3Bucket_versioning(t *testing.T) {
 	t.Parallel()
 
@@ -121,8 +245,160 @@ func TestAccRadosgwS3Bucket_quota(t *testing.T) {
 	})
 }
 
+func TestAccRadosgwS3Bucket_placementRule(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The test gateway only has the default placement target
+				Config: testAccRadosgwS3BucketConfig_placementRule(bucketName, "default-placement"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "placement_rule", "default-placement"),
+				),
+			},
+			// Removing placement_rule from the configuration keeps the bucket
+			{
+				Config: testAccRadosgwS3BucketConfig_basic(bucketName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("radosgw_s3_bucket.test", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			// A placement target that does not exist is rejected
+			{
+				Config:      testAccRadosgwS3BucketConfig_placementRule(randomName("tf-acc-bucket"), "tf-acc-missing-placement"),
+				ExpectError: regexp.MustCompile(`Error Creating Bucket`),
+			},
+		},
+	})
+}
+
 // Helper functions
 
+func TestAccRadosgwS3Bucket_adoptExisting(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			// The bucket and its objects exist before Terraform manages it
+			{
+				PreConfig: func() {
+					_, err := testAccS3Client().CreateBucket(testCtx, &s3.CreateBucketInput{
+						Bucket: aws.String(bucketName),
+					})
+					if err != nil {
+						t.Fatalf("error creating bucket: %s", err)
+					}
+					if err := testAccPutBucketObjects(bucketName, 1); err != nil {
+						t.Fatalf("error uploading objects: %s", err)
+					}
+				},
+				Config: testAccRadosgwS3BucketConfig_adoptExisting(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "versioning", "enabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRadosgwS3Bucket_deletionProtection(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3BucketConfig_deletionProtection(bucketName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccRadosgwS3BucketConfig_deletionProtection(bucketName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			// Disabling the protection allows the destroy at the end of the test
+			{
+				Config: testAccRadosgwS3BucketConfig_deletionProtection(bucketName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRadosgwS3BucketExists("radosgw_s3_bucket.test"),
+					resource.TestCheckResourceAttr("radosgw_s3_bucket.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsBucketExistsError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err    error
+		expect bool
+	}{
+		"already exists": {err: &smithy.GenericAPIError{Code: "BucketAlreadyExists"}, expect: true},
+		"owned by you":   {err: &smithy.GenericAPIError{Code: "BucketAlreadyOwnedByYou"}, expect: true},
+		"wrapped":        {err: fmt.Errorf("create: %w", &smithy.GenericAPIError{Code: "BucketAlreadyExists"}), expect: true},
+		"other code":     {err: &smithy.GenericAPIError{Code: "AccessDenied"}},
+		"not an api":     {err: errors.New("connection refused")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isBucketExistsError(testCase.err); got != testCase.expect {
+				t.Errorf("expected %t, got %t", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3Bucket_invalidName(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Names RadosGW never accepts fail validation
+			{
+				Config:      testAccRadosgwS3BucketConfig_basic("tf acc bucket"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Bucket Name`),
+			},
+			// Names only accepted with relaxed names fail at plan time
+			{
+				Config:      testAccRadosgwS3BucketConfig_basic(randomName("TF_Acc_Bucket")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rgw_relaxed_s3_bucket_names`),
+			},
+		},
+	})
+}
+
 func testAccCheckRadosgwS3BucketExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -171,6 +447,24 @@ resource "radosgw_s3_bucket" "test" {
 `, bucketName)
 }
 
+func testAccRadosgwS3BucketConfig_placementRule(bucketName, placementRule string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket         = %q
+  placement_rule = %q
+}
+`, bucketName, placementRule)
+}
+
+func testAccRadosgwS3BucketConfig_bucketPrefix(bucketPrefix, versioning string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket_prefix = %q
+  versioning    = %q
+}
+`, bucketPrefix, versioning)
+}
+
 func testAccRadosgwS3BucketConfig_forceDestroy(bucketName string) string {
 	return providerConfig() + fmt.Sprintf(`
 resource "radosgw_s3_bucket" "test" {
@@ -180,6 +474,47 @@ resource "radosgw_s3_bucket" "test" {
 `, bucketName)
 }
 
+func testAccRadosgwS3BucketConfig_forceDestroyS3(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket             = %q
+  versioning         = "enabled"
+  force_destroy      = true
+  force_destroy_mode = "s3"
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3BucketConfig_maxObjectsOnDestroy(bucketName string, maxObjects int) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket                 = %q
+  force_destroy          = true
+  max_objects_on_destroy = %d
+}
+`, bucketName, maxObjects)
+}
+
+func testAccRadosgwS3BucketConfig_adoptExisting(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket         = %q
+  adopt_existing = true
+  force_destroy  = true
+  versioning     = "enabled"
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3BucketConfig_deletionProtection(bucketName string, deletionProtection bool) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket              = %q
+  deletion_protection = %t
+}
+`, bucketName, deletionProtection)
+}
+
 func testAccRadosgwS3BucketConfig_versioning(bucketName, versioning string) string {
 	return providerConfig() + fmt.Sprintf(`
 resource "radosgw_s3_bucket" "test" {
@@ -202,3 +537,50 @@ resource "radosgw_s3_bucket" "test" {
 }
 `, bucketName, maxSize, maxObjects)
 }
+
+// testAccS3Client returns an S3 client using the credentials of the
+// acceptance tests, to set up resources outside of Terraform.
+func testAccS3Client() *s3.Client {
+	endpoint := os.Getenv("RADOSGW_ENDPOINT")
+	return s3.NewFromConfig(aws.Config{
+		Region:      "default",
+		Credentials: credentials.NewStaticCredentialsProvider(os.Getenv("RADOSGW_ACCESS_KEY"), os.Getenv("RADOSGW_SECRET_KEY"), ""),
+	}, func(o *s3.Options) {
+		o.BaseEndpoint = &endpoint
+		o.UsePathStyle = true
+	})
+}
+
+// testAccPutBucketObjects uploads count objects to a bucket, overwrites the
+// first one to create a second version, and starts a multipart upload that is
+// never completed.
+func testAccPutBucketObjects(bucket string, count int) error {
+	client := testAccS3Client()
+
+	for i := range count {
+		_, err := client.PutObject(testCtx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(fmt.Sprintf("objects/%05d", i)),
+			Body:   strings.NewReader("test"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := client.PutObject(testCtx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String("objects/00000"),
+		Body:   strings.NewReader("second version"),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CreateMultipartUpload(testCtx, &s3.CreateMultipartUploadInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String("incomplete"),
+		StorageClass: s3types.StorageClassStandard,
+	})
+	return err
+}
@@ -40,6 +40,7 @@ type S3BucketWebsiteConfigurationResource struct {
 
 type S3BucketWebsiteConfigurationModel struct {
 	Bucket              types.String `tfsdk:"bucket"`
+	Tenant              types.String `tfsdk:"tenant"`
 	IndexDocument       types.List   `tfsdk:"index_document"`
 	ErrorDocument       types.List   `tfsdk:"error_document"`
 	RedirectAllRequests types.List   `tfsdk:"redirect_all_requests_to"`
@@ -152,6 +153,13 @@ func (r *S3BucketWebsiteConfigurationResource) Schema(ctx context.Context, req r
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -337,7 +345,7 @@ func (r *S3BucketWebsiteConfigurationResource) Create(ctx context.Context, req r
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	websiteConfig, diags := expandWebsiteConfiguration(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -352,6 +360,7 @@ func (r *S3BucketWebsiteConfigurationResource) Create(ctx context.Context, req r
 
 	_, err := r.client.S3.PutBucketWebsite(ctx, input)
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Creating S3 Bucket Website Configuration",
 			fmt.Sprintf("Could not set website configuration for bucket %s: %s", bucket, err),
@@ -374,7 +383,7 @@ func (r *S3BucketWebsiteConfigurationResource) Read(ctx context.Context, req res
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	output, err := r.client.S3.GetBucketWebsite(ctx, &s3.GetBucketWebsiteInput{
 		Bucket: aws.String(bucket),
@@ -395,8 +404,6 @@ func (r *S3BucketWebsiteConfigurationResource) Read(ctx context.Context, req res
 	}
 
 	// Map response to state
-	state.Bucket = types.StringValue(bucket)
-
 	diags := flattenWebsiteConfiguration(ctx, output, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -414,7 +421,7 @@ func (r *S3BucketWebsiteConfigurationResource) Update(ctx context.Context, req r
 		return
 	}
 
-	bucket := plan.Bucket.ValueString()
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
 
 	websiteConfig, diags := expandWebsiteConfiguration(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -429,6 +436,7 @@ func (r *S3BucketWebsiteConfigurationResource) Update(ctx context.Context, req r
 
 	_, err := r.client.S3.PutBucketWebsite(ctx, input)
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		resp.Diagnostics.AddError(
 			"Error Updating S3 Bucket Website Configuration",
 			fmt.Sprintf("Could not update website configuration for bucket %s: %s", bucket, err),
@@ -451,12 +459,13 @@ func (r *S3BucketWebsiteConfigurationResource) Delete(ctx context.Context, req r
 		return
 	}
 
-	bucket := state.Bucket.ValueString()
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
 
 	_, err := r.client.S3.DeleteBucketWebsite(ctx, &s3.DeleteBucketWebsiteInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
+		err = zoneWriteError(ctx, r.client.Admin, err)
 		if isS3NoSuchWebsiteConfiguration(err) {
 			return
 		}
@@ -473,7 +482,8 @@ func (r *S3BucketWebsiteConfigurationResource) Delete(ctx context.Context, req r
 }
 
 func (r *S3BucketWebsiteConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+	// Import format: "bucket" or "tenant:bucket"
+	importBucketState(ctx, req, resp)
 }
 
 // =============================================================================
@@ -33,6 +33,13 @@ func TestAccRadosgwS3BucketWebsiteConfiguration_basic(t *testing.T) {
 				ImportStateId:                        bucketName,
 				ImportStateVerifyIdentifierAttribute: "bucket",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:    "radosgw_s3_bucket_website_configuration.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   bucketName,
+			},
 		},
 	})
 }
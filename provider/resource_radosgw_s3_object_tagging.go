@@ -0,0 +1,553 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectTaggingResource{}
+var _ resource.ResourceWithImportState = &ObjectTaggingResource{}
+var _ resource.ResourceWithModifyPlan = &ObjectTaggingResource{}
+
+func NewS3ObjectTaggingResource() resource.Resource {
+	return &ObjectTaggingResource{}
+}
+
+// ObjectTaggingResource defines the resource implementation.
+type ObjectTaggingResource struct {
+	client *RadosgwClient
+}
+
+// ObjectTaggingResourceModel describes the resource data model.
+type ObjectTaggingResourceModel struct {
+	Bucket       types.String `tfsdk:"bucket"`
+	Tenant       types.String `tfsdk:"tenant"`
+	Key          types.String `tfsdk:"key"`
+	Tags         types.Map    `tfsdk:"tags"`
+	TagsAll      types.Map    `tfsdk:"tags_all"`
+	ContentType  types.String `tfsdk:"content_type"`
+	CacheControl types.String `tfsdk:"cache_control"`
+	Metadata     types.Map    `tfsdk:"metadata"`
+	ID           types.String `tfsdk:"id"`
+}
+
+// maxObjectTags is the maximum number of tags S3 accepts on an object.
+const maxObjectTags = 10
+
+func (r *ObjectTaggingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_object_tagging"
+}
+
+func (r *ObjectTaggingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the tags and, optionally, the metadata of an existing S3 object, such as an object " +
+			"uploaded by an application, without managing its content. Tags are set with `PutObjectTagging`, for " +
+			"example to classify objects for governance or lifecycle rules filtering on tags.\n\n" +
+			"`content_type`, `cache_control` and `metadata` are left as they are unless configured. S3 has no " +
+			"operation updating the metadata of an object, so changing them copies the object onto itself with " +
+			"`CopyObject` and the `REPLACE` metadata directive, keeping its tags, storage class and other headers. " +
+			"The copy only succeeds while the object is unchanged since it was read.\n\n" +
+			"~> **Note:** Copying an object rewrites its data, is limited to objects of at most 5 GiB and creates " +
+			"a new version in versioned buckets. Destroying this resource removes the tags of the object but " +
+			"keeps the object and its metadata.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "The name of the bucket.",
+				Required:            true,
+				Validators: []validator.String{
+					bucketNameValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "The tenant the bucket belongs to. Leave unset for buckets without a tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the object.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: fmt.Sprintf("Map of tag key to value. At most %d tags, including the `default_tags` "+
+					"of the provider. Tags take precedence over default tags with the same key.", maxObjectTags),
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtMost(maxObjectTags),
+					mapvalidator.KeysAre(stringvalidator.LengthBetween(1, 128)),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtMost(256)),
+				},
+			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "All tags of the object: `tags` merged into the `default_tags` of the provider. " +
+					"They replace all tags of the object.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "The `Content-Type` of the object. Read from the object when not configured.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_control": schema.StringAttribute{
+				MarkdownDescription: "The `Cache-Control` header of the object. Read from the object when not configured.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Map of user metadata (`x-amz-meta-*` headers) of the object. Keys are lowercase. " +
+					"When configured, it replaces all user metadata of the object. Read from the object when not configured.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`),
+							"must only contain lowercase letters, numbers, hyphens and underscores"),
+					),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The object identifier in the format `bucket/key`, with the bucket prefixed by " +
+					"`tenant:` for buckets of a tenant.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ObjectTaggingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ObjectTaggingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagsAll, diags := mergeDefaultTags(ctx, r.client, tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(tagsAll.Elements()) > maxObjectTags {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags"),
+			"Too Many Object Tags",
+			fmt.Sprintf("Objects have at most %d tags, but tags and the default_tags of the provider add up to %d tags.",
+				maxObjectTags, len(tagsAll.Elements())),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+func (r *ObjectTaggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ObjectTaggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, "Creating")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectTaggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ObjectTaggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+	key := state.Key.ValueString()
+
+	head, err := r.client.S3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if isObjectNotFoundError(err) {
+		tflog.Info(ctx, "Object not found, removing tagging from state", map[string]any{
+			"bucket": bucket,
+			"key":    key,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Object",
+			fmt.Sprintf("Could not read object %s/%s: %s", bucket, key, err.Error()),
+		)
+		return
+	}
+
+	tags, err := r.getTags(ctx, bucket, key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Object Tags",
+			fmt.Sprintf("Could not read tags of object %s/%s: %s", bucket, key, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setObjectTaggingState(ctx, &state, head, tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceTags, diags := resourceTagsFromAll(ctx, r.client, tags, state.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Tags = resourceTags
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ObjectTaggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ObjectTaggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, "Updating")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectTaggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ObjectTaggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := s3BucketName(state.Tenant.ValueString(), state.Bucket.ValueString())
+	key := state.Key.ValueString()
+
+	_, err := r.client.S3.DeleteObjectTagging(ctx, &s3.DeleteObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if isObjectNotFoundError(err) {
+		tflog.Info(ctx, "Object already deleted", map[string]any{
+			"bucket": bucket,
+			"key":    key,
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Object Tags",
+			fmt.Sprintf("Could not delete tags of object %s/%s: %s", bucket, key, zoneWriteError(ctx, r.client.Admin, err).Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted object tags", map[string]any{
+		"bucket": bucket,
+		"key":    key,
+	})
+}
+
+func (r *ObjectTaggingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "bucket/key" or "tenant:bucket/key"
+	bucketID, key, ok := strings.Cut(req.ID, "/")
+	if !ok || bucketID == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'bucket/key' or 'tenant:bucket/key'. Example: 'acme:data/reports/2024.csv'",
+		)
+		return
+	}
+
+	tenant, bucket := splitBucketID(bucketID)
+	if tenant != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+// apply replaces the metadata of the object when the plan changes it, sets
+// its tags and stores the resulting object attributes in plan.
+func (r *ObjectTaggingResource) apply(ctx context.Context, plan *ObjectTaggingResourceModel, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bucket := s3BucketName(plan.Tenant.ValueString(), plan.Bucket.ValueString())
+	key := plan.Key.ValueString()
+
+	tags := map[string]string{}
+	diags.Append(plan.TagsAll.ElementsAs(ctx, &tags, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Debug(ctx, operation+" object tagging", map[string]any{
+		"bucket": bucket,
+		"key":    key,
+		"tags":   sortedKeys(tags),
+	})
+
+	head, err := r.client.S3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Reading Object",
+			fmt.Sprintf("Could not read object %s/%s: %s", bucket, key, err.Error()),
+		)
+		return diags
+	}
+
+	contentType := aws.ToString(head.ContentType)
+	if !plan.ContentType.IsUnknown() {
+		contentType = plan.ContentType.ValueString()
+	}
+	cacheControl := aws.ToString(head.CacheControl)
+	if !plan.CacheControl.IsUnknown() {
+		cacheControl = plan.CacheControl.ValueString()
+	}
+	metadata := head.Metadata
+	if !plan.Metadata.IsUnknown() {
+		metadata = map[string]string{}
+		diags.Append(plan.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if contentType != aws.ToString(head.ContentType) || cacheControl != aws.ToString(head.CacheControl) || !maps.Equal(metadata, head.Metadata) {
+		tflog.Debug(ctx, "Replacing object metadata", map[string]any{
+			"bucket": bucket,
+			"key":    key,
+		})
+
+		if err := r.replaceMetadata(ctx, bucket, key, head, contentType, cacheControl, metadata); err != nil {
+			diags.AddError(
+				"Error Updating Object Metadata",
+				fmt.Sprintf("Could not replace metadata of object %s/%s: %s", bucket, key, zoneWriteError(ctx, r.client.Admin, err).Error()),
+			)
+			return diags
+		}
+
+		if head, err = r.client.S3.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}); err != nil {
+			diags.AddError(
+				"Error Reading Object",
+				fmt.Sprintf("Could not read object %s/%s: %s", bucket, key, err.Error()),
+			)
+			return diags
+		}
+	}
+
+	tagSet := make([]s3types.Tag, 0, len(tags))
+	for _, tagKey := range sortedKeys(tags) {
+		tagSet = append(tagSet, s3types.Tag{Key: aws.String(tagKey), Value: aws.String(tags[tagKey])})
+	}
+	if _, err := r.client.S3.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(key),
+		Tagging: &s3types.Tagging{TagSet: tagSet},
+	}); err != nil {
+		diags.AddError(
+			"Error Setting Object Tags",
+			fmt.Sprintf("Could not set tags of object %s/%s: %s", bucket, key, zoneWriteError(ctx, r.client.Admin, err).Error()),
+		)
+		return diags
+	}
+
+	diags.Append(setObjectTaggingState(ctx, plan, head, tags)...)
+
+	tflog.Trace(ctx, "Applied object tagging", map[string]any{
+		"bucket": bucket,
+		"key":    key,
+	})
+
+	return diags
+}
+
+// getTags returns the tags of an object.
+func (r *ObjectTaggingResource) getTags(ctx context.Context, bucket, key string) (map[string]string, error) {
+	output, err := r.client.S3.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(output.TagSet))
+	for _, tag := range output.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// replaceMetadata copies an object onto itself with new metadata. The other
+// headers and the storage class are copied from head, which CopyObject would
+// otherwise reset, and the copy fails if the object changed since head was
+// read.
+func (r *ObjectTaggingResource) replaceMetadata(ctx context.Context, bucket, key string, head *s3.HeadObjectOutput, contentType, cacheControl string, metadata map[string]string) error {
+	input := &s3.CopyObjectInput{
+		Bucket:             aws.String(bucket),
+		Key:                aws.String(key),
+		CopySource:         aws.String(objectCopySource(bucket, key)),
+		CopySourceIfMatch:  head.ETag,
+		MetadataDirective:  s3types.MetadataDirectiveReplace,
+		TaggingDirective:   s3types.TaggingDirectiveCopy,
+		Metadata:           metadata,
+		ContentDisposition: head.ContentDisposition,
+		ContentEncoding:    head.ContentEncoding,
+		ContentLanguage:    head.ContentLanguage,
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if cacheControl != "" {
+		input.CacheControl = aws.String(cacheControl)
+	}
+	if head.StorageClass != "" {
+		input.StorageClass = s3types.StorageClass(head.StorageClass)
+	}
+	if head.ExpiresString != nil {
+		if expires, err := http.ParseTime(aws.ToString(head.ExpiresString)); err == nil {
+			input.Expires = aws.Time(expires)
+		}
+	}
+
+	_, err := r.client.S3.CopyObject(ctx, input)
+	return err
+}
+
+// objectCopySource returns the x-amz-copy-source of an object, with the key
+// URL-encoded.
+func objectCopySource(bucket, key string) string {
+	return bucket + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+// setObjectTaggingState stores the attributes read from an object in data,
+// with tags as tags_all. The configured tags are left unchanged.
+func setObjectTaggingState(ctx context.Context, data *ObjectTaggingResourceModel, head *s3.HeadObjectOutput, tags map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagsValue, d := types.MapValueFrom(ctx, types.StringType, tags)
+	diags.Append(d...)
+	metadata := head.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadataValue, d := types.MapValueFrom(ctx, types.StringType, metadata)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.TagsAll = tagsValue
+	data.ContentType = types.StringValue(aws.ToString(head.ContentType))
+	data.CacheControl = types.StringValue(aws.ToString(head.CacheControl))
+	data.Metadata = metadataValue
+	data.ID = types.StringValue(s3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString()) + "/" + data.Key.ValueString())
+	return diags
+}
+
+// isObjectNotFoundError reports whether err is S3 reporting that an object
+// or its bucket does not exist. HeadObject errors carry no error code, only
+// the 404 status.
+func isObjectNotFoundError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "NoSuchKey", "NoSuchBucket", "NotFound":
+		return true
+	}
+	return false
+}
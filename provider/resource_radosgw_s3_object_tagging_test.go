@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestObjectCopySource(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		bucket   string
+		key      string
+		expected string
+	}{
+		"plain":  {bucket: "data", key: "reports/2024.csv", expected: "data/reports/2024.csv"},
+		"tenant": {bucket: "acme:data", key: "report.csv", expected: "acme:data/report.csv"},
+		"escape": {bucket: "data", key: "a b/c+d?.txt", expected: "data/a%20b/c+d%3F.txt"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if source := objectCopySource(testCase.bucket, testCase.key); source != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, source)
+			}
+		})
+	}
+}
+
+func TestIsObjectNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":          {err: nil, expected: false},
+		"no such key":  {err: &smithy.GenericAPIError{Code: "NoSuchKey"}, expected: true},
+		"head 404":     {err: &smithy.GenericAPIError{Code: "NotFound"}, expected: true},
+		"no bucket":    {err: &smithy.GenericAPIError{Code: "NoSuchBucket"}, expected: true},
+		"access":       {err: &smithy.GenericAPIError{Code: "AccessDenied"}, expected: false},
+		"not api":      {err: errors.New("connection refused"), expected: false},
+		"wrapped code": {err: fmt.Errorf("head: %w", &smithy.GenericAPIError{Code: "NoSuchKey"}), expected: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := isObjectNotFoundError(testCase.err); result != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestAccRadosgwS3ObjectTagging_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := randomName("tf-acc-bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRadosgwS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwS3ObjectTaggingConfig_bucket(bucketName),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccPutBucketObjects(bucketName, 1); err != nil {
+						t.Fatalf("error uploading objects: %s", err)
+					}
+				},
+				Config: testAccRadosgwS3ObjectTaggingConfig(bucketName, `{
+    classification = "internal"
+  }`, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "id", bucketName+"/objects/00000"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags.classification", "internal"),
+					resource.TestCheckResourceAttrSet("radosgw_s3_object_tagging.test", "content_type"),
+				),
+			},
+			{
+				Config: testAccRadosgwS3ObjectTaggingConfig(bucketName, `{
+    classification = "public"
+    team           = "web"
+  }`, `
+  content_type  = "text/plain"
+  cache_control = "max-age=3600"
+  metadata = {
+    owner = "web"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags.classification", "public"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "cache_control", "max-age=3600"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "metadata.owner", "web"),
+				),
+			},
+			// Default tags are merged into tags_all, tags taking precedence
+			{
+				Config: testAccRadosgwS3ObjectTaggingConfig_defaultTags(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags_all.%", "2"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags_all.classification", "public"),
+					resource.TestCheckResourceAttr("radosgw_s3_object_tagging.test", "tags_all.team", "storage"),
+				),
+			},
+			{
+				ResourceName:      "radosgw_s3_object_tagging.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     bucketName + "/objects/00000",
+			},
+		},
+	})
+}
+
+func testAccRadosgwS3ObjectTaggingConfig_bucket(bucketName string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_s3_bucket" "test" {
+  bucket        = %q
+  force_destroy = true
+}
+`, bucketName)
+}
+
+func testAccRadosgwS3ObjectTaggingConfig(bucketName, tags, extra string) string {
+	return testAccRadosgwS3ObjectTaggingConfig_bucket(bucketName) + fmt.Sprintf(`
+resource "radosgw_s3_object_tagging" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+  key    = "objects/00000"
+  tags   = %s
+%s
+}
+`, tags, extra)
+}
+
+func testAccRadosgwS3ObjectTaggingConfig_defaultTags(bucketName string) string {
+	return fmt.Sprintf(`
+provider "radosgw" {
+  default_tags {
+    tags = {
+      classification = "internal"
+      team           = "storage"
+    }
+  }
+}
+
+resource "radosgw_s3_bucket" "test" {
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "radosgw_s3_object_tagging" "test" {
+  bucket = radosgw_s3_bucket.test.bucket
+  key    = "objects/00000"
+  tags = {
+    classification = "public"
+  }
+}
+`, bucketName)
+}
@@ -310,12 +310,7 @@ func (r *SNSTopicResource) Configure(ctx context.Context, req resource.Configure
 	}
 
 	r.client = client
-	r.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	r.iamClient = client.IAM
 }
 
 func (r *SNSTopicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -330,7 +325,7 @@ func (r *SNSTopicResource) Create(ctx context.Context, req resource.CreateReques
 	params.Set("Action", "CreateTopic")
 	params.Set("Name", plan.Name.ValueString())
 
-	body, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating SNS Topic",
@@ -471,6 +466,18 @@ func (r *SNSTopicResource) Read(ctx context.Context, req resource.ReadRequest, r
 	state.CloudEvents = parseSNSBoolArgPreserve(endpointArgs, "cloudevents", prevState.CloudEvents)
 	state.UseSSL = parseSNSBoolArgPreserve(endpointArgs, "use-ssl", prevState.UseSSL)
 
+	// Imported topics on older Ceph versions have no state to preserve, fall
+	// back to the schema defaults
+	if state.VerifySSL.IsNull() {
+		state.VerifySSL = types.BoolValue(true)
+	}
+	if state.CloudEvents.IsNull() {
+		state.CloudEvents = types.BoolValue(false)
+	}
+	if state.UseSSL.IsNull() {
+		state.UseSSL = types.BoolValue(false)
+	}
+
 	// Strings from EndpointArgs — preserve state when key is absent (Reef compat)
 	state.CALocation = parseSNSStringArgPreserve(endpointArgs, "ca-location", prevState.CALocation)
 	state.Mechanism = parseSNSStringArgPreserve(endpointArgs, "mechanism", prevState.Mechanism)
@@ -516,7 +523,7 @@ func (r *SNSTopicResource) Update(ctx context.Context, req resource.UpdateReques
 		params.Set(fmt.Sprintf("Attributes.entry.%d.value", idx), existingPolicy)
 	}
 
-	body, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating SNS Topic",
@@ -557,7 +564,7 @@ func (r *SNSTopicResource) Delete(ctx context.Context, req resource.DeleteReques
 	params.Set("Action", "DeleteTopic")
 	params.Set("TopicArn", state.ARN.ValueString())
 
-	_, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	_, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		// Deleting an already-deleted topic is not considered an error by RadosGW
 		if isSNSTopicNotFound(err) {
@@ -681,7 +688,7 @@ func (r *SNSTopicResource) readTopic(ctx context.Context, arn string) (*snsTopic
 	params.Set("Action", "GetTopicAttributes")
 	params.Set("TopicArn", arn)
 
-	body, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		return nil, err
 	}
@@ -714,7 +721,7 @@ func (r *SNSTopicResource) readTopicAllAttributes(ctx context.Context, arn strin
 	params.Set("Action", "GetTopicAttributes")
 	params.Set("TopicArn", arn)
 
-	body, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SNSTopicPolicyResource{}
 var _ resource.ResourceWithImportState = &SNSTopicPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &SNSTopicPolicyResource{}
 
 func NewSNSTopicPolicyResource() resource.Resource {
 	return &SNSTopicPolicyResource{}
@@ -98,12 +99,18 @@ func (r *SNSTopicPolicyResource) Configure(ctx context.Context, req resource.Con
 	}
 
 	r.client = client
-	r.iamClient = NewIAMClient(
-		client.Admin.Endpoint,
-		client.Admin.AccessKey,
-		client.Admin.SecretKey,
-		client.Admin.HTTPClient,
-	)
+	r.iamClient = client.IAM
+}
+
+func (r *SNSTopicPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// SetTopicAttributes is not available before Squid, fail at plan time
+	if r.client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !r.client.supportsFeature(CephFeature_TopicPolicy) {
+		r.client.addCephFeatureError(&resp.Diagnostics, "Managing topic policies", CephFeature_TopicPolicy)
+	}
 }
 
 func (r *SNSTopicPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -296,7 +303,7 @@ func (r *SNSTopicPolicyResource) setTopicPolicy(ctx context.Context, arn, policy
 	params.Set("AttributeName", "Policy")
 	params.Set("AttributeValue", policy)
 
-	_, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	_, err := r.iamClient.DoRequest(ctx, params, "sns")
 	return err
 }
 
@@ -306,7 +313,7 @@ func (r *SNSTopicPolicyResource) readTopicAttributes(ctx context.Context, arn st
 	params.Set("Action", "GetTopicAttributes")
 	params.Set("TopicArn", arn)
 
-	body, err := r.iamClient.DoPostRequest(ctx, params, "sns")
+	body, err := r.iamClient.DoRequest(ctx, params, "sns")
 	if err != nil {
 		return nil, err
 	}
@@ -16,7 +16,7 @@ func TestAccRadosgwSNSTopicPolicy_basic(t *testing.T) {
 	topicName := randomName("tf-acc-policy")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_TopicPolicy) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwSNSTopicPolicyDestroy,
 		Steps: []resource.TestStep{
@@ -37,6 +37,13 @@ func TestAccRadosgwSNSTopicPolicy_basic(t *testing.T) {
 				ImportStateIdFunc:                    testAccRadosgwSNSTopicPolicyImportStateIDFunc("radosgw_sns_topic_policy.test"),
 				ImportStateVerifyIdentifierAttribute: "arn",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:      "radosgw_sns_topic_policy.test",
+				ImportState:       true,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportStateIdFunc: testAccRadosgwSNSTopicPolicyImportStateIDFunc("radosgw_sns_topic_policy.test"),
+			},
 		},
 	})
 }
@@ -47,7 +54,7 @@ func TestAccRadosgwSNSTopicPolicy_update(t *testing.T) {
 	topicName := randomName("tf-acc-policy")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_TopicPolicy) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwSNSTopicPolicyDestroy,
 		Steps: []resource.TestStep{
@@ -75,7 +82,7 @@ func TestAccRadosgwSNSTopicPolicy_disappears(t *testing.T) {
 	topicName := randomName("tf-acc-policy")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckSkipForVersion(t, CephVersion_Squid) },
+		PreCheck:                 func() { testAccPreCheckFeature(t, CephFeature_TopicPolicy) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRadosgwSNSTopicPolicyDestroy,
 		Steps: []resource.TestStep{
@@ -123,7 +130,7 @@ func testAccCheckRadosgwSNSTopicPolicyExists(resourceName string) resource.TestC
 		params.Set("Action", "GetTopicAttributes")
 		params.Set("TopicArn", arn)
 
-		body, err := iamClient.DoPostRequest(testCtx, params, "sns")
+		body, err := iamClient.DoRequest(testCtx, params, "sns")
 		if err != nil {
 			return fmt.Errorf("error verifying SNS topic policy %s exists: %s", arn, err)
 		}
@@ -169,7 +176,7 @@ func testAccCheckRadosgwSNSTopicPolicyDestroy(s *terraform.State) error {
 		params.Set("Action", "GetTopicAttributes")
 		params.Set("TopicArn", arn)
 
-		body, err := iamClient.DoPostRequest(testCtx, params, "sns")
+		body, err := iamClient.DoRequest(testCtx, params, "sns")
 		if err != nil {
 			// Topic itself is gone — policy is destroyed
 			if isSNSTopicNotFound(err) {
@@ -219,7 +226,7 @@ func testAccDeleteSNSTopic(resourceName string) resource.TestCheckFunc {
 		params.Set("Action", "DeleteTopic")
 		params.Set("TopicArn", arn)
 
-		_, err := iamClient.DoPostRequest(testCtx, params, "sns")
+		_, err := iamClient.DoRequest(testCtx, params, "sns")
 		return err
 	}
 }
@@ -22,7 +22,7 @@ func TestAccRadosgwSNSTopic_basic(t *testing.T) {
 		resource.TestCheckResourceAttr("radosgw_sns_topic.test", "persistent", "false"),
 	}
 	// User is only returned by GetTopicAttributes on Squid+
-	if !getCephVersion().LessThan(CephVersion_Squid) {
+	if FeatureSupported(CephFeature_TopicAttributes, getCephVersion()) {
 		checks = append(checks,
 			resource.TestCheckResourceAttrSet("radosgw_sns_topic.test", "user"),
 			resource.TestCheckResourceAttr("radosgw_sns_topic.test", "verify_ssl", "true"),
@@ -48,6 +48,13 @@ func TestAccRadosgwSNSTopic_basic(t *testing.T) {
 				ImportStateIdFunc:                    testAccRadosgwSNSTopicImportStateIDFunc("radosgw_sns_topic.test"),
 				ImportStateVerifyIdentifierAttribute: "arn",
 			},
+			// Import block - the imported state must not produce a plan
+			{
+				ResourceName:      "radosgw_sns_topic.test",
+				ImportState:       true,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportStateIdFunc: testAccRadosgwSNSTopicImportStateIDFunc("radosgw_sns_topic.test"),
+			},
 		},
 	})
 }
@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSwiftAuthPath is the path of the RadosGW Swift v1 auth endpoint when
+// rgw_swift_auth_entry is left at its default.
+const defaultSwiftAuthPath = "/auth/1.0"
+
+// Swift account metadata headers holding the temporary URL keys.
+const (
+	swiftTempURLKeyHeader  = "X-Account-Meta-Temp-URL-Key"
+	swiftTempURLKey2Header = "X-Account-Meta-Temp-URL-Key-2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SwiftTempURLKeyResource{}
+var _ resource.ResourceWithImportState = &SwiftTempURLKeyResource{}
+
+func NewSwiftTempURLKeyResource() resource.Resource {
+	return &SwiftTempURLKeyResource{}
+}
+
+// SwiftTempURLKeyResource manages the temporary URL keys of a Swift account.
+type SwiftTempURLKeyResource struct {
+	client *RadosgwClient
+}
+
+// SwiftTempURLKeyResourceModel describes the resource data model.
+type SwiftTempURLKeyResourceModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	Subuser  types.String `tfsdk:"subuser"`
+	Key      types.String `tfsdk:"key"`
+	Key2     types.String `tfsdk:"key_2"`
+	AuthPath types.String `tfsdk:"swift_auth_path"`
+	ID       types.String `tfsdk:"id"`
+}
+
+func (r *SwiftTempURLKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_swift_tempurl_key"
+}
+
+func (r *SwiftTempURLKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the temporary URL keys of a Swift account in RadosGW.
+
+The keys are stored as the ` + "`" + swiftTempURLKeyHeader + "`" + ` and ` + "`" + swiftTempURLKey2Header + "`" + ` account
+metadata and are used to sign [temporary URLs](https://docs.ceph.com/en/latest/radosgw/swift/tempurl/). They belong to
+the account of the user, so they are shared by all of its subusers.
+
+The Admin API cannot set account metadata, so the provider authenticates against the Swift API as the given subuser,
+using the Swift key looked up through the Admin API. The subuser must have a Swift key, which ` + "`radosgw_iam_subuser`" + ` generates by
+default, and ` + "`full-control`" + ` access.
+
+Upon deletion, both keys are removed from the account.`,
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The user ID owning the Swift account.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subuser": schema.StringAttribute{
+				MarkdownDescription: "The subuser name (without the user prefix) used to authenticate against the Swift API.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The temporary URL key.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"key_2": schema.StringAttribute{
+				MarkdownDescription: "The secondary temporary URL key. Set it to rotate `key` without invalidating URLs signed with the previous key.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"swift_auth_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the Swift v1 auth endpoint, relative to the provider endpoint. " +
+					"Default is `" + defaultSwiftAuthPath + "`. Change it when `rgw_swift_auth_entry` is customized.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultSwiftAuthPath),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The full subuser ID in the format `user_id:subuser`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SwiftTempURLKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*RadosgwClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RadosgwClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SwiftTempURLKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SwiftTempURLKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subuserID := data.UserID.ValueString() + ":" + data.Subuser.ValueString()
+
+	if err := r.setTempURLKeys(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Swift Temp URL Key",
+			fmt.Sprintf("Could not set temp URL keys of %s: %s", subuserID, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(subuserID)
+
+	tflog.Trace(ctx, "Created Swift temp URL key", map[string]any{
+		"subuser": subuserID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwiftTempURLKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SwiftTempURLKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subuserID := data.UserID.ValueString() + ":" + data.Subuser.ValueString()
+
+	session, err := r.swiftLogin(ctx, data)
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		tflog.Info(ctx, "User no longer exists, removing temp URL key from state", map[string]any{
+			"subuser": subuserID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Swift Temp URL Key",
+			fmt.Sprintf("Could not read temp URL keys of %s: %s", subuserID, err),
+		)
+		return
+	}
+
+	header, err := session.do(ctx, http.MethodHead, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Swift Temp URL Key",
+			fmt.Sprintf("Could not read temp URL keys of %s: %s", subuserID, err),
+		)
+		return
+	}
+
+	data.Key = optionalHeaderValue(header, swiftTempURLKeyHeader)
+	data.Key2 = optionalHeaderValue(header, swiftTempURLKey2Header)
+	if data.AuthPath.IsNull() {
+		data.AuthPath = types.StringValue(defaultSwiftAuthPath)
+	}
+	data.ID = types.StringValue(subuserID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwiftTempURLKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SwiftTempURLKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subuserID := data.UserID.ValueString() + ":" + data.Subuser.ValueString()
+
+	if err := r.setTempURLKeys(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Swift Temp URL Key",
+			fmt.Sprintf("Could not update temp URL keys of %s: %s", subuserID, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(subuserID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwiftTempURLKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SwiftTempURLKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subuserID := data.UserID.ValueString() + ":" + data.Subuser.ValueString()
+
+	session, err := r.swiftLogin(ctx, data)
+	if errors.Is(err, admin.ErrNoSuchUser) {
+		tflog.Info(ctx, "User already deleted, nothing to remove", map[string]any{
+			"subuser": subuserID,
+		})
+		return
+	}
+	if err == nil {
+		_, err = session.do(ctx, http.MethodPost, http.Header{
+			"X-Remove-Account-Meta-Temp-URL-Key":   {"x"},
+			"X-Remove-Account-Meta-Temp-URL-Key-2": {"x"},
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Swift Temp URL Key",
+			fmt.Sprintf("Could not remove temp URL keys of %s: %s", subuserID, err),
+		)
+		return
+	}
+}
+
+func (r *SwiftTempURLKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "user_id:subuser" (the full subuser ID)
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'user_id:subuser'. Example: 'myuser:swift'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subuser"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("swift_auth_path"), defaultSwiftAuthPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// setTempURLKeys writes both temp URL keys of the account, removing the
+// secondary key when it is not configured.
+func (r *SwiftTempURLKeyResource) setTempURLKeys(ctx context.Context, data SwiftTempURLKeyResourceModel) error {
+	session, err := r.swiftLogin(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set(swiftTempURLKeyHeader, data.Key.ValueString())
+	if data.Key2.IsNull() {
+		header.Set("X-Remove-Account-Meta-Temp-URL-Key-2", "x")
+	} else {
+		header.Set(swiftTempURLKey2Header, data.Key2.ValueString())
+	}
+
+	_, err = session.do(ctx, http.MethodPost, header)
+	return err
+}
+
+// swiftLogin looks up the Swift key of the subuser through the Admin API and
+// authenticates against the Swift API with it.
+func (r *SwiftTempURLKeyResource) swiftLogin(ctx context.Context, data SwiftTempURLKeyResourceModel) (*swiftSession, error) {
+	subuserID := data.UserID.ValueString() + ":" + data.Subuser.ValueString()
+
+	user, err := r.client.Admin.GetUser(ctx, admin.User{ID: data.UserID.ValueString()})
+	if err != nil {
+		return nil, fmt.Errorf("could not get user: %w", adminError(err, "users=read"))
+	}
+
+	secret, ok := swiftSubuserSecret(user, subuserID)
+	if !ok {
+		return nil, fmt.Errorf("subuser %s has no Swift key", subuserID)
+	}
+
+	authPath := defaultSwiftAuthPath
+	if !data.AuthPath.IsNull() && data.AuthPath.ValueString() != "" {
+		authPath = "/" + strings.TrimPrefix(data.AuthPath.ValueString(), "/")
+	}
+
+	return swiftAuthenticate(ctx, r.client.Admin.HTTPClient, r.client.Admin.Endpoint+authPath, subuserID, secret)
+}
+
+// swiftSubuserSecret returns the Swift secret key of a subuser.
+func swiftSubuserSecret(user admin.User, subuserID string) (string, bool) {
+	for _, key := range user.SwiftKeys {
+		if key.User == subuserID {
+			return key.SecretKey, true
+		}
+	}
+	return "", false
+}
+
+// swiftSession is an authenticated Swift API session bound to an account.
+type swiftSession struct {
+	httpClient admin.HTTPClient
+	storageURL string
+	token      string
+}
+
+// swiftAuthenticate performs a Swift v1 auth request and returns a session for
+// the storage URL returned by the gateway.
+func swiftAuthenticate(ctx context.Context, httpClient admin.HTTPClient, authURL, user, key string) (*swiftSession, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("X-Auth-User", user)
+	req.Header.Set("X-Auth-Key", key)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("swift authentication failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("swift authentication returned HTTP %d", resp.StatusCode)
+	}
+
+	session := &swiftSession{
+		httpClient: httpClient,
+		storageURL: resp.Header.Get("X-Storage-Url"),
+		token:      resp.Header.Get("X-Auth-Token"),
+	}
+	if session.storageURL == "" || session.token == "" {
+		return nil, errors.New("swift authentication response is missing X-Storage-Url or X-Auth-Token")
+	}
+	return session, nil
+}
+
+// do sends an account request with the given headers and returns the
+// response headers.
+func (s *swiftSession) do(ctx context.Context, method string, header http.Header) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.storageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("X-Auth-Token", s.token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("swift request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("swift %s returned HTTP %d (the subuser may lack full-control access)", method, resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("swift %s returned HTTP %d", method, resp.StatusCode)
+	}
+	return resp.Header, nil
+}
+
+// optionalHeaderValue returns a header value, or null when it is not set.
+func optionalHeaderValue(header http.Header, name string) types.String {
+	if value := header.Get(name); value != "" {
+		return types.StringValue(value)
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSwiftSubuserSecret(t *testing.T) {
+	t.Parallel()
+
+	user := admin.User{
+		SwiftKeys: []admin.SwiftKeySpec{
+			{User: "alice:reader", SecretKey: "reader-secret"},
+			{User: "alice:swift", SecretKey: "swift-secret"},
+		},
+	}
+
+	testCases := map[string]struct {
+		subuserID string
+		expected  string
+		found     bool
+	}{
+		"matching subuser": {
+			subuserID: "alice:swift",
+			expected:  "swift-secret",
+			found:     true,
+		},
+		"missing subuser": {
+			subuserID: "alice:other",
+		},
+		"user prefix only": {
+			subuserID: "alice",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			secret, found := swiftSubuserSecret(user, tc.subuserID)
+			if found != tc.found || secret != tc.expected {
+				t.Errorf("expected (%q, %t), got (%q, %t)", tc.expected, tc.found, secret, found)
+			}
+		})
+	}
+}
+
+func TestSwiftSession(t *testing.T) {
+	t.Parallel()
+
+	var posted http.Header
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		status := http.StatusNoContent
+		switch {
+		case req.URL.Path == "/auth/1.0":
+			if req.Header.Get("X-Auth-User") != "alice:swift" || req.Header.Get("X-Auth-Key") != "secret" {
+				status = http.StatusUnauthorized
+				break
+			}
+			header.Set("X-Storage-Url", "http://rgw.example.com/swift/v1")
+			header.Set("X-Auth-Token", "AUTH_tk123")
+		case req.Header.Get("X-Auth-Token") != "AUTH_tk123":
+			status = http.StatusUnauthorized
+		case req.Method == http.MethodPost:
+			posted = req.Header
+		case req.Method == http.MethodHead:
+			header.Set("X-Account-Meta-Temp-Url-Key", "primary")
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	})}
+
+	_, err := swiftAuthenticate(testCtx, httpClient, "http://rgw.example.com/auth/1.0", "alice:swift", "wrong")
+	if err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Fatalf("expected authentication error, got %v", err)
+	}
+
+	session, err := swiftAuthenticate(testCtx, httpClient, "http://rgw.example.com/auth/1.0", "alice:swift", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if session.storageURL != "http://rgw.example.com/swift/v1" {
+		t.Errorf("expected storage URL from auth response, got %q", session.storageURL)
+	}
+
+	_, err = session.do(testCtx, http.MethodPost, http.Header{
+		"X-Account-Meta-Temp-Url-Key":          {"primary"},
+		"X-Remove-Account-Meta-Temp-Url-Key-2": {"x"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := posted.Get(swiftTempURLKeyHeader); got != "primary" {
+		t.Errorf("expected posted key %q, got %q", "primary", got)
+	}
+	if got := posted.Get("X-Remove-Account-Meta-Temp-URL-Key-2"); got == "" {
+		t.Error("expected secondary key removal header")
+	}
+
+	header, err := session.do(testCtx, http.MethodHead, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := optionalHeaderValue(header, swiftTempURLKeyHeader); got.ValueString() != "primary" {
+		t.Errorf("expected key %q, got %s", "primary", got)
+	}
+	if got := optionalHeaderValue(header, swiftTempURLKey2Header); !got.IsNull() {
+		t.Errorf("expected null secondary key, got %s", got)
+	}
+}
+
+func TestAccRadosgwSwiftTempURLKey_basic(t *testing.T) {
+	t.Parallel()
+
+	userID := randomName("tf-acc-tempurl")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadosgwSwiftTempURLKeyConfig_basic(userID, "first-key"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_swift_tempurl_key.test", "key", "first-key"),
+					resource.TestCheckNoResourceAttr("radosgw_swift_tempurl_key.test", "key_2"),
+					resource.TestCheckResourceAttr("radosgw_swift_tempurl_key.test", "swift_auth_path", defaultSwiftAuthPath),
+					resource.TestCheckResourceAttr("radosgw_swift_tempurl_key.test", "id", userID+":swift"),
+				),
+			},
+			// Rotate the key, keeping the previous one as the secondary key
+			{
+				Config: testAccRadosgwSwiftTempURLKeyConfig_rotated(userID, "second-key", "first-key"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("radosgw_swift_tempurl_key.test", "key", "second-key"),
+					resource.TestCheckResourceAttr("radosgw_swift_tempurl_key.test", "key_2", "first-key"),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      "radosgw_swift_tempurl_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Test configurations
+
+func testAccRadosgwSwiftTempURLKeyConfig_base(userID string) string {
+	return providerConfig() + fmt.Sprintf(`
+resource "radosgw_iam_user" "test" {
+  user_id      = %q
+  display_name = "Temp URL Test User"
+}
+
+resource "radosgw_iam_subuser" "test" {
+  user_id = radosgw_iam_user.test.user_id
+  subuser = "swift"
+  access  = "full-control"
+}
+`, userID)
+}
+
+func testAccRadosgwSwiftTempURLKeyConfig_basic(userID, key string) string {
+	return testAccRadosgwSwiftTempURLKeyConfig_base(userID) + fmt.Sprintf(`
+resource "radosgw_swift_tempurl_key" "test" {
+  user_id = radosgw_iam_subuser.test.user_id
+  subuser = radosgw_iam_subuser.test.subuser
+  key     = %q
+}
+`, key)
+}
+
+func testAccRadosgwSwiftTempURLKeyConfig_rotated(userID, key, key2 string) string {
+	return testAccRadosgwSwiftTempURLKeyConfig_base(userID) + fmt.Sprintf(`
+resource "radosgw_swift_tempurl_key" "test" {
+  user_id = radosgw_iam_subuser.test.user_id
+  subuser = radosgw_iam_subuser.test.subuser
+  key     = %q
+  key_2   = %q
+}
+`, key, key2)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Addressing styles of the S3 client. Path-style puts the bucket in the URL
+// path, virtual-host style in the host name.
+const (
+	s3AddressingStylePath    = "path"
+	s3AddressingStyleVirtual = "virtual"
+)
+
+// s3ClientAttributes returns the s3_access_key, s3_secret_key and
+// s3_addressing_style attributes of the resources that call the S3 API as
+// the bucket owner, so that a single provider can manage buckets of several
+// users.
+func s3ClientAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"s3_access_key": schema.StringAttribute{
+			MarkdownDescription: "The S3 access key to call the S3 API with instead of the credentials of the provider, " +
+				"typically the key of the bucket owner. Must be set together with `s3_secret_key`.",
+			Optional:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.AlsoRequires(path.MatchRoot("s3_secret_key")),
+			},
+		},
+		"s3_secret_key": schema.StringAttribute{
+			MarkdownDescription: "The S3 secret key matching `s3_access_key`.",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.AlsoRequires(path.MatchRoot("s3_access_key")),
+			},
+		},
+		"s3_addressing_style": schema.StringAttribute{
+			MarkdownDescription: "The addressing style of the S3 requests of this resource, overriding the " +
+				"`s3_addressing_style` of the provider. Valid values: `path`, `virtual`.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(s3AddressingStylePath, s3AddressingStyleVirtual),
+			},
+		},
+	}
+}
+
+// s3ClientFor returns the S3 client of the provider, or a copy of it signing
+// requests with the given keys when both are set and using the given
+// addressing style when set. The copy keeps the endpoint, HTTP client and
+// retry settings of the provider.
+func (c *RadosgwClient) s3ClientFor(accessKey, secretKey, addressingStyle types.String) *s3.Client {
+	hasCredentials := accessKey.ValueString() != "" && secretKey.ValueString() != ""
+	if !hasCredentials && addressingStyle.ValueString() == "" {
+		return c.S3
+	}
+
+	return s3.New(c.S3.Options(), func(o *s3.Options) {
+		if hasCredentials {
+			o.Credentials = credentials.NewStaticCredentialsProvider(accessKey.ValueString(), secretKey.ValueString(), "")
+		}
+		if addressingStyle.ValueString() != "" {
+			o.UsePathStyle = addressingStyle.ValueString() == s3AddressingStylePath
+		}
+	})
+}
+
+// signingNameSigner signs the requests of the S3 client with a custom SigV4
+// signing name instead of "s3".
+type signingNameSigner struct {
+	s3.HTTPSignerV4
+	signingName string
+}
+
+func (s signingNameSigner) SignHTTP(ctx context.Context, credentials aws.Credentials, r *http.Request, payloadHash, service, region string, signingTime time.Time, optFns ...func(*v4.SignerOptions)) error {
+	return s.HTTPSignerV4.SignHTTP(ctx, credentials, r, payloadHash, s.signingName, region, signingTime, optFns...)
+}
+
+// signingNamePresigner presigns URLs with a custom SigV4 signing name instead
+// of "s3", matching the requests signed by signingNameSigner.
+type signingNamePresigner struct {
+	s3.HTTPPresignerV4
+	signingName string
+}
+
+func (s signingNamePresigner) PresignHTTP(ctx context.Context, credentials aws.Credentials, r *http.Request, payloadHash, service, region string, signingTime time.Time, optFns ...func(*v4.SignerOptions)) (string, http.Header, error) {
+	return s.HTTPPresignerV4.PresignHTTP(ctx, credentials, r, payloadHash, s.signingName, region, signingTime, optFns...)
+}
+
+// s3PresignClient returns a presign client for the given S3 client, keeping
+// the custom signing name of the provider when one is configured.
+func s3PresignClient(client *s3.Client, expires time.Duration) *s3.PresignClient {
+	return s3.NewPresignClient(client, func(o *s3.PresignOptions) {
+		o.Expires = expires
+		if signer, ok := client.Options().HTTPSignerV4.(signingNameSigner); ok {
+			o.Presigner = signingNamePresigner{HTTPPresignerV4: v4.NewSigner(), signingName: signer.signingName}
+		}
+	})
+}
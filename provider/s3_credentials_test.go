@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestS3ClientFor(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		accessKey         types.String
+		secretKey         types.String
+		addressingStyle   types.String
+		expectShared      bool
+		expectPathStyle   bool
+		expectCredentials bool
+	}{
+		"no overrides": {
+			accessKey:       types.StringNull(),
+			secretKey:       types.StringNull(),
+			addressingStyle: types.StringNull(),
+			expectShared:    true,
+			expectPathStyle: true,
+		},
+		"credentials": {
+			accessKey:         types.StringValue("access"),
+			secretKey:         types.StringValue("secret"),
+			addressingStyle:   types.StringNull(),
+			expectPathStyle:   true,
+			expectCredentials: true,
+		},
+		"virtual addressing": {
+			accessKey:       types.StringNull(),
+			secretKey:       types.StringNull(),
+			addressingStyle: types.StringValue(s3AddressingStyleVirtual),
+			expectPathStyle: false,
+		},
+		"credentials and path addressing": {
+			accessKey:         types.StringValue("access"),
+			secretKey:         types.StringValue("secret"),
+			addressingStyle:   types.StringValue(s3AddressingStylePath),
+			expectPathStyle:   true,
+			expectCredentials: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &RadosgwClient{
+				S3: s3.New(s3.Options{Region: "default", UsePathStyle: true}),
+			}
+
+			s3Client := client.s3ClientFor(testCase.accessKey, testCase.secretKey, testCase.addressingStyle)
+
+			if (s3Client == client.S3) != testCase.expectShared {
+				t.Errorf("expected shared client %t", testCase.expectShared)
+			}
+			options := s3Client.Options()
+			if options.UsePathStyle != testCase.expectPathStyle {
+				t.Errorf("expected path style %t, got %t", testCase.expectPathStyle, options.UsePathStyle)
+			}
+			if (options.Credentials != nil) != testCase.expectCredentials {
+				t.Errorf("expected credentials %t, got %v", testCase.expectCredentials, options.Credentials)
+			}
+		})
+	}
+}
+
+func TestSigningNameSigner(t *testing.T) {
+	t.Parallel()
+
+	var authorization string
+	client := s3.New(s3.Options{
+		Region:       "zg1",
+		BaseEndpoint: aws.String("http://rgw.example.com"),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("AKEY", "SKEY", ""),
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("<ListAllMyBucketsResult/>")),
+			}, nil
+		})},
+	}, func(o *s3.Options) {
+		o.HTTPSignerV4 = signingNameSigner{HTTPSignerV4: o.HTTPSignerV4, signingName: "custom"}
+	})
+
+	if _, err := client.ListBuckets(context.Background(), &s3.ListBucketsInput{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(authorization, "/zg1/custom/aws4_request") {
+		t.Errorf("expected the custom signing name and region in the credential scope, got %q", authorization)
+	}
+}
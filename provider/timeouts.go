@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Operations that can be bounded by the timeouts block of a resource.
+const (
+	timeoutCreate = "create"
+	timeoutRead   = "read"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// operationTimeouts holds the default timeout of each operation of a
+// resource. A zero timeout leaves the operation out of the timeouts block.
+type operationTimeouts map[string]time.Duration
+
+// block returns the timeouts block of a resource, which mirrors the block of
+// other providers: `timeouts { delete = "2h" }`.
+func (t operationTimeouts) block() schema.SingleNestedBlock {
+	attributes := make(map[string]schema.Attribute, len(t))
+	for _, operation := range sortedKeys(t) {
+		attributes[operation] = schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s operation, as a Go duration such as `30m` or `2h`. "+
+				"Default is `%s`.", operation, formatTimeout(t[operation])),
+			Optional:   true,
+			Validators: []validator.String{timeoutValidator{}},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Timeouts of the operations of the resource. When an operation takes longer, it is " +
+			"cancelled and fails; the `request_timeout` of the provider still bounds every single request.",
+		Attributes: attributes,
+	}
+}
+
+// withTimeout returns a context bounded by the configured or default timeout
+// of the operation.
+func (t operationTimeouts) withTimeout(ctx context.Context, timeouts types.Object, operation string, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	timeout := t[operation]
+
+	if !timeouts.IsNull() && !timeouts.IsUnknown() {
+		if value, ok := timeouts.Attributes()[operation].(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			parsed, err := time.ParseDuration(value.ValueString())
+			if err != nil || parsed <= 0 {
+				diags.AddError(
+					"Invalid Timeout",
+					fmt.Sprintf("The %s timeout must be a positive Go duration such as 30m, got %q.", operation, value.ValueString()),
+				)
+				return context.WithCancel(ctx)
+			}
+			timeout = parsed
+		}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// formatTimeout formats a default timeout the way users write them.
+func formatTimeout(timeout time.Duration) string {
+	switch {
+	case timeout%time.Hour == 0:
+		return fmt.Sprintf("%dh", timeout/time.Hour)
+	case timeout%time.Minute == 0:
+		return fmt.Sprintf("%dm", timeout/time.Minute)
+	default:
+		return timeout.String()
+	}
+}
+
+// timeoutValidator validates that a timeout is a positive Go duration.
+type timeoutValidator struct{}
+
+func (v timeoutValidator) Description(ctx context.Context) string {
+	return "value must be a positive Go duration such as 30m"
+}
+
+func (v timeoutValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive Go duration such as `30m`"
+}
+
+func (v timeoutValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || timeout <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timeout",
+			fmt.Sprintf("Must be a positive Go duration such as 30m or 2h, got %q.", req.ConfigValue.ValueString()),
+		)
+	}
+}
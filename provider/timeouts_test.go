@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOperationTimeouts(t *testing.T) {
+	t.Parallel()
+
+	timeouts := operationTimeouts{
+		timeoutCreate: 20 * time.Minute,
+		timeoutDelete: time.Hour,
+	}
+	attrTypes := map[string]attr.Type{
+		timeoutCreate: types.StringType,
+		timeoutDelete: types.StringType,
+	}
+	configured := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		timeoutCreate: types.StringNull(),
+		timeoutDelete: types.StringValue("6h"),
+	})
+
+	tests := []struct {
+		name      string
+		timeouts  types.Object
+		operation string
+		expected  time.Duration
+	}{
+		{"default without block", types.ObjectNull(attrTypes), timeoutDelete, time.Hour},
+		{"default for unset operation", configured, timeoutCreate, 20 * time.Minute},
+		{"configured operation", configured, timeoutDelete, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			start := time.Now()
+			ctx, cancel := timeouts.withTimeout(context.Background(), tt.timeouts, tt.operation, &diags)
+			defer cancel()
+
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("expected a deadline")
+			}
+			if got := deadline.Sub(start); got < tt.expected || got > tt.expected+time.Minute {
+				t.Errorf("expected a timeout of %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	invalid := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		timeoutCreate: types.StringValue("soon"),
+		timeoutDelete: types.StringNull(),
+	})
+	var diags diag.Diagnostics
+	_, cancel := timeouts.withTimeout(context.Background(), invalid, timeoutCreate, &diags)
+	defer cancel()
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid timeout")
+	}
+}
+
+func TestFormatTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[time.Duration]string{
+		time.Hour:        "1h",
+		20 * time.Minute: "20m",
+		90 * time.Second: "1m30s",
+	}
+	for timeout, expected := range tests {
+		if got := formatTimeout(timeout); got != expected {
+			t.Errorf("formatTimeout(%s) = %q, expected %q", timeout, got, expected)
+		}
+	}
+}
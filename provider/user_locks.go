@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// userLockTTL is how long the lock of a user is kept after its last use.
+const userLockTTL = 10 * time.Minute
+
+// userLocks serializes the Admin API operations modifying a user. RadosGW
+// stores a user, its keys, subusers, caps and quota in a single metadata
+// object, so concurrent read-modify-write cycles on the same user lose
+// changes on older releases and fail with ConcurrentModification on newer
+// ones. The registry is shared by all provider instances, as aliased
+// providers usually point at the same gateway.
+var userLocks = newUserLockRegistry(userLockTTL)
+
+// userLockRegistry hands out one lock per user ID. Locks nobody holds or
+// waits for are evicted once unused for ttl, so the registry does not grow
+// with every user an apply touches.
+type userLockRegistry struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	locks     map[string]*userLock
+	lastSweep time.Time
+}
+
+// userLock is the lock of a single user.
+type userLock struct {
+	mu sync.Mutex
+	// refs counts the goroutines holding or waiting for mu, and is guarded
+	// by the mutex of the registry like lastUsed.
+	refs     int
+	lastUsed time.Time
+}
+
+func newUserLockRegistry(ttl time.Duration) *userLockRegistry {
+	return &userLockRegistry{
+		ttl:   ttl,
+		now:   time.Now,
+		locks: map[string]*userLock{},
+	}
+}
+
+// lock blocks until no other operation holds the lock of userID, and returns
+// the function releasing it. The lock is not reentrant: callers must release
+// it before calling code that locks the same user.
+func (r *userLockRegistry) lock(userID string) (unlock func()) {
+	r.mu.Lock()
+	r.evictLocked()
+	lock, ok := r.locks[userID]
+	if !ok {
+		lock = &userLock{}
+		r.locks[userID] = lock
+	}
+	lock.refs++
+	r.mu.Unlock()
+
+	lock.mu.Lock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			lock.mu.Unlock()
+
+			r.mu.Lock()
+			lock.refs--
+			lock.lastUsed = r.now()
+			r.mu.Unlock()
+		})
+	}
+}
+
+// evictLocked removes the locks unused for ttl, at most once per ttl. The
+// mutex of the registry must be held.
+func (r *userLockRegistry) evictLocked() {
+	now := r.now()
+	if now.Sub(r.lastSweep) < r.ttl {
+		return
+	}
+	r.lastSweep = now
+
+	for userID, lock := range r.locks {
+		if lock.refs == 0 && now.Sub(lock.lastUsed) >= r.ttl {
+			delete(r.locks, userID)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUserLockRegistry_serializes(t *testing.T) {
+	t.Parallel()
+
+	registry := newUserLockRegistry(time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer registry.lock("alice")()
+
+			mu.Lock()
+			active++
+			maxActive = max(maxActive, active)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Errorf("expected operations on the same user to be serialized, got %d concurrent", maxActive)
+	}
+}
+
+func TestUserLockRegistry_independentUsers(t *testing.T) {
+	t.Parallel()
+
+	registry := newUserLockRegistry(time.Minute)
+
+	unlockAlice := registry.lock("alice")
+	defer unlockAlice()
+
+	done := make(chan struct{})
+	go func() {
+		defer registry.lock("bob")()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock of another user blocked")
+	}
+}
+
+func TestUserLockRegistry_eviction(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	registry := newUserLockRegistry(10 * time.Minute)
+	registry.now = func() time.Time { return now }
+
+	registry.lock("alice")()
+	unlockBob := registry.lock("bob")
+
+	// Locks are kept while they are recent or held
+	now = now.Add(5 * time.Minute)
+	registry.lock("carol")()
+	if len(registry.locks) != 3 {
+		t.Fatalf("expected 3 locks, got %d", len(registry.locks))
+	}
+
+	now = now.Add(10 * time.Minute)
+	registry.lock("carol")()
+	if _, ok := registry.locks["alice"]; ok {
+		t.Error("expected the unused lock of alice to be evicted")
+	}
+	if _, ok := registry.locks["bob"]; !ok {
+		t.Error("expected the held lock of bob to be kept")
+	}
+	if _, ok := registry.locks["carol"]; !ok {
+		t.Error("expected the lock of carol to be kept")
+	}
+
+	// Releasing twice must not unlock a lock taken since
+	unlockBob()
+	unlockBob()
+	if registry.locks["bob"].refs != 0 {
+		t.Errorf("expected no references to the lock of bob, got %d", registry.locks["bob"].refs)
+	}
+}
@@ -1,76 +1,382 @@
 package provider
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // =============================================================================
-// Retry Utilities
+// Request Throttling and Retries
 // =============================================================================
 
 const (
-	// DefaultOperationTimeout is the default timeout for retryable operations.
-	// Based on AWS examples best practices, 2 minutes is a reasonable default.
-	DefaultOperationTimeout = 2 * time.Minute
+	// defaultMaxRetries is the number of times a failed request is retried
+	// when max_retries is not set.
+	defaultMaxRetries = 10
+
+	// defaultRetryMaxBackoff caps the delay between two attempts of a request
+	// when retry_max_backoff is not set.
+	defaultRetryMaxBackoff = 10 * time.Second
+
+	// retryBaseDelay is the delay before the first retry, doubled on every
+	// following attempt.
+	retryBaseDelay = 500 * time.Millisecond
 )
 
-// isConcurrentModificationError checks if an error is a ConcurrentModification error.
-// Note: go-ceph doesn't expose this as a typed error, so we check the error string.
-func isConcurrentModificationError(err error) bool {
-	if err == nil {
-		return false
+// retryConfig holds the retry and timeout settings shared by the Admin, IAM
+// and S3 clients.
+type retryConfig struct {
+	MaxRetries int
+	MaxBackoff time.Duration
+
+	// RequestTimeout bounds every single attempt of a request. Zero means no
+	// timeout.
+	RequestTimeout time.Duration
+}
+
+// retryTransport is the http.RoundTripper of the Admin API and IAM clients.
+// It retries requests that RadosGW rejected with ConcurrentModification or
+// because it was overloaded, with exponential backoff and jitter. For the
+// Admin API it also caps the number of requests in flight across all
+// resources.
+type retryTransport struct {
+	base http.RoundTripper
+
+	// sem limits the requests in flight. It is nil when unlimited.
+	sem chan struct{}
+
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	timeout    time.Duration
+}
+
+// newRetryTransport returns a retryTransport wrapping base. A maxConcurrent
+// of zero does not limit the number of requests in flight.
+func newRetryTransport(base http.RoundTripper, maxConcurrent int, retry retryConfig) *retryTransport {
+	t := &retryTransport{
+		base:       base,
+		maxRetries: retry.MaxRetries,
+		baseDelay:  min(retryBaseDelay, retry.MaxBackoff),
+		maxDelay:   retry.MaxBackoff,
+		timeout:    retry.RequestTimeout,
 	}
-	return strings.Contains(err.Error(), "ConcurrentModification")
+	if maxConcurrent > 0 {
+		t.sem = make(chan struct{}, maxConcurrent)
+	}
+	return t
 }
 
-// retryOnConcurrentModification wraps an operation with retry logic for ConcurrentModification errors
-// using retry.RetryContext helper.
-func retryOnConcurrentModification(ctx context.Context, operation string, fn func() error) error {
-	var lastErr error
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.roundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
 
-	err := retry.RetryContext(ctx, DefaultOperationTimeout, func() *retry.RetryError {
-		lastErr = fn()
-		if lastErr == nil {
-			return nil
+		reason, resp, err := retryReason(resp)
+		if err != nil || reason == "" {
+			return resp, err
 		}
 
-		// Only retry on ConcurrentModification errors
-		if isConcurrentModificationError(lastErr) {
-			tflog.Debug(ctx, "ConcurrentModification detected, retrying", map[string]any{
-				"operation": operation,
-				"error":     lastErr.Error(),
+		// Requests with a body that cannot be replayed are not retried
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		if attempt >= t.maxRetries {
+			tflog.Warn(ctx, "Request still failing, giving up", map[string]any{
+				"method":   req.Method,
+				"path":     req.URL.Path,
+				"reason":   reason,
+				"attempts": attempt + 1,
 			})
-			return retry.RetryableError(lastErr)
+			return resp, nil
 		}
 
-		// All other errors are non-retryable
-		return retry.NonRetryableError(lastErr)
-	})
+		delay := retryDelay(attempt, t.baseDelay, t.maxDelay)
+		tflog.Debug(ctx, "Retrying request", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"reason":  reason,
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		})
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTrip sends a single attempt of req, waiting for a free slot first.
+// The attempt is canceled when it takes longer than the request timeout.
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		defer func() { <-t.sem }()
+	}
+
+	if t.timeout == 0 {
+		return t.base.RoundTrip(req)
+	}
 
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
 	if err != nil {
-		tflog.Warn(ctx, "Operation failed", map[string]any{
-			"operation": operation,
-			"error":     err.Error(),
-		})
+		cancel()
+		return nil, err
 	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the timeout of an attempt once its response
+// body has been consumed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
 
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
 	return err
 }
 
+// retryReason returns why resp should be retried, or an empty string if it
+// should not. The response body is read and replaced, so that resp can still
+// be consumed by the caller.
+func retryReason(resp *http.Response) (string, *http.Response, error) {
+	switch resp.StatusCode {
+	case http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		return http.StatusText(resp.StatusCode), resp, nil
+	case http.StatusConflict:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		// The Admin API reports errors as JSON and the IAM API as XML
+		if bytes.Contains(body, []byte("ConcurrentModification")) {
+			return "ConcurrentModification", resp, nil
+		}
+	}
+
+	return "", resp, nil
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// retryDelay returns the backoff before the attempt following the given one:
+// an exponentially growing delay capped at maxDelay, with full jitter.
+func retryDelay(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if attempt < 30 {
+		delay = min(baseDelay<<attempt, maxDelay)
+	}
+	return time.Duration(rand.Int64N(int64(delay))) + 1
+}
+
+// =============================================================================
+// Endpoint Failover
+// =============================================================================
+
+// endpointCooldown is how long an endpoint whose connection failed is skipped
+// before requests are sent to it again.
+const endpointCooldown = 30 * time.Second
+
+// failoverTransport spreads the requests of all clients over several RadosGW
+// gateways. The clients are configured with the first endpoint; every request
+// is sent to the next endpoint in round-robin order instead. When the
+// connection to an endpoint fails, the endpoint is skipped for
+// endpointCooldown and the request is sent to the next one, so that a single
+// gateway restart does not fail an apply.
+//
+// The Host header of the request is kept, so that the signature computed for
+// the first endpoint stays valid on the other gateways. Requests to other
+// hosts, such as the gateways of other multisite zones, are sent unchanged.
+type failoverTransport struct {
+	base      http.RoundTripper
+	endpoints []*url.URL
+
+	next atomic.Uint64
+
+	mu        sync.Mutex
+	downUntil []time.Time
+}
+
+// newFailoverTransport returns a failoverTransport wrapping base. The
+// endpoints must be normalized and differ only by scheme and host.
+func newFailoverTransport(base http.RoundTripper, endpoints []string) (*failoverTransport, error) {
+	t := &failoverTransport{
+		base:      base,
+		endpoints: make([]*url.URL, 0, len(endpoints)),
+		downUntil: make([]time.Time, len(endpoints)),
+	}
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		t.endpoints = append(t.endpoints, u)
+	}
+	return t, nil
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.isEndpoint(req.URL) {
+		return t.base.RoundTrip(req)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	var lastErr error
+	for attempt, i := range t.order() {
+		attemptReq := req.Clone(ctx)
+		if attempt > 0 {
+			// A body that cannot be replayed cannot be sent to another endpoint
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				break
+			}
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+		attemptReq.URL.Scheme = t.endpoints[i].Scheme
+		attemptReq.URL.Host = t.endpoints[i].Host
+		attemptReq.Host = host
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err == nil {
+			t.setDownUntil(i, time.Time{})
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		tflog.Warn(ctx, "RadosGW endpoint failed, trying the next one", map[string]any{
+			"endpoint": t.endpoints[i].Host,
+			"error":    err.Error(),
+		})
+		t.setDownUntil(i, time.Now().Add(endpointCooldown))
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// isEndpoint reports whether u points at one of the endpoints.
+func (t *failoverTransport) isEndpoint(u *url.URL) bool {
+	for _, endpoint := range t.endpoints {
+		if strings.EqualFold(u.Host, endpoint.Host) && u.Scheme == endpoint.Scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// order returns the indexes of the endpoints to try for a request: the
+// healthy endpoints in round-robin order, followed by those cooling down, so
+// that requests still go through when every endpoint failed recently.
+func (t *failoverTransport) order() []int {
+	start := int(t.next.Add(1) - 1)
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	healthy := make([]int, 0, len(t.endpoints))
+	var down []int
+	for n := range t.endpoints {
+		i := (start + n) % len(t.endpoints)
+		if now.Before(t.downUntil[i]) {
+			down = append(down, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, down...)
+}
+
+func (t *failoverTransport) setDownUntil(i int, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.downUntil[i] = until
+}
+
 // =============================================================================
 // IAM Client and AWS SigV4 Signing
 // =============================================================================
@@ -85,11 +391,16 @@ type HTTPClient interface {
 // This client uses AWS SigV4 signing and can be used for OIDC providers,
 // roles, policies, and other IAM-like operations supported by RadosGW.
 type IAMClient struct {
-	Endpoint   string
-	AccessKey  string
-	SecretKey  string
-	HTTPClient HTTPClient
-	Signer     *v4.Signer
+	Endpoint string
+	// Credentials are retrieved for every request, so that temporary STS
+	// credentials are refreshed before they expire.
+	Credentials aws.CredentialsProvider
+	// Region and SigningName are the SigV4 credential scope of the requests.
+	// RadosGW accepts an empty region unless it is configured to check it.
+	Region      string
+	SigningName string
+	HTTPClient  HTTPClient
+	Signer      *v4.Signer
 }
 
 // NewIAMClient creates a new IAM client for RadosGW.
@@ -98,47 +409,49 @@ func NewIAMClient(endpoint, accessKey, secretKey string, httpClient HTTPClient)
 		httpClient = http.DefaultClient
 	}
 	return &IAMClient{
-		Endpoint:   endpoint,
-		AccessKey:  accessKey,
-		SecretKey:  secretKey,
-		HTTPClient: httpClient,
-		Signer:     v4.NewSigner(),
+		Endpoint:    endpoint,
+		Credentials: credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+		SigningName: defaultSigningName,
+		HTTPClient:  httpClient,
+		Signer:      v4.NewSigner(),
 	}
 }
 
 // emptyPayloadHash is the SHA256 hash of an empty string
 const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
 
-// DoRequest executes a signed IAM API request and returns the response body.
-// The service parameter should be "iam" for IAM operations or "sts" for STS operations.
+// DoRequest executes a signed IAM, STS or SNS API request and returns the
+// response body. The service parameter is "iam", "sts" or "sns" and is only
+// used for logging.
+//
+// The parameters are sent form-encoded in the POST body, as the AWS SDKs do.
+// The signature then covers the exact bytes of the body, so values such as
+// policy documents containing '+' or spaces are not subject to differences
+// between the query string canonicalization of the signer and of RadosGW.
 func (c *IAMClient) DoRequest(ctx context.Context, params url.Values, service string) ([]byte, error) {
-	// Build the full URL with query parameters
-	reqURL := fmt.Sprintf("%s/?%s", c.Endpoint, params.Encode())
-
 	tflog.Debug(ctx, "Making IAM API request", map[string]interface{}{
 		"action":   params.Get("Action"),
 		"service":  service,
 		"endpoint": c.Endpoint,
 	})
 
-	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, nil)
+	encodedBody := params.Encode()
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/", strings.NewReader(encodedBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	// Set required headers
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
 	req.Header.Set("Host", req.URL.Host)
 
-	// Create credentials for signing
-	credentials := aws.Credentials{
-		AccessKeyID:     c.AccessKey,
-		SecretAccessKey: c.SecretKey,
+	creds, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
 	}
 
-	// Sign the request using AWS SDK v4 signer
-	// Using empty region for RadosGW compatibility
-	// The service is typically "iam" for IAM operations, but RadosGW uses "s3" for signing
-	err = c.Signer.SignHTTP(ctx, credentials, req, emptyPayloadHash, "s3", "", time.Now())
+	// RadosGW signs IAM requests with the "s3" service name by default
+	payloadHash := HashPayload([]byte(encodedBody))
+	err = c.Signer.SignHTTP(ctx, creds, req, payloadHash, c.SigningName, c.Region, time.Now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
@@ -149,14 +462,15 @@ func (c *IAMClient) DoRequest(ctx context.Context, params url.Values, service st
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponseBody(resp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	// The body is not logged, as responses include STS credentials and access keys
 	tflog.Debug(ctx, "Received IAM API response", map[string]interface{}{
 		"status_code": resp.StatusCode,
-		"body":        string(body),
+		"body_length": len(body),
 	})
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -166,6 +480,35 @@ func (c *IAMClient) DoRequest(ctx context.Context, params url.Values, service st
 	return body, nil
 }
 
+// DoRequestXML is DoRequest that decodes the XML response into out. API
+// errors are returned as *IAMError, and undecodable responses as
+// *IAMResponseError.
+func (c *IAMClient) DoRequestXML(ctx context.Context, params url.Values, service string, out any) error {
+	body, err := c.DoRequest(ctx, params, service)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, out); err != nil {
+		return &IAMResponseError{Action: params.Get("Action"), Err: err}
+	}
+	return nil
+}
+
+// readResponseBody reads the body of resp, decompressing it when the
+// transport left it gzip-encoded (custom HTTP clients may not decompress it).
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.ReadAll(resp.Body)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
 // =============================================================================
 // IAM Error Types
 // =============================================================================
@@ -204,6 +547,20 @@ func (e *IAMError) Is(target error) bool {
 	return e.Code == t.Code
 }
 
+// IAMResponseError reports an IAM API response that could not be decoded.
+type IAMResponseError struct {
+	Action string
+	Err    error
+}
+
+func (e *IAMResponseError) Error() string {
+	return fmt.Sprintf("could not parse %s response: %s", e.Action, e.Err)
+}
+
+func (e *IAMResponseError) Unwrap() error {
+	return e.Err
+}
+
 // Common IAM error codes
 var (
 	ErrNoSuchEntity        = &IAMError{Code: "NoSuchEntity"}
@@ -245,65 +602,856 @@ func (c *IAMClient) parseErrorResponse(statusCode int, body []byte, action strin
 	}
 }
 
-// DoPostRequest executes a signed API request with parameters sent in the
-// POST body as application/x-www-form-urlencoded, rather than in the URL
-// query string.  This is required for SNS CreateTopic because RadosGW only
-// parses Attributes.entry.N.key/value parameters from the POST body.
-func (c *IAMClient) DoPostRequest(ctx context.Context, params url.Values, service string) ([]byte, error) {
-	tflog.Debug(ctx, "Making API POST-body request", map[string]interface{}{
-		"action":   params.Get("Action"),
-		"service":  service,
+// HashPayload computes the SHA256 hash of a payload.
+func HashPayload(payload []byte) string {
+	if len(payload) == 0 {
+		return emptyPayloadHash
+	}
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// =============================================================================
+// Admin Ops API Client
+// =============================================================================
+
+// AdminClient performs signed requests against RadosGW Admin Ops API
+// endpoints that go-ceph does not expose in its stable API (for example
+// accounts, which are only available in go-ceph preview builds).
+type AdminClient struct {
+	Endpoint   string
+	AccessKey  string
+	SecretKey  string
+	HTTPClient HTTPClient
+	Signer     *v4.Signer
+}
+
+// NewAdminClient creates a new Admin Ops API client sharing the connection
+// settings of the given go-ceph admin client.
+func NewAdminClient(api *admin.API) *AdminClient {
+	httpClient := api.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &AdminClient{
+		Endpoint:   api.Endpoint,
+		AccessKey:  api.AccessKey,
+		SecretKey:  api.SecretKey,
+		HTTPClient: httpClient,
+		Signer:     v4.NewSigner(),
+	}
+}
+
+// DoRequest executes a signed Admin Ops API request and returns the response body.
+// The path is relative to the "/admin" prefix (e.g. "/account"). Paths may carry
+// a bare query key such as "/account?quota", in which case params are appended.
+func (c *AdminClient) DoRequest(ctx context.Context, method, path string, params url.Values) ([]byte, error) {
+	body, _, err := c.doRequest(ctx, method, path, params)
+	return body, err
+}
+
+// DoRequestWithBody is DoRequest for the endpoints that read a JSON document
+// from the request body, such as the metadata endpoints.
+func (c *AdminClient) DoRequestWithBody(ctx context.Context, method, path string, params url.Values, requestBody []byte) ([]byte, error) {
+	body, _, err := c.doRequestWithBody(ctx, method, path, params, requestBody)
+	return body, err
+}
+
+// doRequest is DoRequest that also returns the response headers.
+func (c *AdminClient) doRequest(ctx context.Context, method, path string, params url.Values) ([]byte, http.Header, error) {
+	return c.doRequestWithBody(ctx, method, path, params, nil)
+}
+
+func (c *AdminClient) doRequestWithBody(ctx context.Context, method, path string, params url.Values, requestBody []byte) ([]byte, http.Header, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("format", "json")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	reqURL := fmt.Sprintf("%s/admin%s%s%s", c.Endpoint, path, separator, params.Encode())
+
+	tflog.Debug(ctx, "Making Admin API request", map[string]interface{}{
+		"method":   method,
+		"path":     path,
 		"endpoint": c.Endpoint,
 	})
 
-	encodedBody := params.Encode()
-	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/", strings.NewReader(encodedBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	var reader io.Reader
+	if requestBody != nil {
+		reader = bytes.NewReader(requestBody)
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Host", req.URL.Host)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	credentials := aws.Credentials{
 		AccessKeyID:     c.AccessKey,
 		SecretAccessKey: c.SecretKey,
 	}
 
-	payloadHash := HashPayload([]byte(encodedBody))
-	err = c.Signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", "", time.Now())
+	// The Admin Ops API uses the same signing parameters as go-ceph
+	err = c.Signer.SignHTTP(ctx, credentials, req, "UNSIGNED-PAYLOAD", "s3", "default", time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign request: %w", err)
+		return nil, nil, fmt.Errorf("failed to sign request: %w", err)
 	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	tflog.Debug(ctx, "Received API POST-body response", map[string]interface{}{
+	// The body is not logged, as responses include the keys of users
+	tflog.Debug(ctx, "Received Admin API response", map[string]interface{}{
 		"status_code": resp.StatusCode,
-		"body":        string(body),
+		"body_length": len(body),
 	})
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, c.parseErrorResponse(resp.StatusCode, body, params.Get("Action"))
+		return nil, resp.Header, parseAdminErrorResponse(resp.StatusCode, body)
 	}
 
-	return body, nil
+	return body, resp.Header, nil
 }
 
-// HashPayload computes the SHA256 hash of a payload.
-func HashPayload(payload []byte) string {
-	if len(payload) == 0 {
-		return emptyPayloadHash
+// AdminError represents a parsed Admin Ops API error.
+type AdminError struct {
+	Code       string
+	StatusCode int
+}
+
+func (e *AdminError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Code, e.StatusCode)
+}
+
+// Is implements error comparison for AdminError.
+func (e *AdminError) Is(target error) bool {
+	t, ok := target.(*AdminError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+func parseAdminErrorResponse(statusCode int, body []byte) error {
+	var errResp struct {
+		Code string `json:"Code"`
+	}
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Code != "" {
+		return &AdminError{Code: errResp.Code, StatusCode: statusCode}
+	}
+
+	return &AdminError{Code: "UnknownError", StatusCode: statusCode}
+}
+
+// isAdminNotFoundError checks if an Admin Ops API error is an HTTP 404.
+func isAdminNotFoundError(err error) bool {
+	var adminErr *AdminError
+	if errors.As(err, &adminErr) {
+		return adminErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// adminErrorMessages explains common Admin API error codes.
+var adminErrorMessages = map[string]string{
+	"NoSuchUser":            "the user does not exist",
+	"NoSuchSubUser":         "the subuser does not exist",
+	"NoSuchBucket":          "the bucket does not exist",
+	"NoSuchKey":             "the access key does not exist",
+	"UserAlreadyExists":     "a user with this ID already exists",
+	"BucketAlreadyExists":   "a bucket with this name already exists",
+	"KeyExists":             "the access key already belongs to another user",
+	"EmailExists":           "the email address is already used by another user",
+	"InvalidAccessKeyId":    "RadosGW does not know the provider access key, check the access_key provider attribute",
+	"SignatureDoesNotMatch": "the provider secret key does not match the access key, check the secret_key provider attribute",
+}
+
+// adminErrorCode returns the code of an error returned by the Admin API
+// through go-ceph or AdminClient, or an empty string for other errors.
+func adminErrorCode(err error) string {
+	var adminErr *AdminError
+	if errors.As(err, &adminErr) {
+		return adminErr.Code
+	}
+
+	// go-ceph does not export its error type, which prints the code first
+	var statusErr interface {
+		error
+		Is(error) bool
+	}
+	if errors.As(err, &statusErr) {
+		code, _, _ := strings.Cut(statusErr.Error(), " ")
+		return code
+	}
+	return ""
+}
+
+// adminError explains the common errors of the Admin API, which only report
+// an error code. requiredCap is the capability the failed call needs (for
+// example "users=write"); it is named when access is denied. Other errors
+// are returned unchanged.
+func adminError(err error, requiredCap string) error {
+	code := adminErrorCode(err)
+	if code == "AccessDenied" && requiredCap != "" {
+		return fmt.Errorf("access denied, the provider user may lack the %s capability. Grant it with "+
+			"`radosgw-admin caps add --uid=<user> --caps=%q` or the radosgw_admin_caps resource (%w)",
+			requiredCap, requiredCap, err)
+	}
+	if message, ok := adminErrorMessages[code]; ok {
+		return fmt.Errorf("%s (%w)", message, err)
+	}
+	return err
+}
+
+// =============================================================================
+// User Dependency Utilities
+// =============================================================================
+
+// addUserDeletedFirstWarning reports that a resource attached to a user was
+// deleted after the user itself. Deleting a user removes its keys, subusers,
+// capabilities and quotas, so the resource is gone; the warning points at the
+// missing dependency that let Terraform delete the user first.
+func addUserDeletedFirstWarning(diags *diag.Diagnostics, what, userID string) {
+	diags.AddWarning(
+		"User Already Deleted",
+		fmt.Sprintf("User %q no longer exists, so its %s were removed along with it. If the user is managed in "+
+			"the same configuration, reference it as user_id = radosgw_iam_user.<name>.user_id so Terraform "+
+			"deletes the %s before the user.", userID, what, what),
+	)
+}
+
+// =============================================================================
+// Deletion Protection Utilities
+// =============================================================================
+
+// checkDeletionProtection reports an error when a resource with
+// deletion_protection enabled is about to be deleted, and returns whether the
+// deletion may proceed. what names the resource, for example `bucket "logs"`.
+func checkDeletionProtection(diags *diag.Diagnostics, deletionProtection types.Bool, what string) bool {
+	if !deletionProtection.ValueBool() {
+		return true
+	}
+
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("Cannot delete %s because deletion_protection is enabled. Set deletion_protection = false and "+
+			"apply the change before deleting or replacing it.", what),
+	)
+	return false
+}
+
+// =============================================================================
+// Tenant Utilities
+// =============================================================================
+
+// s3BucketName returns the bucket name as addressed through the S3 API.
+// Buckets of a tenant are addressed as "tenant:bucket".
+func s3BucketName(tenant, bucket string) string {
+	if tenant == "" {
+		return bucket
+	}
+	return tenant + ":" + bucket
+}
+
+// adminBucketName returns the bucket name as addressed through the Admin Ops
+// API. Buckets of a tenant are addressed as "tenant/bucket".
+func adminBucketName(tenant, bucket string) string {
+	if tenant == "" {
+		return bucket
+	}
+	return tenant + "/" + bucket
+}
+
+// splitBucketID splits a tenant-qualified bucket name into its tenant and
+// bucket. Both the S3 form "tenant:bucket" and the Admin Ops form
+// "tenant/bucket" are accepted; the tenant is empty for other names.
+func splitBucketID(id string) (tenant, bucket string) {
+	if idx := strings.IndexAny(id, ":/"); idx != -1 {
+		return id[:idx], id[idx+1:]
+	}
+	return "", id
+}
+
+// splitUserID splits a tenant-qualified user ID of the form "tenant$user_id"
+// into its tenant and user ID; the tenant is empty for other IDs.
+func splitUserID(id string) (tenant, userID string) {
+	if idx := strings.Index(id, "$"); idx != -1 {
+		return id[:idx], id[idx+1:]
+	}
+	return "", id
+}
+
+// importBucketState sets the bucket and tenant attributes of a resource
+// scoped to a bucket from an import ID of the form "bucket", "tenant:bucket"
+// or "tenant/bucket", and returns the tenant and bucket. The tenant is left
+// unset for buckets without a tenant, matching configurations that omit it.
+func importBucketState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) (tenant, bucket string) {
+	tenant, bucket = splitBucketID(req.ID)
+	if bucket == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID %q must be the bucket name, given as \"tenant:bucket\" for buckets of a tenant.", req.ID),
+		)
+		return "", ""
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	if tenant != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	return tenant, bucket
+}
+
+// =============================================================================
+// Zone Utilities
+// =============================================================================
+
+// zoneStatus describes the multisite role of the zone served by the provider
+// endpoint.
+type zoneStatus struct {
+	RealmID        string
+	ZoneName       string
+	ZonegroupName  string
+	MasterZoneName string
+	IsMaster       bool
+	ReadOnly       bool
+}
+
+// periodJSON is the subset of the current period returned by
+// GET /admin/realm/period that is needed to locate the local zone and the
+// zones it replicates from.
+type periodJSON struct {
+	RealmID    string `json:"realm_id"`
+	MasterZone string `json:"master_zone"`
+	PeriodMap  struct {
+		Zonegroups []struct {
+			Name  string       `json:"name"`
+			Zones []periodZone `json:"zones"`
+		} `json:"zonegroups"`
+	} `json:"period_map"`
+}
+
+// periodZone is a zone of a zonegroup in the current period.
+type periodZone struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Endpoints []string `json:"endpoints"`
+	ReadOnly  jsonBool `json:"read_only"`
+}
+
+// jsonBool decodes booleans that older RadosGW releases encode as strings.
+type jsonBool bool
+
+func (b *jsonBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// zoneNameFromRequestID extracts the zone name from a RadosGW request ID,
+// which has the form "tx<id>-<timestamp>-<instance>-<zone name>".
+func zoneNameFromRequestID(requestID string) string {
+	parts := strings.SplitN(requestID, "-", 4)
+	if len(parts) != 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// getPeriod returns the current period and the name of the zone serving the
+// endpoint, taken from the request ID of the period request. The period is
+// nil for gateways without a realm.
+func (c *AdminClient) getPeriod(ctx context.Context) (*periodJSON, string, error) {
+	body, header, err := c.doRequest(ctx, http.MethodGet, "/realm/period", nil)
+	if header == nil && err != nil {
+		return nil, "", err
+	}
+
+	zoneName := zoneNameFromRequestID(header.Get("X-Amz-Request-Id"))
+	if err != nil {
+		if isAdminNotFoundError(err) {
+			return nil, zoneName, nil
+		}
+		return nil, "", err
+	}
+
+	var period periodJSON
+	if err := json.Unmarshal(body, &period); err != nil {
+		return nil, "", fmt.Errorf("failed to parse period: %w", err)
+	}
+	return &period, zoneName, nil
+}
+
+// GetZoneStatus reports the multisite role of the zone serving the endpoint.
+// The zone is identified by the request ID of the period request, and its
+// role by the current period. Gateways without a realm serve a single zone,
+// which is always the master. Requires the zone=read capability.
+func (c *AdminClient) GetZoneStatus(ctx context.Context) (*zoneStatus, error) {
+	period, zoneName, err := c.getPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return &zoneStatus{ZoneName: zoneName, MasterZoneName: zoneName, IsMaster: true}, nil
+	}
+
+	status := &zoneStatus{RealmID: period.RealmID}
+	found := false
+	for _, zonegroup := range period.PeriodMap.Zonegroups {
+		for _, zone := range zonegroup.Zones {
+			if zone.ID == period.MasterZone {
+				status.MasterZoneName = zone.Name
+			}
+			if zone.Name == zoneName {
+				status.ZoneName = zone.Name
+				status.ZonegroupName = zonegroup.Name
+				status.IsMaster = zone.ID == period.MasterZone
+				status.ReadOnly = bool(zone.ReadOnly)
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("zone %q of the endpoint is not part of the current period", zoneName)
+	}
+
+	return status, nil
+}
+
+// zoneWriteError explains write errors caused by pointing the provider at a
+// zone that cannot accept the change. Access denied and unavailable errors of
+// mutating S3 calls are checked against the zone status of the endpoint; any
+// other error, or a write to a writable master zone, is returned unchanged.
+func zoneWriteError(ctx context.Context, api *admin.API, err error) error {
+	var respErr *awshttp.ResponseError
+	if !errors.As(err, &respErr) {
+		return err
+	}
+	if code := respErr.HTTPStatusCode(); code != http.StatusForbidden && code != http.StatusServiceUnavailable {
+		return err
+	}
+
+	status, zoneErr := NewAdminClient(api).GetZoneStatus(ctx)
+	if zoneErr != nil {
+		tflog.Debug(ctx, "Could not determine zone status", map[string]any{
+			"error": zoneErr.Error(),
+		})
+		return err
+	}
+
+	switch {
+	case status.ReadOnly:
+		return fmt.Errorf("the provider endpoint serves zone %q, which is read-only; point the provider at "+
+			"the endpoint of the master zone %q to make changes: %w", status.ZoneName, status.MasterZoneName, err)
+	case !status.IsMaster:
+		return fmt.Errorf("the provider endpoint serves the secondary zone %q, which forwards metadata changes "+
+			"to the master zone %q; check that the master zone is reachable or point the provider at its endpoint: %w",
+			status.ZoneName, status.MasterZoneName, err)
+	}
+	return err
+}
+
+// =============================================================================
+// Version Utilities
+// =============================================================================
+
+// CephVersion represents a major Ceph release.
+type CephVersion int
+
+// Ceph major releases (named versions).
+const (
+	CephVersion_Unknown  CephVersion = 0
+	CephVersion_Reef     CephVersion = 18 // Reef (18.x)
+	CephVersion_Squid    CephVersion = 19 // Squid (19.x)
+	CephVersion_Tentacle CephVersion = 20 // Tentacle (20.x)
+)
+
+// String returns the version name.
+func (v CephVersion) String() string {
+	switch v {
+	case CephVersion_Reef:
+		return "Reef (18.x)"
+	case CephVersion_Squid:
+		return "Squid (19.x)"
+	case CephVersion_Tentacle:
+		return "Tentacle (20.x)"
+	default:
+		return fmt.Sprintf("Unknown (%d.x)", int(v))
+	}
+}
+
+// LessThan returns true if v is older than other.
+func (v CephVersion) LessThan(other CephVersion) bool {
+	return int(v) < int(other)
+}
+
+// GreaterThan returns true if v is newer than other.
+func (v CephVersion) GreaterThan(other CephVersion) bool {
+	return int(v) > int(other)
+}
+
+// GreaterThanOrEqual returns true if v is the same or newer than other.
+func (v CephVersion) GreaterThanOrEqual(other CephVersion) bool {
+	return int(v) >= int(other)
+}
+
+// LessThanOrEqual returns true if v is the same or older than other.
+func (v CephVersion) LessThanOrEqual(other CephVersion) bool {
+	return int(v) <= int(other)
+}
+
+// parseCephVersion parses a version string into a CephVersion.
+// Accepts: "20", "20.1.0", "tentacle", "Tentacle", "20-tentacle"
+func parseCephVersion(version string) CephVersion {
+	version = strings.ToLower(strings.TrimSpace(version))
+
+	// Check for named versions first
+	switch version {
+	case "reef":
+		return CephVersion_Reef
+	case "squid":
+		return CephVersion_Squid
+	case "tentacle":
+		return CephVersion_Tentacle
+	}
+
+	// Handle versions like "20.1.0" or "20-tentacle" - extract major version
+	parts := strings.Split(version, "-")
+	version = parts[0]
+
+	vParts := strings.Split(version, ".")
+	if len(vParts) >= 1 {
+		if major, err := strconv.Atoi(vParts[0]); err == nil {
+			return CephVersion(major)
+		}
+	}
+
+	return CephVersion_Unknown
+}
+
+// serverReleasePattern matches the release name RadosGW reports in the Server
+// response header, for example "Ceph Object Gateway (squid)".
+var serverReleasePattern = regexp.MustCompile(`\(([a-z]+)\)`)
+
+// detectCephRelease returns the Ceph release name the gateway reports in the
+// Server header of an anonymous request, or "" when it does not report one.
+func detectCephRelease(ctx context.Context, httpClient HTTPClient, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	match := serverReleasePattern.FindStringSubmatch(resp.Header.Get("Server"))
+	if match == nil {
+		return "", nil
+	}
+	return match[1], nil
+}
+
+// addCephVersionError reports that feature is not available on the Ceph
+// version of the cluster.
+func (c *RadosgwClient) addCephVersionError(diags *diag.Diagnostics, feature string, minVersion CephVersion) {
+	diags.AddError(
+		"Unsupported Ceph Version",
+		fmt.Sprintf("%s requires Ceph %s or later, but the cluster runs Ceph %s. "+
+			"If the version was detected incorrectly, set the ceph_version provider attribute.",
+			feature, minVersion, c.CephVersion),
+	)
+}
+
+// =============================================================================
+// S3 Operation Utilities
+// =============================================================================
+
+// s3OperationFeatures holds the feature providing the S3 operations that
+// older supported releases reject. Operations missing from the map are
+// implemented by every supported release.
+var s3OperationFeatures = map[string]CephFeature{
+	"DeleteBucketOwnershipControls": CephFeature_BucketOwnershipControls,
+	"GetBucketOwnershipControls":    CephFeature_BucketOwnershipControls,
+	"PutBucketOwnershipControls":    CephFeature_BucketOwnershipControls,
+	"GetBucketLogging":              CephFeature_BucketLogging,
+	"PutBucketLogging":              CephFeature_BucketLogging,
+}
+
+// s3UnimplementedOperations lists the S3 operations that no RadosGW release
+// implements; RadosGW answers them with a NotImplemented error.
+var s3UnimplementedOperations = map[string]bool{
+	"GetBucketAccelerateConfiguration":         true,
+	"PutBucketAccelerateConfiguration":         true,
+	"GetBucketAnalyticsConfiguration":          true,
+	"PutBucketAnalyticsConfiguration":          true,
+	"GetBucketIntelligentTieringConfiguration": true,
+	"PutBucketIntelligentTieringConfiguration": true,
+	"GetBucketInventoryConfiguration":          true,
+	"PutBucketInventoryConfiguration":          true,
+	"GetBucketMetricsConfiguration":            true,
+	"PutBucketMetricsConfiguration":            true,
+}
+
+// checkS3Operations reports at plan time the S3 operations of feature that
+// the cluster does not implement.
+func (c *RadosgwClient) checkS3Operations(diags *diag.Diagnostics, feature string, operations ...string) {
+	for _, operation := range operations {
+		if s3UnimplementedOperations[operation] {
+			diags.AddError(
+				"Unsupported S3 Operation",
+				fmt.Sprintf("%s requires the S3 %s operation, which RadosGW does not implement.", feature, operation),
+			)
+			return
+		}
+		if required, ok := s3OperationFeatures[operation]; ok && !c.supportsFeature(required) {
+			c.addCephFeatureError(diags, feature, required)
+			return
+		}
+	}
+}
+
+// s3OperationError explains the errors returned by RadosGW releases that do
+// not implement operation, which answer with a generic error code when the
+// plan time check is bypassed (for example when the version could not be
+// detected). Other errors are returned unchanged.
+func s3OperationError(operation string, err error) error {
+	if !isS3NotImplementedError(err) {
+		return err
+	}
+	if s3UnimplementedOperations[operation] {
+		return fmt.Errorf("%w (RadosGW does not implement the S3 %s operation)", err, operation)
+	}
+	if feature, ok := s3OperationFeatures[operation]; ok {
+		return fmt.Errorf("%w (the S3 %s operation requires Ceph %s or higher)", err, operation, feature.MinVersion())
+	}
+	return fmt.Errorf("%w (the S3 %s operation is not implemented by this RadosGW release)", err, operation)
+}
+
+// isS3NotImplementedError reports whether err is RadosGW rejecting an S3
+// operation it does not implement.
+func isS3NotImplementedError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NotImplemented", "MethodNotAllowed":
+			return true
+		}
+	}
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.HTTPStatusCode() {
+		case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return true
+		}
+	}
+	return false
+}
+
+// =============================================================================
+// Default Tags Utilities
+// =============================================================================
+
+// mergeDefaultTags returns the tags_all of a resource: the default_tags of
+// the provider with tags merged in, tags taking precedence. It is unknown
+// while either depends on values known only after apply.
+func mergeDefaultTags(ctx context.Context, client *RadosgwClient, tags types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if client.DefaultTagsUnknown || tags.IsUnknown() {
+		return types.MapUnknown(types.StringType), diags
+	}
+	for _, value := range tags.Elements() {
+		if value.IsUnknown() {
+			return types.MapUnknown(types.StringType), diags
+		}
+	}
+
+	merged := maps.Clone(client.DefaultTags)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	if !tags.IsNull() {
+		resourceTags := map[string]string{}
+		diags.Append(tags.ElementsAs(ctx, &resourceTags, false)...)
+		if diags.HasError() {
+			return types.MapNull(types.StringType), diags
+		}
+		maps.Copy(merged, resourceTags)
+	}
+
+	mergedValue, d := types.MapValueFrom(ctx, types.StringType, merged)
+	diags.Append(d...)
+	return mergedValue, diags
+}
+
+// resourceTagsFromAll returns the tags of a resource given all tags read from
+// RadosGW. Tags equal to a default tag are attributed to the provider unless
+// prior, the tags in state, has them, so that resources repeating a default
+// tag keep it. The result is null when prior is null and no tag is left.
+func resourceTagsFromAll(ctx context.Context, client *RadosgwClient, all map[string]string, prior types.Map) (types.Map, diag.Diagnostics) {
+	priorTags := prior.Elements()
+
+	tags := map[string]string{}
+	for key, value := range all {
+		if _, ok := priorTags[key]; !ok {
+			if defaultValue, ok := client.DefaultTags[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+		tags[key] = value
+	}
+
+	if len(tags) == 0 && prior.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, tags)
+}
+
+// =============================================================================
+// Quota Size Utilities
+// =============================================================================
+
+// quotaSizeUnits maps the unit suffixes accepted in quota sizes to their size
+// in bytes. Like radosgw-admin, single letter units are binary.
+var quotaSizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"Ki":  1 << 10,
+	"KiB": 1 << 10,
+	"KB":  1e3,
+	"M":   1 << 20,
+	"Mi":  1 << 20,
+	"MiB": 1 << 20,
+	"MB":  1e6,
+	"G":   1 << 30,
+	"Gi":  1 << 30,
+	"GiB": 1 << 30,
+	"GB":  1e9,
+	"T":   1 << 40,
+	"Ti":  1 << 40,
+	"TiB": 1 << 40,
+	"TB":  1e12,
+	"P":   1 << 50,
+	"Pi":  1 << 50,
+	"PiB": 1 << 50,
+	"PB":  1e15,
+}
+
+// quotaSizeFormatUnits are the units quota sizes are formatted with, largest
+// first.
+var quotaSizeFormatUnits = []string{"PiB", "TiB", "GiB", "MiB", "KiB"}
+
+var quotaSizePattern = regexp.MustCompile(`^(-?[0-9]+)\s*([A-Za-z]*)$`)
+
+// quotaSizeDescription documents the accepted quota size formats.
+const quotaSizeDescription = "Accepts a number of bytes or a size with a unit, e.g. `500M` or `10GiB`. `K`, `M`, `G`, `T` " +
+	"and `P`, optionally followed by `i` or `iB`, are powers of 1024; `KB`, `MB`, `GB`, `TB` and `PB` are powers of 1000."
+
+// parseQuotaSize converts a quota size such as "10GiB" or "500M" to bytes.
+// Negative sizes, which mean unlimited, are only accepted without a unit.
+func parseQuotaSize(size string) (int64, error) {
+	match := quotaSizePattern.FindStringSubmatch(strings.TrimSpace(size))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q: expected a number of bytes optionally followed by a unit such as MiB or GB", size)
+	}
+
+	multiplier, ok := quotaSizeUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", size, match[2])
+	}
+
+	value, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	if value < 0 && match[2] != "" {
+		return 0, fmt.Errorf("invalid size %q: negative sizes cannot have a unit", size)
+	}
+	if value > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid size %q: size is too large", size)
+	}
+	return value * multiplier, nil
+}
+
+// formatQuotaSize formats a size in bytes with the largest binary unit that
+// represents it exactly, e.g. "10GiB". Other sizes are formatted as bytes.
+func formatQuotaSize(bytes int64) string {
+	if bytes > 0 {
+		for _, unit := range quotaSizeFormatUnits {
+			if multiplier := quotaSizeUnits[unit]; bytes%multiplier == 0 {
+				return strconv.FormatInt(bytes/multiplier, 10) + unit
+			}
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// quotaSizeValue returns the quota size to store in state for a size read
+// from RadosGW. The prior value is kept when it denotes the same number of
+// bytes, so "10GiB" and "10737418240" do not cause a diff. A changed size is
+// formatted with a unit when the prior value had one, and as bytes otherwise.
+func quotaSizeValue(prior types.String, bytes int64) types.String {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(strconv.FormatInt(bytes, 10))
+	}
+
+	priorBytes, err := parseQuotaSize(prior.ValueString())
+	if err == nil && priorBytes == bytes {
+		return prior
+	}
+	if _, err := strconv.ParseInt(strings.TrimSpace(prior.ValueString()), 10, 64); err != nil {
+		return types.StringValue(formatQuotaSize(bytes))
+	}
+	return types.StringValue(strconv.FormatInt(bytes, 10))
+}
+
+// quotaSizeValidator validates that a string is a quota size understood by
+// parseQuotaSize.
+type quotaSizeValidator struct{}
+
+func (v quotaSizeValidator) Description(ctx context.Context) string {
+	return "must be a number of bytes or a size with a unit such as MiB or GB"
+}
+
+func (v quotaSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v quotaSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseQuotaSize(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Quota Size",
+			err.Error(),
+		)
 	}
-	hash := sha256.Sum256(payload)
-	return hex.EncodeToString(hash[:])
 }
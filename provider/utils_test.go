@@ -0,0 +1,976 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestAdminClientGetZoneStatus(t *testing.T) {
+	t.Parallel()
+
+	period := `{
+  "realm_id": "realm-id",
+  "master_zone": "zone-a-id",
+  "period_map": {
+    "zonegroups": [
+      {
+        "name": "eu",
+        "zones": [
+          {"id": "zone-a-id", "name": "eu-west-1", "read_only": "false"},
+          {"id": "zone-b-id", "name": "eu-west-2", "read_only": true}
+        ]
+      }
+    ]
+  }
+}`
+
+	testCases := map[string]struct {
+		statusCode int
+		requestID  string
+		expected   zoneStatus
+	}{
+		"master": {
+			statusCode: http.StatusOK,
+			requestID:  "tx000001a2b3c4d5e6f7a8b-0065a1b2c3-1234-eu-west-1",
+			expected:   zoneStatus{RealmID: "realm-id", ZoneName: "eu-west-1", ZonegroupName: "eu", MasterZoneName: "eu-west-1", IsMaster: true},
+		},
+		"read-only secondary": {
+			statusCode: http.StatusOK,
+			requestID:  "tx000001a2b3c4d5e6f7a8b-0065a1b2c3-1234-eu-west-2",
+			expected:   zoneStatus{RealmID: "realm-id", ZoneName: "eu-west-2", ZonegroupName: "eu", MasterZoneName: "eu-west-1", ReadOnly: true},
+		},
+		"no realm": {
+			statusCode: http.StatusNotFound,
+			requestID:  "tx000001a2b3c4d5e6f7a8b-0065a1b2c3-1234-default",
+			expected:   zoneStatus{ZoneName: "default", MasterZoneName: "default", IsMaster: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body := period
+			if testCase.statusCode != http.StatusOK {
+				body = `{"Code":"NoSuchEntity"}`
+			}
+			client := &AdminClient{
+				Endpoint:  "http://rgw.example.com",
+				AccessKey: "AKEY",
+				SecretKey: "SKEY",
+				HTTPClient: &stubHTTPClient{
+					statusCode: testCase.statusCode,
+					header:     http.Header{"X-Amz-Request-Id": []string{testCase.requestID}},
+					body:       body,
+				},
+				Signer: v4.NewSigner(),
+			}
+
+			status, err := client.GetZoneStatus(context.Background())
+			if err != nil {
+				t.Fatalf("GetZoneStatus returned unexpected error: %s", err)
+			}
+			if *status != testCase.expected {
+				t.Errorf("expected %+v, got %+v", testCase.expected, *status)
+			}
+		})
+	}
+}
+
+func TestAdminClientDoRequest_doesNotLogBody(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := &AdminClient{
+		Endpoint:  "http://rgw.example.com",
+		AccessKey: "AKEY",
+		SecretKey: "SKEY",
+		HTTPClient: &stubHTTPClient{
+			statusCode: http.StatusOK,
+			body:       `{"user_id":"alice","keys":[{"user":"alice","access_key":"ALICEKEY","secret_key":"ALICESECRET"}]}`,
+		},
+		Signer: v4.NewSigner(),
+	}
+
+	if _, err := client.DoRequest(ctx, http.MethodGet, "/user", url.Values{"uid": {"alice"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if strings.Contains(output.String(), "ALICESECRET") {
+		t.Errorf("expected the secret key not to be logged, got %s", output.String())
+	}
+	if !strings.Contains(output.String(), `"body_length"`) {
+		t.Errorf("expected the body length to be logged, got %s", output.String())
+	}
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	client := &RadosgwClient{DefaultTags: map[string]string{"team": "storage", "env": "dev"}}
+
+	merged, diags := mergeDefaultTags(testCtx, client, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"env": types.StringValue("prod"),
+		"app": types.StringValue("web"),
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team": types.StringValue("storage"),
+		"env":  types.StringValue("prod"),
+		"app":  types.StringValue("web"),
+	})
+	if !merged.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, merged)
+	}
+
+	merged, _ = mergeDefaultTags(testCtx, client, types.MapNull(types.StringType))
+	if len(merged.Elements()) != 2 {
+		t.Errorf("expected the default tags, got %s", merged)
+	}
+
+	merged, _ = mergeDefaultTags(testCtx, client, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"app": types.StringUnknown(),
+	}))
+	if !merged.IsUnknown() {
+		t.Errorf("expected unknown tags, got %s", merged)
+	}
+
+	merged, _ = mergeDefaultTags(testCtx, &RadosgwClient{DefaultTagsUnknown: true}, types.MapNull(types.StringType))
+	if !merged.IsUnknown() {
+		t.Errorf("expected unknown tags, got %s", merged)
+	}
+}
+
+func TestResourceTagsFromAll(t *testing.T) {
+	t.Parallel()
+
+	client := &RadosgwClient{DefaultTags: map[string]string{"team": "storage", "env": "dev"}}
+
+	testCases := map[string]struct {
+		all      map[string]string
+		prior    types.Map
+		expected types.Map
+	}{
+		"defaults only": {
+			all:      map[string]string{"team": "storage", "env": "dev"},
+			prior:    types.MapNull(types.StringType),
+			expected: types.MapNull(types.StringType),
+		},
+		"overridden default": {
+			all:      map[string]string{"team": "storage", "env": "prod", "app": "web"},
+			prior:    types.MapNull(types.StringType),
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("prod"), "app": types.StringValue("web")}),
+		},
+		"repeated default": {
+			all:      map[string]string{"team": "storage", "env": "dev"},
+			prior:    types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("dev")}),
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("dev")}),
+		},
+		"emptied": {
+			all:      map[string]string{"team": "storage", "env": "dev"},
+			prior:    types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, diags := resourceTagsFromAll(testCtx, client, testCase.all, testCase.prior)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !tags.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, tags)
+			}
+		})
+	}
+}
+
+func TestDetectCephRelease(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		server   string
+		expected string
+	}{
+		"squid":    {server: "Ceph Object Gateway (squid)", expected: "squid"},
+		"tentacle": {server: "Ceph Object Gateway (tentacle)", expected: "tentacle"},
+		"hidden":   {server: "", expected: ""},
+		"proxy":    {server: "nginx/1.25.3", expected: ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &stubHTTPClient{
+				statusCode: http.StatusOK,
+				header:     http.Header{"Server": []string{testCase.server}},
+			}
+
+			release, err := detectCephRelease(context.Background(), httpClient, "http://rgw.example.com")
+			if err != nil {
+				t.Fatalf("detectCephRelease returned unexpected error: %s", err)
+			}
+			if release != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, release)
+			}
+			if httpClient.request.Header.Get("Authorization") != "" {
+				t.Errorf("expected an anonymous request")
+			}
+		})
+	}
+}
+
+func TestCheckS3Operations(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		version     CephVersion
+		operations  []string
+		expectError string
+	}{
+		"implemented everywhere": {version: CephVersion_Reef, operations: []string{"PutBucketPolicy"}},
+		"too old":                {version: CephVersion_Reef, operations: []string{"GetBucketLogging", "PutBucketLogging"}, expectError: "requires Ceph tentacle"},
+		"new enough":             {version: CephVersion_Tentacle, operations: []string{"PutBucketLogging"}},
+		"unknown version":        {version: CephVersion_Unknown, operations: []string{"PutBucketLogging"}},
+		"never implemented":      {version: CephVersion_Tentacle, operations: []string{"PutBucketAccelerateConfiguration"}, expectError: "RadosGW does not implement"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &RadosgwClient{CephVersion: testCase.version}
+
+			var diags diag.Diagnostics
+			client.checkS3Operations(&diags, "Testing", testCase.operations...)
+
+			if testCase.expectError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got %v", diags)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(strings.ToLower(detail), strings.ToLower(testCase.expectError)) {
+				t.Errorf("expected error containing %q, got %q", testCase.expectError, detail)
+			}
+		})
+	}
+}
+
+func TestS3OperationError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		operation string
+		err       error
+		expected  string
+	}{
+		"other error": {
+			operation: "PutBucketLogging",
+			err:       &smithy.GenericAPIError{Code: "AccessDenied"},
+			expected:  "api error AccessDenied: ",
+		},
+		"versioned operation": {
+			operation: "PutBucketLogging",
+			err:       &smithy.GenericAPIError{Code: "NotImplemented"},
+			expected:  "api error NotImplemented:  (the S3 PutBucketLogging operation requires Ceph Tentacle (20.x) or higher)",
+		},
+		"unimplemented operation": {
+			operation: "PutBucketAccelerateConfiguration",
+			err:       &smithy.GenericAPIError{Code: "MethodNotAllowed"},
+			expected:  "api error MethodNotAllowed:  (RadosGW does not implement the S3 PutBucketAccelerateConfiguration operation)",
+		},
+		"unmapped operation": {
+			operation: "PutBucketPolicy",
+			err:       &smithy.GenericAPIError{Code: "NotImplemented"},
+			expected:  "api error NotImplemented:  (the S3 PutBucketPolicy operation is not implemented by this RadosGW release)",
+		},
+		"status code only": {
+			operation: "GetBucketOwnershipControls",
+			err: &awshttp.ResponseError{ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusMethodNotAllowed}},
+				Err:      errors.New("method not allowed"),
+			}},
+			expected: "(the S3 GetBucketOwnershipControls operation requires Ceph Squid (19.x) or higher)",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := s3OperationError(testCase.operation, testCase.err)
+			if !errors.Is(err, testCase.err) {
+				t.Errorf("expected the original error to be wrapped")
+			}
+			if !strings.HasSuffix(err.Error(), testCase.expected) {
+				t.Errorf("expected error ending with %q, got %q", testCase.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestAdminError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		code        string
+		requiredCap string
+		expected    string
+	}{
+		"no such user":         {code: "NoSuchUser", requiredCap: "users=read", expected: "the user does not exist (NoSuchUser "},
+		"bucket exists":        {code: "BucketAlreadyExists", expected: "a bucket with this name already exists (BucketAlreadyExists "},
+		"unknown access key":   {code: "InvalidAccessKeyId", expected: "RadosGW does not know the provider access key"},
+		"access denied":        {code: "AccessDenied", requiredCap: "users=write", expected: "the provider user may lack the users=write capability"},
+		"access denied no cap": {code: "AccessDenied", expected: "AccessDenied "},
+		"other code":           {code: "InternalError", requiredCap: "users=read", expected: "InternalError "},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"Code":"` + testCase.code + `","RequestId":"tx0","HostId":"default"}`)),
+				}, nil
+			})}
+
+			// Errors returned by go-ceph
+			api, err := admin.New("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_, err = api.GetUser(testCtx, admin.User{ID: "user"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			translated := adminError(err, testCase.requiredCap)
+			if !errors.Is(translated, err) {
+				t.Errorf("expected the original error to be wrapped")
+			}
+			if !strings.Contains(translated.Error(), testCase.expected) {
+				t.Errorf("expected go-ceph error containing %q, got %q", testCase.expected, translated.Error())
+			}
+
+			// Errors returned by AdminClient
+			translated = adminError(&AdminError{Code: testCase.code, StatusCode: http.StatusForbidden}, testCase.requiredCap)
+			if expected := strings.TrimSuffix(testCase.expected, " "); !strings.Contains(translated.Error(), expected) {
+				t.Errorf("expected AdminClient error containing %q, got %q", expected, translated.Error())
+			}
+		})
+	}
+}
+
+func TestSplitBucketID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id             string
+		expectedTenant string
+		expectedBucket string
+	}{
+		"bucket":        {id: "my-bucket", expectedTenant: "", expectedBucket: "my-bucket"},
+		"s3 form":       {id: "tenant:my-bucket", expectedTenant: "tenant", expectedBucket: "my-bucket"},
+		"admin form":    {id: "tenant/my-bucket", expectedTenant: "tenant", expectedBucket: "my-bucket"},
+		"empty tenant":  {id: ":my-bucket", expectedTenant: "", expectedBucket: "my-bucket"},
+		"missing name":  {id: "tenant:", expectedTenant: "tenant", expectedBucket: ""},
+		"dotted bucket": {id: "tenant:logs.example.com", expectedTenant: "tenant", expectedBucket: "logs.example.com"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tenant, bucket := splitBucketID(testCase.id)
+			if tenant != testCase.expectedTenant || bucket != testCase.expectedBucket {
+				t.Errorf("expected (%q, %q), got (%q, %q)", testCase.expectedTenant, testCase.expectedBucket, tenant, bucket)
+			}
+		})
+	}
+}
+
+func TestSplitUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id             string
+		expectedTenant string
+		expectedUserID string
+	}{
+		"user":        {id: "my-user", expectedTenant: "", expectedUserID: "my-user"},
+		"tenant user": {id: "tenant$my-user", expectedTenant: "tenant", expectedUserID: "my-user"},
+		"colon":       {id: "my:user", expectedTenant: "", expectedUserID: "my:user"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tenant, userID := splitUserID(testCase.id)
+			if tenant != testCase.expectedTenant || userID != testCase.expectedUserID {
+				t.Errorf("expected (%q, %q), got (%q, %q)", testCase.expectedTenant, testCase.expectedUserID, tenant, userID)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deletionProtection types.Bool
+		expectAllowed      bool
+	}{
+		"null":     {deletionProtection: types.BoolNull(), expectAllowed: true},
+		"disabled": {deletionProtection: types.BoolValue(false), expectAllowed: true},
+		"enabled":  {deletionProtection: types.BoolValue(true)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			allowed := checkDeletionProtection(&diags, testCase.deletionProtection, `bucket "logs"`)
+			if allowed != testCase.expectAllowed {
+				t.Errorf("expected allowed %t, got %t", testCase.expectAllowed, allowed)
+			}
+			if diags.HasError() == testCase.expectAllowed {
+				t.Errorf("expected error %t, got diagnostics %v", !testCase.expectAllowed, diags)
+			}
+		})
+	}
+}
+
+// TestBucketResourcesImportState checks that every resource scoped to a
+// bucket accepts tenant-qualified import IDs.
+func TestBucketResourcesImportState(t *testing.T) {
+	t.Parallel()
+
+	resources := map[string]func() resource.Resource{
+		"radosgw_s3_bucket_lifecycle_configuration": NewS3BucketLifecycleResource,
+		"radosgw_s3_bucket_logging":                 NewS3BucketLoggingResource,
+		"radosgw_s3_bucket_notification":            NewS3BucketNotificationResource,
+		"radosgw_s3_bucket_ownership_controls":      NewS3BucketOwnershipControlsResource,
+		"radosgw_s3_bucket_policy":                  NewS3BucketPolicyResource,
+		"radosgw_s3_bucket_website_configuration":   NewS3BucketWebsiteConfigurationResource,
+	}
+
+	testCases := map[string]struct {
+		id             string
+		expectedTenant types.String
+		expectedBucket string
+		expectError    bool
+	}{
+		"bucket":       {id: "my-bucket", expectedTenant: types.StringNull(), expectedBucket: "my-bucket"},
+		"s3 form":      {id: "tenant:my-bucket", expectedTenant: types.StringValue("tenant"), expectedBucket: "my-bucket"},
+		"admin form":   {id: "tenant/my-bucket", expectedTenant: types.StringValue("tenant"), expectedBucket: "my-bucket"},
+		"missing name": {id: "tenant:", expectError: true},
+	}
+
+	for resourceName, newResource := range resources {
+		for name, testCase := range testCases {
+			t.Run(resourceName+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				r := newResource()
+				importer, ok := r.(resource.ResourceWithImportState)
+				if !ok {
+					t.Fatalf("%s does not support import", resourceName)
+				}
+
+				var schemaResp resource.SchemaResponse
+				r.Schema(testCtx, resource.SchemaRequest{}, &schemaResp)
+				stateType := schemaResp.Schema.Type().TerraformType(testCtx)
+
+				resp := resource.ImportStateResponse{
+					State: tfsdk.State{
+						Schema: schemaResp.Schema,
+						Raw:    tftypes.NewValue(stateType, nil),
+					},
+				}
+				importer.ImportState(testCtx, resource.ImportStateRequest{ID: testCase.id}, &resp)
+
+				if testCase.expectError {
+					if !resp.Diagnostics.HasError() {
+						t.Fatal("expected an error")
+					}
+					return
+				}
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+
+				var bucket, tenant types.String
+				resp.Diagnostics.Append(resp.State.GetAttribute(testCtx, path.Root("bucket"), &bucket)...)
+				resp.Diagnostics.Append(resp.State.GetAttribute(testCtx, path.Root("tenant"), &tenant)...)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				if bucket.ValueString() != testCase.expectedBucket {
+					t.Errorf("expected bucket %q, got %q", testCase.expectedBucket, bucket.ValueString())
+				}
+				if !tenant.Equal(testCase.expectedTenant) {
+					t.Errorf("expected tenant %s, got %s", testCase.expectedTenant, tenant)
+				}
+			})
+		}
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAdminTransportRetries(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		responses      []int
+		body           string
+		maxRetries     int
+		expectedStatus int
+		expectedCalls  int
+	}{
+		"concurrent modification": {
+			responses:      []int{http.StatusConflict, http.StatusConflict, http.StatusOK},
+			body:           `{"Code":"ConcurrentModification"}`,
+			maxRetries:     5,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+		},
+		"concurrent modification in xml": {
+			responses:      []int{http.StatusConflict, http.StatusOK},
+			body:           `<ErrorResponse><Error><Code>ConcurrentModification</Code></Error></ErrorResponse>`,
+			maxRetries:     5,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"retries exhausted": {
+			responses:      []int{http.StatusConflict, http.StatusConflict, http.StatusOK},
+			body:           `{"Code":"ConcurrentModification"}`,
+			maxRetries:     1,
+			expectedStatus: http.StatusConflict,
+			expectedCalls:  2,
+		},
+		"service unavailable": {
+			responses:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:     5,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"other conflict": {
+			responses:      []int{http.StatusConflict, http.StatusOK},
+			body:           `{"Code":"BucketAlreadyExists"}`,
+			maxRetries:     5,
+			expectedStatus: http.StatusConflict,
+			expectedCalls:  1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			transport := newRetryTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				status := testCase.responses[calls]
+				calls++
+				body := "{}"
+				if status != http.StatusOK {
+					body = testCase.body
+				}
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			}), 0, retryConfig{MaxRetries: testCase.maxRetries, MaxBackoff: time.Millisecond})
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://rgw.example.com/admin/user", nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip returned unexpected error: %s", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != testCase.expectedStatus {
+				t.Errorf("expected status %d, got %d", testCase.expectedStatus, resp.StatusCode)
+			}
+			if calls != testCase.expectedCalls {
+				t.Errorf("expected %d calls, got %d", testCase.expectedCalls, calls)
+			}
+			if body, _ := io.ReadAll(resp.Body); resp.StatusCode != http.StatusOK && string(body) != testCase.body {
+				t.Errorf("expected body %q to be preserved, got %q", testCase.body, body)
+			}
+		})
+	}
+}
+
+func TestAdminTransportConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+	transport := newRetryTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}), 2, retryConfig{MaxBackoff: time.Millisecond})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://rgw.example.com/admin/user", nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("RoundTrip returned unexpected error: %s", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight.Load())
+	}
+}
+
+func TestFailoverTransport(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var hosts []string
+	down := map[string]bool{"rgw2:7480": true}
+
+	transport, err := newFailoverTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		hosts = append(hosts, req.URL.Host)
+		if req.Host != "rgw1:7480" {
+			t.Errorf("expected the Host header of the first endpoint, got %q", req.Host)
+		}
+		if down[req.URL.Host] {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	}), []string{"http://rgw1:7480", "http://rgw2:7480", "http://rgw3:7480"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	send := func() error {
+		req, err := http.NewRequest(http.MethodGet, "http://rgw1:7480/admin/user", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	// Round robin, failing over from rgw2 to rgw3, then skipping rgw2
+	for range 4 {
+		if err := send(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	expected := []string{"rgw1:7480", "rgw2:7480", "rgw3:7480", "rgw3:7480", "rgw1:7480"}
+	if strings.Join(hosts, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected hosts %v, got %v", expected, hosts)
+	}
+
+	// Every endpoint failing returns the last error
+	down["rgw1:7480"], down["rgw3:7480"] = true, true
+	if err := send(); err == nil {
+		t.Error("expected an error when all endpoints fail")
+	}
+}
+
+func TestIAMClientDoRequest(t *testing.T) {
+	t.Parallel()
+
+	policy := `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::a+b c/*"}]}`
+
+	httpClient := &stubHTTPClient{statusCode: http.StatusOK, body: "<PutRolePolicyResponse/>"}
+	iamClient := NewIAMClient("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+
+	params := url.Values{"Action": {"PutRolePolicy"}, "RoleName": {"role"}, "PolicyDocument": {policy}}
+	if _, err := iamClient.DoRequest(testCtx, params, "iam"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req := httpClient.request
+	if req.URL.RawQuery != "" {
+		t.Errorf("expected no query string, got %q", req.URL.RawQuery)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.ContentLength != int64(len(body)) {
+		t.Errorf("expected content length %d, got %d", len(body), req.ContentLength)
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := form.Get("PolicyDocument"); got != policy {
+		t.Errorf("expected policy document %q, got %q", policy, got)
+	}
+
+	// The signature covers the body
+	signedAt, err := time.Parse("20060102T150405Z", req.Header.Get("X-Amz-Date"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected, err := http.NewRequest("POST", "http://rgw.example.com/", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected.Header.Set("Content-Type", req.Header.Get("Content-Type"))
+	err = v4.NewSigner().SignHTTP(testCtx, aws.Credentials{AccessKeyID: "AKEY", SecretAccessKey: "SKEY"}, expected,
+		HashPayload(body), defaultSigningName, "", signedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Header.Get("Authorization") != expected.Header.Get("Authorization") {
+		t.Errorf("expected authorization %q, got %q", expected.Header.Get("Authorization"), req.Header.Get("Authorization"))
+	}
+}
+
+func TestIAMClientDoRequestXML(t *testing.T) {
+	t.Parallel()
+
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	_, _ = writer.Write([]byte("<GetRoleResponse><GetRoleResult><Role><RoleName>role</RoleName></Role></GetRoleResult></GetRoleResponse>"))
+	_ = writer.Close()
+
+	testCases := map[string]struct {
+		statusCode    int
+		header        http.Header
+		body          string
+		expectName    string
+		expectIAMErr  bool
+		expectRespErr bool
+	}{
+		"plain": {
+			statusCode: http.StatusOK,
+			body:       "<GetRoleResponse><GetRoleResult><Role><RoleName>role</RoleName></Role></GetRoleResult></GetRoleResponse>",
+			expectName: "role",
+		},
+		"gzip": {
+			statusCode: http.StatusOK,
+			header:     http.Header{"Content-Encoding": {"gzip"}},
+			body:       gzipped.String(),
+			expectName: "role",
+		},
+		"api error": {
+			statusCode:   http.StatusNotFound,
+			body:         "<ErrorResponse><Error><Code>NoSuchEntity</Code></Error></ErrorResponse>",
+			expectIAMErr: true,
+		},
+		"invalid response": {
+			statusCode:    http.StatusOK,
+			body:          "not xml",
+			expectRespErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				header := testCase.header
+				if header == nil {
+					header = http.Header{}
+				}
+				return &http.Response{
+					StatusCode: testCase.statusCode,
+					Header:     header,
+					Body:       io.NopCloser(strings.NewReader(testCase.body)),
+				}, nil
+			})}
+			iamClient := NewIAMClient("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+
+			var response getRoleResponseXML
+			err := iamClient.DoRequestXML(testCtx, url.Values{"Action": {"GetRole"}, "RoleName": {"role"}}, "iam", &response)
+
+			var iamErr *IAMError
+			if errors.As(err, &iamErr) != testCase.expectIAMErr {
+				t.Errorf("expected IAM error %t, got %v", testCase.expectIAMErr, err)
+			}
+			var respErr *IAMResponseError
+			if errors.As(err, &respErr) != testCase.expectRespErr {
+				t.Errorf("expected response error %t, got %v", testCase.expectRespErr, err)
+			}
+			if err == nil && response.Result.Role.RoleName != testCase.expectName {
+				t.Errorf("expected role %q, got %q", testCase.expectName, response.Result.Role.RoleName)
+			}
+		})
+	}
+}
+
+func TestIAMClientSigningScope(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		region        string
+		signingName   string
+		expectedScope string
+	}{
+		"default": {signingName: defaultSigningName, expectedScope: "//s3/aws4_request"},
+		"region":  {region: "zg1", signingName: defaultSigningName, expectedScope: "/zg1/s3/aws4_request"},
+		"signing": {region: "zg1", signingName: "iam", expectedScope: "/zg1/iam/aws4_request"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &stubHTTPClient{statusCode: http.StatusOK, body: "<ListUsersResponse/>"}
+			iamClient := NewIAMClient("http://rgw.example.com", "AKEY", "SKEY", httpClient)
+			iamClient.Region = testCase.region
+			iamClient.SigningName = testCase.signingName
+
+			if _, err := iamClient.DoRequest(context.Background(), url.Values{"Action": {"ListUsers"}}, "iam"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if auth := httpClient.request.Header.Get("Authorization"); !strings.Contains(auth, testCase.expectedScope) {
+				t.Errorf("expected credential scope %q, got %q", testCase.expectedScope, auth)
+			}
+		})
+	}
+}
+
+func TestParseQuotaSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		size        string
+		expected    int64
+		expectError bool
+	}{
+		"bytes":          {size: "1048576", expected: 1048576},
+		"unlimited":      {size: "-1", expected: -1},
+		"byte unit":      {size: "512B", expected: 512},
+		"single letter":  {size: "500M", expected: 500 << 20},
+		"iec short":      {size: "2Gi", expected: 2 << 30},
+		"iec":            {size: "10GiB", expected: 10 << 30},
+		"si":             {size: "5GB", expected: 5000000000},
+		"space":          {size: "1 TiB", expected: 1 << 40},
+		"unknown unit":   {size: "10XB", expectError: true},
+		"lowercase unit": {size: "10gib", expectError: true},
+		"fraction":       {size: "1.5GiB", expectError: true},
+		"negative unit":  {size: "-1GiB", expectError: true},
+		"overflow":       {size: "9000000PiB", expectError: true},
+		"empty":          {size: "", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseQuotaSize(testCase.size)
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestQuotaSizeValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		prior    types.String
+		bytes    int64
+		expected string
+	}{
+		"import":            {prior: types.StringNull(), bytes: 10 << 30, expected: "10737418240"},
+		"same unit":         {prior: types.StringValue("10GiB"), bytes: 10 << 30, expected: "10GiB"},
+		"equivalent unit":   {prior: types.StringValue("10240M"), bytes: 10 << 30, expected: "10240M"},
+		"same bytes":        {prior: types.StringValue("10737418240"), bytes: 10 << 30, expected: "10737418240"},
+		"changed unit":      {prior: types.StringValue("10GiB"), bytes: 512 << 20, expected: "512MiB"},
+		"changed odd bytes": {prior: types.StringValue("10GiB"), bytes: 1000, expected: "1000"},
+		"changed bytes":     {prior: types.StringValue("10737418240"), bytes: 512 << 20, expected: "536870912"},
+		"changed unlimited": {prior: types.StringValue("10GiB"), bytes: -1, expected: "-1"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := quotaSizeValue(testCase.prior, testCase.bytes).ValueString(); got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ radosgw-admin --conf "$CEPH_DIR/ceph.conf" user create \
     --display-name="$DISPLAY_NAME" \
     --access-key="$USER_ID" \
     --secret-key="secretkey" \
-    --caps="buckets=*;metadata=*;oidc-provider=*;roles=*;users=*"
+    --caps="accounts=*;buckets=*;info=read;metadata=*;oidc-provider=*;ratelimit=*;roles=*;usage=read;user-policy=*;users=*;zone=read"
 
 echo ""
 echo "User created successfully!"
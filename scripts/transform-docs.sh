@@ -235,8 +235,8 @@ transform_file() {
 
 echo "Transforming documentation format..."
 
-# Transform all resource and data source docs
-for file in "$DOCS_DIR"/resources/*.md "$DOCS_DIR"/data-sources/*.md; do
+# Transform all resource, data source and ephemeral resource docs
+for file in "$DOCS_DIR"/resources/*.md "$DOCS_DIR"/data-sources/*.md "$DOCS_DIR"/ephemeral-resources/*.md; do
     if [[ -f "$file" ]]; then
         echo "  Processing: $(basename "$file")"
         transform_file "$file"